@@ -0,0 +1,106 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/storage"
+)
+
+const (
+	// DefaultColibriDelta is the default fraction of the free bandwidth that can be
+	// granted to a single segment reservation request.
+	DefaultColibriDelta = 0.75
+
+	// AdmissionStateless recomputes the demands from the DB for every admission.
+	AdmissionStateless = "stateless"
+	// AdmissionStateful keeps the demands in memory and updates them incrementally.
+	AdmissionStateful = "stateful"
+)
+
+var _ (config.Config) = (*ColibriConfig)(nil)
+
+// ColibriConfig is the configuration for the COLIBRI reservation service.
+type ColibriConfig struct {
+	// ColibriDB contains the COLIBRI DB configuration.
+	ColibriDB storage.DBConfig `toml:"colibri_db,omitempty"`
+	// Capacities is the path to the JSON file containing the capacity matrix. It is
+	// reloaded on SIGHUP.
+	Capacities string `toml:"capacities,omitempty"`
+	// Delta is the fraction of the free bandwidth that can be granted in one request.
+	Delta float64 `toml:"delta,omitempty"`
+	// Admission is the admission algorithm used for segment reservations.
+	Admission string `toml:"admission,omitempty"`
+	// Reservations is the path to the YAML file listing the segment reservations
+	// this AS sets up and keeps alive. If empty, no reservations are managed.
+	Reservations string `toml:"reservations,omitempty"`
+}
+
+// InitDefaults initializes values of unset keys.
+func (cfg *ColibriConfig) InitDefaults() {
+	if cfg.Delta == 0 {
+		cfg.Delta = DefaultColibriDelta
+	}
+	if cfg.Admission == "" {
+		cfg.Admission = AdmissionStateless
+	}
+}
+
+// Enabled returns true if COLIBRI is configured. False otherwise.
+func (cfg *ColibriConfig) Enabled() bool {
+	return cfg.ColibriDB.Connection != ""
+}
+
+// Validate validates that all values are parsable.
+func (cfg *ColibriConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.Capacities == "" {
+		return serrors.New("capacities file must be set when COLIBRI is enabled")
+	}
+	if cfg.Delta <= 0 || cfg.Delta > 1 {
+		return serrors.New("delta must be in (0, 1]", "delta", cfg.Delta)
+	}
+	switch cfg.Admission {
+	case AdmissionStateless, AdmissionStateful:
+	default:
+		return serrors.New("unknown admission algorithm", "admission", cfg.Admission)
+	}
+	return config.ValidateAll(&cfg.ColibriDB)
+}
+
+// Sample writes a config sample to the writer.
+func (cfg *ColibriConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, colibriSample)
+	config.WriteSample(dst, path,
+		config.CtxMap{config.ID: idSample},
+		config.OverrideName(
+			config.FormatData(
+				&cfg.ColibriDB,
+				storage.SetID(storage.SampleColibriDB, idSample).Connection,
+			),
+			"colibri_db",
+		),
+	)
+}
+
+// ConfigName is the key in the toml file.
+func (cfg *ColibriConfig) ConfigName() string {
+	return "colibri"
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/pkg/storage"
+)
+
+func TestColibriConfigSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg ColibriConfig
+	cfg.Sample(&sample, nil, nil)
+	meta, err := toml.Decode(sample.String(), &cfg)
+	require.NoError(t, err)
+	require.Empty(t, meta.Undecoded())
+	cfg.InitDefaults()
+	require.True(t, cfg.Enabled())
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, DefaultColibriDelta, cfg.Delta)
+	assert.Equal(t, AdmissionStateless, cfg.Admission)
+	assert.Equal(t, "/etc/scion/reservations.yml", cfg.Reservations)
+}
+
+func TestColibriConfigValidate(t *testing.T) {
+	var cfg ColibriConfig
+	cfg.InitDefaults()
+	require.False(t, cfg.Enabled())
+	require.NoError(t, cfg.Validate())
+	cfg.ColibriDB.Connection = "a"
+	require.True(t, cfg.Enabled())
+	require.Error(t, cfg.Validate())
+	cfg.Capacities = "capacities.json"
+	require.NoError(t, cfg.Validate())
+	cfg.Delta = 1.5
+	require.Error(t, cfg.Validate())
+	cfg.Delta = DefaultColibriDelta
+	cfg.Admission = AdmissionStateful
+	require.NoError(t, cfg.Validate())
+	cfg.Admission = "optimal"
+	require.Error(t, cfg.Validate())
+}
+
+func TestNewColibriDB(t *testing.T) {
+	cfg := ColibriConfig{}
+	cfg.InitDefaults()
+	cfg.ColibriDB.Connection = tempFile(t)
+	db, err := storage.NewColibriStorage(cfg.ColibriDB)
+	defer func() {
+		db.Close()
+		os.Remove(cfg.ColibriDB.Connection)
+	}()
+	require.NoError(t, err)
+	require.NotNil(t, db)
+}
@@ -84,6 +84,7 @@ type Config struct {
 	CA          CA                 `toml:"ca,omitempty"`
 	TrustEngine trustengine.Config `toml:"trustengine,omitempty"`
 	DRKey       DRKeyConfig        `toml:"drkey,omitempty"`
+	Colibri     ColibriConfig      `toml:"colibri,omitempty"`
 }
 
 // InitDefaults initializes the default values for all parts of the config.
@@ -103,6 +104,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.Colibri,
 	)
 }
 
@@ -122,6 +124,7 @@ func (cfg *Config) Validate() error {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.Colibri,
 	)
 }
 
@@ -167,6 +170,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.Colibri,
 	)
 }
 
@@ -16,21 +16,15 @@ package config
 
 import (
 	"io"
-	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/drkey/protocol"
 	"github.com/scionproto/scion/go/lib/serrors"
-	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/storage"
 )
 
-const (
-	// DefaultEpochDuration is the default duration for the drkey SV and derived keys
-	DefaultEpochDuration = 24 * time.Hour
-)
-
 var _ (config.Config) = (*DRKeyConfig)(nil)
 
 // DRKeyConfig is the configuration for the connection to the trust database.
@@ -39,10 +33,13 @@ type DRKeyConfig struct {
 	enabled bool
 	// DRKeyDB contains the DRKey DB configuration.
 	DRKeyDB storage.DBConfig `toml:"drkey_db,omitempty"`
-	// EpochDuration is the duration of the keys in this CS.
-	EpochDuration util.DurWrap `toml:"epoch_duration,omitempty"`
+	// EpochConfig configures the epochs of the keys in this CS. It must be the same as the
+	// scmp_auth configuration of the routers of this AS.
+	drkey.EpochConfig
 	// AuthorizedDelegations is the DelegationList for this CS.
 	Delegation DelegationList `toml:"delegation,omitempty"`
+	// Protocols declares the DRKey protocols served by this CS.
+	Protocols protocol.ProtocolsConfig `toml:"protocols,omitempty"`
 
 	//TLS config
 	CertFile string `toml:"cert_file,omitempty"`
@@ -54,6 +51,7 @@ func NewDRKeyConfig() *DRKeyConfig {
 	c := DRKeyConfig{
 		DRKeyDB:    storage.DBConfig{},
 		Delegation: DelegationList{},
+		Protocols:  protocol.ProtocolsConfig{},
 	}
 	return &c
 }
@@ -61,23 +59,38 @@ func NewDRKeyConfig() *DRKeyConfig {
 // InitDefaults initializes values of unset keys and determines if the configuration enables DRKey.
 func (cfg *DRKeyConfig) InitDefaults() {
 	cfg.enabled = true
-	if cfg.EpochDuration.Duration == 0 {
-		cfg.EpochDuration.Duration = DefaultEpochDuration
-	}
-	config.InitAll(&cfg.Delegation)
+	cfg.EpochConfig.InitDefaults()
+	config.InitAll(&cfg.Delegation, &cfg.Protocols)
 }
 
 // Enabled returns true if DRKey is configured. False otherwise.
 func (cfg *DRKeyConfig) Enabled() bool {
-	if cfg.DRKeyDB.Connection == "" {
-		return false
-	}
-	return true
+	return cfg.DRKeyDB.Configured()
 }
 
-// Validate validates that all values are parsable.
+// Validate validates that all values are parsable. The epochs are only validated if DRKey is
+// enabled.
 func (cfg *DRKeyConfig) Validate() error {
-	return config.ValidateAll(&cfg.DRKeyDB, &cfg.Delegation)
+	if cfg.Enabled() {
+		if err := cfg.EpochConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := config.ValidateAll(&cfg.DRKeyDB, &cfg.Delegation, &cfg.Protocols); err != nil {
+		return err
+	}
+	protocols := cfg.Protocols.Registry()
+	for proto := range cfg.Delegation {
+		p, err := protocols.Lookup(proto)
+		if err != nil {
+			return serrors.WrapStr("delegation configured for undeclared protocol", err)
+		}
+		if !p.Delegation {
+			return serrors.New("delegation configured for protocol without delegation",
+				"protocol", proto)
+		}
+	}
+	return nil
 }
 
 // Sample writes a config sample to the writer.
@@ -93,6 +106,7 @@ func (cfg *DRKeyConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMa
 			"drkey_db",
 		),
 		&cfg.Delegation,
+		&cfg.Protocols,
 	)
 }
 
@@ -113,12 +127,10 @@ func (cfg *DelegationList) InitDefaults() {
 	}
 }
 
-// Validate validates that the protocols exist, and their addresses are parsable.
+// Validate validates that the addresses are parsable. The protocols are validated against the
+// declared ones by DRKeyConfig.
 func (cfg *DelegationList) Validate() error {
-	for proto, list := range *cfg {
-		if _, found := protocol.KnownDerivations[proto]; !found {
-			return serrors.New("Configured protocol not found", "protocol", proto)
-		}
+	for _, list := range *cfg {
 		for _, ip := range list {
 			if h := addr.HostFromIPStr(ip); h == nil {
 				return serrors.New("Syntax error: not a valid address", "ip", ip)
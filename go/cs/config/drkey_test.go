@@ -26,6 +26,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/storage"
 )
 
@@ -33,6 +35,7 @@ func TestInitDefaults(t *testing.T) {
 	var cfg DRKeyConfig
 	cfg.InitDefaults()
 	assert.EqualValues(t, 24*time.Hour, cfg.EpochDuration.Duration)
+	assert.EqualValues(t, 5*time.Minute, cfg.GracePeriod.Duration)
 }
 
 func TestDRKeyConfigSample(t *testing.T) {
@@ -44,7 +47,18 @@ func TestDRKeyConfigSample(t *testing.T) {
 	require.Empty(t, meta.Undecoded())
 	err = cfg.Validate()
 	require.NoError(t, err)
-	assert.Equal(t, DefaultEpochDuration, cfg.EpochDuration.Duration)
+	assert.Equal(t, drkey.DefaultEpochDuration, cfg.EpochDuration.Duration)
+	assert.Equal(t, drkey.DefaultGracePeriod, cfg.GracePeriod.Duration)
+}
+
+func TestGracePeriodDisabled(t *testing.T) {
+	var cfg DRKeyConfig
+	_, err := toml.Decode(`grace_period = "0s"`, &cfg)
+	require.NoError(t, err)
+	cfg.DRKeyDB.Connection = "a"
+	cfg.InitDefaults()
+	require.NoError(t, cfg.Validate())
+	assert.Zero(t, cfg.Grace())
 }
 
 func TestDisable(t *testing.T) {
@@ -77,6 +91,27 @@ func TestValidate(t *testing.T) {
 	require.NoError(t, cfg.Validate())
 	cfg.DRKeyDB.Connection = "a"
 	require.NoError(t, cfg.Validate())
+	cfg.GracePeriod = &util.DurWrap{Duration: 10 * time.Hour}
+	require.Error(t, cfg.Validate())
+	cfg.GracePeriod = &util.DurWrap{Duration: time.Hour}
+	require.NoError(t, cfg.Validate())
+}
+
+func TestValidateDelegatedProtocols(t *testing.T) {
+	cfg := NewDRKeyConfig()
+	toml.Decode(`piskes = ["1.1.1.1"]`, &cfg.Delegation)
+	// the built-in protocols are served if none is declared.
+	require.NoError(t, cfg.Validate())
+
+	toml.Decode(`foo = { derivation = "delegated", delegation = true }`, &cfg.Protocols)
+	cfg.InitDefaults()
+	require.Error(t, cfg.Validate(), "piskes not declared")
+
+	toml.Decode(`piskes = { derivation = "delegated" }`, &cfg.Protocols)
+	require.Error(t, cfg.Validate(), "delegation not allowed for piskes")
+
+	toml.Decode(`piskes = { derivation = "delegated", delegation = true }`, &cfg.Protocols)
+	require.NoError(t, cfg.Validate())
 }
 
 func TestDelegationListDefaults(t *testing.T) {
@@ -142,3 +177,11 @@ func tempFile(t *testing.T) string {
 	require.NoError(t, err)
 	return name
 }
+
+func TestEnabledMemoryBackend(t *testing.T) {
+	cfg := NewDRKeyConfig()
+	cfg.DRKeyDB.Backend = storage.BackendMemory
+	cfg.InitDefaults()
+	require.True(t, cfg.Enabled())
+	require.NoError(t, cfg.Validate())
+}
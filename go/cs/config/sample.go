@@ -33,8 +33,35 @@ max_as_validity = "3d"
 const drkeySample = `
 # EpochDuration of the DRKey secret value and of all derived keys. (default "24h")
 epoch_duration = "24h"
+
+# Time after the end of an epoch during which the keys of that epoch are still
+# valid, together with the keys of the next epoch. Must be shorter than the
+# epoch_duration, and 0 disables the overlap. The epoch_duration and the
+# grace_period must be the same as in the scmp_auth section of the routers of
+# this AS. (default "5m")
+grace_period = "5m"
 `
 const drkeyDelegationListSample = `
 # The list of hosts authorized to get a DS per protocol.
 piskes = [ "127.0.0.1", "127.0.0.2"]
 `
+const colibriSample = `
+# Path to the JSON file containing the capacity matrix of this AS. Required if
+# the colibri_db is configured. The file is reloaded when the service receives a
+# SIGHUP; invalid capacities are rejected and the previous ones are kept.
+capacities = "/etc/scion/capacities.json"
+
+# Fraction of the free bandwidth that can be granted to a single request.
+# (default 0.75)
+delta = 0.75
+
+# Admission algorithm for segment reservations. Either "stateless", which
+# recomputes the demands from the DB on every request, or "stateful", which
+# keeps them in memory. (default "stateless")
+admission = "stateless"
+
+# Path to the YAML file listing the segment reservations that this AS sets up,
+# renews and tears down automatically. Reservations starting in this AS use the
+# capacities of interface 0. (default "", no reservations are managed)
+reservations = "/etc/scion/reservations.yml"
+`
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	promgrpc "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -37,12 +38,21 @@ import (
 	"github.com/scionproto/scion/go/cs/config"
 	"github.com/scionproto/scion/go/cs/ifstate"
 	"github.com/scionproto/scion/go/cs/onehop"
+	"github.com/scionproto/scion/go/cs/reservation/conf"
+	segadmission "github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	admission "github.com/scionproto/scion/go/cs/reservation/segment/admission/impl"
+	"github.com/scionproto/scion/go/cs/reservationmanager"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	colibribackend "github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	colibrigrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstore"
 	segreggrpc "github.com/scionproto/scion/go/cs/segreg/grpc"
 	"github.com/scionproto/scion/go/cs/segreq"
 	segreqgrpc "github.com/scionproto/scion/go/cs/segreq/grpc"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	libconfig "github.com/scionproto/scion/go/lib/config"
+	libdrkey "github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/drkeystorage"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
@@ -70,8 +80,10 @@ import (
 	cstrustmetrics "github.com/scionproto/scion/go/pkg/cs/trust/metrics"
 	"github.com/scionproto/scion/go/pkg/discovery"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
 	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
 	dpb "github.com/scionproto/scion/go/pkg/proto/discovery"
+	"github.com/scionproto/scion/go/pkg/service"
 	"github.com/scionproto/scion/go/pkg/storage"
 	"github.com/scionproto/scion/go/pkg/trust"
 	"github.com/scionproto/scion/go/pkg/trust/compat"
@@ -123,7 +135,8 @@ func run(file string) error {
 	defer log.HandlePanic()
 	metrics := cs.NewMetrics()
 
-	intfs, err := setup(&cfg)
+	reloads := &reloader{}
+	intfs, err := setup(&cfg, reloads.reload)
 	if err != nil {
 		return err
 	}
@@ -381,8 +394,8 @@ func run(file string) error {
 		if err != nil {
 			return serrors.WrapStr("loading master secret in DRKey", err)
 		}
-		svFactory := drkey.NewSecretValueFactory(
-			masterKey.Key0, cfg.DRKey.EpochDuration.Duration)
+		svFactory := libdrkey.NewSecretValueFactory(masterKey.Key0,
+			cfg.DRKey.EpochDuration.Duration, cfg.DRKey.Grace())
 		drkeyDB, err := storage.NewDRKeyLvl1Storage(cfg.DRKey.DRKeyDB)
 		if err != nil {
 			return serrors.WrapStr("initializing DRKey DB", err)
@@ -410,6 +423,7 @@ func run(file string) error {
 			LocalIA:    topo.IA(),
 			Store:      drkeyServStore,
 			AllowedDSs: cfg.DRKey.Delegation.ToMapPerHost(),
+			Protocols:  cfg.DRKey.Protocols.Registry(),
 		}
 		srvConfig := &tls.Config{
 			InsecureSkipVerify:    true,
@@ -426,11 +440,126 @@ func run(file string) error {
 		)
 		cppb.RegisterDRKeyLvl1ServiceServer(quicTLSServer, drkeyService)
 		cppb.RegisterDRKeyLvl2ServiceServer(tcpServer, drkeyService)
+		cppb.RegisterDRKeyDelegationServiceServer(tcpServer, drkeyService)
 		log.Info("DRKey is enabled")
 	} else {
 		log.Info("DRKey is DISABLED by configuration")
 	}
 
+	// status pages served in addition to the default ones
+	statusPages := service.StatusPages{}
+
+	// COLIBRI feature
+	if cfg.Colibri.Enabled() {
+		if drkeyServStore == nil {
+			return serrors.New("COLIBRI requires DRKey to authenticate requests")
+		}
+		colibriDB, err := storage.NewColibriStorage(cfg.Colibri.ColibriDB)
+		if err != nil {
+			return serrors.WrapStr("initializing COLIBRI DB", err)
+		}
+		defer colibriDB.Close()
+		colibriDB = colibribackend.DBWithMetrics(string(storage.BackendSqlite), colibriDB)
+		capacities, err := conf.NewReloadableCapacities(cfg.Colibri.Capacities)
+		if err != nil {
+			return serrors.WrapStr("loading COLIBRI capacities", err)
+		}
+		// the tokens must carry the same hop field MACs that the border routers verify.
+		colibriMacGen, err := cs.MACGenFactory(cfg.General.ConfigDir)
+		if err != nil {
+			return serrors.WrapStr("initializing COLIBRI MAC generator", err)
+		}
+		var admitter segadmission.Admitter = &admission.StatelessAdmission{
+			DB:         colibriDB,
+			Capacities: capacities,
+			Delta:      cfg.Colibri.Delta,
+		}
+		if cfg.Colibri.Admission == config.AdmissionStateful {
+			admitter = &admission.StatefulAdmission{
+				DB:         colibriDB,
+				Capacities: capacities,
+				Delta:      cfg.Colibri.Delta,
+			}
+		}
+		log.Info("COLIBRI admission", "algorithm", cfg.Colibri.Admission)
+		colibriStore := reservationstore.WithMetrics(
+			reservationstore.NewStore(colibriDB, admitter, drkeyServStore, colibriMacGen),
+			reservationstore.Metrics{
+				Requests:   libmetrics.NewPromCounter(metrics.ColibriRequestsTotal),
+				GrantedBW:  libmetrics.NewPromHistogram(metrics.ColibriGrantedBandwidthKbps),
+				ActiveRsvs: libmetrics.NewPromGauge(metrics.ColibriActiveReservations),
+				ConfirmationLatency: libmetrics.NewPromHistogram(
+					metrics.ColibriIndexConfirmationSeconds),
+				ExpiredIndices: libmetrics.NewPromCounter(metrics.ColibriExpiredIndicesTotal),
+			})
+		colibriRouter := segreq.NewRouter(fetcherCfg)
+		colibriForwarder := colibrigrpc.ServiceForwarder{
+			Dialer: dialer,
+			Router: colibriRouter,
+		}
+		colpb.RegisterColibriServiceServer(quicServer, &colibrigrpc.ColibriServer{
+			LocalIA:   topo.IA(),
+			Store:     colibriStore,
+			Forwarder: colibriForwarder,
+		})
+		colpb.RegisterE2EReservationServiceServer(tcpServer, &colibrigrpc.E2EServer{
+			Initiator: &reservationmanager.E2EInitiator{
+				LocalIA:   topo.IA(),
+				DB:        colibriDB,
+				Store:     colibriStore,
+				DRKeys:    drkeyServStore,
+				Forwarder: colibriForwarder,
+				Lister:    colibriForwarder,
+				Duration:  reservationmanager.DefaultE2EDuration,
+			},
+		})
+		colpb.RegisterReservationListServiceServer(tcpServer, &colibrigrpc.ListServer{
+			Store: colibriStore,
+		})
+		colibriCleaner := periodic.Start(reservationstorage.NewIndexCleaner(colibriStore),
+			30*time.Second, 30*time.Second)
+		defer colibriCleaner.Stop()
+		capacityChecker := &reservationstorage.CapacityChecker{
+			Store:        colibriStore,
+			Capacities:   capacities,
+			OverCapacity: libmetrics.NewPromGauge(metrics.ColibriOverCapacityReservations),
+		}
+		capacityCheckerRunner := periodic.Start(capacityChecker, time.Minute, 30*time.Second)
+		defer capacityCheckerRunner.Stop()
+		statusPages["colibri/capacities"] = capacityChecker.StatusPage
+		statusPages["reservations"] = reservationstorage.NewReservationsHandler(colibriStore)
+		reloads.register(func() {
+			if err := capacities.Reload(); err != nil {
+				log.Error("Unable to reload COLIBRI capacities", "err", err)
+				metrics.ColibriCapacityReloadsTotal.WithLabelValues(prom.ErrValidate).Inc()
+				return
+			}
+			log.Info("Reloaded COLIBRI capacities")
+			metrics.ColibriCapacityReloadsTotal.WithLabelValues(prom.Success).Inc()
+			capacityCheckerRunner.TriggerRun()
+		})
+		if cfg.Colibri.Reservations != "" {
+			colibriManager := periodic.Start(&reservationmanager.Manager{
+				LocalIA:         topo.IA(),
+				Core:            topo.Core(),
+				ConfigFile:      cfg.Colibri.Reservations,
+				DB:              colibriDB,
+				Store:           colibriStore,
+				DRKeys:          drkeyServStore,
+				Router:          colibriRouter,
+				Forwarder:       colibriForwarder,
+				Duration:        reservationmanager.DefaultDuration,
+				RenewalMargin:   reservationmanager.DefaultRenewalMargin,
+				RetryBackoff:    reservationmanager.DefaultRetryBackoff,
+				MaxRetryBackoff: reservationmanager.DefaultMaxRetryBackoff,
+			}, reservationmanager.DefaultInterval, reservationmanager.DefaultInterval)
+			defer colibriManager.Stop()
+		}
+		log.Info("COLIBRI is enabled")
+	} else {
+		log.Info("COLIBRI is DISABLED by configuration")
+	}
+
 	dsHealth := health.NewServer()
 	dsHealth.SetServingStatus("discovery", healthpb.HealthCheckResponse_SERVING)
 	healthpb.RegisterHealthServer(tcpServer, dsHealth)
@@ -459,7 +588,8 @@ func run(file string) error {
 		}()
 	}
 
-	err = cs.StartHTTPEndpoints(cfg.General.ID, cfg, signer, chainBuilder, cfg.Metrics)
+	err = cs.StartHTTPEndpoints(cfg.General.ID, cfg, signer, chainBuilder, cfg.Metrics,
+		statusPages)
 	if err != nil {
 		return serrors.WrapStr("registering status pages", err)
 	}
@@ -549,7 +679,7 @@ func setupBasic(file string) (config.Config, error) {
 	return cfg, nil
 }
 
-func setup(cfg *config.Config) (*ifstate.Interfaces, error) {
+func setup(cfg *config.Config, reload func()) (*ifstate.Interfaces, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, serrors.WrapStr("validating config", err)
 	}
@@ -570,10 +700,31 @@ func setup(cfg *config.Config) (*ifstate.Interfaces, error) {
 	if err := itopo.Update(topo); err != nil {
 		return nil, serrors.WrapStr("setting initial static topology", err)
 	}
-	infraenv.InitInfraEnvironment(cfg.General.Topology())
+	infraenv.InitInfraEnvironmentFunc(cfg.General.Topology(), reload)
 	return intfs, nil
 }
 
+// reloader runs the registered functions when the service receives a SIGHUP, after the
+// topology has been reloaded.
+type reloader struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+func (r *reloader) register(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fns = append(r.fns, fn)
+}
+
+func (r *reloader) reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, fn := range r.fns {
+		fn()
+	}
+}
+
 func loadBeaconStore(core bool, ia addr.IA, cfg config.Config) (cs.Store, bool, error) {
 	db, err := storage.NewBeaconStorage(cfg.BeaconDB, ia)
 	if err != nil {
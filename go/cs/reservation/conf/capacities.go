@@ -16,6 +16,7 @@ package conf
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"sort"
 
 	base "github.com/scionproto/scion/go/cs/reservation"
@@ -50,6 +51,19 @@ func (c *Capacities) Capacity(from, to uint16) uint64       { return c.c.In2Eg[f
 func (c *Capacities) CapacityIngress(ingress uint16) uint64 { return c.c.CapIn[ingress] }
 func (c *Capacities) CapacityEgress(egress uint16) uint64   { return c.c.CapEg[egress] }
 
+// LoadCapacities reads the capacity matrix from the JSON file at path.
+func LoadCapacities(path string) (*Capacities, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, serrors.WrapStr("reading capacities file", err, "path", path)
+	}
+	c := &Capacities{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, serrors.WrapStr("parsing capacities file", err, "path", path)
+	}
+	return c, nil
+}
+
 // UnmarshalJSON deserializes into the json-aware internal data structure.
 func (c *Capacities) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &c.c); err != nil {
@@ -73,6 +73,15 @@ func TestJson(t *testing.T) {
 	}
 }
 
+func TestLoadCapacities(t *testing.T) {
+	c, err := LoadCapacities("testdata/caps1.json")
+	require.NoError(t, err)
+	require.Equal(t, []uint16{1, 2, 3}, c.IngressInterfaces())
+	require.Equal(t, uint64(20), c.Capacity(3, 2))
+	_, err = LoadCapacities("testdata/nonexistent.json")
+	require.Error(t, err)
+}
+
 func TestValidation(t *testing.T) {
 	cases := map[string]struct {
 		okay bool
@@ -0,0 +1,83 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conf
+
+import (
+	"sync"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+)
+
+// ReloadableCapacities is a capacity matrix loaded from a file that can be reloaded at runtime.
+// A reload only replaces the matrix if the new one is valid, and it does so atomically: the
+// users that take a Snapshot keep on seeing the old matrix until they take a new one.
+type ReloadableCapacities struct {
+	path string
+
+	mu   sync.RWMutex
+	caps *Capacities
+}
+
+var _ base.Capacities = (*ReloadableCapacities)(nil)
+var _ base.CapacitiesSnapshotter = (*ReloadableCapacities)(nil)
+
+// NewReloadableCapacities loads the capacity matrix from the JSON file at path.
+func NewReloadableCapacities(path string) (*ReloadableCapacities, error) {
+	caps, err := LoadCapacities(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ReloadableCapacities{path: path, caps: caps}, nil
+}
+
+// Reload loads the capacity matrix again from the file. If the file cannot be read or its
+// contents are not valid, an error is returned and the current matrix is kept.
+func (r *ReloadableCapacities) Reload() error {
+	caps, err := LoadCapacities(r.path)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.caps = caps
+	return nil
+}
+
+// Snapshot returns the current capacity matrix.
+func (r *ReloadableCapacities) Snapshot() base.Capacities {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.caps
+}
+
+func (r *ReloadableCapacities) IngressInterfaces() []uint16 {
+	return r.Snapshot().IngressInterfaces()
+}
+
+func (r *ReloadableCapacities) EgressInterfaces() []uint16 {
+	return r.Snapshot().EgressInterfaces()
+}
+
+func (r *ReloadableCapacities) Capacity(from, to uint16) uint64 {
+	return r.Snapshot().Capacity(from, to)
+}
+
+func (r *ReloadableCapacities) CapacityIngress(ingress uint16) uint64 {
+	return r.Snapshot().CapacityIngress(ingress)
+}
+
+func (r *ReloadableCapacities) CapacityEgress(egress uint16) uint64 {
+	return r.Snapshot().CapacityEgress(egress)
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conf
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestReloadableCapacities(t *testing.T) {
+	dir, cleanF := xtest.MustTempDir("", "capacities")
+	defer cleanF()
+	path := filepath.Join(dir, "capacities.json")
+	write := func(raw string) {
+		require.NoError(t, ioutil.WriteFile(path, []byte(raw), 0644))
+	}
+	write(`{"ingress_kbps": {"1": 100}, "egress_kbps": {"2": 100},
+		"ingress_to_egress_kbps": {"1": {"2": 50}}}`)
+	c, err := NewReloadableCapacities(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), c.CapacityIngress(1))
+	snapshot := c.Snapshot()
+
+	// invalid capacities are not applied
+	write(`{"ingress_kbps": {"1": 10}, "egress_kbps": {"2": 100},
+		"ingress_to_egress_kbps": {"1": {"2": 50}}}`)
+	require.Error(t, c.Reload())
+	require.Equal(t, uint64(100), c.CapacityIngress(1))
+	write(`{"ingress_kbps": `)
+	require.Error(t, c.Reload())
+	require.Equal(t, uint64(100), c.CapacityIngress(1))
+
+	write(`{"ingress_kbps": {"1": 60, "3": 10}, "egress_kbps": {"2": 80},
+		"ingress_to_egress_kbps": {"1": {"2": 50}}}`)
+	require.NoError(t, c.Reload())
+	require.Equal(t, uint64(60), c.CapacityIngress(1))
+	require.Equal(t, uint64(80), c.CapacityEgress(2))
+	require.Equal(t, []uint16{1, 3}, c.IngressInterfaces())
+	// the snapshot taken before the reload does not change
+	require.Equal(t, uint64(100), snapshot.CapacityIngress(1))
+	require.Equal(t, uint64(100), snapshot.CapacityEgress(2))
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Initiated is an e2e reservation set up by this AS on behalf of one of its end hosts. It
+// keeps what this AS needs to renew and clean up the reservation.
+type Initiated struct {
+	ID         reservation.E2EID
+	Host       net.IP                             // the end host that set it up
+	Segments   []reservation.SegmentID            // the stitched segment reservations
+	HopCounts  []uint8                            // the number of ASes of each segment
+	Path       segment.ReservationTransparentPath // stitched from the segment reservations
+	LastIdx    reservation.IndexNumber
+	Expiration time.Time // of the last index
+}
+
+// Validate will return an error for invalid values.
+func (r *Initiated) Validate() error {
+	if r.Host == nil {
+		return serrors.New("initiated e2e reservation without host", "id", r.ID)
+	}
+	if len(r.Segments) < 1 || len(r.Segments) > 3 || len(r.HopCounts) != len(r.Segments) {
+		return serrors.New("wrong number of segment reservations in initiated e2e reservation",
+			"segments", len(r.Segments), "hop_counts", len(r.HopCounts))
+	}
+	return r.Path.Validate()
+}
+
+// OwnedBy returns true if the host set up the reservation.
+func (r *Initiated) OwnedBy(host net.IP) bool {
+	return r.Host.Equal(host)
+}
@@ -53,6 +53,7 @@ func NewRequest(ts time.Time, id *reservation.E2EID, idx reservation.IndexNumber
 
 // SetupRequest represents all possible e2e setup requests.
 type SetupRequest interface {
+	base.MessageWithPath
 	IsSuccessful() bool
 	GetCommonSetupReq() *SetupReq // return the underlying basic SetupReq (common for all)
 }
@@ -93,7 +94,7 @@ func NewSetupRequest(r *Request, segRsvs []reservation.SegmentID, segRsvCount []
 		}
 	}
 	totalASCount -= len(segRsvCount) - 1
-	if currASindex < 0 {
+	if currASindex < 0 || len(allocTrail) >= totalASCount {
 		return nil, serrors.New("error initializing e2e request",
 			"alloc_trail_len", len(allocTrail), "seg_rsv_count", segRsvCount)
 	}
@@ -138,12 +139,13 @@ func (l PathLocation) String() string {
 	return "unknown path location"
 }
 
-// Location returns the location of this node in the path of the request.
+// Location returns the location of this node in the path of the request. Every AS admitting
+// the request appends to the allocation trail, thus its length is the index of this AS.
 func (r *SetupReq) Location() PathLocation {
 	switch len(r.AllocationTrail) {
 	case 0:
 		return Source
-	case r.totalASCount:
+	case r.totalASCount - 1:
 		return Destination
 	default:
 		return Transit
@@ -178,7 +180,7 @@ func (s *SetupReqSuccess) IsSuccessful() bool {
 // SetupReqFailure is a failed e2e setup request also traveling along the reservation path.
 type SetupReqFailure struct {
 	SetupReq
-	ErrorCode uint8
+	ErrorCode reservation.ErrorCode
 }
 
 var _ SetupRequest = (*SetupReqFailure)(nil)
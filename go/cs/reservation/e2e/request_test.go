@@ -62,6 +62,11 @@ func TestNewSetupRequest(t *testing.T) {
 	trail := make([]reservation.BWCls, 0)
 	_, err = NewSetupRequest(baseReq, segmentRsvs, segmentASCount, 5, trail)
 	require.Error(t, err)
+	// the trail cannot contain more entries than ASes before the destination
+	trail = make([]reservation.BWCls, 2)
+	_, err = NewSetupRequest(baseReq, []reservation.SegmentID{*newTestSegmentID(t)},
+		[]uint8{2}, 5, trail)
+	require.Error(t, err)
 
 	cases := map[string]struct {
 		ASCountPerSegment []uint8
@@ -86,14 +91,6 @@ func TestNewSetupRequest(t *testing.T) {
 			TrailLength:       1,
 			TotalASCount:      2,
 			SegmentIndex:      0,
-			PathLocation:      Transit,
-			IsTransfer:        false,
-		},
-		"2 at 2": {
-			ASCountPerSegment: []uint8{2},
-			TrailLength:       2,
-			TotalASCount:      2,
-			SegmentIndex:      0,
 			PathLocation:      Destination,
 			IsTransfer:        false,
 		},
@@ -145,17 +142,17 @@ func TestNewSetupRequest(t *testing.T) {
 			PathLocation:      Transit,
 			IsTransfer:        false,
 		},
-		"3-4-5 at 9": {
+		"3-4-5 at 8": {
 			ASCountPerSegment: []uint8{3, 4, 5},
-			TrailLength:       9,
+			TrailLength:       8,
 			TotalASCount:      10,
 			SegmentIndex:      2,
 			PathLocation:      Transit,
 			IsTransfer:        false,
 		},
-		"3-4-5 at 10": {
+		"3-4-5 at 9": {
 			ASCountPerSegment: []uint8{3, 4, 5},
-			TrailLength:       10,
+			TrailLength:       9,
 			TotalASCount:      10,
 			SegmentIndex:      2,
 			PathLocation:      Destination,
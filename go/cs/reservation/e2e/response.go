@@ -63,11 +63,22 @@ type ResponseSetupSuccess struct {
 }
 
 // ResponseSetupFailure is the response to a failed setup. It's sent on the reverse direction.
-// The failed hop is the length of MaxBWs + 1.
 type ResponseSetupFailure struct {
 	Response
-	ErrorCode uint8
-	MaxBWs    []reservation.BWCls // granted by ASes in the path until the failed hop
+	ErrorCode reservation.ErrorCode
+	MaxBWs    []reservation.BWCls // max bandwidths willing to be granted by the ASes in the path
+}
+
+var _ base.FailureResponse = (*ResponseSetupFailure)(nil)
+
+// Failure returns the failure of the setup, including the maximum bandwidth the failing AS
+// was willing to grant.
+func (r *ResponseSetupFailure) Failure() *reservation.Failure {
+	var maxBW reservation.BWCls
+	if int(r.FailedHop) < len(r.MaxBWs) {
+		maxBW = r.MaxBWs[r.FailedHop]
+	}
+	return base.NewFailure(r.Path(), r.ErrorCode, r.FailedHop, maxBW)
 }
 
 // ResponseCleanupSuccess is a response to a successful cleanup request.
@@ -78,5 +89,10 @@ type ResponseCleanupSuccess struct {
 // ResponseCleanupFailure is a failed index cleanup.
 type ResponseCleanupFailure struct {
 	Response
-	ErrorCode uint8
+	ErrorCode reservation.ErrorCode
+}
+
+// Failure returns the failure of the cleanup.
+func (r *ResponseCleanupFailure) Failure() *reservation.Failure {
+	return base.NewFailure(r.Path(), r.ErrorCode, r.FailedHop, 0)
 }
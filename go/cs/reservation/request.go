@@ -21,7 +21,8 @@ import (
 // RequestMetadata contains information about the request, such as its forwarding path.
 // This base struct can be used by any request or response packets.
 type RequestMetadata struct {
-	path ColibriPath // the path the packet came / will go with
+	path           ColibriPath // the path the packet came / will go with
+	authenticators [][]byte    // one per AS in the path, computed by the initiator
 }
 
 // NewRequestMetadata constructs the base Request type.
@@ -43,3 +44,14 @@ func (m *RequestMetadata) Path() ColibriPath {
 func (m *RequestMetadata) IsLastAS() bool {
 	return m.path.IndexOfCurrentHop() == m.path.NumberOfHops()-1
 }
+
+// Authenticators returns the DRKey authenticators of the request. There is one per AS in the
+// path, the one corresponding to the initiator is empty.
+func (m *RequestMetadata) Authenticators() [][]byte {
+	return m.authenticators
+}
+
+// SetAuthenticators sets the DRKey authenticators of the request.
+func (m *RequestMetadata) SetAuthenticators(authenticators [][]byte) {
+	m.authenticators = authenticators
+}
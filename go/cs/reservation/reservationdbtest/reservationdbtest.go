@@ -19,6 +19,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -41,17 +42,21 @@ type TestableDB interface {
 func TestDB(t *testing.T, db TestableDB) {
 	tests := map[string]func(context.Context, *testing.T, backend.DB){
 		"insert segment reservations create ID":  testNewSegmentRsv,
+		"insert segment reservations keep ID":    testNewSegmentRsvWithID,
 		"persist segment reservation":            testPersistSegmentRsv,
 		"get segment reservation from ID":        testGetSegmentRsvFromID,
 		"get segment reservations from src/dst":  testGetSegmentRsvsFromSrcDstIA,
 		"get segment reservation from path":      testGetSegmentRsvFromPath,
 		"get all segment reservations":           testGetAllSegmentRsvs,
 		"get segment reservation from IF pair":   testGetSegmentRsvsFromIFPair,
+		"get telescopic segment reservations":    testGetTelescopicSegmentRsvs,
 		"delete segment reservation":             testDeleteSegmentRsv,
 		"delete expired indices":                 testDeleteExpiredIndices,
 		"persist e2e reservation":                testPersistE2ERsv,
 		"get e2e reservation from ID":            testGetE2ERsvFromID,
 		"get e2e reservations from segment ones": testGetE2ERsvsOnSegRsv,
+		"get all e2e reservations":               testGetAllE2ERsvs,
+		"initiated e2e reservations":             testInitiatedE2ERsvs,
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -98,6 +103,21 @@ func testNewSegmentRsv(ctx context.Context, t *testing.T, db backend.DB) {
 	require.Equal(t, r, rsv)
 }
 
+func testNewSegmentRsvWithID(ctx context.Context, t *testing.T, db backend.DB) {
+	r := newTestReservation(t)
+	copy(r.ID.Suffix[:], xtest.MustParseHexString("beefcafe"))
+	err := db.NewSegmentRsvWithID(ctx, r)
+	require.NoError(t, err)
+	require.Equal(t, xtest.MustParseHexString("beefcafe"), r.ID.Suffix[:])
+	rsv, err := db.GetSegmentRsvFromID(ctx, &r.ID)
+	require.NoError(t, err)
+	require.Equal(t, r, rsv)
+	// the ID is already in use
+	r.Path = nil
+	err = db.NewSegmentRsvWithID(ctx, r)
+	require.Error(t, err)
+}
+
 func testPersistSegmentRsv(ctx context.Context, t *testing.T, db backend.DB) {
 	r := newTestReservation(t)
 	for i := uint32(1); i < 10; i++ {
@@ -326,6 +346,25 @@ func testGetSegmentRsvsFromIFPair(ctx context.Context, t *testing.T, db backend.
 	require.Len(t, rsvs, 2)
 	expected = []*segment.Reservation{r1, r3}
 	require.ElementsMatch(t, expected, rsvs)
+	// telescopic reservations stacked on r1 are left out, unless they diverge from it
+	stacked := newTestReservation(t)
+	stacked.Ingress = r1.Ingress
+	stacked.Egress = r1.Egress
+	stacked.BaseID = &reservation.SegmentID{ASID: r1.ID.ASID, Suffix: r1.ID.Suffix}
+	err = db.NewSegmentRsv(ctx, stacked)
+	require.NoError(t, err)
+	diverged := newTestReservation(t)
+	diverged.Ingress = r1.Ingress
+	diverged.Egress = r3.Egress
+	diverged.BaseID = &reservation.SegmentID{ASID: r1.ID.ASID, Suffix: r1.ID.Suffix}
+	err = db.NewSegmentRsv(ctx, diverged)
+	require.NoError(t, err)
+	rsvs, err = db.GetSegmentRsvsFromIFPair(ctx, &r1.Ingress, &r1.Egress)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*segment.Reservation{r1}, rsvs)
+	rsvs, err = db.GetSegmentRsvsFromIFPair(ctx, &r1.Ingress, nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*segment.Reservation{r1, r3, diverged}, rsvs)
 	// no matches
 	var inexistentIngress uint16 = 222
 	rsvs, err = db.GetSegmentRsvsFromIFPair(ctx, &inexistentIngress, nil)
@@ -336,6 +375,64 @@ func testGetSegmentRsvsFromIFPair(ctx context.Context, t *testing.T, db backend.
 	require.Error(t, err)
 }
 
+func testGetTelescopicSegmentRsvs(ctx context.Context, t *testing.T, db backend.DB) {
+	// two base reservations, t1 and t2 on top of b1, t3 on top of b2
+	b1 := newTestReservation(t)
+	err := db.NewSegmentRsv(ctx, b1)
+	require.NoError(t, err)
+	b2 := newTestReservation(t)
+	err = db.NewSegmentRsv(ctx, b2)
+	require.NoError(t, err)
+	t1 := newTestReservation(t)
+	t1.BaseID = &reservation.SegmentID{ASID: b1.ID.ASID, Suffix: b1.ID.Suffix}
+	err = db.NewSegmentRsv(ctx, t1)
+	require.NoError(t, err)
+	t2 := newTestReservation(t)
+	t2.BaseID = &reservation.SegmentID{ASID: b1.ID.ASID, Suffix: b1.ID.Suffix}
+	err = db.NewSegmentRsv(ctx, t2)
+	require.NoError(t, err)
+	t3 := newTestReservation(t)
+	t3.BaseID = &reservation.SegmentID{ASID: b2.ID.ASID, Suffix: b2.ID.Suffix}
+	err = db.NewSegmentRsv(ctx, t3)
+	require.NoError(t, err)
+	// same base suffix but different ID prefix
+	other := newTestReservation(t)
+	other.ID.ASID = xtest.MustParseAS("ff00:1234:1")
+	other.BaseID = &reservation.SegmentID{ASID: other.ID.ASID, Suffix: b1.ID.Suffix}
+	err = db.NewSegmentRsv(ctx, other)
+	require.NoError(t, err)
+	// the base is kept when reading and persisting
+	rsv, err := db.GetSegmentRsvFromID(ctx, &t1.ID)
+	require.NoError(t, err)
+	require.Equal(t, t1, rsv)
+	rsv, err = db.GetSegmentRsvFromID(ctx, &b1.ID)
+	require.NoError(t, err)
+	require.Nil(t, rsv.BaseID)
+	t1.Egress = 2
+	err = db.PersistSegmentRsv(ctx, t1)
+	require.NoError(t, err)
+	rsv, err = db.GetSegmentRsvFromID(ctx, &t1.ID)
+	require.NoError(t, err)
+	require.Equal(t, t1, rsv)
+	// query by base
+	rsvs, err := db.GetTelescopicSegmentRsvs(ctx, &b1.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*segment.Reservation{t1, t2}, rsvs)
+	rsvs, err = db.GetTelescopicSegmentRsvs(ctx, &b2.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*segment.Reservation{t3}, rsvs)
+	rsvs, err = db.GetTelescopicSegmentRsvs(ctx, &t3.ID)
+	require.NoError(t, err)
+	require.Len(t, rsvs, 0)
+	// a telescopic reservation that is no longer stacked
+	t2.BaseID = nil
+	err = db.PersistSegmentRsv(ctx, t2)
+	require.NoError(t, err)
+	rsvs, err = db.GetTelescopicSegmentRsvs(ctx, &b1.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*segment.Reservation{t1}, rsvs)
+}
+
 func testDeleteSegmentRsv(ctx context.Context, t *testing.T, db backend.DB) {
 	r := newTestReservation(t)
 	err := db.NewSegmentRsv(ctx, r)
@@ -484,6 +581,63 @@ func testDeleteExpiredIndices(ctx context.Context, t *testing.T, db backend.DB)
 	require.Len(t, e2es, 0) // r4 is gone, cascades for e5
 }
 
+func testInitiatedE2ERsvs(ctx context.Context, t *testing.T, db backend.DB) {
+	id, err := reservation.NewE2EID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("beefcafebeefcafebeef"))
+	require.NoError(t, err)
+	rsv, err := db.GetInitiatedE2ERsv(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, rsv)
+	r := &e2e.Initiated{
+		ID:   *id,
+		Host: net.ParseIP("10.0.0.1"),
+		Segments: []reservation.SegmentID{
+			{ASID: xtest.MustParseAS("ff00:0:1"), Suffix: [4]byte{0, 0, 0, 1}},
+			{ASID: xtest.MustParseAS("ff00:0:2"), Suffix: [4]byte{0, 0, 0, 2}},
+		},
+		HopCounts: []uint8{2, 2},
+		Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:1", 1, 1, "1-ff00:0:2", 2,
+			1, "1-ff00:0:3", 0),
+		LastIdx:    0,
+		Expiration: util.SecsToTime(10),
+	}
+	err = db.PersistInitiatedE2ERsv(ctx, r)
+	require.NoError(t, err)
+	rsv, err = db.GetInitiatedE2ERsv(ctx, id)
+	require.NoError(t, err)
+	require.True(t, rsv.OwnedBy(net.ParseIP("10.0.0.1")))
+	rsv.Host = r.Host
+	require.Equal(t, r, rsv)
+	// renewed
+	r.LastIdx = 1
+	r.Expiration = util.SecsToTime(20)
+	err = db.PersistInitiatedE2ERsv(ctx, r)
+	require.NoError(t, err)
+	rsv, err = db.GetInitiatedE2ERsv(ctx, id)
+	require.NoError(t, err)
+	rsv.Host = r.Host
+	require.Equal(t, r, rsv)
+	// kept until its last index expires
+	_, err = db.DeleteExpiredIndices(ctx, util.SecsToTime(20))
+	require.NoError(t, err)
+	rsv, err = db.GetInitiatedE2ERsv(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, rsv)
+	_, err = db.DeleteExpiredIndices(ctx, util.SecsToTime(21))
+	require.NoError(t, err)
+	rsv, err = db.GetInitiatedE2ERsv(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, rsv)
+	// deleted
+	err = db.PersistInitiatedE2ERsv(ctx, r)
+	require.NoError(t, err)
+	err = db.DeleteInitiatedE2ERsv(ctx, id)
+	require.NoError(t, err)
+	rsv, err = db.GetInitiatedE2ERsv(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, rsv)
+}
+
 func testPersistE2ERsv(ctx context.Context, t *testing.T, db backend.DB) {
 	r1 := newTestE2EReservation(t)
 	for _, seg := range r1.SegmentReservations {
@@ -642,6 +796,29 @@ func testGetE2ERsvsOnSegRsv(ctx context.Context, t *testing.T, db backend.DB) {
 	require.ElementsMatch(t, rsvs, []*e2e.Reservation{e2, e3})
 }
 
+func testGetAllE2ERsvs(ctx context.Context, t *testing.T, db backend.DB) {
+	rsvs, err := db.GetAllE2ERsvs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rsvs)
+	s1 := newTestReservation(t)
+	err = db.NewSegmentRsv(ctx, s1)
+	require.NoError(t, err)
+	e1 := newTestE2EReservation(t)
+	e1.ID.ASID = xtest.MustParseAS("ff00:0:1")
+	e1.SegmentReservations = []*segment.Reservation{s1}
+	err = db.PersistE2ERsv(ctx, e1)
+	require.NoError(t, err)
+	e2 := newTestE2EReservation(t)
+	e2.ID.ASID = xtest.MustParseAS("ff00:0:2")
+	e2.SegmentReservations = []*segment.Reservation{s1}
+	err = db.PersistE2ERsv(ctx, e2)
+	require.NoError(t, err)
+	// test
+	rsvs, err = db.GetAllE2ERsvs(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, rsvs, []*e2e.Reservation{e1, e2})
+}
+
 // newToken just returns a token that can be serialized. This one has two HopFields.
 func newToken() *reservation.Token {
 	t, err := reservation.TokenFromRaw(xtest.MustParseHexString(
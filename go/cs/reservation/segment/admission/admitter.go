@@ -16,13 +16,33 @@ package admission
 
 import (
 	"context"
+	"time"
 
 	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
 )
 
+// ErrAdmissionDenied is returned when the bandwidth this AS can allocate to a request is
+// smaller than its minimum bandwidth. Other admission errors are internal failures.
+var ErrAdmissionDenied = serrors.New("admission denied")
+
 // Admitter specifies what an admission entity has to implement to govern the segment admission.
 type Admitter interface {
 	// req will be modified with the allowed and maximum bandwidths if they were computed.
-	// It can also return an error.
+	// It can also return an error, which is ErrAdmissionDenied if the request cannot be
+	// granted its minimum bandwidth.
 	AdmitRsv(ctx context.Context, req *segment.SetupReq) error
 }
+
+// ReservationTracker is implemented by admission entities that keep their own state about the
+// segment reservations. The reservation store notifies them of every change it persists.
+type ReservationTracker interface {
+	// UpdateRsv is called after the reservation has been persisted.
+	UpdateRsv(rsv *segment.Reservation)
+	// RemoveRsv is called after the reservation has been deleted.
+	RemoveRsv(id *reservation.SegmentID)
+	// RemoveExpired is called after the indices that expired before now have been deleted,
+	// together with the reservations left without indices.
+	RemoveExpired(now time.Time)
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"math"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// The functions in this file implement the admission formulas. They are shared by all the
+// admission algorithms, which differ only in how they obtain the demands of the existing
+// reservations.
+
+// demands represents the demands for a given source, and a specific ingress-egress interface pair.
+// from the admission spec: srcDem, inDem and egDem for a given source.
+type demands struct {
+	src, in, eg uint64
+}
+
+// demsPerSrc is used in the transit demand computation.
+type demPerSource map[addr.AS]demands
+
+// allocate adds the allocation bead of this AS to the request. It returns an error if the
+// maximum bandwidth this AS can allocate is smaller than the minimum requested.
+func allocate(req *segment.SetupReq, avail, ideal uint64) error {
+	maxAlloc := reservation.BWClsFromBW(minBW(avail, ideal))
+	bead := reservation.AllocationBead{
+		AllocBW: reservation.MinBWCls(maxAlloc, req.MaxBW),
+		MaxBW:   maxAlloc,
+	}
+	req.AllocTrail = append(req.AllocTrail, bead)
+	if maxAlloc < req.MinBW {
+		return serrors.WithCtx(admission.ErrAdmissionDenied, "maxalloc", maxAlloc,
+			"minbw", req.MinBW, "segment_id", req.ID)
+	}
+	return nil
+}
+
+// availableBW returns the bandwidth that can be granted to the request, given the bandwidth
+// blocked by the other reservations using the same ingress and egress interfaces.
+func availableBW(caps base.Capacities, delta float64, req *segment.SetupReq,
+	blockedIngress, blockedEgress uint64) uint64 {
+
+	freeIngress := freeBW(caps.CapacityIngress(req.Ingress), blockedIngress)
+	freeEgress := freeBW(caps.CapacityEgress(req.Egress), blockedEgress)
+	free := float64(minBW(freeIngress, freeEgress))
+	return uint64(free * delta)
+}
+
+// freeBW returns the capacity not blocked. The blocked bandwidth can exceed the capacity,
+// e.g. after the capacities are reloaded with lower values, in which case nothing is free.
+func freeBW(capacity, blocked uint64) uint64 {
+	if blocked >= capacity {
+		return 0
+	}
+	return capacity - blocked
+}
+
+func idealBW(caps base.Capacities, req *segment.SetupReq, tubeRatio, linkRatio float64) uint64 {
+	cap := float64(caps.CapacityEgress(req.Egress))
+	return uint64(cap * tubeRatio * linkRatio)
+}
+
+// tubeRatio computes the tube ratio of the request. The parameter demsPerSrc must hold the
+// demands for the ingress interface of the request, and demsForIngress must return them for
+// any other ingress interface.
+func tubeRatio(caps base.Capacities, req *segment.SetupReq, demsPerSrc demPerSource,
+	demsForIngress func(ingress uint16) (demPerSource, error)) (float64, error) {
+
+	capIn := caps.CapacityIngress(req.Ingress)
+	capEg := caps.CapacityEgress(req.Egress)
+	numerator := minBW(capIn, transitDemand(capIn, capEg, demsPerSrc))
+	var sum uint64
+	for _, in := range caps.IngressInterfaces() {
+		demandsForThisIngress, err := demsForIngress(in)
+		if err != nil {
+			return 0, serrors.WrapStr("cannot compute transit demand", err)
+		}
+		dem := transitDemand(caps.CapacityIngress(in), capEg, demandsForThisIngress)
+		sum += minBW(caps.CapacityIngress(in), dem)
+	}
+	return float64(numerator) / float64(sum), nil
+}
+
+// linkRatio computes the link ratio of the request. The parameter srcAllocPerSrc must hold
+// the bandwidth blocked by the other reservations, grouped by source.
+func linkRatio(caps base.Capacities, req *segment.SetupReq, demsPerSrc demPerSource,
+	srcAllocPerSrc map[addr.AS]uint64) (float64, error) {
+
+	capEg := caps.CapacityEgress(req.Egress)
+	demEg := demsPerSrc[req.ID.ASID].eg
+
+	prevBW := req.AllocTrail.MinMax().ToKbps() // min of maxBW in the trail
+	var egScalFctr float64
+	if demEg != 0 {
+		egScalFctr = float64(minBW(capEg, demEg)) / float64(demEg)
+	}
+	numerator := egScalFctr * float64(prevBW)
+	egScalFctrs := make(map[addr.AS]float64)
+	for src, dem := range demsPerSrc {
+		var egScalFctr float64
+		if dem.eg != 0 {
+			egScalFctr = float64(minBW(capEg, dem.eg)) / float64(dem.eg)
+		}
+		egScalFctrs[src] = egScalFctr
+	}
+	if _, found := srcAllocPerSrc[req.ID.ASID]; !found {
+		// add the source of the request, if not already present
+		srcAllocPerSrc[req.ID.ASID] = 0 // the value of the srcAlloc itself won't be used
+	}
+	// TODO(juagargi) after debugging, integrate this loop into the previous one:
+	var denom float64
+	for src, srcAlloc := range srcAllocPerSrc {
+		if src == req.ID.ASID {
+			srcAlloc += prevBW
+		}
+		egScalFctr, found := egScalFctrs[src]
+		if !found {
+			return 0, serrors.New("cannot compute link ratio, internal error: "+
+				"source not found in the egress scale factors", "src", src)
+		}
+		denom += float64(srcAlloc) * egScalFctr
+	}
+	return numerator / denom, nil
+}
+
+// transitDemand computes the transit demand from an ingress interface to the egress one.
+// The parameter demsPerSrc must hold the inDem, egDem and srcDem of all reservations,
+// grouped by source, for that ingress interface.
+func transitDemand(capIn, capEg uint64, demsPerSrc demPerSource) uint64 {
+	// TODO(juagargi) adjSrcDem is not needed, remove after finishing debugging the admission
+	adjSrcDem := make(map[addr.AS]uint64) // every adjSrcDem grouped by source
+	for src, dems := range demsPerSrc {
+		var inScalFctr float64 = 1.
+		if dems.in != 0 {
+			inScalFctr = float64(minBW(capIn, dems.in)) / float64(dems.in)
+		}
+		var egScalFctr float64 = 1.
+		if dems.eg != 0 {
+			egScalFctr = float64(minBW(capEg, dems.eg)) / float64(dems.eg)
+		}
+		adjSrcDem[src] = uint64(math.Min(inScalFctr, egScalFctr) * float64(dems.src))
+	}
+	// now reduce adjSrcDem
+	var transitDem uint64
+	for _, dem := range adjSrcDem {
+		transitDem += dem
+	}
+	return transitDem
+}
+
+// addRequestDemands adds the demand of the request itself to whatever we have for its source.
+func addRequestDemands(demsPerSrc demPerSource, capIn, capEg uint64, ingress uint16,
+	req *segment.SetupReq) {
+
+	bucket := demsPerSrc[req.ID.ASID]
+	dem := min3BW(capIn, capEg, req.MaxBW.ToKbps())
+	if req.Ingress == ingress {
+		bucket.in += dem
+	}
+	bucket.eg += dem
+	if req.Ingress == ingress {
+		bucket.src += dem
+	}
+	demsPerSrc[req.ID.ASID] = bucket
+}
+
+func minBW(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func min3BW(a, b, c uint64) uint64 {
+	return minBW(minBW(a, b), c)
+}
@@ -0,0 +1,389 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// StatefulAdmission admits segment reservations with the same formulas as StatelessAdmission,
+// but keeps the demands of the existing reservations in memory, grouped per source and
+// interface. The state is loaded from the DB on the first admission, and then updated
+// incrementally by the reservation store, through the admission.ReservationTracker methods.
+type StatefulAdmission struct {
+	DB         backend.DB
+	Capacities base.Capacities // aka capacity matrix
+	Delta      float64         // fraction of free BW that can be reserved in one request
+
+	mu      sync.Mutex
+	loaded  bool
+	rsvs    map[reservation.SegmentID]*trackedRsv
+	sources map[addr.AS]*sourceDemands
+	// blocked BW per ingress and egress interface
+	blockedIngress map[uint16]uint64
+	blockedEgress  map[uint16]uint64
+}
+
+var _ admission.Admitter = (*StatefulAdmission)(nil)
+var _ admission.ReservationTracker = (*StatefulAdmission)(nil)
+
+// AdmitRsv admits a segment reservation. The request will be modified with the allowed and
+// maximum bandwidths if they were computed. It can also return an error that must be checked.
+func (a *StatefulAdmission) AdmitRsv(ctx context.Context, req *segment.SetupReq) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.load(ctx); err != nil {
+		return serrors.WrapStr("cannot load admission state", err, "segment_id", req.ID)
+	}
+	// the whole admission uses the same capacities, even if they are reloaded meanwhile
+	caps := base.SnapshotCapacities(a.Capacities)
+	var err error
+	a.excluding(req.ID, func() {
+		avail := availableBW(caps, a.Delta, req,
+			a.blockedIngress[req.Ingress], a.blockedEgress[req.Egress])
+		var ideal uint64
+		if ideal, err = a.idealBW(caps, req); err != nil {
+			err = serrors.WrapStr("cannot compute ideal bandwidth", err, "segment_id", req.ID)
+			return
+		}
+		err = allocate(req, avail, ideal)
+	})
+	return err
+}
+
+// UpdateRsv updates the demands of the reservation.
+func (a *StatefulAdmission) UpdateRsv(rsv *segment.Reservation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.loaded {
+		// the reservation will be found in the DB when loading.
+		return
+	}
+	a.remove(rsv.ID)
+	tracked := newTrackedRsv(rsv)
+	if rsv.BaseID != nil {
+		base, ok := a.rsvs[*rsv.BaseID]
+		tracked.stacked = ok && base.ingress == rsv.Ingress && base.egress == rsv.Egress
+	}
+	a.add(rsv.ID, tracked)
+}
+
+// RemoveRsv removes the demands of the reservation.
+func (a *StatefulAdmission) RemoveRsv(id *reservation.SegmentID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.loaded {
+		return
+	}
+	a.remove(*id)
+}
+
+// RemoveExpired removes the indices that expired before now, as the DB does. The reservations
+// left without indices are removed.
+func (a *StatefulAdmission) RemoveExpired(now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.loaded {
+		return
+	}
+	for id, rsv := range a.rsvs {
+		if !rsv.expiresBefore(now) {
+			continue
+		}
+		a.remove(id)
+		if rsv = rsv.withoutExpired(now); len(rsv.indices) > 0 {
+			a.add(id, rsv)
+		}
+	}
+}
+
+// load reads all the reservations from the DB, only the first time it's called.
+func (a *StatefulAdmission) load(ctx context.Context) error {
+	if a.loaded {
+		return nil
+	}
+	rsvs, err := a.DB.GetAllSegmentRsvs(ctx)
+	if err != nil {
+		return serrors.WrapStr("cannot list all reservations", err)
+	}
+	a.rsvs = make(map[reservation.SegmentID]*trackedRsv, len(rsvs))
+	a.sources = make(map[addr.AS]*sourceDemands)
+	a.blockedIngress = make(map[uint16]uint64)
+	a.blockedEgress = make(map[uint16]uint64)
+	notStacked := make(map[reservation.SegmentID]struct{}, len(rsvs))
+	for _, rsv := range withoutStacked(rsvs) {
+		notStacked[rsv.ID] = struct{}{}
+	}
+	for _, rsv := range rsvs {
+		tracked := newTrackedRsv(rsv)
+		_, ok := notStacked[rsv.ID]
+		tracked.stacked = !ok
+		a.add(rsv.ID, tracked)
+	}
+	a.loaded = true
+	return nil
+}
+
+func (a *StatefulAdmission) add(id reservation.SegmentID, rsv *trackedRsv) {
+	a.rsvs[id] = rsv
+	if rsv.stacked {
+		// it uses the bandwidth of its base
+		return
+	}
+	src, ok := a.sources[id.ASID]
+	if !ok {
+		src = newSourceDemands()
+		a.sources[id.ASID] = src
+	}
+	src.add(rsv, 1)
+	a.blockedIngress[rsv.ingress] += rsv.blocked
+	a.blockedEgress[rsv.egress] += rsv.blocked
+}
+
+func (a *StatefulAdmission) remove(id reservation.SegmentID) {
+	rsv, ok := a.rsvs[id]
+	if !ok {
+		return
+	}
+	delete(a.rsvs, id)
+	if rsv.stacked {
+		return
+	}
+	src := a.sources[id.ASID]
+	src.add(rsv, -1)
+	if src.count == 0 {
+		delete(a.sources, id.ASID)
+	}
+	a.blockedIngress[rsv.ingress] -= rsv.blocked
+	a.blockedEgress[rsv.egress] -= rsv.blocked
+}
+
+// excluding runs fn without the demands of the reservation with that ID, as an existing
+// reservation doesn't compete with its own renewal.
+func (a *StatefulAdmission) excluding(id reservation.SegmentID, fn func()) {
+	if rsv, ok := a.rsvs[id]; ok {
+		a.remove(id)
+		defer a.add(id, rsv)
+	}
+	fn()
+}
+
+func (a *StatefulAdmission) idealBW(caps base.Capacities, req *segment.SetupReq) (
+	uint64, error) {
+
+	demsPerSrcRegIngress := a.computeTempDemands(caps, req.Ingress, req)
+	tubeRatio, err := a.tubeRatio(caps, req, demsPerSrcRegIngress)
+	if err != nil {
+		return 0, serrors.WrapStr("cannot compute tube ratio", err)
+	}
+	linkRatio, err := a.linkRatio(caps, req, demsPerSrcRegIngress)
+	if err != nil {
+		return 0, serrors.WrapStr("cannot compute link ratio", err)
+	}
+	return idealBW(caps, req, tubeRatio, linkRatio), nil
+}
+
+func (a *StatefulAdmission) tubeRatio(caps base.Capacities, req *segment.SetupReq,
+	demsPerSrc demPerSource) (float64, error) {
+
+	return tubeRatio(caps, req, demsPerSrc, func(ingress uint16) (demPerSource, error) {
+		return a.computeTempDemands(caps, ingress, req), nil
+	})
+}
+
+func (a *StatefulAdmission) linkRatio(caps base.Capacities, req *segment.SetupReq,
+	demsPerSrc demPerSource) (float64, error) {
+
+	srcAllocPerSrc := make(map[addr.AS]uint64, len(a.sources))
+	for as, src := range a.sources {
+		srcAllocPerSrc[as] = src.blocked
+	}
+	return linkRatio(caps, req, demsPerSrc, srcAllocPerSrc)
+}
+
+// computeTempDemands computes inDem, egDem and srcDem grouped by source, for all sources,
+// from the demands kept per source and interface.
+func (a *StatefulAdmission) computeTempDemands(caps base.Capacities, ingress uint16,
+	req *segment.SetupReq) demPerSource {
+
+	capIn := caps.CapacityIngress(ingress)
+	capEg := caps.CapacityEgress(req.Egress)
+	capDem := minBW(capIn, capEg)
+	demsPerSrc := make(demPerSource, len(a.sources))
+	for as, src := range a.sources {
+		demsPerSrc[as] = demands{
+			in:  src.ingress[ingress].capped(capDem),
+			eg:  src.egress[req.Egress].capped(capDem),
+			src: src.pair[ifPair{ingress: ingress, egress: req.Egress}].capped(capDem),
+		}
+	}
+	addRequestDemands(demsPerSrc, capIn, capEg, ingress, req)
+	return demsPerSrc
+}
+
+// trackedRsv is the part of a segment reservation relevant for the admission.
+type trackedRsv struct {
+	ingress   uint16
+	egress    uint16
+	indices   []trackedIndex
+	requested uint64 // max requested BW
+	blocked   uint64 // max blocked BW
+	stacked   bool   // telescopic, with the same interfaces as its base
+}
+
+type trackedIndex struct {
+	expiration time.Time
+	maxBW      reservation.BWCls
+	allocBW    reservation.BWCls
+}
+
+// expiredBefore uses the same granularity as the DB.
+func (index trackedIndex) expiredBefore(now time.Time) bool {
+	return util.TimeToSecs(index.expiration) < util.TimeToSecs(now)
+}
+
+func newTrackedRsv(rsv *segment.Reservation) *trackedRsv {
+	t := &trackedRsv{
+		ingress:   rsv.Ingress,
+		egress:    rsv.Egress,
+		indices:   make([]trackedIndex, len(rsv.Indices)),
+		requested: rsv.MaxRequestedBW(),
+		blocked:   rsv.MaxBlockedBW(),
+	}
+	for i, index := range rsv.Indices {
+		t.indices[i] = trackedIndex{
+			expiration: index.Expiration,
+			maxBW:      index.MaxBW,
+			allocBW:    index.AllocBW,
+		}
+	}
+	return t
+}
+
+func (r *trackedRsv) expiresBefore(now time.Time) bool {
+	for _, index := range r.indices {
+		if index.expiredBefore(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutExpired returns a copy of the reservation without the indices expired before now.
+func (r *trackedRsv) withoutExpired(now time.Time) *trackedRsv {
+	rsv := segment.NewReservation()
+	rsv.Ingress = r.ingress
+	rsv.Egress = r.egress
+	for _, index := range r.indices {
+		if !index.expiredBefore(now) {
+			rsv.Indices = append(rsv.Indices, segment.Index{
+				Expiration: index.expiration,
+				MaxBW:      index.maxBW,
+				AllocBW:    index.allocBW,
+			})
+		}
+	}
+	t := newTrackedRsv(rsv)
+	t.stacked = r.stacked
+	return t
+}
+
+type ifPair struct {
+	ingress uint16
+	egress  uint16
+}
+
+// sourceDemands keeps the demands of all the reservations of one source.
+type sourceDemands struct {
+	count   int    // number of reservations
+	blocked uint64 // sum of the max blocked BW
+	ingress map[uint16]demandHistogram
+	egress  map[uint16]demandHistogram
+	pair    map[ifPair]demandHistogram
+}
+
+func newSourceDemands() *sourceDemands {
+	return &sourceDemands{
+		ingress: make(map[uint16]demandHistogram),
+		egress:  make(map[uint16]demandHistogram),
+		pair:    make(map[ifPair]demandHistogram),
+	}
+}
+
+// add adds (sign=1) or removes (sign=-1) the reservation to the demands of the source.
+func (s *sourceDemands) add(rsv *trackedRsv, sign int) {
+	s.count += sign
+	if sign > 0 {
+		s.blocked += rsv.blocked
+	} else {
+		s.blocked -= rsv.blocked
+	}
+	if h := s.ingress[rsv.ingress].add(rsv.requested, sign); h != nil {
+		s.ingress[rsv.ingress] = h
+	} else {
+		delete(s.ingress, rsv.ingress)
+	}
+	if h := s.egress[rsv.egress].add(rsv.requested, sign); h != nil {
+		s.egress[rsv.egress] = h
+	} else {
+		delete(s.egress, rsv.egress)
+	}
+	pair := ifPair{ingress: rsv.ingress, egress: rsv.egress}
+	if h := s.pair[pair].add(rsv.requested, sign); h != nil {
+		s.pair[pair] = h
+	} else {
+		delete(s.pair, pair)
+	}
+}
+
+// demandHistogram counts the reservations per requested bandwidth. As the requested
+// bandwidth is always a bandwidth class, it has few entries, and the demands can be capped
+// at any capacity without iterating the reservations.
+type demandHistogram map[uint64]int
+
+// add adds or removes a demand, and returns the resulting histogram, nil if empty.
+func (h demandHistogram) add(bw uint64, sign int) demandHistogram {
+	if h == nil {
+		h = make(demandHistogram)
+	}
+	h[bw] += sign
+	if h[bw] == 0 {
+		delete(h, bw)
+	}
+	if len(h) == 0 {
+		return nil
+	}
+	return h
+}
+
+// capped returns the sum of all demands, each one capped at capDem.
+func (h demandHistogram) capped(capDem uint64) uint64 {
+	var sum uint64
+	for bw, count := range h {
+		sum += minBW(capDem, bw) * uint64(count)
+	}
+	return sum
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend/mock_backend"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestStatefulAdmission(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	// the stateless admitter reads the reservations from this DB every time.
+	rsvs := []*segment.Reservation{
+		testNewRsv(t, "ff00:1:1", "00000001", 1, 2, 5, 5, 5),
+		testNewRsv(t, "ff00:1:2", "00000001", 1, 2, 5, 9, 9),
+		testNewRsv(t, "ff00:1:1", "00000002", 3, 2, 5, 7, 7),
+		testNewRsv(t, "ff00:1:3", "00000001", 3, 1, 5, 9, 9),
+		testNewRsv(t, "ff00:1:4", "00000001", 2, 3, 5, 13, 11),
+	}
+	db := mock_backend.NewMockDB(mctrl)
+	db.EXPECT().GetAllSegmentRsvs(gomock.Any()).AnyTimes().DoAndReturn(
+		func(context.Context) ([]*segment.Reservation, error) {
+			return rsvs, nil
+		})
+	db.EXPECT().GetSegmentRsvsFromIFPair(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, ingress, egress *uint16) (
+			[]*segment.Reservation, error) {
+
+			var found []*segment.Reservation
+			for _, rsv := range rsvs {
+				if (ingress == nil || rsv.Ingress == *ingress) &&
+					(egress == nil || rsv.Egress == *egress) {
+					found = append(found, rsv)
+				}
+			}
+			return withoutStacked(found), nil
+		})
+	caps := &testCapacities{
+		Cap:    1024 * 8,
+		Ifaces: []uint16{1, 2, 3},
+	}
+	stateless := &StatelessAdmission{DB: db, Capacities: caps, Delta: .75}
+	// the stateful admitter reads the DB only once.
+	statefulDB := mock_backend.NewMockDB(mctrl)
+	statefulDB.EXPECT().GetAllSegmentRsvs(gomock.Any()).Times(1).DoAndReturn(
+		func(context.Context) ([]*segment.Reservation, error) {
+			return rsvs, nil
+		})
+	stateful := &StatefulAdmission{DB: statefulDB, Capacities: caps, Delta: .75}
+	// ignored until loaded
+	stateful.UpdateRsv(testNewRsv(t, "ff00:1:9", "00000001", 1, 2, 5, 5, 5))
+
+	requireSameDecisions := func(t *testing.T) {
+		requests := []*segment.SetupReq{
+			newTestRequest(t, 1, 2, 5, 7),
+			newTestRequest(t, 1, 2, 1, 13),
+			newTestRequest(t, 3, 2, 9, 9),
+			newTestRequest(t, 2, 1, 3, 11),
+			newTestRequest(t, 3, 1, 13, 13),
+			testAddAllocTrail(newTestRequest(t, 1, 3, 5, 9), 7, 7),
+			testAddAllocTrail(newTestRequest(t, 2, 3, 5, 13), 13, 13, 11, 11),
+		}
+		for i, req := range requests {
+			other := *req
+			other.AllocTrail = append(reservation.AllocationBeads{}, req.AllocTrail...)
+			errStateless := stateless.AdmitRsv(context.Background(), req)
+			errStateful := stateful.AdmitRsv(context.Background(), &other)
+			require.Equal(t, errStateless == nil, errStateful == nil, "request %d", i)
+			require.Equal(t, req.AllocTrail, other.AllocTrail, "request %d", i)
+		}
+	}
+
+	t.Run("initial state", requireSameDecisions)
+
+	t.Run("admitted", func(t *testing.T) {
+		rsv := testNewRsv(t, "ff00:1:1", "beefcafe", 1, 2, 5, 9, 9)
+		rsvs = append(rsvs, rsv)
+		stateful.UpdateRsv(rsv)
+		requireSameDecisions(t)
+	})
+
+	t.Run("renewed", func(t *testing.T) {
+		rsv := rsvs[1]
+		_, err := rsv.NewIndexAtSource(util.SecsToTime(20), 1, 11, 11, 1, reservation.CorePath)
+		require.NoError(t, err)
+		stateful.UpdateRsv(rsv)
+		requireSameDecisions(t)
+	})
+
+	t.Run("torn down", func(t *testing.T) {
+		id := rsvs[0].ID
+		rsvs = rsvs[1:]
+		stateful.RemoveRsv(&id)
+		requireSameDecisions(t)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		// all indices expire at 2 seconds but the one added in the renewal
+		now := util.SecsToTime(10)
+		var alive []*segment.Reservation
+		for _, rsv := range rsvs {
+			var indices segment.Indices
+			for _, index := range rsv.Indices {
+				if !index.Expiration.Before(now) {
+					indices = append(indices, index)
+				}
+			}
+			if len(indices) > 0 {
+				rsv.Indices = indices
+				alive = append(alive, rsv)
+			}
+		}
+		require.Len(t, alive, 1)
+		rsvs = alive
+		stateful.RemoveExpired(now)
+		require.Len(t, stateful.rsvs, 1)
+		requireSameDecisions(t)
+	})
+	t.Run("telescopic", func(t *testing.T) {
+		base := rsvs[0]
+		blocked := stateful.blockedEgress[base.Egress]
+		stacked := testNewRsv(t, base.ID.ASID.String(), "00000010", base.Ingress, base.Egress,
+			5, 13, 13)
+		stacked.BaseID = &base.ID
+		rsvs = append(rsvs, stacked)
+		stateful.UpdateRsv(stacked)
+		require.Equal(t, blocked, stateful.blockedEgress[base.Egress])
+		requireSameDecisions(t)
+		diverged := testNewRsv(t, base.ID.ASID.String(), "00000011", base.Ingress, 3,
+			5, 9, 9)
+		diverged.BaseID = &base.ID
+		rsvs = append(rsvs, diverged)
+		stateful.UpdateRsv(diverged)
+		require.Equal(t, diverged.MaxBlockedBW(), stateful.blockedEgress[3])
+		requireSameDecisions(t)
+		stateful.RemoveRsv(&stacked.ID)
+		stateful.RemoveRsv(&diverged.ID)
+		rsvs = rsvs[:len(rsvs)-2]
+		require.Equal(t, blocked, stateful.blockedEgress[base.Egress])
+		require.Zero(t, stateful.blockedEgress[3])
+	})
+	t.Run("capacity reduced", func(t *testing.T) {
+		// the capacities are reloaded below the bandwidth already blocked
+		caps.Cap = 256
+		requireSameDecisions(t)
+		req := newTestRequest(t, 1, 2, 1, 13)
+		err := stateless.AdmitRsv(context.Background(), req)
+		require.True(t, errors.Is(err, admission.ErrAdmissionDenied), err)
+		req = newTestRequest(t, 1, 2, 1, 13)
+		err = stateful.AdmitRsv(context.Background(), req)
+		require.True(t, errors.Is(err, admission.ErrAdmissionDenied), err)
+		require.Equal(t, reservation.BWCls(0), req.AllocTrail[0].MaxBW)
+	})
+}
@@ -319,6 +319,16 @@ func TestTubeRatio(t *testing.T) {
 			ratio, err := adm.tubeRatio(ctx, tc.req, demPerSrc)
 			require.NoError(t, err)
 			require.Equal(t, tc.tubeRatio, ratio)
+
+			// the stateful admission must compute the same ratio
+			stateful := newTestStatefulAdmitter(t, adm)
+			caps := stateful.Capacities
+			stateful.excluding(tc.req.ID, func() {
+				demPerSrc := stateful.computeTempDemands(caps, tc.req.Ingress, tc.req)
+				ratio, err := stateful.tubeRatio(caps, tc.req, demPerSrc)
+				require.NoError(t, err)
+				require.Equal(t, tc.tubeRatio, ratio)
+			})
 		})
 	}
 }
@@ -422,6 +432,16 @@ func TestLinkRatio(t *testing.T) {
 			linkRatio, err := adm.linkRatio(ctx, tc.req, demsPerSrc)
 			require.NoError(t, err)
 			require.Equal(t, tc.linkRatio, linkRatio)
+
+			// the stateful admission must compute the same ratio
+			stateful := newTestStatefulAdmitter(t, adm)
+			caps := stateful.Capacities
+			stateful.excluding(tc.req.ID, func() {
+				demsPerSrc := stateful.computeTempDemands(caps, tc.req.Ingress, tc.req)
+				linkRatio, err := stateful.linkRatio(caps, tc.req, demsPerSrc)
+				require.NoError(t, err)
+				require.Equal(t, tc.linkRatio, linkRatio)
+			})
 		})
 	}
 
@@ -454,6 +474,18 @@ func newTestAdmitter(t *testing.T) (*StatelessAdmission, func()) {
 	}, mctlr.Finish
 }
 
+// newTestStatefulAdmitter creates a stateful admitter with the same configuration as the
+// stateless one, and loads its state from the same DB.
+func newTestStatefulAdmitter(t *testing.T, adm *StatelessAdmission) *StatefulAdmission {
+	stateful := &StatefulAdmission{
+		DB:         adm.DB,
+		Capacities: adm.Capacities,
+		Delta:      adm.Delta,
+	}
+	require.NoError(t, stateful.load(context.Background()))
+	return stateful
+}
+
 // newTestRequest creates a request ID ff00:1:1 beefcafe
 func newTestRequest(t *testing.T, ingress, egress uint16,
 	minBW, maxBW reservation.BWCls) *segment.SetupReq {
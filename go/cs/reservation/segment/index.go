@@ -15,6 +15,7 @@
 package segment
 
 import (
+	"fmt"
 	"time"
 
 	base "github.com/scionproto/scion/go/cs/reservation"
@@ -30,6 +31,19 @@ const (
 	IndexActive
 )
 
+func (s IndexState) String() string {
+	switch s {
+	case IndexTemporary:
+		return "temporary"
+	case IndexPending:
+		return "pending"
+	case IndexActive:
+		return "active"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
 // Index is a segment reservation index.
 type Index struct {
 	Idx        reservation.IndexNumber
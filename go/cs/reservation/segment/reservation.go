@@ -33,6 +33,7 @@ type Reservation struct {
 	PathType     reservation.PathType       // the type of path (up,core,down)
 	PathEndProps reservation.PathEndProps   // the properties for stitching and start/end
 	TrafficSplit reservation.SplitCls       // the traffic split between control and data planes
+	BaseID       *reservation.SegmentID     // the base reservation if telescopic, nil otherwise
 }
 
 func NewReservation() *Reservation {
@@ -64,6 +65,15 @@ func (r *Reservation) Validate() error {
 			activeIndex = i
 		}
 	}
+	if r.BaseID != nil {
+		if r.BaseID.ASID != r.ID.ASID {
+			return serrors.New("telescopic reservation does not share the ID prefix of its base",
+				"id_as", r.ID.ASID, "base_as", r.BaseID.ASID)
+		}
+		if r.BaseID.Suffix == r.ID.Suffix {
+			return serrors.New("telescopic reservation cannot be its own base")
+		}
+	}
 	var err error
 	if r.Path != nil {
 		if r.Ingress != 0 {
@@ -84,6 +94,11 @@ func (r *Reservation) Validate() error {
 	return nil
 }
 
+// IsTelescopic returns true if this reservation is stacked on top of a base one.
+func (r *Reservation) IsTelescopic() bool {
+	return r.BaseID != nil
+}
+
 // ActiveIndex returns the currently active Index for this reservation, or nil if none.
 func (r *Reservation) ActiveIndex() *Index {
 	if r.activeIndex == -1 {
@@ -207,6 +222,25 @@ func (r *Reservation) RemoveIndex(idx reservation.IndexNumber) error {
 	return nil
 }
 
+// RemoveTemporaryIndex removes only this index, which must be the last one and not yet
+// confirmed. It is used to clean up an index whose setup failed, keeping the active one.
+func (r *Reservation) RemoveTemporaryIndex(idx reservation.IndexNumber) error {
+	sliceIndex, err := base.FindIndex(r.Indices, idx)
+	if err != nil {
+		return err
+	}
+	if sliceIndex != len(r.Indices)-1 {
+		return serrors.New("attempt to remove an index that is not the last one",
+			"index_number", idx, "last", r.Indices[len(r.Indices)-1].Idx)
+	}
+	if r.Indices[sliceIndex].state != IndexTemporary {
+		return serrors.New("attempt to remove a confirmed index", "index_number", idx,
+			"state", r.Indices[sliceIndex].state)
+	}
+	r.Indices = r.Indices[:sliceIndex]
+	return nil
+}
+
 // MaxBlockedBW returns the maximum bandwidth blocked by this reservation, which is
 // the same as the maximum allocated bandwidth indicated by its indices.
 func (r *Reservation) MaxBlockedBW() uint64 {
@@ -126,6 +126,20 @@ func TestReservationValidate(t *testing.T) {
 	r.Path = nil
 	err = r.Validate()
 	require.Error(t, err)
+	// telescopic with the same ID prefix
+	r = segmenttest.NewReservation()
+	r.BaseID = &reservation.SegmentID{ASID: r.ID.ASID, Suffix: [4]byte{0, 0, 0, 9}}
+	err = r.Validate()
+	require.NoError(t, err)
+	require.True(t, r.IsTelescopic())
+	// telescopic with a different ID prefix
+	r.BaseID.ASID = r.ID.ASID + 1
+	err = r.Validate()
+	require.Error(t, err)
+	// telescopic on top of itself
+	r.BaseID = &r.ID
+	err = r.Validate()
+	require.Error(t, err)
 }
 
 func TestIndex(t *testing.T) {
@@ -225,6 +239,29 @@ func TestRemoveIndex(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRemoveTemporaryIndex(t *testing.T) {
+	r := segmenttest.NewReservation()
+	expTime := util.SecsToTime(1)
+	active, _ := r.NewIndexAtSource(expTime, 0, 0, 0, 0, reservation.CorePath)
+	require.NoError(t, r.SetIndexConfirmed(active))
+	require.NoError(t, r.SetIndexActive(active))
+	pending, _ := r.NewIndexAtSource(expTime, 0, 0, 0, 0, reservation.CorePath)
+	failed, _ := r.NewIndexAtSource(expTime, 0, 0, 0, 0, reservation.CorePath)
+
+	// only the last index can be removed
+	require.Error(t, r.RemoveTemporaryIndex(pending))
+	require.NoError(t, r.RemoveTemporaryIndex(failed))
+	require.Len(t, r.Indices, 2)
+	require.Equal(t, active, r.ActiveIndex().Idx)
+	require.NoError(t, r.Validate())
+
+	// and only if not confirmed
+	require.NoError(t, r.SetIndexConfirmed(pending))
+	require.Error(t, r.RemoveTemporaryIndex(pending))
+	require.Error(t, r.RemoveTemporaryIndex(failed))
+	require.Len(t, r.Indices, 2)
+}
+
 func TestMaxBlockedBW(t *testing.T) {
 	r := segmenttest.NewReservation()
 	r.Indices = r.Indices[:0]
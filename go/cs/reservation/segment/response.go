@@ -62,9 +62,22 @@ type ResponseSetupSuccess struct {
 // ResponseSetupFailure is the response to a failed setup. It's sent on the reverse direction.
 type ResponseSetupFailure struct {
 	Response
+	ErrorCode   reservation.ErrorCode // not in the payload, only travels in the Failure
 	FailedSetup *SetupReq
 }
 
+var _ base.FailureResponse = (*ResponseSetupFailure)(nil)
+
+// Failure returns the failure of the setup. The maximum bandwidth is the one the failing AS
+// recorded in the allocation trail, if it did.
+func (r *ResponseSetupFailure) Failure() *reservation.Failure {
+	var maxBW reservation.BWCls
+	if r.FailedSetup != nil && int(r.FailedHop) < len(r.FailedSetup.AllocTrail) {
+		maxBW = r.FailedSetup.AllocTrail[r.FailedHop].MaxBW
+	}
+	return base.NewFailure(r.Path(), r.ErrorCode, r.FailedHop, maxBW)
+}
+
 // ResponseTeardownSuccess is sent by the last AS in the reverse path.
 type ResponseTeardownSuccess struct {
 	Response
@@ -73,7 +86,12 @@ type ResponseTeardownSuccess struct {
 // ResponseTeardownFailure is sent in the reverse path.
 type ResponseTeardownFailure struct {
 	Response
-	ErrorCode uint8
+	ErrorCode reservation.ErrorCode
+}
+
+// Failure returns the failure of the request.
+func (r *ResponseTeardownFailure) Failure() *reservation.Failure {
+	return base.NewFailure(r.Path(), r.ErrorCode, r.FailedHop, 0)
 }
 
 // ResponseIndexConfirmationSuccess is a successful index confirmation. The target state is
@@ -86,7 +104,12 @@ type ResponseIndexConfirmationSuccess struct {
 // ResponseIndexConfirmationFailure is a failed index confirmation.
 type ResponseIndexConfirmationFailure struct {
 	Response
-	ErrorCode uint8
+	ErrorCode reservation.ErrorCode
+}
+
+// Failure returns the failure of the request.
+func (r *ResponseIndexConfirmationFailure) Failure() *reservation.Failure {
+	return base.NewFailure(r.Path(), r.ErrorCode, r.FailedHop, 0)
 }
 
 // ResponseCleanupSuccess is a response to a successful cleanup request.
@@ -97,5 +120,10 @@ type ResponseCleanupSuccess struct {
 // ResponseCleanupFailure is a failed index cleanup.
 type ResponseCleanupFailure struct {
 	Response
-	ErrorCode uint8
+	ErrorCode reservation.ErrorCode
+}
+
+// Failure returns the failure of the request.
+func (r *ResponseCleanupFailure) Failure() *reservation.Failure {
+	return base.NewFailure(r.Path(), r.ErrorCode, r.FailedHop, 0)
 }
@@ -20,6 +20,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 	"time"
@@ -47,9 +48,10 @@ var _ backend.DB = (*Backend)(nil)
 
 // New returns a new SQLite backend opening a database at the given path. If
 // no database exists a new database is be created. If the schema version of the
-// stored database is different from the one in schema.go, an error is returned.
+// stored database is older than the one in schema.go, the database is migrated to it; if
+// it is newer, an error is returned.
 func New(path string) (*Backend, error) {
-	db, err := db.NewSqlite(path, Schema, SchemaVersion)
+	db, err := db.NewSqliteWithMigrations(path, Schema, SchemaVersion, migrations)
 	if err != nil {
 		return nil, err
 	}
@@ -186,7 +188,9 @@ func (x *executor) GetAllSegmentRsvs(ctx context.Context) ([]*segment.Reservatio
 }
 
 // GetSegmentRsvsFromIFPair returns all segment reservations that enter this AS at
-// the specified ingress and exit at that egress.
+// the specified ingress and exit at that egress. Telescopic reservations stacked on a base
+// reservation with the same ingress and egress are not returned, as they use the bandwidth
+// of their base.
 func (x *executor) GetSegmentRsvsFromIFPair(ctx context.Context, ingress, egress *uint16) (
 	[]*segment.Reservation, error) {
 
@@ -203,10 +207,24 @@ func (x *executor) GetSegmentRsvsFromIFPair(ctx context.Context, ingress, egress
 	if len(conditions) == 0 {
 		return nil, serrors.New("no ingress or egress provided")
 	}
+	conditions = append(conditions, `NOT EXISTS (SELECT 1 FROM seg_reservation AS base
+		WHERE base.id_as = seg_reservation.id_as AND base.id_suffix = seg_reservation.base_suffix
+		AND base.ingress = seg_reservation.ingress AND base.egress = seg_reservation.egress)`)
 	condition := fmt.Sprintf("WHERE %s", strings.Join(conditions, " AND "))
 	return getSegReservations(ctx, x.db, condition, params)
 }
 
+// GetTelescopicSegmentRsvs returns all segment reservations stacked on top of the base one.
+func (x *executor) GetTelescopicSegmentRsvs(ctx context.Context, baseID *reservation.SegmentID) (
+	[]*segment.Reservation, error) {
+
+	params := []interface{}{
+		baseID.ASID,
+		binary.BigEndian.Uint32(baseID.Suffix[:]),
+	}
+	return getSegReservations(ctx, x.db, "WHERE id_as = ? AND base_suffix = ?", params)
+}
+
 // NewSegmentRsv creates a new segment reservation in the DB, with an unused reservation ID.
 // The reservation must contain at least one index.
 // The created ID is set in the reservation pointer argument.
@@ -235,6 +253,19 @@ func (x *executor) NewSegmentRsv(ctx context.Context, rsv *segment.Reservation)
 	return db.NewTxError("error inserting segment reservation after 3 retries", err)
 }
 
+// NewSegmentRsvWithID creates a new segment reservation in the DB keeping its ID, which was
+// chosen by the AS that started the reservation. It fails if the ID is already in use.
+func (x *executor) NewSegmentRsvWithID(ctx context.Context, rsv *segment.Reservation) error {
+	err := db.DoInTx(ctx, x.db, func(ctx context.Context, tx *sql.Tx) error {
+		suffix := binary.BigEndian.Uint32(rsv.ID.Suffix[:])
+		return insertNewSegReservation(ctx, tx, rsv, suffix)
+	})
+	if err != nil {
+		return db.NewTxError("error inserting segment reservation", err, "id", rsv.ID)
+	}
+	return nil
+}
+
 func (x *executor) PersistSegmentRsv(ctx context.Context, rsv *segment.Reservation) error {
 	err := db.DoInTx(ctx, x.db, func(ctx context.Context, tx *sql.Tx) error {
 		err := deleteSegmentRsv(ctx, tx, &rsv.ID)
@@ -275,6 +306,12 @@ func (x *executor) DeleteExpiredIndices(ctx context.Context, now time.Time) (int
 			}
 		}
 
+		// forget the e2e reservations set up by this AS whose last index expired
+		const query = `DELETE FROM e2e_initiated WHERE expiration < ?`
+		if _, err := tx.ExecContext(ctx, query, util.TimeToSecs(now)); err != nil {
+			return err
+		}
+
 		// delete segment indices
 		rowIDs, rsvRowIDs, err = getExpiredSegIndexRowIDs(ctx, tx, now)
 		if err != nil {
@@ -313,6 +350,17 @@ func (x *executor) GetE2ERsvFromID(ctx context.Context, ID *reservation.E2EID) (
 	return rsv, err
 }
 
+// GetAllE2ERsvs returns all e2e reservations.
+func (x *executor) GetAllE2ERsvs(ctx context.Context) ([]*e2e.Reservation, error) {
+	var rsvs []*e2e.Reservation
+	err := db.DoInTx(ctx, x.db, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		rsvs, err = getE2ERsvs(ctx, tx, `SELECT ROWID,reservation_id FROM e2e_reservation`)
+		return err
+	})
+	return rsvs, err
+}
+
 // GetE2ERsvsOnSegRsv returns the e2e reservations running on top of a given segment one.
 func (x *executor) GetE2ERsvsOnSegRsv(ctx context.Context, ID *reservation.SegmentID) (
 	[]*e2e.Reservation, error) {
@@ -340,6 +388,71 @@ func (x *executor) PersistE2ERsv(ctx context.Context, rsv *e2e.Reservation) erro
 	return nil
 }
 
+// GetInitiatedE2ERsv returns the e2e reservation set up by this AS with that ID, or nil.
+func (x *executor) GetInitiatedE2ERsv(ctx context.Context, ID *reservation.E2EID) (
+	*e2e.Initiated, error) {
+
+	const query = `SELECT host,segments,hop_counts,path,last_index,expiration
+		FROM e2e_initiated WHERE reservation_id = ?`
+	var host string
+	var segments, hopCounts, path []byte
+	var lastIdx, expiration uint32
+	err := x.db.QueryRowContext(ctx, query, ID.ToRaw()).Scan(&host, &segments, &hopCounts,
+		&path, &lastIdx, &expiration)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rsv := &e2e.Initiated{
+		ID:         *ID,
+		Host:       net.ParseIP(host),
+		HopCounts:  hopCounts,
+		LastIdx:    reservation.IndexNumber(lastIdx),
+		Expiration: util.SecsToTime(expiration),
+	}
+	if len(segments)%reservation.SegmentIDLen != 0 {
+		return nil, db.NewDataError("invalid segment reservation IDs", nil,
+			"len", len(segments), "id", ID)
+	}
+	for len(segments) > 0 {
+		segID, err := reservation.SegmentIDFromRaw(segments[:reservation.SegmentIDLen])
+		if err != nil {
+			return nil, db.NewDataError("invalid segment reservation ID", err, "id", ID)
+		}
+		rsv.Segments = append(rsv.Segments, *segID)
+		segments = segments[reservation.SegmentIDLen:]
+	}
+	if rsv.Path, err = segment.NewPathFromRaw(path); err != nil {
+		return nil, db.NewDataError("invalid path", err, "id", ID)
+	}
+	return rsv, nil
+}
+
+// PersistInitiatedE2ERsv creates or updates an e2e reservation set up by this AS.
+func (x *executor) PersistInitiatedE2ERsv(ctx context.Context, rsv *e2e.Initiated) error {
+	if err := rsv.Validate(); err != nil {
+		return err
+	}
+	const query = `INSERT OR REPLACE INTO e2e_initiated (reservation_id,host,segments,
+		hop_counts,path,last_index,expiration) VALUES (?,?,?,?,?,?,?)`
+	segments := make([]byte, 0, len(rsv.Segments)*reservation.SegmentIDLen)
+	for _, id := range rsv.Segments {
+		segments = append(segments, id.ToRaw()...)
+	}
+	_, err := x.db.ExecContext(ctx, query, rsv.ID.ToRaw(), rsv.Host.String(), segments,
+		rsv.HopCounts, rsv.Path.ToRaw(), rsv.LastIdx, util.TimeToSecs(rsv.Expiration))
+	return err
+}
+
+// DeleteInitiatedE2ERsv removes an e2e reservation set up by this AS.
+func (x *executor) DeleteInitiatedE2ERsv(ctx context.Context, ID *reservation.E2EID) error {
+	const query = `DELETE FROM e2e_initiated WHERE reservation_id = ?`
+	_, err := x.db.ExecContext(ctx, query, ID.ToRaw())
+	return err
+}
+
 // newSuffix finds a segment reservation ID suffix not being used at the moment. Should be called
 // inside a transaction so the suffix is not used in the meantime, or fail.
 func newSuffix(ctx context.Context, x db.Sqler, ASID addr.AS) (uint32, error) {
@@ -365,12 +478,17 @@ func insertNewSegReservation(ctx context.Context, x *sql.Tx, rsv *segment.Reserv
 	if rsv.ActiveIndex() != nil {
 		activeIndex = int(rsv.ActiveIndex().Idx)
 	}
+	var baseSuffix sql.NullInt64
+	if rsv.BaseID != nil {
+		baseSuffix.Int64 = int64(binary.BigEndian.Uint32(rsv.BaseID.Suffix[:]))
+		baseSuffix.Valid = true
+	}
 	const query = `INSERT INTO seg_reservation (id_as, id_suffix, ingress, egress,
-		path, end_props, traffic_split, src_ia, dst_ia,active_index)
-		VALUES (?, ?,?,?,?,?,?,?,?,?)`
+		path, end_props, traffic_split, src_ia, dst_ia,active_index, base_suffix)
+		VALUES (?, ?,?,?,?,?,?,?,?,?,?)`
 	res, err := x.ExecContext(ctx, query, rsv.ID.ASID, suffix,
 		rsv.Ingress, rsv.Egress, rsv.Path.ToRaw(), rsv.PathEndProps, rsv.TrafficSplit,
-		rsv.Path.GetSrcIA().IAInt(), rsv.Path.GetDstIA().IAInt(), activeIndex)
+		rsv.Path.GetSrcIA().IAInt(), rsv.Path.GetDstIA().IAInt(), activeIndex, baseSuffix)
 	if err != nil {
 		return err
 	}
@@ -406,13 +524,14 @@ type rsvFields struct {
 	EndProps     int
 	TrafficSplit int
 	ActiveIndex  int
+	BaseSuffix   sql.NullInt64
 }
 
 func getSegReservations(ctx context.Context, x db.Sqler, condition string, params []interface{}) (
 	[]*segment.Reservation, error) {
 
 	const queryTmpl = `SELECT ROWID,id_as,id_suffix,ingress,egress,path,
-		end_props,traffic_split,active_index
+		end_props,traffic_split,active_index,base_suffix
 		FROM seg_reservation %s`
 	query := fmt.Sprintf(queryTmpl, condition)
 
@@ -426,7 +545,7 @@ func getSegReservations(ctx context.Context, x db.Sqler, condition string, param
 	for rows.Next() {
 		var f rsvFields
 		err := rows.Scan(&f.RowID, &f.AsID, &f.Suffix, &f.Ingress, &f.Egress, &f.Path,
-			&f.EndProps, &f.TrafficSplit, &f.ActiveIndex)
+			&f.EndProps, &f.TrafficSplit, &f.ActiveIndex, &f.BaseSuffix)
 		if err != nil {
 			return nil, err
 		}
@@ -464,6 +583,10 @@ func buildSegRsvFromFields(ctx context.Context, x db.Sqler, fields *rsvFields) (
 	rsv.PathEndProps = reservation.PathEndProps(fields.EndProps)
 	rsv.TrafficSplit = reservation.SplitCls(fields.TrafficSplit)
 	rsv.Indices = indices
+	if fields.BaseSuffix.Valid {
+		rsv.BaseID = &reservation.SegmentID{ASID: rsv.ID.ASID}
+		binary.BigEndian.PutUint32(rsv.BaseID.Suffix[:], uint32(fields.BaseSuffix.Int64))
+	}
 	if fields.ActiveIndex != -1 {
 		if err := rsv.SetIndexActive(reservation.IndexNumber(fields.ActiveIndex)); err != nil {
 			return nil, err
@@ -604,13 +727,21 @@ func getE2ERsvFromID(ctx context.Context, x *sql.Tx, ID *reservation.E2EID) (
 func getE2ERsvsFromSegment(ctx context.Context, x *sql.Tx, ID *reservation.SegmentID) (
 	[]*e2e.Reservation, error) {
 
-	rowID2e2eIDs := make(map[int]*reservation.E2EID)
 	const query = `SELECT ROWID,reservation_id FROM e2e_reservation WHERE ROWID IN (
 		SELECT e2e FROM e2e_to_seg WHERE seg =  (
 			SELECT ROWID FROM seg_reservation WHERE id_as = ? AND id_suffix = ?
 		))`
 	suffix := binary.BigEndian.Uint32(ID.Suffix[:])
-	rows, err := x.QueryContext(ctx, query, ID.ASID, suffix)
+	return getE2ERsvs(ctx, x, query, ID.ASID, suffix)
+}
+
+// getE2ERsvs returns the e2e reservations selected by the query. The query must select the
+// ROWID and reservation_id of the e2e reservations.
+func getE2ERsvs(ctx context.Context, x *sql.Tx, query string, params ...interface{}) (
+	[]*e2e.Reservation, error) {
+
+	rowID2e2eIDs := make(map[int]*reservation.E2EID)
+	rows, err := x.QueryContext(ctx, query, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,10 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mattn/go-sqlite3"
@@ -84,6 +88,50 @@ func TestRaceForSuffix(t *testing.T) {
 	require.Equal(t, sqlite3.ErrConstraint, sqliteError.Code)
 }
 
+func TestMigrationFromVersion1(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reservation-db-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "reservations.db")
+
+	// the schema of version 1 has no base reservations nor initiated e2e reservations.
+	schemaV1 := strings.Replace(Schema, "base_suffix\tINTEGER,\n", "", 1)
+	i := strings.Index(schemaV1, `CREATE INDEX "index5_seg_reservation"`)
+	require.NotEqual(t, -1, i)
+	j := strings.Index(schemaV1, `CREATE UNIQUE INDEX "index_seg_index"`)
+	schemaV1 = schemaV1[:i] + schemaV1[j:]
+	i = strings.Index(schemaV1, `CREATE TABLE e2e_initiated`)
+	require.NotEqual(t, -1, i)
+	j = strings.Index(schemaV1, `CREATE TABLE e2e_to_seg`)
+	schemaV1 = schemaV1[:i] + schemaV1[j:]
+	require.NotContains(t, schemaV1, "base_suffix")
+	require.NotContains(t, schemaV1, "e2e_initiated")
+	old, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	_, err = old.Exec(schemaV1)
+	require.NoError(t, err)
+	_, err = old.Exec("PRAGMA user_version = 1")
+	require.NoError(t, err)
+	_, err = old.Exec(`INSERT INTO seg_reservation (id_as, id_suffix, ingress, egress,
+		end_props, traffic_split, active_index) VALUES (1, 1, 0, 0, 0, 0, -1)`)
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	db, err := New(path)
+	require.NoError(t, err)
+	defer db.Close()
+	var version int
+	require.NoError(t, db.db.QueryRow("PRAGMA user_version").Scan(&version))
+	require.Equal(t, SchemaVersion, version)
+	rsvs, err := db.GetAllSegmentRsvs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rsvs, 1)
+	require.Nil(t, rsvs[0].BaseID)
+	rsv, err := db.GetInitiatedE2ERsv(context.Background(), &reservation.E2EID{})
+	require.NoError(t, err)
+	require.Nil(t, rsv)
+}
+
 func BenchmarkNewSuffix10K(b *testing.B)  { benchmarkNewSuffix(b, 10000) }
 func BenchmarkNewSuffix100K(b *testing.B) { benchmarkNewSuffix(b, 100000) }
 func BenchmarkNewSuffix1M(b *testing.B)   { benchmarkNewSuffix(b, 1000000) }
@@ -18,7 +18,7 @@ const (
 	// SchemaVersion is the version of the SQLite schema understood by this backend.
 	// Whenever changes to the schema are made, this version number should be increased
 	// to prevent data corruption between incompatible database schemas.
-	SchemaVersion = 1
+	SchemaVersion = 3
 	// Schema is the SQLite database layout.
 	Schema = `CREATE TABLE seg_reservation (
 		ROWID	INTEGER,
@@ -32,6 +32,7 @@ const (
 		src_ia INTEGER,
 		dst_ia INTEGER,
 		active_index	INTEGER NOT NULL,
+		base_suffix	INTEGER,
 		PRIMARY KEY(ROWID),
 		UNIQUE(id_as,id_suffix),
 		UNIQUE(path)
@@ -63,6 +64,16 @@ const (
 		PRIMARY KEY(reservation,index_number),
 		FOREIGN KEY(reservation) REFERENCES e2e_reservation(ROWID) ON DELETE CASCADE
 	);
+	CREATE TABLE e2e_initiated (
+		reservation_id	BLOB NOT NULL,
+		host	TEXT NOT NULL,
+		segments	BLOB NOT NULL,
+		hop_counts	BLOB NOT NULL,
+		path	BLOB NOT NULL,
+		last_index	INTEGER NOT NULL,
+		expiration	INTEGER NOT NULL,
+		PRIMARY KEY(reservation_id)
+	);
 	CREATE TABLE e2e_to_seg (
 		e2e	INTEGER NOT NULL,
 		seg	INTEGER NOT NULL,
@@ -83,6 +94,10 @@ const (
 	CREATE UNIQUE INDEX "index4_seg_reservation" ON "seg_reservation" (
 		"path"
 	);
+	CREATE INDEX "index5_seg_reservation" ON "seg_reservation" (
+		"id_as",
+		"base_suffix"
+	);
 	CREATE UNIQUE INDEX "index_seg_index" ON "seg_index" (
 		"reservation",
 		"index_number"
@@ -101,3 +116,25 @@ const (
 		"seg"
 	);`
 )
+
+// migrations upgrade the stored databases with an older schema version: migrations[v] upgrades
+// the schema from version v to v+1.
+var migrations = map[int]string{
+	// version 2 adds the base reservation of telescopic segment reservations.
+	1: `ALTER TABLE seg_reservation ADD COLUMN base_suffix INTEGER;
+	CREATE INDEX "index5_seg_reservation" ON "seg_reservation" (
+		"id_as",
+		"base_suffix"
+	);`,
+	// version 3 adds the e2e reservations set up by this AS on behalf of its end hosts.
+	2: `CREATE TABLE e2e_initiated (
+		reservation_id	BLOB NOT NULL,
+		host	TEXT NOT NULL,
+		segments	BLOB NOT NULL,
+		hop_counts	BLOB NOT NULL,
+		path	BLOB NOT NULL,
+		last_index	INTEGER NOT NULL,
+		expiration	INTEGER NOT NULL,
+		PRIMARY KEY(reservation_id)
+	);`,
+}
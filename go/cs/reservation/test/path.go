@@ -16,6 +16,7 @@ package test
 
 import (
 	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/lib/addr"
 )
 
 type TestColibriPath struct {
@@ -23,6 +24,9 @@ type TestColibriPath struct {
 	CurrentHop int
 	Ingress    uint16
 	Egress     uint16
+	IAs        []addr.IA
+	// IFIDs are the ingress and egress interfaces of every hop, if set.
+	IFIDs [][2]uint16
 }
 
 var _ base.ColibriPath = (*TestColibriPath)(nil)
@@ -47,6 +51,20 @@ func (p *TestColibriPath) IngressEgressIFIDs() (uint16, uint16) {
 	return p.Ingress, p.Egress
 }
 
+func (p *TestColibriPath) IA(hop int) addr.IA {
+	if hop < 0 || hop >= len(p.IAs) {
+		return addr.IA{}
+	}
+	return p.IAs[hop]
+}
+
+func (p *TestColibriPath) Interfaces(hop int) (uint16, uint16) {
+	if hop < 0 || hop >= len(p.IFIDs) {
+		return 0, 0
+	}
+	return p.IFIDs[hop][0], p.IFIDs[hop][1]
+}
+
 // NewTestPath returns a new path with one segment consisting on 3 hopfields: (0,2)->(1,2)->(1,0).
 func NewTestPath() base.ColibriPath {
 	path := TestColibriPath{
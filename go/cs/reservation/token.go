@@ -0,0 +1,75 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservation
+
+import (
+	"hash"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+// DataPlaneInfoField returns the InfoField used in the COLIBRI path type for the reservation
+// with the given ID suffix. The suffix of a segment reservation is its whole serialized ID, as
+// the segment ID suffixes are only unique per AS, padded with zeroes.
+func DataPlaneInfoField(suffix []byte, segment bool,
+	inf *reservation.InfoField) *colibri.InfoField {
+
+	dpInf := &colibri.InfoField{
+		S:        segment,
+		ExpTick:  uint32(inf.ExpirationTick),
+		BwCls:    uint8(inf.BWCls),
+		RLC:      uint8(inf.RLC),
+		Idx:      uint8(inf.Idx),
+		PathType: uint8(inf.PathType),
+	}
+	copy(dpInf.ResIDSuffix[:], suffix)
+	return dpInf
+}
+
+// NewHopField returns the hop field of this AS for the reservation with the given ID suffix
+// and InfoField. The MAC is computed with the AS local hash, exactly as the border router
+// verifies it.
+func NewHopField(mac hash.Hash, suffix []byte, segment bool, inf *reservation.InfoField,
+	ingress, egress uint16) reservation.HopField {
+
+	dpHF := &colibri.HopField{
+		IngressID: ingress,
+		EgressID:  egress,
+	}
+	return reservation.HopField{
+		Ingress: ingress,
+		Egress:  egress,
+		Mac:     colibri.MAC(mac, DataPlaneInfoField(suffix, segment, inf), dpHF),
+	}
+}
+
+// DataPlanePath returns the COLIBRI path that uses the reservation with the given ID suffix
+// and token. The current hop field is the first one.
+func DataPlanePath(suffix []byte, segment bool, tok *reservation.Token) *colibri.Path {
+	p := &colibri.Path{
+		InfoField: *DataPlaneInfoField(suffix, segment, &tok.InfoField),
+		HopFields: make([]*colibri.HopField, len(tok.HopFields)),
+	}
+	p.InfoField.HFCount = uint8(len(tok.HopFields))
+	for i, hf := range tok.HopFields {
+		p.HopFields[i] = &colibri.HopField{
+			IngressID: hf.Ingress,
+			EgressID:  hf.Egress,
+			Mac:       hf.Mac,
+		}
+	}
+	return p
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestNewHopField(t *testing.T) {
+	cases := map[string]struct {
+		suffix  []byte
+		segment bool
+	}{
+		"segment": {
+			suffix:  xtest.MustParseHexString("ff0000000001beefcafe"),
+			segment: true,
+		},
+		"e2e": {
+			suffix:  xtest.MustParseHexString("0123456789abcdef0123"),
+			segment: false,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// each AS computes its hop field with its own key.
+			keys := []string{"testkey_as1_xxxx", "testkey_as2_xxxx", "testkey_as3_xxxx"}
+			interfaces := [][2]uint16{{0, 1}, {2, 3}, {4, 0}}
+			tok := &reservation.Token{
+				InfoField: reservation.InfoField{
+					ExpirationTick: 12,
+					BWCls:          13,
+					RLC:            4,
+					Idx:            2,
+					PathType:       reservation.CorePath,
+				},
+			}
+			for i := range keys {
+				mac, err := scrypto.InitMac([]byte(keys[i]))
+				require.NoError(t, err)
+				tok.HopFields = append(tok.HopFields, NewHopField(mac, tc.suffix, tc.segment,
+					&tok.InfoField, interfaces[i][0], interfaces[i][1]))
+			}
+
+			p := DataPlanePath(tc.suffix, tc.segment, tok)
+			require.Equal(t, tc.segment, p.InfoField.S)
+			require.Equal(t, uint8(len(keys)), p.InfoField.HFCount)
+			require.Equal(t, tc.suffix, p.InfoField.ResIDSuffix[:len(tc.suffix)])
+			for i, hf := range p.HopFields {
+				require.Equal(t, interfaces[i][0], hf.IngressID)
+				require.Equal(t, interfaces[i][1], hf.EgressID)
+				mac, err := scrypto.InitMac([]byte(keys[i]))
+				require.NoError(t, err)
+				require.NoError(t, colibri.VerifyMAC(mac, &p.InfoField, hf))
+				// only the AS that created the hop field can verify it
+				other, err := scrypto.InitMac([]byte(keys[(i+1)%len(keys)]))
+				require.NoError(t, err)
+				require.Error(t, colibri.VerifyMAC(other, &p.InfoField, hf))
+			}
+		})
+	}
+}
+
+func TestNewHopFieldSegmentOfOtherAS(t *testing.T) {
+	// the segment ID suffixes are allocated per AS, so they are not unique.
+	id1, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("00000001"))
+	require.NoError(t, err)
+	id2, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:2"),
+		xtest.MustParseHexString("00000001"))
+	require.NoError(t, err)
+	inf := &reservation.InfoField{
+		ExpirationTick: 12,
+		BWCls:          13,
+		RLC:            4,
+		Idx:            2,
+		PathType:       reservation.CorePath,
+	}
+	mac, err := scrypto.InitMac([]byte("testkey_xxxxxxxx"))
+	require.NoError(t, err)
+	hf1 := NewHopField(mac, id1.ToRaw(), true, inf, 1, 2)
+	hf2 := NewHopField(mac, id2.ToRaw(), true, inf, 1, 2)
+	require.NotEqual(t, hf1.Mac, hf2.Mac)
+	require.NotEqual(t, DataPlaneInfoField(id1.ToRaw(), true, inf).ResIDSuffix,
+		DataPlaneInfoField(id2.ToRaw(), true, inf).ResIDSuffix)
+}
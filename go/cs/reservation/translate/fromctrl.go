@@ -307,7 +307,7 @@ func newRequestE2ESetup(ctrl *colibri_mgmt.E2ESetup, ts time.Time,
 	case proto.E2ESetupReqData_Which_failure:
 		return &e2e.SetupReqFailure{
 			SetupReq:  *setup,
-			ErrorCode: ctrl.Failure.ErrorCode,
+			ErrorCode: reservation.ErrorCode(ctrl.Failure.ErrorCode),
 		}, nil
 	default:
 		return nil, serrors.New("invalid ctrl message", "ctrl", ctrl.Which.String())
@@ -386,7 +386,7 @@ func newResponseSegmentTeardown(ctrl *colibri_mgmt.SegmentTeardownRes, resp *col
 	} else {
 		return &segment.ResponseTeardownFailure{
 			Response:  *r,
-			ErrorCode: ctrl.ErrorCode,
+			ErrorCode: reservation.ErrorCode(ctrl.ErrorCode),
 		}, nil
 	}
 }
@@ -411,7 +411,7 @@ func newResponseSegmentIndexConfirmation(ctrl *colibri_mgmt.SegmentIndexConfirma
 	} else {
 		return &segment.ResponseIndexConfirmationFailure{
 			Response:  *r,
-			ErrorCode: ctrl.ErrorCode,
+			ErrorCode: reservation.ErrorCode(ctrl.ErrorCode),
 		}, nil
 	}
 }
@@ -435,7 +435,7 @@ func newResponseSegmentCleanup(ctrl *colibri_mgmt.SegmentCleanupRes, resp *colib
 	} else {
 		return &segment.ResponseCleanupFailure{
 			Response:  *r,
-			ErrorCode: ctrl.ErrorCode,
+			ErrorCode: reservation.ErrorCode(ctrl.ErrorCode),
 		}, nil
 	}
 }
@@ -469,7 +469,7 @@ func newResponseE2ESetup(ctrl *colibri_mgmt.E2ESetupRes, resp *colibri_mgmt.Resp
 		}
 		return &e2e.ResponseSetupFailure{
 			Response:  *r,
-			ErrorCode: ctrl.Failure.ErrorCode,
+			ErrorCode: reservation.ErrorCode(ctrl.Failure.ErrorCode),
 			MaxBWs:    maxBWs,
 		}, nil
 	default:
@@ -496,7 +496,7 @@ func newResponseE2EClenaup(ctrl *colibri_mgmt.E2ECleanupRes, resp *colibri_mgmt.
 	} else {
 		return &e2e.ResponseCleanupFailure{
 			Response:  *r,
-			ErrorCode: ctrl.ErrorCode,
+			ErrorCode: reservation.ErrorCode(ctrl.ErrorCode),
 		}, nil
 	}
 }
@@ -160,7 +160,7 @@ func TestNewRequestE2ESetupFailure(t *testing.T) {
 		allocTrail[i] = reservation.BWCls(ctrlMsg.AllocationTrail[i])
 	}
 	require.Equal(t, allocTrail, r.AllocationTrail)
-	require.Equal(t, ctrlMsg.Failure.ErrorCode, r.ErrorCode)
+	require.Equal(t, ctrlMsg.Failure.ErrorCode, uint8(r.ErrorCode))
 }
 
 func TestNewRequestE2ECleanup(t *testing.T) {
@@ -264,7 +264,7 @@ func TestNewResponseSegmentTeardown(t *testing.T) {
 				rs := r.(*segment.ResponseTeardownFailure)
 				checkIDs(t, tc.Ctrl.SegmentTeardown.Base.ID, &rs.ID)
 				require.Equal(t, tc.Ctrl.SegmentTeardown.Base.Index, uint8(rs.Index))
-				require.Equal(t, tc.Ctrl.SegmentTeardown.ErrorCode, rs.ErrorCode)
+				require.Equal(t, tc.Ctrl.SegmentTeardown.ErrorCode, uint8(rs.ErrorCode))
 			}
 		})
 	}
@@ -312,7 +312,7 @@ func TestNewResponseSegmentIndexConfirmation(t *testing.T) {
 				rs := r.(*segment.ResponseIndexConfirmationFailure)
 				checkIDs(t, tc.Ctrl.SegmentIndexConfirmation.Base.ID, &rs.ID)
 				require.Equal(t, tc.Ctrl.SegmentIndexConfirmation.Base.Index, uint8(rs.Index))
-				require.Equal(t, tc.Ctrl.SegmentIndexConfirmation.ErrorCode, rs.ErrorCode)
+				require.Equal(t, tc.Ctrl.SegmentIndexConfirmation.ErrorCode, uint8(rs.ErrorCode))
 			}
 		})
 	}
@@ -359,7 +359,7 @@ func TestNewResponseSegmentCleanup(t *testing.T) {
 				rs := r.(*segment.ResponseCleanupFailure)
 				checkIDs(t, tc.Ctrl.SegmentCleanup.Base.ID, &rs.ID)
 				require.Equal(t, tc.Ctrl.SegmentCleanup.Base.Index, uint8(rs.Index))
-				require.Equal(t, tc.Ctrl.SegmentCleanup.ErrorCode, rs.ErrorCode)
+				require.Equal(t, tc.Ctrl.SegmentCleanup.ErrorCode, uint8(rs.ErrorCode))
 			}
 		})
 	}
@@ -405,7 +405,7 @@ func TestNewResponseE2ESetup(t *testing.T) {
 				rs := r.(*e2e.ResponseSetupFailure)
 				checkE2EIDs(t, tc.Ctrl.E2ESetup.Base.ID, &rs.ID)
 				require.Equal(t, tc.Ctrl.E2ESetup.Base.Index, uint8(rs.Index))
-				require.Equal(t, tc.Ctrl.E2ESetup.Failure.ErrorCode, rs.ErrorCode)
+				require.Equal(t, tc.Ctrl.E2ESetup.Failure.ErrorCode, uint8(rs.ErrorCode))
 				require.Len(t, rs.MaxBWs, len(tc.Ctrl.E2ESetup.Failure.AllocationTrail))
 			}
 		})
@@ -452,7 +452,7 @@ func TestNewResponseE2EClenaup(t *testing.T) {
 				rs := r.(*e2e.ResponseCleanupFailure)
 				checkE2EIDs(t, tc.Ctrl.E2ECleanup.Base.ID, &rs.ID)
 				require.Equal(t, tc.Ctrl.E2ECleanup.Base.Index, uint8(rs.Index))
-				require.Equal(t, tc.Ctrl.E2ECleanup.ErrorCode, rs.ErrorCode)
+				require.Equal(t, tc.Ctrl.E2ECleanup.ErrorCode, uint8(rs.ErrorCode))
 			}
 		})
 	}
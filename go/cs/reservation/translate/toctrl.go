@@ -338,7 +338,7 @@ func setE2ESetupReqFailure(msg *e2e.SetupReqFailure,
 	ctrl.Request.E2ESetup = newE2ESetup(&msg.SetupReq)
 	ctrl.Request.E2ESetup.Which = proto.E2ESetupReqData_Which_failure
 	ctrl.Request.E2ESetup.Failure = &colibri_mgmt.E2ESetupReqFailure{
-		ErrorCode: msg.ErrorCode,
+		ErrorCode: uint8(msg.ErrorCode),
 	}
 
 	return nil
@@ -352,7 +352,7 @@ func setE2ERenewalReqFailure(msg *e2e.SetupReqFailure,
 	ctrl.Request.E2ERenewal = newE2ESetup(&msg.SetupReq)
 	ctrl.Request.E2ERenewal.Which = proto.E2ESetupReqData_Which_failure
 	ctrl.Request.E2ERenewal.Failure = &colibri_mgmt.E2ESetupReqFailure{
-		ErrorCode: msg.ErrorCode,
+		ErrorCode: uint8(msg.ErrorCode),
 	}
 	return nil
 }
@@ -434,7 +434,7 @@ func setSegmentTeardownFailureResponse(msg *segment.ResponseTeardownFailure,
 	thisIsAResponse(ctrl, msg.FailedHop)
 	ctrl.Response.SegmentTeardown = &colibri_mgmt.SegmentTeardownRes{
 		Base:      newSegmentBaseFromResponse(&msg.Response),
-		ErrorCode: msg.ErrorCode,
+		ErrorCode: uint8(msg.ErrorCode),
 	}
 	ctrl.Response.Which = proto.Response_Which_segmentTeardown
 	return nil
@@ -457,7 +457,7 @@ func setSegmentIndexConfirmationFailureResponse(msg *segment.ResponseIndexConfir
 	thisIsAResponse(ctrl, msg.FailedHop)
 	ctrl.Response.SegmentIndexConfirmation = &colibri_mgmt.SegmentIndexConfirmationRes{
 		Base:      newSegmentBaseFromResponse(&msg.Response),
-		ErrorCode: msg.ErrorCode,
+		ErrorCode: uint8(msg.ErrorCode),
 	}
 	ctrl.Response.Which = proto.Response_Which_segmentIndexConfirmation
 	return nil
@@ -480,7 +480,7 @@ func setSegmentCleanupFailureResponse(msg *segment.ResponseCleanupFailure,
 	thisIsAResponse(ctrl, msg.FailedHop)
 	ctrl.Response.SegmentCleanup = &colibri_mgmt.SegmentCleanupRes{
 		Base:      newSegmentBaseFromResponse(&msg.Response),
-		ErrorCode: msg.ErrorCode,
+		ErrorCode: uint8(msg.ErrorCode),
 	}
 	ctrl.Response.Which = proto.Response_Which_segmentCleanup
 	return nil
@@ -528,7 +528,7 @@ func setE2ESetupFailureResponse(msg *e2e.ResponseSetupFailure,
 		Base:  newE2EBaseFromResponse(&msg.Response),
 		Which: proto.E2ESetupResData_Which_failure,
 		Failure: &colibri_mgmt.E2ESetupFailure{
-			ErrorCode:       msg.ErrorCode,
+			ErrorCode:       uint8(msg.ErrorCode),
 			AllocationTrail: maxBWs,
 		},
 	}
@@ -548,7 +548,7 @@ func setE2ERenewalFailureResponse(msg *e2e.ResponseSetupFailure,
 		Base:  newE2EBaseFromResponse(&msg.Response),
 		Which: proto.E2ESetupResData_Which_failure,
 		Failure: &colibri_mgmt.E2ESetupFailure{
-			ErrorCode:       msg.ErrorCode,
+			ErrorCode:       uint8(msg.ErrorCode),
 			AllocationTrail: maxBWs,
 		},
 	}
@@ -573,7 +573,7 @@ func setE2ECleanupFailureResponse(msg *e2e.ResponseCleanupFailure,
 	thisIsAResponse(ctrl, msg.FailedHop)
 	ctrl.Response.E2ECleanup = &colibri_mgmt.E2ECleanupRes{
 		Base:      newE2EBaseFromResponse(&msg.Response),
-		ErrorCode: msg.ErrorCode,
+		ErrorCode: uint8(msg.ErrorCode),
 	}
 	ctrl.Response.Which = proto.Response_Which_e2eCleanup
 	return nil
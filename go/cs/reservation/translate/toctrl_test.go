@@ -19,9 +19,16 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
 	"github.com/scionproto/scion/go/cs/reservation/test"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/proto"
 )
 
@@ -365,3 +372,85 @@ func TestNewCtrlFromMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenRoundTrip(t *testing.T) {
+	segID, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("beefcafe"))
+	require.NoError(t, err)
+	e2eID, err := reservation.NewE2EID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("0123456789abcdef0123"))
+	require.NoError(t, err)
+	ts := util.SecsToTime(1)
+	cases := map[string]struct {
+		suffix  []byte
+		segment bool
+		newMsg  func(tok reservation.Token) base.MessageWithPath
+		token   func(msg base.MessageWithPath) reservation.Token
+	}{
+		"segment": {
+			suffix:  segID.ToRaw(),
+			segment: true,
+			newMsg: func(tok reservation.Token) base.MessageWithPath {
+				r, err := segment.NewResponse(ts, segID, 2, test.NewTestPath(), true, 0)
+				require.NoError(t, err)
+				return &segment.ResponseSetupSuccess{Response: *r, Token: tok}
+			},
+			token: func(msg base.MessageWithPath) reservation.Token {
+				require.IsType(t, &segment.ResponseSetupSuccess{}, msg)
+				return msg.(*segment.ResponseSetupSuccess).Token
+			},
+		},
+		"e2e": {
+			suffix:  e2eID.Suffix[:],
+			segment: false,
+			newMsg: func(tok reservation.Token) base.MessageWithPath {
+				r, err := e2e.NewResponse(ts, e2eID, 2, test.NewTestPath(), true, 0)
+				require.NoError(t, err)
+				return &e2e.ResponseSetupSuccess{Response: *r, Token: tok}
+			},
+			token: func(msg base.MessageWithPath) reservation.Token {
+				require.IsType(t, &e2e.ResponseSetupSuccess{}, msg)
+				return msg.(*e2e.ResponseSetupSuccess).Token
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mac, err := scrypto.InitMac([]byte("testkey_xxxxxxxx"))
+			require.NoError(t, err)
+			tok := reservation.Token{
+				InfoField: reservation.InfoField{
+					ExpirationTick: 12,
+					BWCls:          13,
+					RLC:            4,
+					Idx:            2,
+					PathType:       reservation.CorePath,
+				},
+			}
+			// the hop fields are added in the reverse path, starting at the last AS
+			for _, ifids := range [][2]uint16{{4, 0}, {2, 3}, {0, 1}} {
+				hf := base.NewHopField(mac, tc.suffix, tc.segment, &tok.InfoField,
+					ifids[0], ifids[1])
+				tok.HopFields = append([]reservation.HopField{hf}, tok.HopFields...)
+			}
+
+			ctrl, err := NewCtrlFromMsg(tc.newMsg(tok), false)
+			require.NoError(t, err)
+			raw, err := ctrl.PackRoot()
+			require.NoError(t, err)
+			ctrl, err = colibri_mgmt.NewFromRaw(raw)
+			require.NoError(t, err)
+			msg, err := NewMsgFromCtrl(ctrl, test.NewTestPath())
+			require.NoError(t, err)
+			newTok := tc.token(msg)
+			require.Equal(t, tok, newTok)
+
+			p := base.DataPlanePath(tc.suffix, tc.segment, &newTok)
+			for _, hf := range p.HopFields {
+				require.NoError(t, colibri.VerifyMAC(mac, &p.InfoField, hf))
+			}
+		})
+	}
+}
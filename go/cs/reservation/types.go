@@ -14,6 +14,11 @@
 
 package reservation
 
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+)
+
 // Capacities describes what a capacity description must offer.
 type Capacities interface {
 	IngressInterfaces() []uint16
@@ -23,9 +28,25 @@ type Capacities interface {
 	CapacityEgress(egress uint16) uint64
 }
 
-// ColibriPath is a path of type COLIBRI.
-// This type will be moved to its right place in slayers once the header has been approved.
-// TODO(juagargi): move the type to slayers.
+// CapacitiesSnapshotter is implemented by the capacity descriptions that can change at runtime.
+type CapacitiesSnapshotter interface {
+	// Snapshot returns the current capacities, which will not change afterwards.
+	Snapshot() Capacities
+}
+
+// SnapshotCapacities returns capacities that won't change while they are being used, so that
+// e.g. a whole admission computation sees a consistent capacity matrix.
+func SnapshotCapacities(caps Capacities) Capacities {
+	if s, ok := caps.(CapacitiesSnapshotter); ok {
+		return s.Snapshot()
+	}
+	return caps
+}
+
+// ColibriPath is the path a COLIBRI control message travels along: the ASes of the
+// reservation with their interfaces, e.g. the grpc.Path of the reservation storage. It stays
+// in the control service, as the data plane path with its wire format is the colibri path
+// type in slayers.
 type ColibriPath interface {
 	Copy() ColibriPath
 	// Reverse reverses the contained path.
@@ -33,6 +54,10 @@ type ColibriPath interface {
 	NumberOfHops() int
 	IndexOfCurrentHop() int
 	IngressEgressIFIDs() (uint16, uint16)
+	// IA returns the IA of the AS at the given hop index.
+	IA(hop int) addr.IA
+	// Interfaces returns the ingress and egress interfaces of the AS at the given hop index.
+	Interfaces(hop int) (uint16, uint16)
 }
 
 // MessageWithPath is used to send messages from the COLIBRI service via the BR.
@@ -40,3 +65,35 @@ type MessageWithPath interface {
 	Path() ColibriPath
 	// Payload() []byte
 }
+
+// AuthenticatedRequest is a request that carries DRKey authenticators for the ASes in its path.
+type AuthenticatedRequest interface {
+	MessageWithPath
+	Authenticators() [][]byte
+	SetAuthenticators([][]byte)
+}
+
+// FailureResponse is a response to a request rejected by an AS in the reservation path.
+type FailureResponse interface {
+	MessageWithPath
+	// Failure describes which AS rejected the request and why.
+	Failure() *reservation.Failure
+}
+
+// NewFailure returns the failure described by a response traveling on the reverse path.
+// The failed hop is the index of the failing AS in the path of the request.
+func NewFailure(revPath ColibriPath, code reservation.ErrorCode, failedHop uint8,
+	maxBW reservation.BWCls) *reservation.Failure {
+
+	f := &reservation.Failure{
+		Code:      code,
+		FailedHop: failedHop,
+		MaxBW:     maxBW,
+	}
+	if revPath != nil {
+		if hop := revPath.NumberOfHops() - 1 - int(failedHop); hop >= 0 {
+			f.FailedIA = revPath.IA(hop)
+		}
+	}
+	return f
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationmanager
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Path types that can be configured for a segment reservation.
+const (
+	PathTypeUp   = "up"
+	PathTypeDown = "down"
+	PathTypeCore = "core"
+)
+
+// Config contains the segment reservations that this AS keeps up.
+type Config struct {
+	Reservations []*Reservation `yaml:"reservations"`
+}
+
+// Reservation describes a segment reservation originating in this AS.
+type Reservation struct {
+	// Destination is the AS where the reservation ends.
+	Destination addr.IA `yaml:"destination"`
+	// PathPredicate is a hop predicate sequence the path of the reservation must match.
+	// An empty predicate matches any path.
+	PathPredicate string `yaml:"path_predicate,omitempty"`
+	// MinBW is the minimum bandwidth class the reservation can be admitted with.
+	MinBW reservation.BWCls `yaml:"min_bw"`
+	// MaxBW is the bandwidth class requested for the reservation.
+	MaxBW reservation.BWCls `yaml:"max_bw"`
+	// SplitCls is the traffic split between control and data planes.
+	SplitCls reservation.SplitCls `yaml:"split_cls"`
+	// PathType is one of up, down or core. If not set, non core ASes use up and
+	// core ASes use core.
+	PathType string `yaml:"path_type,omitempty"`
+
+	sequence *pathpol.Sequence
+}
+
+// LoadConfig reads and validates the segment reservations from a YAML file.
+func LoadConfig(file string) (*Config, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, serrors.WrapStr("reading reservations file", err, "file", file)
+	}
+	cfg, err := ParseConfig(raw)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing reservations file", err, "file", file)
+	}
+	return cfg, nil
+}
+
+// ParseConfig parses and validates the segment reservations in YAML format.
+func ParseConfig(raw []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(raw, cfg); err != nil {
+		return nil, err
+	}
+	for i, r := range cfg.Reservations {
+		if err := r.init(); err != nil {
+			return nil, serrors.WrapStr("invalid reservation", err, "index", i)
+		}
+	}
+	return cfg, nil
+}
+
+func (r *Reservation) init() error {
+	if r.Destination.IsZero() || r.Destination.IsWildcard() {
+		return serrors.New("invalid destination", "destination", r.Destination)
+	}
+	if err := r.MinBW.Validate(); err != nil {
+		return err
+	}
+	if err := r.MaxBW.Validate(); err != nil {
+		return err
+	}
+	if r.MinBW > r.MaxBW {
+		return serrors.New("min_bw larger than max_bw", "min_bw", r.MinBW, "max_bw", r.MaxBW)
+	}
+	switch r.PathType {
+	case "", PathTypeUp, PathTypeDown, PathTypeCore:
+	default:
+		return serrors.New("unknown path type", "path_type", r.PathType)
+	}
+	seq, err := pathpol.NewSequence(r.PathPredicate)
+	if err != nil {
+		return serrors.WrapStr("parsing path predicate", err)
+	}
+	r.sequence = seq
+	return nil
+}
+
+// pathType returns the COLIBRI path type of the reservation.
+func (r *Reservation) pathType(core bool) reservation.PathType {
+	switch r.PathType {
+	case PathTypeUp:
+		return reservation.UpPath
+	case PathTypeDown:
+		return reservation.DownPath
+	case PathTypeCore:
+		return reservation.CorePath
+	}
+	if core {
+		return reservation.CorePath
+	}
+	return reservation.UpPath
+}
+
+// key identifies the reservation among the configured ones. Identical reservations have the
+// same key.
+func (r *Reservation) key() string {
+	return fmt.Sprintf("%s %q %d-%d %d %s", r.Destination, r.PathPredicate, r.MinBW, r.MaxBW,
+		r.SplitCls, r.PathType)
+}
+
+// endProps returns the properties at both ends of a reservation of the given path type.
+func endProps(pathType reservation.PathType) reservation.PathEndProps {
+	switch pathType {
+	case reservation.UpPath:
+		return reservation.NewPathEndProps(true, false, true, true)
+	case reservation.DownPath:
+		return reservation.NewPathEndProps(true, true, true, false)
+	default:
+		return reservation.NewPathEndProps(true, true, true, true)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := LoadConfig("testdata/reservations.yml")
+	require.NoError(t, err)
+	require.Len(t, cfg.Reservations, 2)
+	r := cfg.Reservations[0]
+	require.Equal(t, xtest.MustParseIA("1-ff00:0:110"), r.Destination)
+	require.Equal(t, reservation.BWCls(5), r.MinBW)
+	require.Equal(t, reservation.BWCls(13), r.MaxBW)
+	require.Equal(t, reservation.SplitCls(2), r.SplitCls)
+	require.Equal(t, reservation.UpPath, r.pathType(false))
+	require.Equal(t, reservation.CorePath, r.pathType(true))
+	require.NotNil(t, r.sequence)
+	r = cfg.Reservations[1]
+	require.Equal(t, reservation.CorePath, r.pathType(false))
+
+	_, err = LoadConfig("testdata/doesnotexist.yml")
+	require.Error(t, err)
+}
+
+func TestParseConfig(t *testing.T) {
+	cases := map[string]struct {
+		raw       string
+		assertErr require.ErrorAssertionFunc
+	}{
+		"empty": {
+			raw:       "",
+			assertErr: require.NoError,
+		},
+		"unknown key": {
+			raw:       "reservations:\n- destination: 1-ff00:0:110\n  max_bandwidth: 3\n",
+			assertErr: require.Error,
+		},
+		"no destination": {
+			raw:       "reservations:\n- max_bw: 3\n",
+			assertErr: require.Error,
+		},
+		"wildcard destination": {
+			raw:       "reservations:\n- destination: 1-0\n",
+			assertErr: require.Error,
+		},
+		"min larger than max": {
+			raw:       "reservations:\n- destination: 1-ff00:0:110\n  min_bw: 4\n  max_bw: 3\n",
+			assertErr: require.Error,
+		},
+		"invalid bandwidth class": {
+			raw:       "reservations:\n- destination: 1-ff00:0:110\n  max_bw: 64\n",
+			assertErr: require.Error,
+		},
+		"invalid path type": {
+			raw:       "reservations:\n- destination: 1-ff00:0:110\n  path_type: e2e\n",
+			assertErr: require.Error,
+		},
+		"invalid predicate": {
+			raw:       "reservations:\n- destination: 1-ff00:0:110\n  path_predicate: 1-ff00:0:110#\n",
+			assertErr: require.Error,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseConfig([]byte(tc.raw))
+			tc.assertErr(t, err)
+		})
+	}
+}
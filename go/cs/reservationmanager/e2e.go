@@ -0,0 +1,374 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationmanager
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/drkeystorage"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DefaultE2EDuration is the default validity of an e2e reservation index.
+const DefaultE2EDuration = 16 * time.Second
+
+// E2EInitiator sets up e2e reservations on behalf of the end hosts of this AS. The e2e
+// reservations are stitched from the segment reservations starting in this AS, and the core
+// and down segment reservations listed by the ASes where those end.
+// The stitched path and the host of each reservation are kept in the DB to renew and clean it
+// up, which only that host can do.
+type E2EInitiator struct {
+	LocalIA addr.IA
+	// DB keeps the e2e reservations set up by this AS.
+	DB    backend.DB
+	Store reservationstorage.Store
+	// DRKeys are used to compute the authenticators of the requests.
+	DRKeys drkeystorage.ServiceStore
+	// Forwarder sends the requests to the next AS in the reservation path.
+	Forwarder colgrpc.Forwarder
+	// Lister obtains the segment reservations of other ASes.
+	Lister colgrpc.StitchableLister
+	// Duration is the validity of each new index.
+	Duration time.Duration
+}
+
+var _ colgrpc.HostE2EInitiator = (*E2EInitiator)(nil)
+
+// SetupE2E stitches the segment reservations with the most allocated bandwidth to reach dst,
+// and sets up a new e2e reservation on top of them, owned by the host.
+func (i *E2EInitiator) SetupE2E(ctx context.Context, host net.IP, dst addr.IA,
+	bw reservation.BWCls) (*colgrpc.E2EReservation, error) {
+
+	if dst.Equal(i.LocalIA) {
+		return nil, serrors.New("e2e reservation to the local AS", "dst", dst)
+	}
+	segments, err := i.stitch(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+	path, err := stitchPath(segments)
+	if err != nil {
+		return nil, err
+	}
+	rsv := &e2e.Initiated{
+		ID:        reservation.E2EID{ASID: i.LocalIA.A},
+		Host:      host,
+		Segments:  make([]reservation.SegmentID, len(segments)),
+		HopCounts: make([]uint8, len(segments)),
+		Path:      path,
+	}
+	if _, err := rand.Read(rsv.ID.Suffix[:]); err != nil {
+		return nil, serrors.WrapStr("generating e2e reservation ID", err)
+	}
+	for j, s := range segments {
+		rsv.Segments[j] = s.ID
+		rsv.HopCounts[j] = uint8(len(s.Path))
+	}
+	res, err := i.requestIndex(ctx, rsv, 0, bw, false)
+	if err != nil {
+		return nil, serrors.WrapStr("setting up e2e reservation", err, "id", rsv.ID)
+	}
+	if err := i.DB.PersistInitiatedE2ERsv(ctx, rsv); err != nil {
+		i.cleanupFailed(ctx, &rsv.ID, 0, rsv.Path)
+		return nil, serrors.WrapStr("cannot store e2e reservation", err, "id", rsv.ID)
+	}
+	return res, nil
+}
+
+// RenewE2E sets up a new index for an e2e reservation set up by this AS for the host.
+func (i *E2EInitiator) RenewE2E(ctx context.Context, host net.IP, id *reservation.E2EID,
+	bw reservation.BWCls) (*colgrpc.E2EReservation, error) {
+
+	rsv, err := i.get(ctx, host, id)
+	if err != nil {
+		return nil, err
+	}
+	idx := rsv.LastIdx.Add(1)
+	res, err := i.requestIndex(ctx, rsv, idx, bw, true)
+	if err != nil {
+		return nil, serrors.WrapStr("renewing e2e reservation", err, "id", id)
+	}
+	if err := i.DB.PersistInitiatedE2ERsv(ctx, rsv); err != nil {
+		i.cleanupFailed(ctx, id, idx, rsv.Path)
+		return nil, serrors.WrapStr("cannot store e2e reservation", err, "id", id)
+	}
+	return res, nil
+}
+
+// CleanupE2E removes the index, and all the previous ones, of an e2e reservation set up by
+// this AS for the host. Cleaning up the last index forgets the reservation.
+func (i *E2EInitiator) CleanupE2E(ctx context.Context, host net.IP, id *reservation.E2EID,
+	idx reservation.IndexNumber) error {
+
+	rsv, err := i.get(ctx, host, id)
+	if err != nil {
+		return err
+	}
+	if err := i.cleanup(ctx, id, idx, rsv.Path); err != nil {
+		return err
+	}
+	if rsv.LastIdx == idx {
+		if err := i.DB.DeleteInitiatedE2ERsv(ctx, id); err != nil {
+			return serrors.WrapStr("cannot delete e2e reservation", err, "id", id)
+		}
+	}
+	return nil
+}
+
+// requestIndex sets up the index in all the ASes of the reservation, and sets it as the last
+// index of the reservation. On failure, the index is cleaned up in the ASes that admitted it.
+func (i *E2EInitiator) requestIndex(ctx context.Context, rsv *e2e.Initiated,
+	idx reservation.IndexNumber, bw reservation.BWCls, renewal bool) (
+	*colgrpc.E2EReservation, error) {
+
+	now := time.Now()
+	r, err := e2e.NewRequest(now, &rsv.ID, idx, &colgrpc.Path{Steps: rsv.Path})
+	if err != nil {
+		return nil, err
+	}
+	setup, err := e2e.NewSetupRequest(r, rsv.Segments, rsv.HopCounts, bw, nil)
+	if err != nil {
+		return nil, err
+	}
+	expiration := now.Add(i.Duration)
+	req := &e2e.SetupReqSuccess{
+		SetupReq: *setup,
+		Token: reservation.Token{
+			InfoField: reservation.InfoField{
+				ExpirationTick: reservation.TickFromTime(expiration),
+				BWCls:          bw,
+				Idx:            idx,
+				PathType:       reservation.E2EPath,
+			},
+		},
+	}
+	msg, err := i.Store.AdmitE2EReservation(ctx, req)
+	if err != nil {
+		return nil, serrors.WrapStr("not admitted in this AS", err)
+	}
+	res, err := initiate(ctx, i.DRKeys, i.Forwarder, msg, renewal, now)
+	if err != nil {
+		i.cleanupFailed(ctx, &rsv.ID, idx, rsv.Path)
+		return nil, err
+	}
+	success, ok := res.(*e2e.ResponseSetupSuccess)
+	if !ok {
+		i.cleanupFailed(ctx, &rsv.ID, idx, rsv.Path)
+		return nil, serrors.New("setup rejected", "index", idx,
+			"response", fmt.Sprintf("%T", res))
+	}
+	processed, err := i.Store.ProcessE2ESetupResponse(ctx, success)
+	if err != nil {
+		return nil, serrors.WrapStr("processing setup response", err)
+	}
+	tok := processed.(*e2e.ResponseSetupSuccess).Token
+	if len(tok.HopFields) != len(rsv.Path) {
+		return nil, serrors.New("incomplete token", "hop_fields", len(tok.HopFields),
+			"path_len", len(rsv.Path))
+	}
+	rsv.LastIdx = idx
+	rsv.Expiration = tok.ExpirationTick.ToTime()
+	return &colgrpc.E2EReservation{
+		ID:    rsv.ID,
+		Index: idx,
+		Token: tok,
+		Path:  rsv.Path,
+	}, nil
+}
+
+// cleanup removes the index, and all the previous ones, in all the ASes of the path.
+func (i *E2EInitiator) cleanup(ctx context.Context, id *reservation.E2EID,
+	idx reservation.IndexNumber, path segment.ReservationTransparentPath) error {
+
+	now := time.Now()
+	r, err := e2e.NewRequest(now, id, idx, &colgrpc.Path{Steps: path})
+	if err != nil {
+		return err
+	}
+	msg, err := i.Store.CleanupE2EReservation(ctx, &e2e.CleanupReq{Request: *r})
+	if err != nil {
+		return serrors.WrapStr("cannot clean up in this AS", err)
+	}
+	res, err := initiate(ctx, i.DRKeys, i.Forwarder, msg, false, now)
+	if err != nil {
+		return err
+	}
+	if _, ok := res.(*e2e.ResponseCleanupSuccess); !ok {
+		return serrors.New("cleanup rejected", "index", idx,
+			"response", fmt.Sprintf("%T", res))
+	}
+	return nil
+}
+
+func (i *E2EInitiator) cleanupFailed(ctx context.Context, id *reservation.E2EID,
+	idx reservation.IndexNumber, path segment.ReservationTransparentPath) {
+
+	if err := i.cleanup(ctx, id, idx, path); err != nil {
+		log.FromCtx(ctx).Info("Cannot clean up failed COLIBRI e2e index", "id", id,
+			"index", idx, "err", err)
+	}
+}
+
+// get returns the e2e reservation set up by this AS for the host. Reservations whose last
+// index expired are unknown, even if they are not yet removed from the DB.
+func (i *E2EInitiator) get(ctx context.Context, host net.IP, id *reservation.E2EID) (
+	*e2e.Initiated, error) {
+
+	rsv, err := i.DB.GetInitiatedE2ERsv(ctx, id)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot obtain e2e reservation", err, "id", id)
+	}
+	if rsv == nil || rsv.Expiration.Before(time.Now()) {
+		return nil, serrors.New("unknown e2e reservation", "id", id)
+	}
+	if !rsv.OwnedBy(host) {
+		return nil, serrors.New("e2e reservation set up by another host", "id", id,
+			"host", host)
+	}
+	return rsv, nil
+}
+
+// stitch returns the segment reservations with the most allocated bandwidth that reach dst.
+// Up reservations are followed by core or down ones, and core ones by down ones.
+func (i *E2EInitiator) stitch(ctx context.Context, dst addr.IA) (
+	[]*colgrpc.Stitchable, error) {
+
+	rsvs, err := i.Store.ListStitchableSegments(ctx, i.LocalIA, dst)
+	if err != nil {
+		return nil, serrors.WrapStr("listing local segment reservations", err)
+	}
+	listed := make(map[addr.IA][]*colgrpc.Stitchable)
+	list := func(ia addr.IA) []*colgrpc.Stitchable {
+		if l, ok := listed[ia]; ok {
+			return l
+		}
+		l, err := i.Lister.ListStitchables(ctx, ia, dst)
+		if err != nil {
+			log.FromCtx(ctx).Debug("Cannot list stitchable segment reservations", "ia", ia,
+				"err", err)
+		}
+		listed[ia] = l
+		return l
+	}
+	var best []*colgrpc.Stitchable
+	consider := func(candidate ...*colgrpc.Stitchable) {
+		if _, err := stitchPath(candidate); err != nil {
+			return
+		}
+		if best == nil || betterStitching(candidate, best) {
+			best = candidate
+		}
+	}
+	for _, rsv := range rsvs {
+		first, err := colgrpc.NewStitchable(rsv)
+		if err != nil {
+			continue
+		}
+		end := first.Path.GetDstIA()
+		if end.Equal(dst) {
+			consider(first)
+			continue
+		}
+		if first.PathType != reservation.UpPath && first.PathType != reservation.CorePath {
+			continue
+		}
+		for _, second := range list(end) {
+			end := second.Path.GetDstIA()
+			switch {
+			case second.PathType == reservation.DownPath && end.Equal(dst):
+				consider(first, second)
+			case second.PathType == reservation.CorePath && first.PathType == reservation.UpPath:
+				if end.Equal(dst) {
+					consider(first, second)
+					continue
+				}
+				for _, third := range list(end) {
+					if third.PathType == reservation.DownPath && third.Path.GetDstIA().Equal(dst) {
+						consider(first, second, third)
+					}
+				}
+			}
+		}
+	}
+	if best == nil {
+		return nil, serrors.New("no segment reservations to stitch", "dst", dst)
+	}
+	return best, nil
+}
+
+// betterStitching returns true if a allows more bandwidth than b, or the same bandwidth
+// through fewer ASes.
+func betterStitching(a, b []*colgrpc.Stitchable) bool {
+	minA, minB := minAllocBW(a), minAllocBW(b)
+	if minA != minB {
+		return minA > minB
+	}
+	return pathLen(a) < pathLen(b)
+}
+
+func minAllocBW(segments []*colgrpc.Stitchable) reservation.BWCls {
+	min := segments[0].AllocBW
+	for _, s := range segments[1:] {
+		min = reservation.MinBWCls(min, s.AllocBW)
+	}
+	return min
+}
+
+func pathLen(segments []*colgrpc.Stitchable) int {
+	n := 1
+	for _, s := range segments {
+		n += len(s.Path) - 1
+	}
+	return n
+}
+
+// stitchPath returns the reservation path traversing the segment reservations in order.
+// The transfer AS between two of them enters through the first and leaves through the next.
+func stitchPath(segments []*colgrpc.Stitchable) (segment.ReservationTransparentPath, error) {
+	var path segment.ReservationTransparentPath
+	visited := make(map[addr.IA]struct{})
+	for _, s := range segments {
+		steps := s.Path
+		if len(path) > 0 {
+			last := &path[len(path)-1]
+			if !last.IA.Equal(steps[0].IA) {
+				return nil, serrors.New("segment reservations are not contiguous",
+					"end", last.IA, "start", steps[0].IA)
+			}
+			last.Egress = steps[0].Egress
+			steps = steps[1:]
+		}
+		for _, step := range steps {
+			if _, ok := visited[step.IA]; ok {
+				return nil, serrors.New("stitched path contains a loop", "ia", step.IA)
+			}
+			visited[step.IA] = struct{}{}
+		}
+		path = append(path, steps...)
+	}
+	return path, nil
+}
@@ -0,0 +1,331 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationmanager
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	"github.com/scionproto/scion/go/cs/reservation/translate"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend/mock_backend"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/grpc/mock_grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkeystorage/mock_drkeystorage"
+	"github.com/scionproto/scion/go/lib/xtest"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	coreIA  = xtest.MustParseIA("1-ff00:0:110")
+	core2IA = xtest.MustParseIA("1-ff00:0:120")
+	e2eDst  = xtest.MustParseIA("1-ff00:0:112")
+	e2eHost = net.ParseIP("10.0.0.1")
+)
+
+func TestStitchPath(t *testing.T) {
+	up := &colgrpc.Stitchable{
+		Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:111", 1, 2, "1-ff00:0:110", 0),
+	}
+	down := &colgrpc.Stitchable{
+		Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:110", 3, 4, "1-ff00:0:112", 0),
+	}
+	back := &colgrpc.Stitchable{
+		Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:110", 5, 6, "1-ff00:0:111", 0),
+	}
+	path, err := stitchPath([]*colgrpc.Stitchable{up, down})
+	require.NoError(t, err)
+	require.Equal(t, segmenttest.NewPathFromComponents(0, "1-ff00:0:111", 1,
+		2, "1-ff00:0:110", 3, 4, "1-ff00:0:112", 0), path)
+	// the segments are not modified
+	require.Equal(t, uint16(0), up.Path[1].Egress)
+
+	_, err = stitchPath([]*colgrpc.Stitchable{down, up})
+	require.Error(t, err)
+	_, err = stitchPath([]*colgrpc.Stitchable{up, back})
+	require.Error(t, err)
+}
+
+func TestSetupE2E(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := newTestInitiatedDB(ctrl)
+	i, store, fwd, lister := newTestE2EInitiator(ctrl, db)
+	up := newTestStitchable(t, 1, reservation.UpPath, 10, 0, "1-ff00:0:111", 1,
+		2, "1-ff00:0:110", 0)
+	store.EXPECT().ListStitchableSegments(gomock.Any(), localIA, e2eDst).Return(
+		[]*segment.Reservation{up}, nil)
+	// up+down allows bandwidth 8, up+core+down allows 9.
+	lister.EXPECT().ListStitchables(gomock.Any(), coreIA, e2eDst).Return(
+		[]*colgrpc.Stitchable{
+			{
+				ID: reservation.SegmentID{ASID: coreIA.A},
+				Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:110", 3,
+					4, "1-ff00:0:112", 0),
+				PathType: reservation.DownPath,
+				AllocBW:  8,
+			},
+			{
+				ID: reservation.SegmentID{ASID: coreIA.A, Suffix: [4]byte{0, 0, 0, 2}},
+				Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:110", 5,
+					6, "1-ff00:0:120", 0),
+				PathType: reservation.CorePath,
+				AllocBW:  12,
+			},
+		}, nil)
+	lister.EXPECT().ListStitchables(gomock.Any(), core2IA, e2eDst).Return(
+		[]*colgrpc.Stitchable{
+			{
+				ID: reservation.SegmentID{ASID: core2IA.A},
+				Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:120", 7,
+					8, "1-ff00:0:112", 0),
+				PathType: reservation.DownPath,
+				AllocBW:  9,
+			},
+		}, nil)
+	expectedPath := segmenttest.NewPathFromComponents(0, "1-ff00:0:111", 1,
+		2, "1-ff00:0:110", 5, 6, "1-ff00:0:120", 7, 8, "1-ff00:0:112", 0)
+	expectAdmission(t, store, 0, []reservation.SegmentID{up.ID,
+		{ASID: coreIA.A, Suffix: [4]byte{0, 0, 0, 2}}, {ASID: core2IA.A}})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respondE2E(t, true))
+	store.EXPECT().ProcessE2ESetupResponse(gomock.Any(), gomock.Any()).DoAndReturn(
+		addSourceHopField)
+
+	rsv, err := i.SetupE2E(context.Background(), e2eHost, e2eDst, 5)
+	require.NoError(t, err)
+	require.Equal(t, localIA.A, rsv.ID.ASID)
+	require.Equal(t, reservation.IndexNumber(0), rsv.Index)
+	require.Equal(t, expectedPath, rsv.Path)
+	require.Len(t, rsv.Token.HopFields, len(expectedPath))
+	require.Equal(t, uint16(1), rsv.Token.HopFields[0].Egress)
+	require.Equal(t, reservation.BWCls(5), rsv.Token.BWCls)
+	require.Equal(t, reservation.E2EPath, rsv.Token.PathType)
+
+	// only the host that set it up can renew and clean it up
+	otherHost := net.ParseIP("10.0.0.2")
+	_, err = i.RenewE2E(context.Background(), otherHost, &rsv.ID, 6)
+	require.Error(t, err)
+	require.Error(t, i.CleanupE2E(context.Background(), otherHost, &rsv.ID, 0))
+
+	// renew, with an initiator started again on the same DB
+	i, store, fwd, _ = newTestE2EInitiator(ctrl, db)
+	expectAdmission(t, store, 1, nil)
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respondE2E(t, true))
+	store.EXPECT().ProcessE2ESetupResponse(gomock.Any(), gomock.Any()).DoAndReturn(
+		addSourceHopField)
+	renewed, err := i.RenewE2E(context.Background(), e2eHost, &rsv.ID, 6)
+	require.NoError(t, err)
+	require.Equal(t, reservation.IndexNumber(1), renewed.Index)
+	require.Equal(t, expectedPath, renewed.Path)
+	require.Equal(t, reservation.BWCls(6), renewed.Token.BWCls)
+
+	// cleanup the last index forgets the reservation
+	store.EXPECT().CleanupE2EReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *e2e.CleanupReq) (base.MessageWithPath, error) {
+			require.Equal(t, rsv.ID, req.ID)
+			require.Equal(t, reservation.IndexNumber(1), req.Index)
+			return req, nil
+		})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respondE2E(t, true))
+	require.NoError(t, i.CleanupE2E(context.Background(), e2eHost, &rsv.ID, 1))
+	_, err = i.RenewE2E(context.Background(), e2eHost, &rsv.ID, 6)
+	require.Error(t, err)
+}
+
+func TestSetupE2EFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	i, store, fwd, lister := newTestE2EInitiator(ctrl, newTestInitiatedDB(ctrl))
+	lister.EXPECT().ListStitchables(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	// nothing to stitch
+	store.EXPECT().ListStitchableSegments(gomock.Any(), localIA, e2eDst)
+	_, err := i.SetupE2E(context.Background(), e2eHost, e2eDst, 5)
+	require.Error(t, err)
+
+	// rejected by the destination: the index is cleaned up in the ASes that admitted it
+	direct := newTestStitchable(t, 1, reservation.UpPath, 10, 0, "1-ff00:0:111", 1,
+		2, "1-ff00:0:112", 0)
+	store.EXPECT().ListStitchableSegments(gomock.Any(), localIA, e2eDst).Return(
+		[]*segment.Reservation{direct}, nil)
+	expectAdmission(t, store, 0, []reservation.SegmentID{direct.ID})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respondE2E(t, false))
+	store.EXPECT().CleanupE2EReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *e2e.CleanupReq) (base.MessageWithPath, error) {
+			return req, nil
+		})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respondE2E(t, true))
+	_, err = i.SetupE2E(context.Background(), e2eHost, e2eDst, 5)
+	require.Error(t, err)
+}
+
+func newTestE2EInitiator(ctrl *gomock.Controller, db *mock_backend.MockDB) (*E2EInitiator,
+	*mock_reservationstorage.MockStore, *mock_grpc.MockForwarder,
+	*mock_grpc.MockStitchableLister) {
+
+	keys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	keys.EXPECT().GetLvl1Key(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		drkey.Lvl1Key{Key: drkey.DRKey(xtest.MustParseHexString(
+			"c584cad32613547c64823c756651b6f5"))}, nil).AnyTimes()
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	fwd := mock_grpc.NewMockForwarder(ctrl)
+	lister := mock_grpc.NewMockStitchableLister(ctrl)
+	i := &E2EInitiator{
+		LocalIA:   localIA,
+		DB:        db,
+		Store:     store,
+		DRKeys:    keys,
+		Forwarder: fwd,
+		Lister:    lister,
+		Duration:  DefaultE2EDuration,
+	}
+	return i, store, fwd, lister
+}
+
+// newTestInitiatedDB returns a DB keeping the initiated e2e reservations in memory.
+func newTestInitiatedDB(ctrl *gomock.Controller) *mock_backend.MockDB {
+	rsvs := make(map[reservation.E2EID]e2e.Initiated)
+	db := mock_backend.NewMockDB(ctrl)
+	db.EXPECT().GetInitiatedE2ERsv(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id *reservation.E2EID) (*e2e.Initiated, error) {
+			rsv, ok := rsvs[*id]
+			if !ok {
+				return nil, nil
+			}
+			return &rsv, nil
+		}).AnyTimes()
+	db.EXPECT().PersistInitiatedE2ERsv(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, rsv *e2e.Initiated) error {
+			rsvs[rsv.ID] = *rsv
+			return nil
+		}).AnyTimes()
+	db.EXPECT().DeleteInitiatedE2ERsv(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id *reservation.E2EID) error {
+			delete(rsvs, *id)
+			return nil
+		}).AnyTimes()
+	return db
+}
+
+// newTestStitchable returns a segment reservation originating in this AS with an active index.
+func newTestStitchable(t *testing.T, suffix byte, pathType reservation.PathType,
+	bw reservation.BWCls, path ...interface{}) *segment.Reservation {
+
+	rsv := segment.NewReservation()
+	rsv.ID.ASID = localIA.A
+	rsv.ID.Suffix = [4]byte{0, 0, 0, suffix}
+	rsv.Path = segmenttest.NewPathFromComponents(path...)
+	rsv.Egress = rsv.Path[0].Egress
+	rsv.PathType = pathType
+	idx, err := rsv.NewIndexAtSource(time.Now().Add(time.Minute), 1, bw, bw, 0, pathType)
+	require.NoError(t, err)
+	require.NoError(t, rsv.SetIndexConfirmed(idx))
+	require.NoError(t, rsv.SetIndexActive(idx))
+	rsv.Indices[0].AllocBW = bw
+	return rsv
+}
+
+// expectAdmission expects the admission of the e2e request in this AS. The segment
+// reservations are only checked if not nil.
+func expectAdmission(t *testing.T, store *mock_reservationstorage.MockStore,
+	idx reservation.IndexNumber, segmentRsvs []reservation.SegmentID) {
+
+	store.EXPECT().AdmitE2EReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req e2e.SetupRequest) (base.MessageWithPath, error) {
+			setup := req.GetCommonSetupReq()
+			require.Equal(t, e2e.Source, setup.Location())
+			require.Equal(t, idx, setup.Index)
+			if segmentRsvs != nil {
+				require.Equal(t, segmentRsvs, setup.SegmentRsvs)
+			}
+			setup.AllocationTrail = append(setup.AllocationTrail, setup.RequestedBW)
+			return req, nil
+		})
+}
+
+func addSourceHopField(_ context.Context, resp *e2e.ResponseSetupSuccess) (
+	base.MessageWithPath, error) {
+
+	resp.Token.HopFields = append([]reservation.HopField{{Egress: 1}}, resp.Token.HopFields...)
+	return resp, nil
+}
+
+// respondE2E returns a forwarding function that answers the e2e requests in place of all the
+// other ASes. Setup requests are accepted or rejected by the destination.
+func respondE2E(t *testing.T, accept bool) func(context.Context, *colpb.ProcessRequest) (
+	*colpb.ProcessResponse, error) {
+
+	return func(_ context.Context, req *colpb.ProcessRequest) (*colpb.ProcessResponse, error) {
+		path, err := colgrpc.PathFromPB(req.Path)
+		require.NoError(t, err)
+		require.Len(t, req.Authenticators, path.NumberOfHops())
+		require.Equal(t, 1, path.CurrentStep)
+		ctrl, err := colibri_mgmt.NewFromRaw(req.Raw)
+		require.NoError(t, err)
+		renewal := ctrl.Request.Which == proto.Request_Which_e2eRenewal
+		msg, err := translate.NewMsgFromCtrl(ctrl, path)
+		require.NoError(t, err)
+		revPath := path.Copy()
+		require.NoError(t, revPath.Reverse())
+		var res base.MessageWithPath
+		switch r := msg.(type) {
+		case *e2e.SetupReqSuccess:
+			resp, err := e2e.NewResponse(time.Now(), &r.ID, r.Index, revPath, accept, 0)
+			require.NoError(t, err)
+			if accept {
+				hfs := make([]reservation.HopField, path.NumberOfHops()-1)
+				res = &e2e.ResponseSetupSuccess{
+					Response: *resp,
+					Token:    reservation.Token{InfoField: r.Token.InfoField, HopFields: hfs},
+				}
+			} else {
+				resp.FailedHop = uint8(path.NumberOfHops() - 1)
+				res = &e2e.ResponseSetupFailure{
+					Response:  *resp,
+					ErrorCode: 1,
+					MaxBWs:    r.AllocationTrail,
+				}
+			}
+		case *e2e.CleanupReq:
+			resp, err := e2e.NewResponse(time.Now(), &r.ID, r.Index, revPath, true, 0)
+			require.NoError(t, err)
+			res = &e2e.ResponseCleanupSuccess{Response: *resp}
+		default:
+			require.FailNow(t, "unexpected request", "%T", msg)
+		}
+		ctrl, err = translate.NewCtrlFromMsg(res, renewal)
+		require.NoError(t, err)
+		raw, err := ctrl.PackRoot()
+		require.NoError(t, err)
+		pbPath, err := colgrpc.PathToPB(revPath)
+		require.NoError(t, err)
+		return &colpb.ProcessResponse{Raw: raw, Path: pbPath}, nil
+	}
+}
@@ -0,0 +1,488 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstore"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/drkeystorage"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+)
+
+const (
+	// DefaultDuration is the default validity of a new segment reservation index.
+	DefaultDuration = 5 * time.Minute
+	// DefaultRenewalMargin is the default time before the expiration of the active index
+	// at which the reservation is renewed.
+	DefaultRenewalMargin = time.Minute
+	// DefaultInterval is the default period of the manager task.
+	DefaultInterval = 10 * time.Second
+	// DefaultRetryBackoff is the default time after a failure before a configured
+	// reservation is tried again.
+	DefaultRetryBackoff = 10 * time.Second
+	// DefaultMaxRetryBackoff is the default maximum time between the attempts to keep up a
+	// configured reservation that keeps failing.
+	DefaultMaxRetryBackoff = 5 * time.Minute
+)
+
+// Manager is a periodic task keeping up the segment reservations configured for this AS.
+// In every run it reads the reservations file, sets up the configured reservations not yet
+// present, renews the ones about to expire and activates their confirmed indices.
+// The reservations originating in this AS that are no longer configured are torn down.
+// Reservations are matched to the configuration by destination, path type, split class and
+// path predicate, so the manager keeps no state other than the reservation DB and the
+// backoff of the configured reservations that failed.
+// A renewal that fails is cleaned up in the ASes that admitted it, and a failed setup is
+// torn down.
+type Manager struct {
+	LocalIA addr.IA
+	// Core is true if this AS is a core AS.
+	Core bool
+	// ConfigFile is the YAML file containing the reservations to keep up.
+	ConfigFile string
+	DB         backend.DB
+	Store      reservationstorage.Store
+	// DRKeys are used to compute the authenticators of the requests.
+	DRKeys drkeystorage.ServiceStore
+	// Router finds the paths for new reservations.
+	Router snet.Router
+	// Forwarder sends the requests to the next AS in the reservation path.
+	Forwarder colgrpc.Forwarder
+	// Duration is the validity of each new index.
+	Duration time.Duration
+	// RenewalMargin is the time before the expiration of the active index at which a
+	// new index is requested.
+	RenewalMargin time.Duration
+	// RetryBackoff is the time after a failure before the configured reservation is tried
+	// again. It doubles with every consecutive failure, up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+
+	cfg     *Config          // last valid configuration
+	retries map[string]retry // of the configured reservations that failed
+}
+
+// retry is the backoff state of a configured reservation that failed.
+type retry struct {
+	failures int
+	next     time.Time
+}
+
+var _ periodic.Task = (*Manager)(nil)
+
+// Name returns the task name.
+func (m *Manager) Name() string {
+	return "colibri_segment_reservation_manager"
+}
+
+// Run keeps up the configured reservations.
+func (m *Manager) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	cfg, err := LoadConfig(m.ConfigFile)
+	if err != nil {
+		if m.cfg == nil {
+			logger.Error("Cannot load COLIBRI reservations", "err", err)
+			return
+		}
+		logger.Error("Cannot reload COLIBRI reservations, keeping previous ones", "err", err)
+		cfg = m.cfg
+	}
+	m.cfg = cfg
+	rsvs, err := m.DB.GetSegmentRsvsFromSrcDstIA(ctx, m.LocalIA, addr.IA{})
+	if err != nil {
+		logger.Error("Cannot list COLIBRI segment reservations", "err", err)
+		return
+	}
+	now := time.Now()
+	unclaimed := make(map[reservation.SegmentID]*segment.Reservation, len(rsvs))
+	for _, rsv := range rsvs {
+		unclaimed[rsv.ID] = rsv
+	}
+	retries := make(map[string]retry)
+	occurrences := make(map[string]int)
+	for _, entry := range cfg.Reservations {
+		rsv := m.match(entry, rsvs, unclaimed)
+		if rsv != nil {
+			delete(unclaimed, rsv.ID)
+		}
+		// identical entries keep up different reservations
+		key := entry.key()
+		occurrences[key]++
+		key = fmt.Sprintf("%s #%d", key, occurrences[key])
+		r, failed := m.retries[key]
+		if failed && now.Before(r.next) {
+			retries[key] = r
+			continue
+		}
+		if rsv == nil {
+			err = m.setup(ctx, entry, rsvs)
+		} else {
+			err = m.keepUp(ctx, entry, rsv, now)
+		}
+		if err != nil {
+			r.failures++
+			r.next = now.Add(m.backoff(r.failures))
+			retries[key] = r
+			logger.Info("Cannot keep up COLIBRI segment reservation",
+				"destination", entry.Destination, "failures", r.failures,
+				"retry_at", r.next, "err", err)
+		}
+	}
+	m.retries = retries
+	for _, rsv := range unclaimed {
+		if err := m.teardown(ctx, rsv); err != nil {
+			logger.Info("Cannot tear down COLIBRI segment reservation", "id", rsv.ID,
+				"err", err)
+		}
+	}
+}
+
+// backoff returns the time to wait before trying again a configured reservation that failed
+// that many consecutive times.
+func (m *Manager) backoff(failures int) time.Duration {
+	d := m.RetryBackoff
+	for i := 1; i < failures && d < m.MaxRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > m.MaxRetryBackoff {
+		return m.MaxRetryBackoff
+	}
+	return d
+}
+
+// match returns the first unclaimed reservation fulfilling the entry, or nil if none does.
+func (m *Manager) match(entry *Reservation, rsvs []*segment.Reservation,
+	unclaimed map[reservation.SegmentID]*segment.Reservation) *segment.Reservation {
+
+	for _, rsv := range rsvs {
+		if _, ok := unclaimed[rsv.ID]; !ok {
+			continue
+		}
+		if rsv.Path.GetDstIA() != entry.Destination || rsv.TrafficSplit != entry.SplitCls ||
+			rsv.PathType != entry.pathType(m.Core) {
+			continue
+		}
+		if len(entry.sequence.Eval([]snet.Path{pathFromSteps(rsv.Path)})) == 1 {
+			return rsv
+		}
+	}
+	return nil
+}
+
+// setup creates a new reservation for the entry, on a path not used by another reservation.
+func (m *Manager) setup(ctx context.Context, entry *Reservation,
+	existing []*segment.Reservation) error {
+
+	paths, err := m.Router.AllRoutes(ctx, entry.Destination)
+	if err != nil {
+		return serrors.WrapStr("finding paths", err)
+	}
+	var steps segment.ReservationTransparentPath
+	for _, p := range entry.sequence.Eval(paths) {
+		candidate, err := stepsFromPath(p)
+		if err != nil || isUsed(candidate, existing) {
+			continue
+		}
+		steps = candidate
+		break
+	}
+	if steps == nil {
+		return serrors.New("no available path matches the predicate",
+			"predicate", entry.PathPredicate, "paths", len(paths))
+	}
+	rsv := segment.NewReservation()
+	rsv.ID.ASID = m.LocalIA.A
+	rsv.Path = steps
+	rsv.Egress = steps[0].Egress
+	rsv.PathType = entry.pathType(m.Core)
+	rsv.PathEndProps = endProps(rsv.PathType)
+	rsv.TrafficSplit = entry.SplitCls
+	if err := m.DB.NewSegmentRsv(ctx, rsv); err != nil {
+		return serrors.WrapStr("creating segment reservation", err)
+	}
+	if err := m.requestIndex(ctx, entry, rsv, false); err != nil {
+		if err := m.teardown(ctx, rsv); err != nil {
+			log.FromCtx(ctx).Info("Cannot tear down failed COLIBRI segment reservation",
+				"id", rsv.ID, "err", err)
+		}
+		return serrors.WrapStr("setting up segment reservation", err, "id", rsv.ID)
+	}
+	return nil
+}
+
+// keepUp activates the last index if it was confirmed, or requests a new one if the
+// active index is about to expire or no longer matches the entry.
+func (m *Manager) keepUp(ctx context.Context, entry *Reservation, rsv *segment.Reservation,
+	now time.Time) error {
+
+	if n := len(rsv.Indices); n > 0 {
+		last := &rsv.Indices[n-1]
+		if last.State() == segment.IndexPending ||
+			(last.State() == segment.IndexTemporary && completeToken(rsv, last)) {
+			// the setup succeeded, but the index was not activated
+			return m.activate(ctx, rsv, last.Idx)
+		}
+	}
+	active := rsv.ActiveIndex()
+	if active != nil && active.Expiration.Sub(now) > m.RenewalMargin &&
+		active.MinBW == entry.MinBW && active.MaxBW == entry.MaxBW {
+		return nil
+	}
+	if err := m.requestIndex(ctx, entry, rsv, true); err != nil {
+		return serrors.WrapStr("renewing segment reservation", err, "id", rsv.ID)
+	}
+	return nil
+}
+
+// requestIndex sets up a new index for the reservation and activates it. If a renewal fails
+// after this AS admitted it, the new index is cleaned up.
+func (m *Manager) requestIndex(ctx context.Context, entry *Reservation,
+	rsv *segment.Reservation, renewal bool) error {
+
+	now := time.Now()
+	idx := reservation.IndexNumber(0)
+	if n := len(rsv.Indices); n > 0 {
+		idx = rsv.Indices[n-1].Idx.Add(1)
+	}
+	r, err := segment.NewRequest(now, &rsv.ID, idx, &colgrpc.Path{Steps: rsv.Path})
+	if err != nil {
+		return err
+	}
+	req := &segment.SetupReq{
+		Request: *r,
+		InfoField: reservation.InfoField{
+			ExpirationTick: reservation.TickFromTime(now.Add(m.Duration)),
+			BWCls:          entry.MaxBW,
+			Idx:            idx,
+			PathType:       rsv.PathType,
+		},
+		MinBW:     entry.MinBW,
+		MaxBW:     entry.MaxBW,
+		SplitCls:  rsv.TrafficSplit,
+		PathProps: rsv.PathEndProps,
+	}
+	// this AS admits the request first, and stores the new index.
+	msg, err := m.Store.AdmitSegmentReservation(ctx, req)
+	if err != nil {
+		return serrors.WrapStr("not admitted in this AS", err)
+	}
+	if err := m.setupIndex(ctx, msg, idx, renewal, now); err != nil {
+		if renewal {
+			// a failed setup is torn down by the caller
+			m.cleanupFailed(ctx, rsv, idx)
+		}
+		return err
+	}
+	return m.activate(ctx, rsv, idx)
+}
+
+// setupIndex sends the setup request admitted by this AS along its path, and processes
+// the response.
+func (m *Manager) setupIndex(ctx context.Context, msg base.MessageWithPath,
+	idx reservation.IndexNumber, renewal bool, timestamp time.Time) error {
+
+	res, err := m.initiate(ctx, msg, renewal, timestamp)
+	if err != nil {
+		return err
+	}
+	success, ok := res.(*segment.ResponseSetupSuccess)
+	if !ok {
+		return serrors.New("setup rejected", "index", idx, "response", fmt.Sprintf("%T", res))
+	}
+	if _, err := m.Store.ProcessSegmentSetupResponse(ctx, success); err != nil {
+		return serrors.WrapStr("processing setup response", err)
+	}
+	return nil
+}
+
+// cleanupFailed removes the index that could not be set up in all the ASes that admitted
+// it. The cleanup stops at the first AS that did not.
+func (m *Manager) cleanupFailed(ctx context.Context, rsv *segment.Reservation,
+	idx reservation.IndexNumber) {
+
+	logger := log.FromCtx(ctx)
+	now := time.Now()
+	r, err := segment.NewRequest(now, &rsv.ID, idx, &colgrpc.Path{Steps: rsv.Path})
+	if err != nil {
+		logger.Info("Cannot clean up failed COLIBRI segment index", "id", rsv.ID,
+			"index", idx, "err", err)
+		return
+	}
+	msg, err := m.Store.CleanupSegmentReservation(ctx, &segment.CleanupReq{Request: *r})
+	if err != nil {
+		logger.Info("Cannot clean up failed COLIBRI segment index in this AS", "id", rsv.ID,
+			"index", idx, "err", err)
+		return
+	}
+	res, err := m.initiate(ctx, msg, false, now)
+	if err != nil {
+		logger.Debug("Cannot clean up failed COLIBRI segment index", "id", rsv.ID,
+			"index", idx, "err", err)
+		return
+	}
+	if _, ok := res.(*segment.ResponseCleanupSuccess); !ok {
+		logger.Debug("Failed COLIBRI segment index not cleaned up in all ASes", "id", rsv.ID,
+			"index", idx, "response", fmt.Sprintf("%T", res))
+	}
+}
+
+// activate confirms and activates the index in all the ASes of the reservation.
+func (m *Manager) activate(ctx context.Context, rsv *segment.Reservation,
+	idx reservation.IndexNumber) error {
+
+	now := time.Now()
+	r, err := segment.NewRequest(now, &rsv.ID, idx, &colgrpc.Path{Steps: rsv.Path})
+	if err != nil {
+		return err
+	}
+	msg, err := m.Store.ConfirmSegmentReservation(ctx, &segment.IndexConfirmationReq{
+		Request: *r,
+		State:   segment.IndexActive,
+	})
+	if err != nil {
+		return serrors.WrapStr("cannot activate index in this AS", err, "index", idx)
+	}
+	res, err := m.initiate(ctx, msg, false, now)
+	if err != nil {
+		return err
+	}
+	if _, ok := res.(*segment.ResponseIndexConfirmationSuccess); !ok {
+		return serrors.New("index activation rejected", "index", idx,
+			"response", fmt.Sprintf("%T", res))
+	}
+	return nil
+}
+
+// teardown removes the reservation in all the ASes of its path.
+func (m *Manager) teardown(ctx context.Context, rsv *segment.Reservation) error {
+	now := time.Now()
+	var idx reservation.IndexNumber
+	if n := len(rsv.Indices); n > 0 {
+		idx = rsv.Indices[n-1].Idx
+	}
+	r, err := segment.NewRequest(now, &rsv.ID, idx, &colgrpc.Path{Steps: rsv.Path})
+	if err != nil {
+		return err
+	}
+	msg, err := m.Store.TearDownSegmentReservation(ctx, &segment.TeardownReq{Request: *r})
+	if err != nil {
+		return serrors.WrapStr("cannot tear down in this AS", err)
+	}
+	res, err := m.initiate(ctx, msg, false, now)
+	if err != nil {
+		return err
+	}
+	if _, ok := res.(*segment.ResponseTeardownSuccess); !ok {
+		return serrors.New("teardown rejected", "response", fmt.Sprintf("%T", res))
+	}
+	return nil
+}
+
+// initiate authenticates the request processed by this AS, and sends it along its path.
+func (m *Manager) initiate(ctx context.Context, msg base.MessageWithPath, renewal bool,
+	timestamp time.Time) (base.MessageWithPath, error) {
+
+	return initiate(ctx, m.DRKeys, m.Forwarder, msg, renewal, timestamp)
+}
+
+// initiate authenticates a request originating in this AS and already processed by it,
+// and sends it along its path.
+func initiate(ctx context.Context, keys drkeystorage.ServiceStore, fwd colgrpc.Forwarder,
+	msg base.MessageWithPath, renewal bool, timestamp time.Time) (base.MessageWithPath, error) {
+
+	req, ok := msg.(base.AuthenticatedRequest)
+	if !ok {
+		return nil, serrors.New("unexpected message from this AS",
+			"type", fmt.Sprintf("%T", msg))
+	}
+	if err := reservationstore.ComputeAuthenticators(ctx, keys, req); err != nil {
+		return nil, serrors.WrapStr("computing authenticators", err)
+	}
+	return colgrpc.Initiate(ctx, fwd, msg, renewal, timestamp)
+}
+
+// completeToken returns true if the token of the index carries the hop fields of all ASes.
+func completeToken(rsv *segment.Reservation, index *segment.Index) bool {
+	return index.Token != nil && len(index.Token.HopFields) == len(rsv.Path)
+}
+
+func isUsed(steps segment.ReservationTransparentPath, rsvs []*segment.Reservation) bool {
+	for _, rsv := range rsvs {
+		if rsv.Path.Equal(steps) {
+			return true
+		}
+	}
+	return false
+}
+
+// stepsFromPath returns the reservation path traversing the same interfaces as the path.
+func stepsFromPath(p snet.Path) (segment.ReservationTransparentPath, error) {
+	meta := p.Metadata()
+	if meta == nil || len(meta.Interfaces) == 0 || len(meta.Interfaces)%2 != 0 {
+		return nil, serrors.New("path without valid interface metadata")
+	}
+	ifaces := meta.Interfaces
+	steps := make(segment.ReservationTransparentPath, 0, len(ifaces)/2+1)
+	steps = append(steps, newStep(ifaces[0].IA, 0, ifaces[0].ID))
+	for i := 1; i < len(ifaces)-1; i += 2 {
+		steps = append(steps, newStep(ifaces[i].IA, ifaces[i].ID, ifaces[i+1].ID))
+	}
+	last := ifaces[len(ifaces)-1]
+	steps = append(steps, newStep(last.IA, last.ID, 0))
+	return steps, nil
+}
+
+// pathFromSteps returns a path with the interfaces of the reservation path, used to
+// evaluate the path predicates.
+func pathFromSteps(steps segment.ReservationTransparentPath) snet.Path {
+	ifaces := make([]snet.PathInterface, 0, 2*len(steps))
+	for i, s := range steps {
+		if i > 0 {
+			ifaces = append(ifaces, snet.PathInterface{IA: s.IA, ID: common.IFIDType(s.Ingress)})
+		}
+		if i < len(steps)-1 {
+			ifaces = append(ifaces, snet.PathInterface{IA: s.IA, ID: common.IFIDType(s.Egress)})
+		}
+	}
+	return snetpath.Path{
+		Dst:  steps.GetDstIA(),
+		Meta: snet.PathMetadata{Interfaces: ifaces},
+	}
+}
+
+func newStep(ia addr.IA, ingress, egress common.IFIDType) segment.PathStepWithIA {
+	return segment.PathStepWithIA{
+		PathStep: segment.PathStep{
+			Ingress: uint16(ingress),
+			Egress:  uint16(egress),
+		},
+		IA: ia,
+	}
+}
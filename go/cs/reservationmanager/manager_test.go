@@ -0,0 +1,415 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationmanager
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	"github.com/scionproto/scion/go/cs/reservation/translate"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend/mock_backend"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/grpc/mock_grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkeystorage/mock_drkeystorage"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+const testConfig = `reservations:
+- destination: 1-ff00:0:110
+  path_predicate: 1-ff00:0:111#0,1 1-ff00:0:110#2,0
+  min_bw: 1
+  max_bw: 13
+  split_cls: 2
+`
+
+var (
+	localIA = xtest.MustParseIA("1-ff00:0:111")
+	dstIA   = xtest.MustParseIA("1-ff00:0:110")
+)
+
+func TestPathConversion(t *testing.T) {
+	p := newTestPath(1, 2)
+	steps, err := stepsFromPath(p)
+	require.NoError(t, err)
+	require.Equal(t, segmenttest.NewPathFromComponents(
+		0, "1-ff00:0:111", 1, 2, "1-ff00:0:110", 0), steps)
+	require.Equal(t, p.Metadata().Interfaces, pathFromSteps(steps).Metadata().Interfaces)
+	_, err = stepsFromPath(snetpath.Path{Dst: dstIA})
+	require.Error(t, err)
+}
+
+func TestRunSetup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, db, store, router, fwd := newTestManager(t, ctrl, testConfig)
+
+	db.EXPECT().GetSegmentRsvsFromSrcDstIA(gomock.Any(), localIA, addr.IA{}).Return(nil, nil)
+	// only the second path matches the predicate
+	router.EXPECT().AllRoutes(gomock.Any(), dstIA).Return(
+		[]snet.Path{newTestPath(3, 4), newTestPath(1, 2)}, nil)
+	var id reservation.SegmentID
+	db.EXPECT().NewSegmentRsv(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, rsv *segment.Reservation) error {
+			require.Equal(t, localIA.A, rsv.ID.ASID)
+			require.Equal(t, uint16(1), rsv.Egress)
+			require.Equal(t, reservation.UpPath, rsv.PathType)
+			require.Equal(t, reservation.SplitCls(2), rsv.TrafficSplit)
+			require.NoError(t, rsv.PathEndProps.ValidateWithPathType(rsv.PathType))
+			rsv.ID.Suffix = [4]byte{0, 0, 0, 1}
+			id = rsv.ID
+			return nil
+		})
+	store.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+			require.Equal(t, id, req.ID)
+			require.Equal(t, reservation.IndexNumber(0), req.InfoField.Idx)
+			require.Equal(t, reservation.BWCls(1), req.MinBW)
+			require.Equal(t, reservation.BWCls(13), req.MaxBW)
+			require.Equal(t, 0, req.Path().IndexOfCurrentHop())
+			return req, nil
+		})
+	store.EXPECT().ProcessSegmentSetupResponse(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, resp *segment.ResponseSetupSuccess) (
+			base.MessageWithPath, error) {
+
+			require.Equal(t, id, resp.ID)
+			require.Equal(t, localIA, resp.Path().(*colgrpc.Path).CurrentIA())
+			return resp, nil
+		})
+	store.EXPECT().ConfirmSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *segment.IndexConfirmationReq) (
+			base.MessageWithPath, error) {
+
+			require.Equal(t, segment.IndexActive, req.State)
+			return req, nil
+		})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respond(t)).Times(2)
+
+	m.Run(context.Background())
+}
+
+func TestRunSetupFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, db, store, router, fwd := newTestManager(t, ctrl, testConfig)
+
+	db.EXPECT().GetSegmentRsvsFromSrcDstIA(gomock.Any(), localIA, addr.IA{}).Return(nil, nil)
+	router.EXPECT().AllRoutes(gomock.Any(), dstIA).Return([]snet.Path{newTestPath(1, 2)}, nil)
+	db.EXPECT().NewSegmentRsv(gomock.Any(), gomock.Any())
+	store.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+			return req, nil
+		})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).Return(nil, serrors.New("test error"))
+	// the failed reservation is removed from all ASes
+	store.EXPECT().TearDownSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *segment.TeardownReq) (base.MessageWithPath, error) {
+			return req, nil
+		})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respond(t))
+
+	m.Run(context.Background())
+}
+
+func TestRunSetupBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, db, _, router, _ := newTestManager(t, ctrl, testConfig)
+
+	db.EXPECT().GetSegmentRsvsFromSrcDstIA(gomock.Any(), localIA, addr.IA{}).Return(
+		nil, nil).Times(3)
+	router.EXPECT().AllRoutes(gomock.Any(), dstIA).Return(nil, serrors.New("test error"))
+	m.Run(context.Background())
+	// not tried again until the backoff elapses
+	m.Run(context.Background())
+	router.EXPECT().AllRoutes(gomock.Any(), dstIA).Return(nil, serrors.New("test error"))
+	for key, r := range m.retries {
+		r.next = time.Now()
+		m.retries[key] = r
+	}
+	m.Run(context.Background())
+	require.Len(t, m.retries, 1)
+	for _, r := range m.retries {
+		require.Equal(t, 2, r.failures)
+	}
+
+	// the backoff doubles up to the maximum
+	require.Equal(t, DefaultRetryBackoff, m.backoff(1))
+	require.Equal(t, 2*DefaultRetryBackoff, m.backoff(2))
+	require.Equal(t, 4*DefaultRetryBackoff, m.backoff(3))
+	require.Equal(t, DefaultMaxRetryBackoff, m.backoff(100))
+}
+
+func TestRunRenewalFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, db, store, _, fwd := newTestManager(t, ctrl, testConfig)
+	rsv := newTestReservation(t, time.Now().Add(DefaultRenewalMargin/2), 13,
+		segment.IndexActive)
+
+	db.EXPECT().GetSegmentRsvsFromSrcDstIA(gomock.Any(), localIA, addr.IA{}).Return(
+		[]*segment.Reservation{rsv}, nil)
+	store.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+			return req, nil
+		})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).Return(nil, serrors.New("test error"))
+	// only the new index is removed from the ASes that admitted it
+	store.EXPECT().CleanupSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *segment.CleanupReq) (base.MessageWithPath, error) {
+			require.Equal(t, rsv.ID, req.ID)
+			require.Equal(t, reservation.IndexNumber(1), req.Index)
+			return req, nil
+		})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respond(t))
+
+	m.Run(context.Background())
+}
+
+func TestRunKeepUp(t *testing.T) {
+	cases := map[string]struct {
+		expiration time.Time
+		maxBW      reservation.BWCls
+		state      segment.IndexState
+		prepare    func(*mock_reservationstorage.MockStore)
+	}{
+		"up to date": {
+			expiration: time.Now().Add(DefaultDuration),
+			maxBW:      13,
+			state:      segment.IndexActive,
+			prepare:    func(*mock_reservationstorage.MockStore) {},
+		},
+		"about to expire": {
+			expiration: time.Now().Add(DefaultRenewalMargin / 2),
+			maxBW:      13,
+			state:      segment.IndexActive,
+			prepare: func(store *mock_reservationstorage.MockStore) {
+				store.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *segment.SetupReq) (
+						base.MessageWithPath, error) {
+
+						require.Equal(t, reservation.IndexNumber(1), req.InfoField.Idx)
+						return nil, serrors.New("test error")
+					})
+			},
+		},
+		"bandwidth changed": {
+			expiration: time.Now().Add(DefaultDuration),
+			maxBW:      11,
+			state:      segment.IndexActive,
+			prepare: func(store *mock_reservationstorage.MockStore) {
+				store.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).Return(
+					nil, serrors.New("test error"))
+			},
+		},
+		"not activated": {
+			expiration: time.Now().Add(DefaultDuration),
+			maxBW:      13,
+			state:      segment.IndexPending,
+			prepare: func(store *mock_reservationstorage.MockStore) {
+				store.EXPECT().ConfirmSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *segment.IndexConfirmationReq) (
+						base.MessageWithPath, error) {
+
+						require.Equal(t, reservation.IndexNumber(0), req.Index)
+						return nil, serrors.New("test error")
+					})
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m, db, store, _, _ := newTestManager(t, ctrl, testConfig)
+			rsv := newTestReservation(t, tc.expiration, tc.maxBW, tc.state)
+			db.EXPECT().GetSegmentRsvsFromSrcDstIA(gomock.Any(), localIA, addr.IA{}).Return(
+				[]*segment.Reservation{rsv}, nil)
+			tc.prepare(store)
+			m.Run(context.Background())
+		})
+	}
+}
+
+func TestRunTeardown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, db, store, _, fwd := newTestManager(t, ctrl, testConfig)
+	rsv := newTestReservation(t, time.Now().Add(DefaultDuration), 13, segment.IndexActive)
+	rsvs := []*segment.Reservation{rsv}
+
+	// the reservation is configured
+	db.EXPECT().GetSegmentRsvsFromSrcDstIA(gomock.Any(), localIA, addr.IA{}).Return(rsvs, nil)
+	m.Run(context.Background())
+	// an invalid configuration keeps the previous one
+	require.NoError(t, ioutil.WriteFile(m.ConfigFile, []byte("reservations: 3"), 0644))
+	db.EXPECT().GetSegmentRsvsFromSrcDstIA(gomock.Any(), localIA, addr.IA{}).Return(rsvs, nil)
+	m.Run(context.Background())
+	// removed from the configuration
+	require.NoError(t, ioutil.WriteFile(m.ConfigFile, []byte("reservations: []"), 0644))
+	db.EXPECT().GetSegmentRsvsFromSrcDstIA(gomock.Any(), localIA, addr.IA{}).Return(rsvs, nil)
+	store.EXPECT().TearDownSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *segment.TeardownReq) (base.MessageWithPath, error) {
+			require.Equal(t, rsv.ID, req.ID)
+			return req, nil
+		})
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(respond(t))
+	m.Run(context.Background())
+}
+
+func newTestManager(t *testing.T, ctrl *gomock.Controller, config string) (*Manager,
+	*mock_backend.MockDB, *mock_reservationstorage.MockStore, *mock_snet.MockRouter,
+	*mock_grpc.MockForwarder) {
+
+	dir, cleanF := xtest.MustTempDir("", "reservationmanager")
+	t.Cleanup(cleanF)
+	file := filepath.Join(dir, "reservations.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(config), 0644))
+	keys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	keys.EXPECT().GetLvl1Key(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		drkey.Lvl1Key{Key: drkey.DRKey(xtest.MustParseHexString(
+			"c584cad32613547c64823c756651b6f5"))}, nil).AnyTimes()
+	db := mock_backend.NewMockDB(ctrl)
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	router := mock_snet.NewMockRouter(ctrl)
+	fwd := mock_grpc.NewMockForwarder(ctrl)
+	m := &Manager{
+		LocalIA:         localIA,
+		ConfigFile:      file,
+		DB:              db,
+		Store:           store,
+		DRKeys:          keys,
+		Router:          router,
+		Forwarder:       fwd,
+		Duration:        DefaultDuration,
+		RenewalMargin:   DefaultRenewalMargin,
+		RetryBackoff:    DefaultRetryBackoff,
+		MaxRetryBackoff: DefaultMaxRetryBackoff,
+	}
+	return m, db, store, router, fwd
+}
+
+// newTestPath returns a path from the local AS to the destination through the interfaces.
+func newTestPath(egress, ingress common.IFIDType) snet.Path {
+	return snetpath.Path{
+		Dst: dstIA,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: localIA, ID: egress},
+				{IA: dstIA, ID: ingress},
+			},
+		},
+	}
+}
+
+func newTestReservation(t *testing.T, expiration time.Time, maxBW reservation.BWCls,
+	state segment.IndexState) *segment.Reservation {
+
+	rsv := segment.NewReservation()
+	rsv.ID.ASID = localIA.A
+	rsv.ID.Suffix = [4]byte{0, 0, 0, 1}
+	rsv.Path = segmenttest.NewPathFromComponents(0, "1-ff00:0:111", 1, 2, "1-ff00:0:110", 0)
+	rsv.Egress = 1
+	rsv.PathType = reservation.UpPath
+	rsv.PathEndProps = endProps(reservation.UpPath)
+	rsv.TrafficSplit = 2
+	idx, err := rsv.NewIndexAtSource(expiration, 1, maxBW, maxBW, 0, reservation.UpPath)
+	require.NoError(t, err)
+	require.NoError(t, rsv.SetIndexConfirmed(idx))
+	if state == segment.IndexActive {
+		require.NoError(t, rsv.SetIndexActive(idx))
+	}
+	return rsv
+}
+
+// respond returns a forwarding function that accepts the requests in all the other ASes.
+func respond(t *testing.T) func(context.Context, *colpb.ProcessRequest) (
+	*colpb.ProcessResponse, error) {
+
+	return func(_ context.Context, req *colpb.ProcessRequest) (*colpb.ProcessResponse, error) {
+		require.Len(t, req.Authenticators, 2)
+		path, err := colgrpc.PathFromPB(req.Path)
+		require.NoError(t, err)
+		require.Equal(t, 1, path.CurrentStep)
+		ctrl, err := colibri_mgmt.NewFromRaw(req.Raw)
+		require.NoError(t, err)
+		msg, err := translate.NewMsgFromCtrl(ctrl, path)
+		require.NoError(t, err)
+		revPath := path.Copy()
+		require.NoError(t, revPath.Reverse())
+		var id *reservation.SegmentID
+		var idx reservation.IndexNumber
+		switch r := msg.(type) {
+		case *segment.SetupReq:
+			id, idx = &r.ID, r.Index
+		case *segment.IndexConfirmationReq:
+			id, idx = &r.ID, r.Index
+		case *segment.TeardownReq:
+			id, idx = &r.ID, r.Index
+		case *segment.CleanupReq:
+			id, idx = &r.ID, r.Index
+		}
+		resp, err := segment.NewResponse(time.Now(), id, idx, revPath, true, 0)
+		require.NoError(t, err)
+		var res base.MessageWithPath
+		switch r := msg.(type) {
+		case *segment.SetupReq:
+			res = &segment.ResponseSetupSuccess{
+				Response: *resp,
+				Token: reservation.Token{
+					InfoField: r.InfoField,
+					HopFields: []reservation.HopField{{Ingress: 2}},
+				},
+			}
+		case *segment.IndexConfirmationReq:
+			res = &segment.ResponseIndexConfirmationSuccess{
+				Response: *resp,
+				State:    r.State,
+			}
+		case *segment.TeardownReq:
+			res = &segment.ResponseTeardownSuccess{Response: *resp}
+		case *segment.CleanupReq:
+			res = &segment.ResponseCleanupSuccess{Response: *resp}
+		}
+		ctrl, err = translate.NewCtrlFromMsg(res, false)
+		require.NoError(t, err)
+		raw, err := ctrl.PackRoot()
+		require.NoError(t, err)
+		pbPath, err := colgrpc.PathToPB(revPath)
+		require.NoError(t, err)
+		return &colpb.ProcessResponse{Raw: raw, Path: pbPath}, nil
+	}
+}
@@ -39,16 +39,30 @@ type ReserverOnly interface {
 	// NewSegmentRsv creates a new segment reservation in the DB, with an unused reservation ID.
 	// The created ID is set in the reservation pointer argument. Used by setup req.
 	NewSegmentRsv(ctx context.Context, rsv *segment.Reservation) error
+
+	// GetInitiatedE2ERsv returns the e2e reservation set up by this AS with that ID, or nil
+	// if there is none. Used by e2e renewal and cleanup on behalf of end hosts.
+	GetInitiatedE2ERsv(ctx context.Context, ID *reservation.E2EID) (*e2e.Initiated, error)
+	// PersistInitiatedE2ERsv creates or updates an e2e reservation set up by this AS.
+	PersistInitiatedE2ERsv(ctx context.Context, rsv *e2e.Initiated) error
+	// DeleteInitiatedE2ERsv removes an e2e reservation set up by this AS.
+	DeleteInitiatedE2ERsv(ctx context.Context, ID *reservation.E2EID) error
 }
 
 // TransitOnly represents an AS in-path of a reservation, not the one originating it.
 type TransitOnly interface {
 	// GetAllSegmentRsvs returns all segment reservations. Used by setup req.
 	GetAllSegmentRsvs(ctx context.Context) ([]*segment.Reservation, error)
+	// NewSegmentRsvWithID creates a new segment reservation in the DB with the ID it already
+	// has, chosen by the AS that started the reservation. Used by setup req.
+	NewSegmentRsvWithID(ctx context.Context, rsv *segment.Reservation) error
 	// GetSegmentRsvsFromIFPair returns all segment reservations that enter this AS at
-	// the specified ingress and exit at that egress. Used by setup req.
+	// the specified ingress and exit at that egress. Telescopic reservations stacked on a
+	// base with the same interfaces are left out, as they use its bandwidth. Used by setup req.
 	GetSegmentRsvsFromIFPair(ctx context.Context, ingress, egress *uint16) (
 		[]*segment.Reservation, error)
+	// GetAllE2ERsvs returns all e2e reservations.
+	GetAllE2ERsvs(ctx context.Context) ([]*e2e.Reservation, error)
 }
 
 // ReserverAndTransit contains the functionality for any AS that has a COLIBRI service.
@@ -59,12 +73,17 @@ type ReserverAndTransit interface {
 		*segment.Reservation, error)
 	// PersistSegmentRsv ensures the DB contains the reservation as represented in rsv.
 	PersistSegmentRsv(ctx context.Context, rsv *segment.Reservation) error
+	// GetTelescopicSegmentRsvs returns the segment reservations stacked on top of the base one.
+	// Used by telescopic setup req.
+	GetTelescopicSegmentRsvs(ctx context.Context, baseID *reservation.SegmentID) (
+		[]*segment.Reservation, error)
 	// DeleteSegmentRsv removes the segment reservation. Used in teardown.
 	DeleteSegmentRsv(ctx context.Context, ID *reservation.SegmentID) error
 
 	// DeleteExpiredIndices will remove expired indices from the DB. If a reservation is left
 	// without any index after removing the expired ones, it will also be removed. This applies to
-	// both segment and e2e reservations.
+	// both segment and e2e reservations, and to the e2e reservations set up by this AS whose
+	// last index expired.
 	// Used on schedule.
 	DeleteExpiredIndices(ctx context.Context, now time.Time) (int, error)
 
@@ -0,0 +1,388 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/tracing"
+)
+
+const (
+	dbNamespace = "colibridb"
+	labelDbName = "db"
+)
+
+var (
+	queriesTotal *prometheus.CounterVec
+	resultsTotal *prometheus.CounterVec
+
+	initMetricsOnce sync.Once
+)
+
+type dbLabels struct {
+	db, op string
+}
+
+func (l dbLabels) Labels() []string {
+	return []string{labelDbName, prom.LabelOperation}
+}
+
+func (l dbLabels) Values() []string {
+	return []string{l.db, l.op}
+}
+
+type dbLabelsWithResult struct {
+	db, op, result string
+}
+
+func (l dbLabelsWithResult) Labels() []string {
+	return []string{labelDbName, prom.LabelOperation, prom.LabelResult}
+}
+
+func (l dbLabelsWithResult) Values() []string {
+	return []string{l.db, l.op, l.result}
+}
+
+func initMetrics() {
+	initMetricsOnce.Do(func() {
+		queriesTotal = prom.NewCounterVecWithLabels(dbNamespace, "", "queries_total",
+			"Total queries to the database.", dbLabels{})
+		resultsTotal = prom.NewCounterVecWithLabels(dbNamespace, "", "results_total",
+			"Results of colibridb operations.", dbLabelsWithResult{})
+	})
+}
+
+// DBWithMetrics wraps the given db into a db that exports metrics.
+func DBWithMetrics(dbName string, db DB) *MetricsDB {
+	initMetrics()
+	return &MetricsDB{
+		executor: &executor{
+			db:      db,
+			metrics: newCounters(dbName),
+		},
+		db: db,
+	}
+}
+
+var _ DB = (*MetricsDB)(nil)
+
+// MetricsDB is a wrapper around the reservation db that exports metrics.
+type MetricsDB struct {
+	*executor
+	db DB
+}
+
+func (db *MetricsDB) SetMaxOpenConns(maxOpenConns int) {
+	db.db.SetMaxOpenConns(maxOpenConns)
+}
+
+func (db *MetricsDB) SetMaxIdleConns(maxIdleConns int) {
+	db.db.SetMaxIdleConns(maxIdleConns)
+}
+
+func (db *MetricsDB) Close() error {
+	return db.db.Close()
+}
+
+func (db *MetricsDB) BeginTransaction(ctx context.Context,
+	opts *sql.TxOptions) (Transaction, error) {
+
+	var tx Transaction
+	var err error
+	db.metrics.Observe(ctx, "begin_tx", func(ctx context.Context) error {
+		tx, err = db.db.BeginTransaction(ctx, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsTransaction{
+		executor: &executor{
+			db:      tx,
+			metrics: db.metrics,
+		},
+		tx:  tx,
+		ctx: ctx,
+	}, nil
+}
+
+var _ Transaction = (*MetricsTransaction)(nil)
+
+// MetricsTransaction is a wrapper around a reservation db transaction that exports metrics.
+type MetricsTransaction struct {
+	*executor
+	tx  Transaction
+	ctx context.Context
+}
+
+func (tx *MetricsTransaction) Commit() error {
+	var err error
+	tx.metrics.Observe(tx.ctx, "tx_commit", func(_ context.Context) error {
+		err = tx.tx.Commit()
+		return err
+	})
+	return err
+}
+
+func (tx *MetricsTransaction) Rollback() error {
+	var err error
+	tx.metrics.Observe(tx.ctx, "tx_rollback", func(_ context.Context) error {
+		err = tx.tx.Rollback()
+		if err == sql.ErrTxDone {
+			return nil
+		}
+		return err
+	})
+	return err
+}
+
+type counters struct {
+	queriesTotal *prometheus.CounterVec
+	resultsTotal *prometheus.CounterVec
+}
+
+func newCounters(dbName string) *counters {
+	labels := prometheus.Labels{labelDbName: dbName}
+	return &counters{
+		queriesTotal: queriesTotal.MustCurryWith(labels),
+		resultsTotal: resultsTotal.MustCurryWith(labels),
+	}
+}
+
+func (c *counters) Observe(ctx context.Context, op string, action func(ctx context.Context) error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, fmt.Sprintf("colibridb.%s", op))
+	defer span.Finish()
+	c.queriesTotal.WithLabelValues(op).Inc()
+	err := action(ctx)
+
+	label := db.ErrToMetricLabel(err)
+	ext.Error.Set(span, err != nil)
+	tracing.ResultLabel(span, label)
+
+	c.resultsTotal.WithLabelValues(op, label).Inc()
+}
+
+type executor struct {
+	db interface {
+		ReserverOnly
+		TransitOnly
+		ReserverAndTransit
+	}
+	metrics *counters
+}
+
+func (e *executor) GetSegmentRsvsFromSrcDstIA(ctx context.Context, srcIA, dstIA addr.IA) (
+	[]*segment.Reservation, error) {
+
+	var ret []*segment.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_segment_rsvs_from_src_dst_ia", func(ctx context.Context) error {
+		ret, err = e.db.GetSegmentRsvsFromSrcDstIA(ctx, srcIA, dstIA)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) GetSegmentRsvFromPath(ctx context.Context,
+	path segment.ReservationTransparentPath) (*segment.Reservation, error) {
+
+	var ret *segment.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_segment_rsv_from_path", func(ctx context.Context) error {
+		ret, err = e.db.GetSegmentRsvFromPath(ctx, path)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) NewSegmentRsv(ctx context.Context, rsv *segment.Reservation) error {
+	var err error
+	e.metrics.Observe(ctx, "new_segment_rsv", func(ctx context.Context) error {
+		err = e.db.NewSegmentRsv(ctx, rsv)
+		return err
+	})
+	return err
+}
+
+func (e *executor) NewSegmentRsvWithID(ctx context.Context, rsv *segment.Reservation) error {
+	var err error
+	e.metrics.Observe(ctx, "new_segment_rsv_with_id", func(ctx context.Context) error {
+		err = e.db.NewSegmentRsvWithID(ctx, rsv)
+		return err
+	})
+	return err
+}
+
+func (e *executor) GetAllSegmentRsvs(ctx context.Context) ([]*segment.Reservation, error) {
+	var ret []*segment.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_all_segment_rsvs", func(ctx context.Context) error {
+		ret, err = e.db.GetAllSegmentRsvs(ctx)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) GetSegmentRsvsFromIFPair(ctx context.Context, ingress, egress *uint16) (
+	[]*segment.Reservation, error) {
+
+	var ret []*segment.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_segment_rsvs_from_if_pair", func(ctx context.Context) error {
+		ret, err = e.db.GetSegmentRsvsFromIFPair(ctx, ingress, egress)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) GetAllE2ERsvs(ctx context.Context) ([]*e2e.Reservation, error) {
+	var ret []*e2e.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_all_e2e_rsvs", func(ctx context.Context) error {
+		ret, err = e.db.GetAllE2ERsvs(ctx)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) GetSegmentRsvFromID(ctx context.Context, ID *reservation.SegmentID) (
+	*segment.Reservation, error) {
+
+	var ret *segment.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_segment_rsv_from_id", func(ctx context.Context) error {
+		ret, err = e.db.GetSegmentRsvFromID(ctx, ID)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) PersistSegmentRsv(ctx context.Context, rsv *segment.Reservation) error {
+	var err error
+	e.metrics.Observe(ctx, "persist_segment_rsv", func(ctx context.Context) error {
+		err = e.db.PersistSegmentRsv(ctx, rsv)
+		return err
+	})
+	return err
+}
+
+func (e *executor) GetTelescopicSegmentRsvs(ctx context.Context, baseID *reservation.SegmentID) (
+	[]*segment.Reservation, error) {
+
+	var ret []*segment.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_telescopic_segment_rsvs", func(ctx context.Context) error {
+		ret, err = e.db.GetTelescopicSegmentRsvs(ctx, baseID)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) DeleteSegmentRsv(ctx context.Context, ID *reservation.SegmentID) error {
+	var err error
+	e.metrics.Observe(ctx, "delete_segment_rsv", func(ctx context.Context) error {
+		err = e.db.DeleteSegmentRsv(ctx, ID)
+		return err
+	})
+	return err
+}
+
+func (e *executor) DeleteExpiredIndices(ctx context.Context, now time.Time) (int, error) {
+	var ret int
+	var err error
+	e.metrics.Observe(ctx, "delete_expired_indices", func(ctx context.Context) error {
+		ret, err = e.db.DeleteExpiredIndices(ctx, now)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) GetE2ERsvFromID(ctx context.Context, ID *reservation.E2EID) (
+	*e2e.Reservation, error) {
+
+	var ret *e2e.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_e2e_rsv_from_id", func(ctx context.Context) error {
+		ret, err = e.db.GetE2ERsvFromID(ctx, ID)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) GetE2ERsvsOnSegRsv(ctx context.Context, ID *reservation.SegmentID) (
+	[]*e2e.Reservation, error) {
+
+	var ret []*e2e.Reservation
+	var err error
+	e.metrics.Observe(ctx, "get_e2e_rsvs_on_seg_rsv", func(ctx context.Context) error {
+		ret, err = e.db.GetE2ERsvsOnSegRsv(ctx, ID)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) PersistE2ERsv(ctx context.Context, rsv *e2e.Reservation) error {
+	var err error
+	e.metrics.Observe(ctx, "persist_e2e_rsv", func(ctx context.Context) error {
+		err = e.db.PersistE2ERsv(ctx, rsv)
+		return err
+	})
+	return err
+}
+
+func (e *executor) GetInitiatedE2ERsv(ctx context.Context, ID *reservation.E2EID) (
+	*e2e.Initiated, error) {
+
+	var ret *e2e.Initiated
+	var err error
+	e.metrics.Observe(ctx, "get_initiated_e2e_rsv", func(ctx context.Context) error {
+		ret, err = e.db.GetInitiatedE2ERsv(ctx, ID)
+		return err
+	})
+	return ret, err
+}
+
+func (e *executor) PersistInitiatedE2ERsv(ctx context.Context, rsv *e2e.Initiated) error {
+	var err error
+	e.metrics.Observe(ctx, "persist_initiated_e2e_rsv", func(ctx context.Context) error {
+		err = e.db.PersistInitiatedE2ERsv(ctx, rsv)
+		return err
+	})
+	return err
+}
+
+func (e *executor) DeleteInitiatedE2ERsv(ctx context.Context, ID *reservation.E2EID) error {
+	var err error
+	e.metrics.Observe(ctx, "delete_initiated_e2e_rsv", func(ctx context.Context) error {
+		err = e.db.DeleteInitiatedE2ERsv(ctx, ID)
+		return err
+	})
+	return err
+}
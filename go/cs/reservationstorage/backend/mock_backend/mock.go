@@ -84,6 +84,20 @@ func (mr *MockDBMockRecorder) DeleteExpiredIndices(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIndices", reflect.TypeOf((*MockDB)(nil).DeleteExpiredIndices), arg0, arg1)
 }
 
+// DeleteInitiatedE2ERsv mocks base method
+func (m *MockDB) DeleteInitiatedE2ERsv(arg0 context.Context, arg1 *reservation.E2EID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInitiatedE2ERsv", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInitiatedE2ERsv indicates an expected call of DeleteInitiatedE2ERsv
+func (mr *MockDBMockRecorder) DeleteInitiatedE2ERsv(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInitiatedE2ERsv", reflect.TypeOf((*MockDB)(nil).DeleteInitiatedE2ERsv), arg0, arg1)
+}
+
 // DeleteSegmentRsv mocks base method
 func (m *MockDB) DeleteSegmentRsv(arg0 context.Context, arg1 *reservation.SegmentID) error {
 	m.ctrl.T.Helper()
@@ -98,6 +112,21 @@ func (mr *MockDBMockRecorder) DeleteSegmentRsv(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSegmentRsv", reflect.TypeOf((*MockDB)(nil).DeleteSegmentRsv), arg0, arg1)
 }
 
+// GetAllE2ERsvs mocks base method
+func (m *MockDB) GetAllE2ERsvs(arg0 context.Context) ([]*e2e.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllE2ERsvs", arg0)
+	ret0, _ := ret[0].([]*e2e.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllE2ERsvs indicates an expected call of GetAllE2ERsvs
+func (mr *MockDBMockRecorder) GetAllE2ERsvs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllE2ERsvs", reflect.TypeOf((*MockDB)(nil).GetAllE2ERsvs), arg0)
+}
+
 // GetAllSegmentRsvs mocks base method
 func (m *MockDB) GetAllSegmentRsvs(arg0 context.Context) ([]*segment.Reservation, error) {
 	m.ctrl.T.Helper()
@@ -143,6 +172,21 @@ func (mr *MockDBMockRecorder) GetE2ERsvsOnSegRsv(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetE2ERsvsOnSegRsv", reflect.TypeOf((*MockDB)(nil).GetE2ERsvsOnSegRsv), arg0, arg1)
 }
 
+// GetInitiatedE2ERsv mocks base method
+func (m *MockDB) GetInitiatedE2ERsv(arg0 context.Context, arg1 *reservation.E2EID) (*e2e.Initiated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInitiatedE2ERsv", arg0, arg1)
+	ret0, _ := ret[0].(*e2e.Initiated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInitiatedE2ERsv indicates an expected call of GetInitiatedE2ERsv
+func (mr *MockDBMockRecorder) GetInitiatedE2ERsv(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitiatedE2ERsv", reflect.TypeOf((*MockDB)(nil).GetInitiatedE2ERsv), arg0, arg1)
+}
+
 // GetSegmentRsvFromID mocks base method
 func (m *MockDB) GetSegmentRsvFromID(arg0 context.Context, arg1 *reservation.SegmentID) (*segment.Reservation, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +247,21 @@ func (mr *MockDBMockRecorder) GetSegmentRsvsFromSrcDstIA(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentRsvsFromSrcDstIA", reflect.TypeOf((*MockDB)(nil).GetSegmentRsvsFromSrcDstIA), arg0, arg1, arg2)
 }
 
+// GetTelescopicSegmentRsvs mocks base method
+func (m *MockDB) GetTelescopicSegmentRsvs(arg0 context.Context, arg1 *reservation.SegmentID) ([]*segment.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTelescopicSegmentRsvs", arg0, arg1)
+	ret0, _ := ret[0].([]*segment.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTelescopicSegmentRsvs indicates an expected call of GetTelescopicSegmentRsvs
+func (mr *MockDBMockRecorder) GetTelescopicSegmentRsvs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTelescopicSegmentRsvs", reflect.TypeOf((*MockDB)(nil).GetTelescopicSegmentRsvs), arg0, arg1)
+}
+
 // NewSegmentRsv mocks base method
 func (m *MockDB) NewSegmentRsv(arg0 context.Context, arg1 *segment.Reservation) error {
 	m.ctrl.T.Helper()
@@ -217,6 +276,20 @@ func (mr *MockDBMockRecorder) NewSegmentRsv(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSegmentRsv", reflect.TypeOf((*MockDB)(nil).NewSegmentRsv), arg0, arg1)
 }
 
+// NewSegmentRsvWithID mocks base method
+func (m *MockDB) NewSegmentRsvWithID(arg0 context.Context, arg1 *segment.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSegmentRsvWithID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NewSegmentRsvWithID indicates an expected call of NewSegmentRsvWithID
+func (mr *MockDBMockRecorder) NewSegmentRsvWithID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSegmentRsvWithID", reflect.TypeOf((*MockDB)(nil).NewSegmentRsvWithID), arg0, arg1)
+}
+
 // PersistE2ERsv mocks base method
 func (m *MockDB) PersistE2ERsv(arg0 context.Context, arg1 *e2e.Reservation) error {
 	m.ctrl.T.Helper()
@@ -231,6 +304,20 @@ func (mr *MockDBMockRecorder) PersistE2ERsv(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistE2ERsv", reflect.TypeOf((*MockDB)(nil).PersistE2ERsv), arg0, arg1)
 }
 
+// PersistInitiatedE2ERsv mocks base method
+func (m *MockDB) PersistInitiatedE2ERsv(arg0 context.Context, arg1 *e2e.Initiated) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistInitiatedE2ERsv", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PersistInitiatedE2ERsv indicates an expected call of PersistInitiatedE2ERsv
+func (mr *MockDBMockRecorder) PersistInitiatedE2ERsv(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistInitiatedE2ERsv", reflect.TypeOf((*MockDB)(nil).PersistInitiatedE2ERsv), arg0, arg1)
+}
+
 // PersistSegmentRsv mocks base method
 func (m *MockDB) PersistSegmentRsv(arg0 context.Context, arg1 *segment.Reservation) error {
 	m.ctrl.T.Helper()
@@ -321,6 +408,20 @@ func (mr *MockTransactionMockRecorder) DeleteExpiredIndices(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIndices", reflect.TypeOf((*MockTransaction)(nil).DeleteExpiredIndices), arg0, arg1)
 }
 
+// DeleteInitiatedE2ERsv mocks base method
+func (m *MockTransaction) DeleteInitiatedE2ERsv(arg0 context.Context, arg1 *reservation.E2EID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInitiatedE2ERsv", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInitiatedE2ERsv indicates an expected call of DeleteInitiatedE2ERsv
+func (mr *MockTransactionMockRecorder) DeleteInitiatedE2ERsv(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInitiatedE2ERsv", reflect.TypeOf((*MockTransaction)(nil).DeleteInitiatedE2ERsv), arg0, arg1)
+}
+
 // DeleteSegmentRsv mocks base method
 func (m *MockTransaction) DeleteSegmentRsv(arg0 context.Context, arg1 *reservation.SegmentID) error {
 	m.ctrl.T.Helper()
@@ -335,6 +436,21 @@ func (mr *MockTransactionMockRecorder) DeleteSegmentRsv(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSegmentRsv", reflect.TypeOf((*MockTransaction)(nil).DeleteSegmentRsv), arg0, arg1)
 }
 
+// GetAllE2ERsvs mocks base method
+func (m *MockTransaction) GetAllE2ERsvs(arg0 context.Context) ([]*e2e.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllE2ERsvs", arg0)
+	ret0, _ := ret[0].([]*e2e.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllE2ERsvs indicates an expected call of GetAllE2ERsvs
+func (mr *MockTransactionMockRecorder) GetAllE2ERsvs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllE2ERsvs", reflect.TypeOf((*MockTransaction)(nil).GetAllE2ERsvs), arg0)
+}
+
 // GetAllSegmentRsvs mocks base method
 func (m *MockTransaction) GetAllSegmentRsvs(arg0 context.Context) ([]*segment.Reservation, error) {
 	m.ctrl.T.Helper()
@@ -380,6 +496,21 @@ func (mr *MockTransactionMockRecorder) GetE2ERsvsOnSegRsv(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetE2ERsvsOnSegRsv", reflect.TypeOf((*MockTransaction)(nil).GetE2ERsvsOnSegRsv), arg0, arg1)
 }
 
+// GetInitiatedE2ERsv mocks base method
+func (m *MockTransaction) GetInitiatedE2ERsv(arg0 context.Context, arg1 *reservation.E2EID) (*e2e.Initiated, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInitiatedE2ERsv", arg0, arg1)
+	ret0, _ := ret[0].(*e2e.Initiated)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInitiatedE2ERsv indicates an expected call of GetInitiatedE2ERsv
+func (mr *MockTransactionMockRecorder) GetInitiatedE2ERsv(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitiatedE2ERsv", reflect.TypeOf((*MockTransaction)(nil).GetInitiatedE2ERsv), arg0, arg1)
+}
+
 // GetSegmentRsvFromID mocks base method
 func (m *MockTransaction) GetSegmentRsvFromID(arg0 context.Context, arg1 *reservation.SegmentID) (*segment.Reservation, error) {
 	m.ctrl.T.Helper()
@@ -440,6 +571,21 @@ func (mr *MockTransactionMockRecorder) GetSegmentRsvsFromSrcDstIA(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentRsvsFromSrcDstIA", reflect.TypeOf((*MockTransaction)(nil).GetSegmentRsvsFromSrcDstIA), arg0, arg1, arg2)
 }
 
+// GetTelescopicSegmentRsvs mocks base method
+func (m *MockTransaction) GetTelescopicSegmentRsvs(arg0 context.Context, arg1 *reservation.SegmentID) ([]*segment.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTelescopicSegmentRsvs", arg0, arg1)
+	ret0, _ := ret[0].([]*segment.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTelescopicSegmentRsvs indicates an expected call of GetTelescopicSegmentRsvs
+func (mr *MockTransactionMockRecorder) GetTelescopicSegmentRsvs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTelescopicSegmentRsvs", reflect.TypeOf((*MockTransaction)(nil).GetTelescopicSegmentRsvs), arg0, arg1)
+}
+
 // NewSegmentRsv mocks base method
 func (m *MockTransaction) NewSegmentRsv(arg0 context.Context, arg1 *segment.Reservation) error {
 	m.ctrl.T.Helper()
@@ -454,6 +600,20 @@ func (mr *MockTransactionMockRecorder) NewSegmentRsv(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSegmentRsv", reflect.TypeOf((*MockTransaction)(nil).NewSegmentRsv), arg0, arg1)
 }
 
+// NewSegmentRsvWithID mocks base method
+func (m *MockTransaction) NewSegmentRsvWithID(arg0 context.Context, arg1 *segment.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewSegmentRsvWithID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NewSegmentRsvWithID indicates an expected call of NewSegmentRsvWithID
+func (mr *MockTransactionMockRecorder) NewSegmentRsvWithID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewSegmentRsvWithID", reflect.TypeOf((*MockTransaction)(nil).NewSegmentRsvWithID), arg0, arg1)
+}
+
 // PersistE2ERsv mocks base method
 func (m *MockTransaction) PersistE2ERsv(arg0 context.Context, arg1 *e2e.Reservation) error {
 	m.ctrl.T.Helper()
@@ -468,6 +628,20 @@ func (mr *MockTransactionMockRecorder) PersistE2ERsv(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistE2ERsv", reflect.TypeOf((*MockTransaction)(nil).PersistE2ERsv), arg0, arg1)
 }
 
+// PersistInitiatedE2ERsv mocks base method
+func (m *MockTransaction) PersistInitiatedE2ERsv(arg0 context.Context, arg1 *e2e.Initiated) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistInitiatedE2ERsv", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PersistInitiatedE2ERsv indicates an expected call of PersistInitiatedE2ERsv
+func (mr *MockTransactionMockRecorder) PersistInitiatedE2ERsv(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistInitiatedE2ERsv", reflect.TypeOf((*MockTransaction)(nil).PersistInitiatedE2ERsv), arg0, arg1)
+}
+
 // PersistSegmentRsv mocks base method
 func (m *MockTransaction) PersistSegmentRsv(arg0 context.Context, arg1 *segment.Reservation) error {
 	m.ctrl.T.Helper()
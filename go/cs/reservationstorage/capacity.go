@@ -0,0 +1,161 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstorage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// OverCapacityRsv is a segment reservation that blocks bandwidth on an interface whose
+// capacity is lower than the total bandwidth blocked on it, e.g. because the capacities were
+// reloaded with lower values after the reservation had been admitted.
+type OverCapacityRsv struct {
+	ID                string `json:"id"`
+	Ingress           uint16 `json:"ingress"`
+	Egress            uint16 `json:"egress"`
+	BlockedKbps       uint64 `json:"blocked_kbps"`
+	IngressOverloaded bool   `json:"ingress_overloaded"`
+	EgressOverloaded  bool   `json:"egress_overloaded"`
+}
+
+// CapacityChecker finds the segment reservations that exceed the capacities of their
+// interfaces. These reservations are not removed, but reported in the logs, the OverCapacity
+// metric and the status page, so that the operator can act on them.
+type CapacityChecker struct {
+	Store      Store
+	Capacities base.Capacities
+	// OverCapacity, if set, is set to the number of reservations exceeding the capacities.
+	OverCapacity metrics.Gauge
+
+	mu      sync.Mutex
+	checked time.Time
+	rsvs    []OverCapacityRsv
+}
+
+var _ periodic.Task = (*CapacityChecker)(nil)
+
+// Name returns the task name.
+func (c *CapacityChecker) Name() string {
+	return "colibri_capacity_checker"
+}
+
+// Run checks the reservations against the capacities, and logs the errors.
+func (c *CapacityChecker) Run(ctx context.Context) {
+	if err := c.Check(ctx); err != nil {
+		log.FromCtx(ctx).Error("Checking COLIBRI capacities", "err", err)
+	}
+}
+
+// Check compares the bandwidth blocked by all segment reservations on each interface with
+// the capacity of that interface, and records the reservations using overloaded interfaces.
+func (c *CapacityChecker) Check(ctx context.Context) error {
+	rsvs, err := c.Store.ListSegmentReservations(ctx)
+	if err != nil {
+		return serrors.WrapStr("listing segment reservations", err)
+	}
+	caps := base.SnapshotCapacities(c.Capacities)
+	blockedIngress := make(map[uint16]uint64)
+	blockedEgress := make(map[uint16]uint64)
+	for _, rsv := range rsvs {
+		blocked := rsv.MaxBlockedBW()
+		blockedIngress[rsv.Ingress] += blocked
+		blockedEgress[rsv.Egress] += blocked
+	}
+	var over []OverCapacityRsv
+	for _, rsv := range rsvs {
+		blocked := rsv.MaxBlockedBW()
+		if blocked == 0 {
+			continue
+		}
+		// ingress 0 and egress 0 mean that the reservation starts or ends in this AS
+		inOver := rsv.Ingress != 0 &&
+			blockedIngress[rsv.Ingress] > caps.CapacityIngress(rsv.Ingress)
+		egOver := rsv.Egress != 0 &&
+			blockedEgress[rsv.Egress] > caps.CapacityEgress(rsv.Egress)
+		if inOver || egOver {
+			over = append(over, OverCapacityRsv{
+				ID:                rsv.ID.String(),
+				Ingress:           rsv.Ingress,
+				Egress:            rsv.Egress,
+				BlockedKbps:       blocked,
+				IngressOverloaded: inOver,
+				EgressOverloaded:  egOver,
+			})
+		}
+	}
+	sort.Slice(over, func(i, j int) bool { return over[i].ID < over[j].ID })
+	if len(over) > 0 {
+		log.FromCtx(ctx).Info("COLIBRI reservations exceed the interface capacities",
+			"count", len(over))
+	}
+	metrics.GaugeSet(c.OverCapacity, float64(len(over)))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checked = time.Now()
+	c.rsvs = over
+	return nil
+}
+
+// OverCapacityReservations returns the reservations found exceeding the capacities by the
+// last check, and the time of that check.
+func (c *CapacityChecker) OverCapacityReservations() ([]OverCapacityRsv, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]OverCapacityRsv{}, c.rsvs...), c.checked
+}
+
+// StatusPage serves the capacities and the reservations exceeding them as JSON.
+func (c *CapacityChecker) StatusPage(w http.ResponseWriter, _ *http.Request) {
+	caps := base.SnapshotCapacities(c.Capacities)
+	type ifCapacity struct {
+		Ingress map[uint16]uint64 `json:"ingress_kbps"`
+		Egress  map[uint16]uint64 `json:"egress_kbps"`
+	}
+	rep := struct {
+		Capacities   ifCapacity        `json:"capacities"`
+		Checked      time.Time         `json:"checked"`
+		OverCapacity []OverCapacityRsv `json:"over_capacity"`
+	}{
+		Capacities: ifCapacity{
+			Ingress: make(map[uint16]uint64),
+			Egress:  make(map[uint16]uint64),
+		},
+	}
+	for _, ifid := range caps.IngressInterfaces() {
+		rep.Capacities.Ingress[ifid] = caps.CapacityIngress(ifid)
+	}
+	for _, ifid := range caps.EgressInterfaces() {
+		rep.Capacities.Egress[ifid] = caps.CapacityEgress(ifid)
+	}
+	rep.OverCapacity, rep.Checked = c.OverCapacityReservations()
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		http.Error(w, "Unable to marshal response", http.StatusInternalServerError)
+	}
+}
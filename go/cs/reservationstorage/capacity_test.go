@@ -0,0 +1,104 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstorage_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/conf"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestCapacityChecker(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	caps := &conf.Capacities{}
+	err := json.Unmarshal([]byte(
+		`{"ingress_kbps": {"1": 100}, "egress_kbps": {"2": 100, "3": 100}}`), caps)
+	require.NoError(t, err)
+	rsvs := []*segment.Reservation{
+		newRsv(t, "00000001", 1, 2, 5),
+		newRsv(t, "00000002", 1, 3, 5),
+		newRsv(t, "00000003", 0, 2, 3),
+	}
+	store := mock_reservationstorage.NewMockStore(mctrl)
+	store.EXPECT().ListSegmentReservations(gomock.Any()).Return(rsvs, nil)
+	gauge := metrics.NewTestGauge()
+	checker := &reservationstorage.CapacityChecker{
+		Store:        store,
+		Capacities:   caps,
+		OverCapacity: gauge,
+	}
+	err = checker.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, float64(2), metrics.GaugeValue(gauge))
+	over, checked := checker.OverCapacityReservations()
+	require.NotZero(t, checked)
+	blocked := reservation.BWCls(5).ToKbps()
+	require.Equal(t, []reservationstorage.OverCapacityRsv{
+		{
+			ID:                "ff00:0:1-00000001",
+			Ingress:           1,
+			Egress:            2,
+			BlockedKbps:       blocked,
+			IngressOverloaded: true,
+		},
+		{
+			ID:                "ff00:0:1-00000002",
+			Ingress:           1,
+			Egress:            3,
+			BlockedKbps:       blocked,
+			IngressOverloaded: true,
+		},
+	}, over)
+
+	w := httptest.NewRecorder()
+	checker.StatusPage(w, httptest.NewRequest("GET", "/colibri/capacities", nil))
+	var page struct {
+		OverCapacity []reservationstorage.OverCapacityRsv `json:"over_capacity"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	require.Equal(t, over, page.OverCapacity)
+}
+
+func newRsv(t *testing.T, suffix string, ingress, egress uint16,
+	bw reservation.BWCls) *segment.Reservation {
+
+	id, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString(suffix))
+	require.NoError(t, err)
+	rsv := segmenttest.NewReservation()
+	rsv.ID = *id
+	rsv.Ingress = ingress
+	rsv.Egress = egress
+	_, err = rsv.NewIndexAtSource(time.Now().Add(time.Minute), 1, bw, bw, 1,
+		reservation.UpPath)
+	require.NoError(t, err)
+	return rsv
+}
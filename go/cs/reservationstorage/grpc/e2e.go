@@ -0,0 +1,218 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+// E2EReservation is an index of an e2e reservation set up by this AS.
+type E2EReservation struct {
+	ID    reservation.E2EID
+	Index reservation.IndexNumber
+	Token reservation.Token                  // contains the hop fields of all ASes
+	Path  segment.ReservationTransparentPath // stitched from the segment reservations
+}
+
+// E2EInitiator sets up e2e reservations from this AS on behalf of its end hosts.
+type E2EInitiator interface {
+	// SetupE2E stitches segment reservations to reach dst and sets up a new e2e
+	// reservation on top of them.
+	SetupE2E(ctx context.Context, dst addr.IA, bw reservation.BWCls) (*E2EReservation, error)
+	// RenewE2E sets up a new index for an e2e reservation.
+	RenewE2E(ctx context.Context, id *reservation.E2EID, bw reservation.BWCls) (
+		*E2EReservation, error)
+	// CleanupE2E removes the index, and all the previous ones, of an e2e reservation.
+	CleanupE2E(ctx context.Context, id *reservation.E2EID, idx reservation.IndexNumber) error
+}
+
+// HostE2EInitiator sets up e2e reservations from this AS on behalf of its end hosts. Only the
+// host that set up a reservation can renew and clean it up. For the reservations requested
+// through a SCION daemon, the host is the one of the daemon, which in turn only lets the
+// application host that set up a reservation renew and clean it up.
+type HostE2EInitiator interface {
+	// SetupE2E stitches segment reservations to reach dst and sets up a new e2e
+	// reservation on top of them, owned by the host.
+	SetupE2E(ctx context.Context, host net.IP, dst addr.IA, bw reservation.BWCls) (
+		*E2EReservation, error)
+	// RenewE2E sets up a new index for an e2e reservation owned by the host.
+	RenewE2E(ctx context.Context, host net.IP, id *reservation.E2EID, bw reservation.BWCls) (
+		*E2EReservation, error)
+	// CleanupE2E removes the index, and all the previous ones, of an e2e reservation owned
+	// by the host.
+	CleanupE2E(ctx context.Context, host net.IP, id *reservation.E2EID,
+		idx reservation.IndexNumber) error
+}
+
+// E2EServer handles the e2e reservation requests of the end hosts in this AS. The end host
+// is identified by the address the request comes from.
+type E2EServer struct {
+	Initiator HostE2EInitiator
+}
+
+var _ colpb.E2EReservationServiceServer = (*E2EServer)(nil)
+
+// Setup sets up a new e2e reservation. If an AS in the path rejects it, the response carries
+// the failure instead of the reservation.
+func (s *E2EServer) Setup(ctx context.Context, req *colpb.E2ESetupRequest) (
+	*colpb.E2ESetupResponse, error) {
+
+	bw := reservation.BWCls(req.RequestedBw)
+	if err := bw.Validate(); err != nil {
+		return nil, err
+	}
+	host, err := RequestingHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dst := addr.IAInt(req.DstIsdAs).IA()
+	rsv, err := s.Initiator.SetupE2E(ctx, host, dst, bw)
+	if err != nil {
+		log.FromCtx(ctx).Info("Cannot set up COLIBRI e2e reservation", "dst", dst, "err", err)
+		if failure, ok := asFailure(err); ok {
+			return &colpb.E2ESetupResponse{Failure: FailureToPB(failure)}, nil
+		}
+		return nil, serrors.WrapStr("setting up e2e reservation", err, "dst", dst)
+	}
+	pb, err := E2EReservationToPB(rsv)
+	if err != nil {
+		return nil, err
+	}
+	return &colpb.E2ESetupResponse{Reservation: pb}, nil
+}
+
+// Renew requests a new index for an existing e2e reservation.
+func (s *E2EServer) Renew(ctx context.Context, req *colpb.E2ERenewRequest) (
+	*colpb.E2ERenewResponse, error) {
+
+	id, err := reservation.E2EIDFromRaw(req.Id)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing e2e reservation ID", err)
+	}
+	bw := reservation.BWCls(req.RequestedBw)
+	if err := bw.Validate(); err != nil {
+		return nil, err
+	}
+	host, err := RequestingHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rsv, err := s.Initiator.RenewE2E(ctx, host, id, bw)
+	if err != nil {
+		log.FromCtx(ctx).Info("Cannot renew COLIBRI e2e reservation", "id", id, "err", err)
+		if failure, ok := asFailure(err); ok {
+			return &colpb.E2ERenewResponse{Failure: FailureToPB(failure)}, nil
+		}
+		return nil, serrors.WrapStr("renewing e2e reservation", err, "id", id)
+	}
+	pb, err := E2EReservationToPB(rsv)
+	if err != nil {
+		return nil, err
+	}
+	return &colpb.E2ERenewResponse{Reservation: pb}, nil
+}
+
+// Cleanup removes an index of an existing e2e reservation.
+func (s *E2EServer) Cleanup(ctx context.Context, req *colpb.E2ECleanupRequest) (
+	*colpb.E2ECleanupResponse, error) {
+
+	id, err := reservation.E2EIDFromRaw(req.Id)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing e2e reservation ID", err)
+	}
+	idx := reservation.IndexNumber(req.Index)
+	if err := idx.Validate(); err != nil {
+		return nil, err
+	}
+	host, err := RequestingHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Initiator.CleanupE2E(ctx, host, id, idx); err != nil {
+		return nil, serrors.WrapStr("cleaning up e2e reservation", err, "id", id, "idx", idx)
+	}
+	return &colpb.E2ECleanupResponse{}, nil
+}
+
+// RequestingHost returns the address of the host that sent the request.
+func RequestingHost(ctx context.Context) (net.IP, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, serrors.New("retrieving peer information from ctx")
+	}
+	switch a := p.Addr.(type) {
+	case *net.TCPAddr:
+		return a.IP, nil
+	case *net.UDPAddr:
+		return a.IP, nil
+	default:
+		return nil, serrors.New("unsupported peer address type", "peer", p.Addr,
+			"type", common.TypeOf(p.Addr))
+	}
+}
+
+// E2EReservationToPB converts an e2e reservation into its protobuf representation.
+func E2EReservationToPB(rsv *E2EReservation) (*colpb.E2EReservation, error) {
+	path, err := PathToPB(&Path{Steps: rsv.Path})
+	if err != nil {
+		return nil, err
+	}
+	return &colpb.E2EReservation{
+		Id:    rsv.ID.ToRaw(),
+		Index: uint32(rsv.Index),
+		Token: rsv.Token.ToRaw(),
+		Path:  path,
+	}, nil
+}
+
+// E2EReservationFromPB converts a protobuf e2e reservation into its application type.
+func E2EReservationFromPB(pb *colpb.E2EReservation) (*E2EReservation, error) {
+	if pb == nil {
+		return nil, serrors.New("missing e2e reservation")
+	}
+	id, err := reservation.E2EIDFromRaw(pb.Id)
+	if err != nil {
+		return nil, err
+	}
+	idx := reservation.IndexNumber(pb.Index)
+	if err := idx.Validate(); err != nil {
+		return nil, err
+	}
+	tok, err := reservation.TokenFromRaw(pb.Token)
+	if err != nil {
+		return nil, err
+	}
+	path, err := PathFromPB(pb.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &E2EReservation{
+		ID:    *id,
+		Index: idx,
+		Token: *tok,
+		Path:  path.Steps,
+	}, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/grpc/mock_grpc"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+func TestE2EServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	rsv := &colgrpc.E2EReservation{
+		ID: reservation.E2EID{
+			ASID:   xtest.MustParseAS("ff00:0:111"),
+			Suffix: [10]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		Index: 3,
+		Token: reservation.Token{
+			InfoField: reservation.InfoField{
+				ExpirationTick: 42,
+				BWCls:          5,
+				RLC:            7,
+				Idx:            3,
+				PathType:       reservation.E2EPath,
+			},
+			HopFields: []reservation.HopField{
+				{Egress: 1, Mac: [4]byte{1, 2, 3, 4}},
+				{Ingress: 2, Mac: [4]byte{5, 6, 7, 8}},
+			},
+		},
+		Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:111", 1, 2, "1-ff00:0:112", 0),
+	}
+	initiator := mock_grpc.NewMockHostE2EInitiator(ctrl)
+	s := &colgrpc.E2EServer{Initiator: initiator}
+	host := net.ParseIP("10.0.0.1")
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: host, Port: 40000},
+	})
+
+	initiator.EXPECT().SetupE2E(gomock.Any(), host, dst, reservation.BWCls(5)).Return(rsv, nil)
+	setup, err := s.Setup(ctx, &colpb.E2ESetupRequest{
+		DstIsdAs:    uint64(dst.IAInt()),
+		RequestedBw: 5,
+	})
+	require.NoError(t, err)
+	got, err := colgrpc.E2EReservationFromPB(setup.Reservation)
+	require.NoError(t, err)
+	require.Equal(t, rsv, got)
+
+	initiator.EXPECT().RenewE2E(gomock.Any(), host, &rsv.ID, reservation.BWCls(6)).Return(rsv,
+		nil)
+	renew, err := s.Renew(ctx, &colpb.E2ERenewRequest{
+		Id:          rsv.ID.ToRaw(),
+		RequestedBw: 6,
+	})
+	require.NoError(t, err)
+	got, err = colgrpc.E2EReservationFromPB(renew.Reservation)
+	require.NoError(t, err)
+	require.Equal(t, rsv, got)
+
+	// rejections by an AS in the path are returned in the response
+	failure := &reservation.Failure{
+		Code:      reservation.ErrorInsufficientBW,
+		FailedHop: 1,
+		FailedIA:  dst,
+		MaxBW:     4,
+	}
+	initiator.EXPECT().RenewE2E(gomock.Any(), host, &rsv.ID, reservation.BWCls(6)).Return(nil,
+		serrors.WrapStr("renewing", failure))
+	renew, err = s.Renew(ctx, &colpb.E2ERenewRequest{
+		Id:          rsv.ID.ToRaw(),
+		RequestedBw: 6,
+	})
+	require.NoError(t, err)
+	require.Nil(t, renew.Reservation)
+	require.Equal(t, failure, colgrpc.FailureFromPB(renew.Failure))
+
+	initiator.EXPECT().CleanupE2E(gomock.Any(), host, &rsv.ID,
+		reservation.IndexNumber(3)).Return(serrors.New("unknown reservation"))
+	_, err = s.Cleanup(ctx, &colpb.E2ECleanupRequest{
+		Id:    rsv.ID.ToRaw(),
+		Index: 3,
+	})
+	require.Error(t, err)
+
+	// invalid requests do not reach the initiator
+	_, err = s.Setup(ctx, &colpb.E2ESetupRequest{
+		DstIsdAs:    uint64(dst.IAInt()),
+		RequestedBw: 64,
+	})
+	require.Error(t, err)
+	_, err = s.Renew(ctx, &colpb.E2ERenewRequest{Id: []byte{1, 2}})
+	require.Error(t, err)
+	// nor requests from an unknown host
+	_, err = s.Cleanup(context.Background(), &colpb.E2ECleanupRequest{
+		Id:    rsv.ID.ToRaw(),
+		Index: 3,
+	})
+	require.Error(t, err)
+}
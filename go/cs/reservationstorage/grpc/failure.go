@@ -0,0 +1,79 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+// FailureToPB converts a failure into its protobuf representation.
+func FailureToPB(f *reservation.Failure) *colpb.Failure {
+	return &colpb.Failure{
+		ErrorCode:   uint32(f.Code),
+		FailedHop:   uint32(f.FailedHop),
+		FailedIsdAs: uint64(f.FailedIA.IAInt()),
+		MaxBw:       uint32(f.MaxBW),
+	}
+}
+
+// FailureFromPB converts a protobuf failure into its application type.
+func FailureFromPB(pb *colpb.Failure) *reservation.Failure {
+	return &reservation.Failure{
+		Code:      reservation.ErrorCode(pb.ErrorCode),
+		FailedHop: uint8(pb.FailedHop),
+		FailedIA:  addr.IAInt(pb.FailedIsdAs).IA(),
+		MaxBW:     reservation.BWCls(pb.MaxBw),
+	}
+}
+
+// failureStatus returns the status error to reject a request with an invalid authenticator.
+// The status carries the failure as detail.
+func failureStatus(f *reservation.Failure, msg string) error {
+	st, err := status.New(codes.Unauthenticated, msg).WithDetails(FailureToPB(f))
+	if err != nil {
+		return status.Error(codes.Unauthenticated, msg)
+	}
+	return st.Err()
+}
+
+// failureFromStatus returns the failure carried by a status error, if any.
+func failureFromStatus(err error) (*reservation.Failure, bool) {
+	st, ok := grpcStatus(err)
+	if !ok {
+		return nil, false
+	}
+	for _, d := range st.Details() {
+		if pb, ok := d.(*colpb.Failure); ok {
+			return FailureFromPB(pb), true
+		}
+	}
+	return nil, false
+}
+
+// asFailure returns the failure in the error chain, if any.
+func asFailure(err error) (*reservation.Failure, bool) {
+	var f *reservation.Failure
+	if errors.As(err, &f) {
+		return f, true
+	}
+	return nil, false
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+// ServiceForwarder forwards COLIBRI requests to the control service of the next AS.
+// It also obtains the stitchable segment reservations of other ASes.
+type ServiceForwarder struct {
+	Dialer libgrpc.Dialer
+	Router snet.Router
+}
+
+var _ Forwarder = ServiceForwarder{}
+var _ StitchableLister = ServiceForwarder{}
+
+// Forward sends the request to the control service of the AS in the current step of the path.
+func (f ServiceForwarder) Forward(ctx context.Context, req *colpb.ProcessRequest) (
+	*colpb.ProcessResponse, error) {
+
+	path, err := PathFromPB(req.Path)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing reservation path", err)
+	}
+	dstIA := path.CurrentIA()
+	conn, err := f.dial(ctx, dstIA)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := colpb.NewColibriServiceClient(conn)
+	rep, err := client.Process(ctx, req, libgrpc.RetryProfile...)
+	if err != nil {
+		return nil, serrors.WrapStr("sending COLIBRI request", err, "dst_ia", dstIA)
+	}
+	return rep, nil
+}
+
+// ListStitchables obtains from the control service of the AS ia its segment reservations
+// that can be stitched to reach dst.
+func (f ServiceForwarder) ListStitchables(ctx context.Context, ia, dst addr.IA) (
+	[]*Stitchable, error) {
+
+	conn, err := f.dial(ctx, ia)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := colpb.NewColibriServiceClient(conn)
+	rep, err := client.ListStitchables(ctx, &colpb.ListStitchablesRequest{
+		DstIsdAs: uint64(dst.IAInt()),
+	}, libgrpc.RetryProfile...)
+	if err != nil {
+		return nil, serrors.WrapStr("listing stitchable reservations", err, "ia", ia)
+	}
+	stitchables := make([]*Stitchable, 0, len(rep.Reservations))
+	for _, pb := range rep.Reservations {
+		s, err := StitchableFromPB(pb)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing stitchable reservation", err, "ia", ia)
+		}
+		stitchables = append(stitchables, s)
+	}
+	return stitchables, nil
+}
+
+func (f ServiceForwarder) dial(ctx context.Context, dstIA addr.IA) (*grpc.ClientConn, error) {
+	p, err := f.Router.Route(ctx, dstIA)
+	if err != nil {
+		return nil, serrors.WrapStr("retrieving paths", err, "dst_ia", dstIA)
+	}
+	if p == nil {
+		return nil, serrors.New("no path found", "dst_ia", dstIA)
+	}
+	remote := &snet.SVCAddr{
+		IA:      dstIA,
+		Path:    p.Path(),
+		NextHop: p.UnderlayNextHop(),
+		SVC:     addr.SvcCS,
+	}
+	conn, err := f.Dialer.Dial(ctx, remote)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing", err, "dst_ia", dstIA)
+	}
+	return conn, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/translate"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// Initiate sends a request originating in this AS to the next AS in its reservation path.
+// The request must have been already processed by this AS. The returned message is the
+// response, once it has traveled back to this AS.
+// If an AS in the path rejected the request, the returned error is a *reservation.Failure,
+// together with the failure response if one traveled back.
+func Initiate(ctx context.Context, fwd Forwarder, msg base.MessageWithPath, renewal bool,
+	timestamp time.Time) (base.MessageWithPath, error) {
+
+	req, err := newForwardRequest(msg, renewal, uint32(reservation.TickFromTime(timestamp)))
+	if err != nil {
+		return nil, serrors.WrapStr("preparing request for next AS", err)
+	}
+	rep, err := fwd.Forward(ctx, req)
+	if err != nil {
+		if failure, ok := failureFromStatus(err); ok {
+			return nil, failure
+		}
+		return nil, serrors.WrapStr("forwarding COLIBRI request", err)
+	}
+	path, err := PathFromPB(rep.Path)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing response path", err)
+	}
+	next, err := path.Next()
+	if err != nil {
+		return nil, serrors.WrapStr("moving response path to this AS", err)
+	}
+	if next.CurrentIA() != msg.Path().IA(0) {
+		return nil, serrors.New("response does not return to the initiator",
+			"initiator", msg.Path().IA(0), "current_ia", next.CurrentIA())
+	}
+	ctrl, err := colibri_mgmt.NewFromRaw(rep.Raw)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing COLIBRI response", err)
+	}
+	if ctrl.Which != proto.ColibriRequestPayload_Which_response {
+		return nil, serrors.New("expected a COLIBRI response", "type", ctrl.Which.String())
+	}
+	res, err := translate.NewMsgFromCtrl(ctrl, next)
+	if err != nil {
+		return nil, serrors.WrapStr("translating COLIBRI response", err)
+	}
+	if rep.Failure != nil {
+		return res, FailureFromPB(rep.Failure)
+	}
+	if failed, ok := res.(base.FailureResponse); ok {
+		return res, failed.Failure()
+	}
+	return res, nil
+}
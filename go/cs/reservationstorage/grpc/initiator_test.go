@@ -0,0 +1,83 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/translate"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/grpc/mock_grpc"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+func TestInitiate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pbReq := newSetupRequest(t, 0)
+	path, err := colgrpc.PathFromPB(pbReq.Path)
+	require.NoError(t, err)
+	payload, err := colibri_mgmt.NewFromRaw(pbReq.Raw)
+	require.NoError(t, err)
+	msg, err := translate.NewMsgFromCtrl(payload, path)
+	require.NoError(t, err)
+	msg.(*segment.SetupReq).SetAuthenticators(testAuthenticators)
+
+	fwd := mock_grpc.NewMockForwarder(ctrl)
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *colpb.ProcessRequest) (*colpb.ProcessResponse, error) {
+			require.Equal(t, uint32(1), req.Path.CurrentStep)
+			require.Equal(t, testAuthenticators, req.Authenticators)
+			return newSetupResponse(t, req), nil
+		})
+	res, err := colgrpc.Initiate(context.Background(), fwd, msg, false, time.Now())
+	require.NoError(t, err)
+	require.IsType(t, &segment.ResponseSetupSuccess{}, res)
+	require.Equal(t, xtest.MustParseIA("1-ff00:0:1"), res.Path().(*colgrpc.Path).CurrentIA())
+
+	// errors from the next AS are returned
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).Return(nil, serrors.New("test error"))
+	_, err = colgrpc.Initiate(context.Background(), fwd, msg, false, time.Now())
+	require.Error(t, err)
+
+	// rejections carry the failure
+	failure := &reservation.Failure{
+		Code:      reservation.ErrorInvalidAuthenticator,
+		FailedHop: 2,
+		FailedIA:  xtest.MustParseIA("1-ff00:0:3"),
+	}
+	st, err := status.New(codes.Unauthenticated, "invalid authenticator").WithDetails(
+		colgrpc.FailureToPB(failure))
+	require.NoError(t, err)
+	fwd.EXPECT().Forward(gomock.Any(), gomock.Any()).Return(nil,
+		serrors.WrapStr("sending COLIBRI request", st.Err()))
+	_, err = colgrpc.Initiate(context.Background(), fwd, msg, false, time.Now())
+	var rejected *reservation.Failure
+	require.True(t, errors.As(err, &rejected))
+	require.Equal(t, failure, rejected)
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	timestamppb "github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+// ReservationLister lists the reservations stored in the local AS.
+type ReservationLister interface {
+	// ListReservations returns the segment and e2e reservations stored in the local AS.
+	ListReservations(ctx context.Context) (*colpb.ListReservationsResponse, error)
+}
+
+// ListServer lists the reservations of this AS to its end hosts.
+type ListServer struct {
+	Store reservationstorage.Store
+}
+
+var _ colpb.ReservationListServiceServer = (*ListServer)(nil)
+
+// List returns the segment and e2e reservations stored in this AS.
+func (s *ListServer) List(ctx context.Context, _ *colpb.ListReservationsRequest) (
+	*colpb.ListReservationsResponse, error) {
+
+	segRsvs, err := s.Store.ListSegmentReservations(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("listing segment reservations", err)
+	}
+	e2eRsvs, err := s.Store.ListE2EReservations(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("listing e2e reservations", err)
+	}
+	res := &colpb.ListReservationsResponse{
+		Segments: make([]*colpb.SegmentReservationInfo, 0, len(segRsvs)),
+		E2E:      make([]*colpb.E2EReservationInfo, 0, len(e2eRsvs)),
+	}
+	for _, rsv := range segRsvs {
+		pb, err := SegmentReservationToPB(rsv)
+		if err != nil {
+			return nil, err
+		}
+		res.Segments = append(res.Segments, pb)
+	}
+	for _, rsv := range e2eRsvs {
+		res.E2E = append(res.E2E, E2EReservationInfoToPB(rsv))
+	}
+	return res, nil
+}
+
+// SegmentReservationToPB converts a stored segment reservation into its protobuf
+// representation.
+func SegmentReservationToPB(rsv *segment.Reservation) (*colpb.SegmentReservationInfo, error) {
+	pb := &colpb.SegmentReservationInfo{
+		Id:       rsv.ID.ToRaw(),
+		PathType: uint32(rsv.PathType),
+		Ingress:  uint32(rsv.Ingress),
+		Egress:   uint32(rsv.Egress),
+		Indices:  make([]*colpb.ReservationIndex, len(rsv.Indices)),
+	}
+	if len(rsv.Path) > 0 {
+		path, err := PathToPB(&Path{Steps: rsv.Path})
+		if err != nil {
+			return nil, err
+		}
+		pb.Path = path
+	}
+	if rsv.BaseID != nil {
+		pb.BaseId = rsv.BaseID.ToRaw()
+	}
+	for i, index := range rsv.Indices {
+		pb.Indices[i] = &colpb.ReservationIndex{
+			Index:      uint32(index.Idx),
+			Expiration: &timestamppb.Timestamp{Seconds: index.Expiration.Unix()},
+			AllocBw:    uint32(index.AllocBW),
+			State:      indexStateToPB(index.State()),
+		}
+	}
+	return pb, nil
+}
+
+// E2EReservationInfoToPB converts a stored e2e reservation into its protobuf representation.
+func E2EReservationInfoToPB(rsv *e2e.Reservation) *colpb.E2EReservationInfo {
+	pb := &colpb.E2EReservationInfo{
+		Id:         rsv.ID.ToRaw(),
+		SegmentIds: make([][]byte, len(rsv.SegmentReservations)),
+		Indices:    make([]*colpb.ReservationIndex, len(rsv.Indices)),
+	}
+	for i, segRsv := range rsv.SegmentReservations {
+		pb.SegmentIds[i] = segRsv.ID.ToRaw()
+	}
+	for i, index := range rsv.Indices {
+		pb.Indices[i] = &colpb.ReservationIndex{
+			Index:      uint32(index.Idx),
+			Expiration: &timestamppb.Timestamp{Seconds: index.Expiration.Unix()},
+			AllocBw:    uint32(index.AllocBW),
+		}
+	}
+	return pb
+}
+
+func indexStateToPB(state segment.IndexState) colpb.IndexState {
+	switch state {
+	case segment.IndexTemporary:
+		return colpb.IndexState_INDEX_STATE_TEMPORARY
+	case segment.IndexPending:
+		return colpb.IndexState_INDEX_STATE_PENDING
+	case segment.IndexActive:
+		return colpb.IndexState_INDEX_STATE_ACTIVE
+	default:
+		return colpb.IndexState_INDEX_STATE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+func TestListServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expiration := util.SecsToTime(uint32(time.Now().Add(time.Hour).Unix()))
+	segRsv := segment.NewReservation()
+	segRsv.ID.ASID = xtest.MustParseAS("ff00:0:111")
+	segRsv.ID.Suffix = [4]byte{0, 0, 0, 1}
+	segRsv.Egress = 1
+	segRsv.PathType = reservation.UpPath
+	segRsv.Path = segmenttest.NewPathFromComponents(0, "1-ff00:0:111", 1, 2, "1-ff00:0:110", 0)
+	_, err := segRsv.NewIndexAtSource(expiration, 1, 5, 5, 5, reservation.UpPath)
+	require.NoError(t, err)
+	require.NoError(t, segRsv.SetIndexConfirmed(0))
+	require.NoError(t, segRsv.SetIndexActive(0))
+	// a transit reservation does not know its path
+	transit := segment.NewReservation()
+	transit.ID.ASID = xtest.MustParseAS("ff00:0:112")
+	transit.Ingress = 3
+	transit.Egress = 4
+	transit.PathType = reservation.CorePath
+	e2eRsv := &e2e.Reservation{
+		ID:                  reservation.E2EID{ASID: xtest.MustParseAS("ff00:0:111")},
+		SegmentReservations: []*segment.Reservation{segRsv},
+		Indices: e2e.Indices{{
+			Idx:        2,
+			Expiration: expiration,
+			AllocBW:    3,
+		}},
+	}
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	store.EXPECT().ListSegmentReservations(gomock.Any()).Return(
+		[]*segment.Reservation{segRsv, transit}, nil)
+	store.EXPECT().ListE2EReservations(gomock.Any()).Return([]*e2e.Reservation{e2eRsv}, nil)
+	s := &colgrpc.ListServer{Store: store}
+
+	res, err := s.List(context.Background(), &colpb.ListReservationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Segments, 2)
+	seg := res.Segments[0]
+	require.Equal(t, segRsv.ID.ToRaw(), seg.Id)
+	require.Equal(t, uint32(reservation.UpPath), seg.PathType)
+	require.Len(t, seg.Path.Steps, 2)
+	require.Len(t, seg.Indices, 1)
+	require.Equal(t, colpb.IndexState_INDEX_STATE_ACTIVE, seg.Indices[0].State)
+	require.Equal(t, uint32(5), seg.Indices[0].AllocBw)
+	require.Equal(t, expiration.Unix(), seg.Indices[0].Expiration.Seconds)
+	require.Nil(t, res.Segments[1].Path)
+	require.Equal(t, uint32(3), res.Segments[1].Ingress)
+	require.Len(t, res.E2E, 1)
+	require.Equal(t, e2eRsv.ID.ToRaw(), res.E2E[0].Id)
+	require.Equal(t, [][]byte{segRsv.ID.ToRaw()}, res.E2E[0].SegmentIds)
+	require.Equal(t, colpb.IndexState_INDEX_STATE_UNSPECIFIED, res.E2E[0].Indices[0].State)
+
+	store.EXPECT().ListSegmentReservations(gomock.Any()).Return(nil, serrors.New("test error"))
+	_, err = s.List(context.Background(), &colpb.ListReservationsRequest{})
+	require.Error(t, err)
+}
@@ -0,0 +1,226 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/scionproto/scion/go/cs/reservationstorage/grpc (interfaces: Forwarder,StitchableLister,E2EInitiator,HostE2EInitiator)
+
+// Package mock_grpc is a generated GoMock package.
+package mock_grpc
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	grpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	addr "github.com/scionproto/scion/go/lib/addr"
+	reservation "github.com/scionproto/scion/go/lib/colibri/reservation"
+	colibri "github.com/scionproto/scion/go/pkg/proto/colibri"
+	net "net"
+	reflect "reflect"
+)
+
+// MockForwarder is a mock of Forwarder interface
+type MockForwarder struct {
+	ctrl     *gomock.Controller
+	recorder *MockForwarderMockRecorder
+}
+
+// MockForwarderMockRecorder is the mock recorder for MockForwarder
+type MockForwarderMockRecorder struct {
+	mock *MockForwarder
+}
+
+// NewMockForwarder creates a new mock instance
+func NewMockForwarder(ctrl *gomock.Controller) *MockForwarder {
+	mock := &MockForwarder{ctrl: ctrl}
+	mock.recorder = &MockForwarderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockForwarder) EXPECT() *MockForwarderMockRecorder {
+	return m.recorder
+}
+
+// Forward mocks base method
+func (m *MockForwarder) Forward(arg0 context.Context, arg1 *colibri.ProcessRequest) (*colibri.ProcessResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forward", arg0, arg1)
+	ret0, _ := ret[0].(*colibri.ProcessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Forward indicates an expected call of Forward
+func (mr *MockForwarderMockRecorder) Forward(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forward", reflect.TypeOf((*MockForwarder)(nil).Forward), arg0, arg1)
+}
+
+// MockStitchableLister is a mock of StitchableLister interface
+type MockStitchableLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockStitchableListerMockRecorder
+}
+
+// MockStitchableListerMockRecorder is the mock recorder for MockStitchableLister
+type MockStitchableListerMockRecorder struct {
+	mock *MockStitchableLister
+}
+
+// NewMockStitchableLister creates a new mock instance
+func NewMockStitchableLister(ctrl *gomock.Controller) *MockStitchableLister {
+	mock := &MockStitchableLister{ctrl: ctrl}
+	mock.recorder = &MockStitchableListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStitchableLister) EXPECT() *MockStitchableListerMockRecorder {
+	return m.recorder
+}
+
+// ListStitchables mocks base method
+func (m *MockStitchableLister) ListStitchables(arg0 context.Context, arg1, arg2 addr.IA) ([]*grpc.Stitchable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStitchables", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*grpc.Stitchable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStitchables indicates an expected call of ListStitchables
+func (mr *MockStitchableListerMockRecorder) ListStitchables(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStitchables", reflect.TypeOf((*MockStitchableLister)(nil).ListStitchables), arg0, arg1, arg2)
+}
+
+// MockE2EInitiator is a mock of E2EInitiator interface
+type MockE2EInitiator struct {
+	ctrl     *gomock.Controller
+	recorder *MockE2EInitiatorMockRecorder
+}
+
+// MockE2EInitiatorMockRecorder is the mock recorder for MockE2EInitiator
+type MockE2EInitiatorMockRecorder struct {
+	mock *MockE2EInitiator
+}
+
+// NewMockE2EInitiator creates a new mock instance
+func NewMockE2EInitiator(ctrl *gomock.Controller) *MockE2EInitiator {
+	mock := &MockE2EInitiator{ctrl: ctrl}
+	mock.recorder = &MockE2EInitiatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockE2EInitiator) EXPECT() *MockE2EInitiatorMockRecorder {
+	return m.recorder
+}
+
+// CleanupE2E mocks base method
+func (m *MockE2EInitiator) CleanupE2E(arg0 context.Context, arg1 *reservation.E2EID, arg2 reservation.IndexNumber) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupE2E", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupE2E indicates an expected call of CleanupE2E
+func (mr *MockE2EInitiatorMockRecorder) CleanupE2E(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupE2E", reflect.TypeOf((*MockE2EInitiator)(nil).CleanupE2E), arg0, arg1, arg2)
+}
+
+// RenewE2E mocks base method
+func (m *MockE2EInitiator) RenewE2E(arg0 context.Context, arg1 *reservation.E2EID, arg2 reservation.BWCls) (*grpc.E2EReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewE2E", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*grpc.E2EReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewE2E indicates an expected call of RenewE2E
+func (mr *MockE2EInitiatorMockRecorder) RenewE2E(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewE2E", reflect.TypeOf((*MockE2EInitiator)(nil).RenewE2E), arg0, arg1, arg2)
+}
+
+// SetupE2E mocks base method
+func (m *MockE2EInitiator) SetupE2E(arg0 context.Context, arg1 addr.IA, arg2 reservation.BWCls) (*grpc.E2EReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetupE2E", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*grpc.E2EReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetupE2E indicates an expected call of SetupE2E
+func (mr *MockE2EInitiatorMockRecorder) SetupE2E(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupE2E", reflect.TypeOf((*MockE2EInitiator)(nil).SetupE2E), arg0, arg1, arg2)
+}
+
+// MockHostE2EInitiator is a mock of HostE2EInitiator interface
+type MockHostE2EInitiator struct {
+	ctrl     *gomock.Controller
+	recorder *MockHostE2EInitiatorMockRecorder
+}
+
+// MockHostE2EInitiatorMockRecorder is the mock recorder for MockHostE2EInitiator
+type MockHostE2EInitiatorMockRecorder struct {
+	mock *MockHostE2EInitiator
+}
+
+// NewMockHostE2EInitiator creates a new mock instance
+func NewMockHostE2EInitiator(ctrl *gomock.Controller) *MockHostE2EInitiator {
+	mock := &MockHostE2EInitiator{ctrl: ctrl}
+	mock.recorder = &MockHostE2EInitiatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockHostE2EInitiator) EXPECT() *MockHostE2EInitiatorMockRecorder {
+	return m.recorder
+}
+
+// CleanupE2E mocks base method
+func (m *MockHostE2EInitiator) CleanupE2E(arg0 context.Context, arg1 net.IP, arg2 *reservation.E2EID, arg3 reservation.IndexNumber) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupE2E", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupE2E indicates an expected call of CleanupE2E
+func (mr *MockHostE2EInitiatorMockRecorder) CleanupE2E(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupE2E", reflect.TypeOf((*MockHostE2EInitiator)(nil).CleanupE2E), arg0, arg1, arg2, arg3)
+}
+
+// RenewE2E mocks base method
+func (m *MockHostE2EInitiator) RenewE2E(arg0 context.Context, arg1 net.IP, arg2 *reservation.E2EID, arg3 reservation.BWCls) (*grpc.E2EReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewE2E", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*grpc.E2EReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewE2E indicates an expected call of RenewE2E
+func (mr *MockHostE2EInitiatorMockRecorder) RenewE2E(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewE2E", reflect.TypeOf((*MockHostE2EInitiator)(nil).RenewE2E), arg0, arg1, arg2, arg3)
+}
+
+// SetupE2E mocks base method
+func (m *MockHostE2EInitiator) SetupE2E(arg0 context.Context, arg1 net.IP, arg2 addr.IA, arg3 reservation.BWCls) (*grpc.E2EReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetupE2E", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*grpc.E2EReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetupE2E indicates an expected call of SetupE2E
+func (mr *MockHostE2EInitiatorMockRecorder) SetupE2E(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupE2E", reflect.TypeOf((*MockHostE2EInitiator)(nil).SetupE2E), arg0, arg1, arg2, arg3)
+}
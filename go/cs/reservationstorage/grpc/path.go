@@ -0,0 +1,169 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+// Path is the reservation path used to carry COLIBRI control messages hop by hop between
+// the control services of the ASes in the path.
+type Path struct {
+	Steps       segment.ReservationTransparentPath
+	CurrentStep int
+}
+
+var _ base.ColibriPath = (*Path)(nil)
+
+// Copy returns a deep copy of the path.
+func (p *Path) Copy() base.ColibriPath {
+	steps := make(segment.ReservationTransparentPath, len(p.Steps))
+	copy(steps, p.Steps)
+	return &Path{
+		Steps:       steps,
+		CurrentStep: p.CurrentStep,
+	}
+}
+
+// Reverse reverses the steps and swaps their ingress and egress interfaces.
+// The current step keeps pointing to the same AS.
+func (p *Path) Reverse() error {
+	for i, j := 0, len(p.Steps)-1; i < j; i, j = i+1, j-1 {
+		p.Steps[i], p.Steps[j] = p.Steps[j], p.Steps[i]
+	}
+	for i := range p.Steps {
+		p.Steps[i].Ingress, p.Steps[i].Egress = p.Steps[i].Egress, p.Steps[i].Ingress
+	}
+	p.CurrentStep = len(p.Steps) - 1 - p.CurrentStep
+	return nil
+}
+
+// NumberOfHops returns the number of ASes in the path.
+func (p *Path) NumberOfHops() int {
+	return len(p.Steps)
+}
+
+// IndexOfCurrentHop returns the index of the AS processing the message.
+func (p *Path) IndexOfCurrentHop() int {
+	return p.CurrentStep
+}
+
+// IngressEgressIFIDs returns the interfaces of the current step.
+func (p *Path) IngressEgressIFIDs() (uint16, uint16) {
+	if p.CurrentStep < 0 || p.CurrentStep >= len(p.Steps) {
+		return 0, 0
+	}
+	return p.Steps[p.CurrentStep].Ingress, p.Steps[p.CurrentStep].Egress
+}
+
+// IA returns the IA of the AS at the given step.
+func (p *Path) IA(hop int) addr.IA {
+	if hop < 0 || hop >= len(p.Steps) {
+		return addr.IA{}
+	}
+	return p.Steps[hop].IA
+}
+
+// Interfaces returns the ingress and egress interfaces of the given step.
+func (p *Path) Interfaces(hop int) (uint16, uint16) {
+	if hop < 0 || hop >= len(p.Steps) {
+		return 0, 0
+	}
+	return p.Steps[hop].Ingress, p.Steps[hop].Egress
+}
+
+// CurrentIA returns the IA of the AS processing the message.
+func (p *Path) CurrentIA() addr.IA {
+	if p.CurrentStep < 0 || p.CurrentStep >= len(p.Steps) {
+		return addr.IA{}
+	}
+	return p.Steps[p.CurrentStep].IA
+}
+
+// Next returns a copy of the path with the current step moved to the next AS.
+func (p *Path) Next() (*Path, error) {
+	if p.CurrentStep+1 >= len(p.Steps) {
+		return nil, serrors.New("no next step in path", "current_step", p.CurrentStep,
+			"steps", len(p.Steps))
+	}
+	next := p.Copy().(*Path)
+	next.CurrentStep++
+	return next, nil
+}
+
+// Validate checks that the current step points to a step in the path.
+func (p *Path) Validate() error {
+	if len(p.Steps) < 2 {
+		return serrors.New("invalid path length", "len", len(p.Steps))
+	}
+	if p.CurrentStep < 0 || p.CurrentStep >= len(p.Steps) {
+		return serrors.New("current step out of bounds", "current_step", p.CurrentStep,
+			"steps", len(p.Steps))
+	}
+	return nil
+}
+
+// PathFromPB converts a protobuf reservation path into its application type.
+func PathFromPB(pb *colpb.ReservationPath) (*Path, error) {
+	if pb == nil {
+		return nil, serrors.New("nil reservation path")
+	}
+	steps := make(segment.ReservationTransparentPath, len(pb.Steps))
+	for i, s := range pb.Steps {
+		if s.Ingress > 0xFFFF || s.Egress > 0xFFFF {
+			return nil, serrors.New("invalid interface ID in path step", "step", i,
+				"ingress", s.Ingress, "egress", s.Egress)
+		}
+		steps[i] = segment.PathStepWithIA{
+			PathStep: segment.PathStep{
+				Ingress: uint16(s.Ingress),
+				Egress:  uint16(s.Egress),
+			},
+			IA: addr.IAInt(s.IsdAs).IA(),
+		}
+	}
+	p := &Path{
+		Steps:       steps,
+		CurrentStep: int(pb.CurrentStep),
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// PathToPB converts a path into its protobuf representation.
+func PathToPB(path base.ColibriPath) (*colpb.ReservationPath, error) {
+	p, ok := path.(*Path)
+	if !ok {
+		return nil, serrors.New("unsupported path type for transport")
+	}
+	steps := make([]*colpb.PathStep, len(p.Steps))
+	for i, s := range p.Steps {
+		steps[i] = &colpb.PathStep{
+			IsdAs:   uint64(s.IA.IAInt()),
+			Ingress: uint32(s.Ingress),
+			Egress:  uint32(s.Egress),
+		}
+	}
+	return &colpb.ReservationPath{
+		Steps:       steps,
+		CurrentStep: uint32(p.CurrentStep),
+	}, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestPathReverse(t *testing.T) {
+	p := &colgrpc.Path{
+		Steps: segmenttest.NewPathFromComponents(0, "1-ff00:0:1", 1, 2, "1-ff00:0:2", 3,
+			4, "1-ff00:0:3", 0),
+		CurrentStep: 1,
+	}
+	rev := p.Copy()
+	require.NoError(t, rev.Reverse())
+	expected := &colgrpc.Path{
+		Steps: segmenttest.NewPathFromComponents(0, "1-ff00:0:3", 4, 3, "1-ff00:0:2", 2,
+			1, "1-ff00:0:1", 0),
+		CurrentStep: 1,
+	}
+	require.Equal(t, expected, rev)
+	// the original path must not be modified
+	require.Equal(t, xtest.MustParseIA("1-ff00:0:1"), p.Steps[0].IA)
+	in, eg := rev.IngressEgressIFIDs()
+	require.Equal(t, uint16(3), in)
+	require.Equal(t, uint16(2), eg)
+}
+
+func TestPathNext(t *testing.T) {
+	p := &colgrpc.Path{
+		Steps:       segmenttest.NewPathFromComponents(0, "1-ff00:0:1", 1, 2, "1-ff00:0:2", 0),
+		CurrentStep: 0,
+	}
+	next, err := p.Next()
+	require.NoError(t, err)
+	require.Equal(t, 1, next.IndexOfCurrentHop())
+	require.Equal(t, xtest.MustParseIA("1-ff00:0:2"), next.CurrentIA())
+	require.Equal(t, 0, p.IndexOfCurrentHop())
+	_, err = next.Next()
+	require.Error(t, err)
+}
+
+func TestPathPB(t *testing.T) {
+	p := &colgrpc.Path{
+		Steps:       segmenttest.NewPathFromComponents(0, "1-ff00:0:1", 1, 2, "1-ff00:0:2", 0),
+		CurrentStep: 1,
+	}
+	pb, err := colgrpc.PathToPB(p)
+	require.NoError(t, err)
+	p2, err := colgrpc.PathFromPB(pb)
+	require.NoError(t, err)
+	require.Equal(t, p, p2)
+
+	pb.CurrentStep = 2
+	_, err = colgrpc.PathFromPB(pb)
+	require.Error(t, err)
+	_, err = colgrpc.PathFromPB(nil)
+	require.Error(t, err)
+}
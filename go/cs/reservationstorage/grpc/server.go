@@ -0,0 +1,303 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/translate"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// Forwarder sends a COLIBRI request to the next AS in its reservation path.
+type Forwarder interface {
+	// Forward sends the request in raw to the AS pointed by the current step of the path,
+	// and returns the response obtained from it.
+	Forward(ctx context.Context, req *colpb.ProcessRequest) (*colpb.ProcessResponse, error)
+}
+
+// ColibriServer handles the COLIBRI control requests arriving at this AS.
+type ColibriServer struct {
+	LocalIA addr.IA
+	Store   reservationstorage.Store
+	// Forwarder is used to send requests to the next AS in the reservation path.
+	Forwarder Forwarder
+}
+
+var _ colpb.ColibriServiceServer = (*ColibriServer)(nil)
+
+// Process handles the request in this AS, and either forwards it to the next AS in the
+// reservation path or returns the response that travels back to the initiator.
+func (s *ColibriServer) Process(ctx context.Context, req *colpb.ProcessRequest) (
+	*colpb.ProcessResponse, error) {
+
+	logger := log.FromCtx(ctx)
+	path, err := PathFromPB(req.Path)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing reservation path", err)
+	}
+	if path.CurrentIA() != s.LocalIA {
+		return nil, serrors.New("current step in path is not this AS",
+			"local_ia", s.LocalIA, "current_ia", path.CurrentIA())
+	}
+	if path.CurrentStep == 0 {
+		// requests initiated by this AS never arrive from another AS.
+		return nil, status.Error(codes.Unauthenticated, "request claims to originate here")
+	}
+	ctrl, err := colibri_mgmt.NewFromRaw(req.Raw)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing COLIBRI payload", err)
+	}
+	if ctrl.Which != proto.ColibriRequestPayload_Which_request {
+		return nil, serrors.New("expected a COLIBRI request", "type", ctrl.Which.String())
+	}
+	msg, err := translate.NewMsgFromCtrl(ctrl, path)
+	if err != nil {
+		return nil, serrors.WrapStr("translating COLIBRI request", err)
+	}
+	authReq, ok := msg.(base.AuthenticatedRequest)
+	if !ok {
+		return nil, serrors.New("COLIBRI request cannot be authenticated",
+			"type", fmt.Sprintf("%T", msg))
+	}
+	authReq.SetAuthenticators(req.Authenticators)
+	renewal := isRenewal(ctrl.Request)
+	res, err := s.handle(ctx, msg)
+	if errors.Is(err, reservationstorage.ErrInvalidAuthenticator) {
+		logger.Info("Rejected COLIBRI request", "type", fmt.Sprintf("%T", msg), "err", err)
+		return nil, failureStatus(&reservation.Failure{
+			Code:      reservation.ErrorInvalidAuthenticator,
+			FailedHop: uint8(path.CurrentStep),
+			FailedIA:  s.LocalIA,
+		}, err.Error())
+	}
+	if res == nil {
+		return nil, serrors.WrapStr("handling COLIBRI request", err)
+	}
+	if err != nil {
+		logger.Info("COLIBRI request not admitted", "type", fmt.Sprintf("%T", msg), "err", err)
+	}
+	if !isRequest(res) {
+		rep, err := newProcessResponse(res, renewal)
+		if err != nil {
+			return nil, err
+		}
+		if failed, ok := res.(base.FailureResponse); ok {
+			rep.Failure = FailureToPB(failed.Failure())
+		}
+		return rep, nil
+	}
+	// the request continues to the next AS in the reservation path.
+	fwd, err := newForwardRequest(res, renewal, ctrl.Timestamp)
+	if err != nil {
+		return nil, serrors.WrapStr("preparing request for next AS", err)
+	}
+	rep, err := s.Forwarder.Forward(ctx, fwd)
+	if err != nil {
+		// keep the rejection of a request by an AS down the path distinguishable
+		// for the initiator.
+		if st, ok := grpcStatus(err); ok && st.Code() == codes.Unauthenticated {
+			return nil, st.Err()
+		}
+		return nil, serrors.WrapStr("forwarding COLIBRI request", err)
+	}
+	return s.processResponse(ctx, rep, renewal)
+}
+
+// processResponse handles the response traveling back to the initiator, and moves its path
+// to this AS. This AS adds its hop field to the token of a successful setup.
+func (s *ColibriServer) processResponse(ctx context.Context, rep *colpb.ProcessResponse,
+	renewal bool) (*colpb.ProcessResponse, error) {
+
+	path, err := PathFromPB(rep.Path)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing response path", err)
+	}
+	next, err := path.Next()
+	if err != nil {
+		return nil, serrors.WrapStr("moving response path to this AS", err)
+	}
+	if next.CurrentIA() != s.LocalIA {
+		return nil, serrors.New("response does not travel through this AS",
+			"local_ia", s.LocalIA, "current_ia", next.CurrentIA())
+	}
+	pbPath, err := PathToPB(next)
+	if err != nil {
+		return nil, err
+	}
+	ctrl, err := colibri_mgmt.NewFromRaw(rep.Raw)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing COLIBRI response", err)
+	}
+	if ctrl.Which != proto.ColibriRequestPayload_Which_response {
+		return nil, serrors.New("expected a COLIBRI response", "type", ctrl.Which.String())
+	}
+	msg, err := translate.NewMsgFromCtrl(ctrl, next)
+	if err != nil {
+		return nil, serrors.WrapStr("translating COLIBRI response", err)
+	}
+	var res base.MessageWithPath
+	switch r := msg.(type) {
+	case *segment.ResponseSetupSuccess:
+		res, err = s.Store.ProcessSegmentSetupResponse(ctx, r)
+	case *e2e.ResponseSetupSuccess:
+		res, err = s.Store.ProcessE2ESetupResponse(ctx, r)
+	default:
+		return &colpb.ProcessResponse{
+			Raw:     rep.Raw,
+			Path:    pbPath,
+			Failure: rep.Failure,
+		}, nil
+	}
+	if err != nil {
+		return nil, serrors.WrapStr("processing COLIBRI response", err)
+	}
+	raw, err := packMsg(res, renewal, ctrl.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &colpb.ProcessResponse{
+		Raw:  raw,
+		Path: pbPath,
+	}, nil
+}
+
+// handle passes the request to the appropriate function of the store.
+func (s *ColibriServer) handle(ctx context.Context, msg base.MessageWithPath) (
+	base.MessageWithPath, error) {
+
+	switch r := msg.(type) {
+	case *segment.SetupReq:
+		return s.Store.AdmitSegmentReservation(ctx, r)
+	case *segment.SetupTelesReq:
+		return s.Store.AdmitTelescopicSegmentReservation(ctx, r)
+	case *segment.IndexConfirmationReq:
+		return s.Store.ConfirmSegmentReservation(ctx, r)
+	case *segment.CleanupReq:
+		return s.Store.CleanupSegmentReservation(ctx, r)
+	case *segment.TeardownReq:
+		return s.Store.TearDownSegmentReservation(ctx, r)
+	case e2e.SetupRequest:
+		return s.Store.AdmitE2EReservation(ctx, r)
+	case *e2e.CleanupReq:
+		return s.Store.CleanupE2EReservation(ctx, r)
+	default:
+		return nil, serrors.New("unsupported COLIBRI request", "type", fmt.Sprintf("%T", msg))
+	}
+}
+
+// isRequest returns true if the message travels forward in the reservation path.
+func isRequest(msg base.MessageWithPath) bool {
+	switch msg.(type) {
+	case *segment.SetupReq, *segment.SetupTelesReq, *segment.IndexConfirmationReq,
+		*segment.CleanupReq, *segment.TeardownReq, *e2e.SetupReqSuccess, *e2e.SetupReqFailure,
+		*e2e.CleanupReq:
+		return true
+	}
+	return false
+}
+
+func isRenewal(req *colibri_mgmt.Request) bool {
+	switch req.Which {
+	case proto.Request_Which_segmentRenewal, proto.Request_Which_segmentTelesRenewal,
+		proto.Request_Which_e2eRenewal:
+		return true
+	}
+	return false
+}
+
+// newForwardRequest builds the request for the next AS in the reservation path.
+func newForwardRequest(msg base.MessageWithPath, renewal bool, timestamp uint32) (
+	*colpb.ProcessRequest, error) {
+
+	path, ok := msg.Path().(*Path)
+	if !ok {
+		return nil, serrors.New("unsupported path type for transport")
+	}
+	next, err := path.Next()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := packMsg(msg, renewal, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	pbPath, err := PathToPB(next)
+	if err != nil {
+		return nil, err
+	}
+	var authenticators [][]byte
+	if authReq, ok := msg.(base.AuthenticatedRequest); ok {
+		authenticators = authReq.Authenticators()
+	}
+	return &colpb.ProcessRequest{
+		Raw:            raw,
+		Path:           pbPath,
+		Authenticators: authenticators,
+	}, nil
+}
+
+// grpcStatus returns the gRPC status contained in the error chain, if any.
+func grpcStatus(err error) (*status.Status, bool) {
+	var withStatus interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &withStatus) {
+		return nil, false
+	}
+	return withStatus.GRPCStatus(), true
+}
+
+func newProcessResponse(msg base.MessageWithPath, renewal bool) (*colpb.ProcessResponse, error) {
+	raw, err := packMsg(msg, renewal, uint32(reservation.TickFromTime(time.Now())))
+	if err != nil {
+		return nil, err
+	}
+	pbPath, err := PathToPB(msg.Path())
+	if err != nil {
+		return nil, err
+	}
+	return &colpb.ProcessResponse{
+		Raw:  raw,
+		Path: pbPath,
+	}, nil
+}
+
+func packMsg(msg base.MessageWithPath, renewal bool, timestamp uint32) ([]byte, error) {
+	ctrl, err := translate.NewCtrlFromMsg(msg, renewal)
+	if err != nil {
+		return nil, serrors.WrapStr("translating COLIBRI message", err)
+	}
+	ctrl.Timestamp = timestamp
+	raw, err := ctrl.PackRoot()
+	if err != nil {
+		return nil, serrors.WrapStr("packing COLIBRI message", err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,377 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	"github.com/scionproto/scion/go/cs/reservation/translate"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/grpc/mock_grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/ctrl/colibri_mgmt"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestProcess(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:2")
+	cases := map[string]struct {
+		currentStep int
+		prepare     func(*mock_reservationstorage.MockStore, *mock_grpc.MockForwarder)
+		assertErr   require.ErrorAssertionFunc
+		code        codes.Code
+		expected    proto.ColibriRequestPayload_Which
+		hopFields   int
+	}{
+		"forward": {
+			currentStep: 1,
+			prepare: func(s *mock_reservationstorage.MockStore, f *mock_grpc.MockForwarder) {
+				s.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+						return req, nil
+					})
+				f.EXPECT().Forward(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *colpb.ProcessRequest) (
+						*colpb.ProcessResponse, error) {
+
+						require.Equal(t, uint32(2), req.Path.CurrentStep)
+						require.Equal(t, testAuthenticators, req.Authenticators)
+						return newSetupResponse(t, req), nil
+					})
+				s.EXPECT().ProcessSegmentSetupResponse(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, resp *segment.ResponseSetupSuccess) (
+						base.MessageWithPath, error) {
+
+						require.Equal(t, localIA, resp.Path().(*colgrpc.Path).CurrentIA())
+						require.Len(t, resp.Token.HopFields, 1)
+						resp.Token.HopFields = append([]reservation.HopField{{
+							Ingress: 1,
+							Egress:  2,
+						}}, resp.Token.HopFields...)
+						return resp, nil
+					})
+			},
+			assertErr: require.NoError,
+			expected:  proto.ColibriRequestPayload_Which_response,
+			hopFields: 2,
+		},
+		"invalid response": {
+			currentStep: 1,
+			prepare: func(s *mock_reservationstorage.MockStore, f *mock_grpc.MockForwarder) {
+				s.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+						return req, nil
+					})
+				f.EXPECT().Forward(gomock.Any(), gomock.Any()).Return(
+					&colpb.ProcessResponse{Raw: []byte("response")}, nil)
+			},
+			assertErr: require.Error,
+		},
+		"last AS": {
+			currentStep: 1,
+			prepare: func(s *mock_reservationstorage.MockStore, f *mock_grpc.MockForwarder) {
+				s.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+						revPath := req.Path().Copy()
+						require.NoError(t, revPath.Reverse())
+						resp, err := segment.NewResponse(util.SecsToTime(1), &req.ID,
+							req.Index, revPath, true, 0)
+						require.NoError(t, err)
+						return &segment.ResponseSetupSuccess{
+							Response: *resp,
+							Token: reservation.Token{
+								InfoField: req.InfoField,
+								HopFields: []reservation.HopField{{Ingress: 1, Egress: 2}},
+							},
+						}, nil
+					})
+			},
+			assertErr: require.NoError,
+			expected:  proto.ColibriRequestPayload_Which_response,
+			hopFields: 1,
+		},
+		"not this AS": {
+			currentStep: 0,
+			prepare:     func(*mock_reservationstorage.MockStore, *mock_grpc.MockForwarder) {},
+			assertErr:   require.Error,
+		},
+		"store error": {
+			currentStep: 1,
+			prepare: func(s *mock_reservationstorage.MockStore, f *mock_grpc.MockForwarder) {
+				s.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).Return(
+					nil, serrors.New("test error"))
+			},
+			assertErr: require.Error,
+		},
+		"invalid authenticator": {
+			currentStep: 1,
+			prepare: func(s *mock_reservationstorage.MockStore, f *mock_grpc.MockForwarder) {
+				s.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+						require.Equal(t, testAuthenticators, req.Authenticators())
+						return nil, serrors.WrapStr("error validating request",
+							reservationstorage.ErrInvalidAuthenticator)
+					})
+			},
+			assertErr: require.Error,
+			code:      codes.Unauthenticated,
+		},
+		"rejected by next AS": {
+			currentStep: 1,
+			prepare: func(s *mock_reservationstorage.MockStore, f *mock_grpc.MockForwarder) {
+				s.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+						return req, nil
+					})
+				f.EXPECT().Forward(gomock.Any(), gomock.Any()).Return(nil,
+					serrors.WrapStr("sending COLIBRI request",
+						status.Error(codes.Unauthenticated, "invalid authenticator")))
+			},
+			assertErr: require.Error,
+			code:      codes.Unauthenticated,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			fwd := mock_grpc.NewMockForwarder(ctrl)
+			tc.prepare(store, fwd)
+			s := colgrpc.ColibriServer{
+				LocalIA:   localIA,
+				Store:     store,
+				Forwarder: fwd,
+			}
+			rep, err := s.Process(context.Background(), newSetupRequest(t, tc.currentStep))
+			tc.assertErr(t, err)
+			if err != nil {
+				if tc.code != codes.OK {
+					require.Equal(t, tc.code, status.Code(err))
+				}
+				return
+			}
+			pld, err := colibri_mgmt.NewFromRaw(rep.Raw)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, pld.Which)
+			require.True(t, pld.Response.Accepted)
+			path, err := colgrpc.PathFromPB(rep.Path)
+			require.NoError(t, err)
+			require.Equal(t, xtest.MustParseIA("1-ff00:0:3"), path.Steps[0].IA)
+			require.Equal(t, localIA, path.CurrentIA())
+			tok, err := reservation.TokenFromRaw(pld.Response.SegmentSetup.Token)
+			require.NoError(t, err)
+			require.Len(t, tok.HopFields, tc.hopFields)
+		})
+	}
+}
+
+func TestProcessFailure(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:2")
+	cases := map[string]struct {
+		prepare  func(*mock_reservationstorage.MockStore)
+		expected *reservation.Failure
+		code     codes.Code
+	}{
+		"not admitted": {
+			prepare: func(s *mock_reservationstorage.MockStore) {
+				s.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *segment.SetupReq) (base.MessageWithPath, error) {
+						revPath := req.Path().Copy()
+						require.NoError(t, revPath.Reverse())
+						resp, err := segment.NewResponse(util.SecsToTime(1), &req.ID,
+							req.Index, revPath, false, 1)
+						require.NoError(t, err)
+						req.AllocTrail = append(req.AllocTrail,
+							reservation.AllocationBead{AllocBW: 0, MaxBW: 3})
+						return &segment.ResponseSetupFailure{
+							Response:    *resp,
+							ErrorCode:   reservation.ErrorInsufficientBW,
+							FailedSetup: req,
+						}, serrors.New("not enough bandwidth")
+					})
+			},
+			expected: &reservation.Failure{
+				Code:      reservation.ErrorInsufficientBW,
+				FailedHop: 1,
+				FailedIA:  localIA,
+				MaxBW:     3,
+			},
+		},
+		"invalid authenticator": {
+			prepare: func(s *mock_reservationstorage.MockStore) {
+				s.EXPECT().AdmitSegmentReservation(gomock.Any(), gomock.Any()).Return(nil,
+					serrors.WrapStr("error validating request",
+						reservationstorage.ErrInvalidAuthenticator))
+			},
+			expected: &reservation.Failure{
+				Code:      reservation.ErrorInvalidAuthenticator,
+				FailedHop: 1,
+				FailedIA:  localIA,
+			},
+			code: codes.Unauthenticated,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			tc.prepare(store)
+			s := colgrpc.ColibriServer{
+				LocalIA: localIA,
+				Store:   store,
+			}
+			rep, err := s.Process(context.Background(), newSetupRequest(t, 1))
+			if tc.code != codes.OK {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+				require.Len(t, st.Details(), 1)
+				require.Equal(t, tc.expected,
+					colgrpc.FailureFromPB(st.Details()[0].(*colpb.Failure)))
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, rep.Failure)
+			require.Equal(t, tc.expected, colgrpc.FailureFromPB(rep.Failure))
+		})
+	}
+}
+
+func TestProcessTelescopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	baseID, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("00000001"))
+	require.NoError(t, err)
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	store.EXPECT().AdmitTelescopicSegmentReservation(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *segment.SetupTelesReq) (base.MessageWithPath, error) {
+			require.Equal(t, *baseID, req.BaseID)
+			return nil, serrors.New("test error")
+		})
+	s := colgrpc.ColibriServer{
+		LocalIA: xtest.MustParseIA("1-ff00:0:2"),
+		Store:   store,
+	}
+	pbReq := newSetupRequest(t, 1)
+	path, err := colgrpc.PathFromPB(pbReq.Path)
+	require.NoError(t, err)
+	msg, err := colibri_mgmt.NewFromRaw(pbReq.Raw)
+	require.NoError(t, err)
+	setup, err := translate.NewMsgFromCtrl(msg, path)
+	require.NoError(t, err)
+	teles := &segment.SetupTelesReq{
+		SetupReq: *setup.(*segment.SetupReq),
+		BaseID:   *baseID,
+	}
+	msg, err = translate.NewCtrlFromMsg(teles, false)
+	require.NoError(t, err)
+	pbReq.Raw, err = msg.PackRoot()
+	require.NoError(t, err)
+	_, err = s.Process(context.Background(), pbReq)
+	require.Error(t, err)
+}
+
+var testAuthenticators = [][]byte{nil, {1, 2, 3}, {4, 5, 6}}
+
+func newSetupRequest(t *testing.T, currentStep int) *colpb.ProcessRequest {
+	path := &colgrpc.Path{
+		Steps: segmenttest.NewPathFromComponents(0, "1-ff00:0:1", 1, 1, "1-ff00:0:2", 2,
+			1, "1-ff00:0:3", 0),
+		CurrentStep: currentStep,
+	}
+	id, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("beefcafe"))
+	require.NoError(t, err)
+	r, err := segment.NewRequest(util.SecsToTime(1), id, 1, path)
+	require.NoError(t, err)
+	req := &segment.SetupReq{
+		Request: *r,
+		InfoField: reservation.InfoField{
+			ExpirationTick: 2,
+			BWCls:          5,
+			RLC:            3,
+			Idx:            1,
+			PathType:       reservation.UpPath,
+		},
+		MinBW:      2,
+		MaxBW:      5,
+		SplitCls:   4,
+		PathProps:  reservation.StartLocal | reservation.EndTransfer,
+		AllocTrail: reservation.AllocationBeads{{AllocBW: 5, MaxBW: 5}},
+	}
+	ctrl, err := translate.NewCtrlFromMsg(req, false)
+	require.NoError(t, err)
+	raw, err := ctrl.PackRoot()
+	require.NoError(t, err)
+	pbPath, err := colgrpc.PathToPB(path)
+	require.NoError(t, err)
+	return &colpb.ProcessRequest{
+		Raw:            raw,
+		Path:           pbPath,
+		Authenticators: testAuthenticators,
+	}
+}
+
+// newSetupResponse returns the successful response of the last AS to the setup request.
+func newSetupResponse(t *testing.T, req *colpb.ProcessRequest) *colpb.ProcessResponse {
+	path, err := colgrpc.PathFromPB(req.Path)
+	require.NoError(t, err)
+	ctrl, err := colibri_mgmt.NewFromRaw(req.Raw)
+	require.NoError(t, err)
+	msg, err := translate.NewMsgFromCtrl(ctrl, path)
+	require.NoError(t, err)
+	setup := msg.(*segment.SetupReq)
+	revPath := path.Copy()
+	require.NoError(t, revPath.Reverse())
+	resp, err := segment.NewResponse(util.SecsToTime(1), &setup.ID, setup.Index, revPath,
+		true, 0)
+	require.NoError(t, err)
+	ctrl, err = translate.NewCtrlFromMsg(&segment.ResponseSetupSuccess{
+		Response: *resp,
+		Token: reservation.Token{
+			InfoField: setup.InfoField,
+			HopFields: []reservation.HopField{{Ingress: 1, Egress: 0}},
+		},
+	}, false)
+	require.NoError(t, err)
+	raw, err := ctrl.PackRoot()
+	require.NoError(t, err)
+	pbPath, err := colgrpc.PathToPB(revPath)
+	require.NoError(t, err)
+	return &colpb.ProcessResponse{
+		Raw:  raw,
+		Path: pbPath,
+	}
+}
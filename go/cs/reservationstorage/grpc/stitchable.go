@@ -0,0 +1,127 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+// Stitchable is a segment reservation that can be part of an e2e reservation.
+type Stitchable struct {
+	ID       reservation.SegmentID
+	Path     segment.ReservationTransparentPath
+	PathType reservation.PathType
+	AllocBW  reservation.BWCls // allocated to the active index
+}
+
+// NewStitchable returns the stitchable view of a segment reservation originating in this AS.
+// The reservation must have an active index.
+func NewStitchable(rsv *segment.Reservation) (*Stitchable, error) {
+	active := rsv.ActiveIndex()
+	if active == nil {
+		return nil, serrors.New("segment reservation without active index", "id", rsv.ID)
+	}
+	if len(rsv.Path) < 2 {
+		return nil, serrors.New("segment reservation without path", "id", rsv.ID)
+	}
+	return &Stitchable{
+		ID:       rsv.ID,
+		Path:     rsv.Path,
+		PathType: rsv.PathType,
+		AllocBW:  active.AllocBW,
+	}, nil
+}
+
+// StitchableLister obtains the segment reservations of another AS that can be stitched to
+// reach a destination.
+type StitchableLister interface {
+	// ListStitchables returns the segment reservations starting at the AS ia that an e2e
+	// reservation to dst can use.
+	ListStitchables(ctx context.Context, ia, dst addr.IA) ([]*Stitchable, error)
+}
+
+// ListStitchables returns the segment reservations originating in this AS that an e2e
+// reservation to the requested destination can continue on.
+func (s *ColibriServer) ListStitchables(ctx context.Context,
+	req *colpb.ListStitchablesRequest) (*colpb.ListStitchablesResponse, error) {
+
+	dst := addr.IAInt(req.DstIsdAs).IA()
+	rsvs, err := s.Store.ListStitchableSegments(ctx, s.LocalIA, dst)
+	if err != nil {
+		return nil, serrors.WrapStr("listing stitchable reservations", err, "dst", dst)
+	}
+	res := &colpb.ListStitchablesResponse{
+		Reservations: make([]*colpb.StitchableSegment, 0, len(rsvs)),
+	}
+	for _, rsv := range rsvs {
+		stitchable, err := NewStitchable(rsv)
+		if err != nil {
+			continue
+		}
+		pb, err := StitchableToPB(stitchable)
+		if err != nil {
+			return nil, err
+		}
+		res.Reservations = append(res.Reservations, pb)
+	}
+	return res, nil
+}
+
+// StitchableToPB converts a stitchable segment reservation into its protobuf representation.
+func StitchableToPB(s *Stitchable) (*colpb.StitchableSegment, error) {
+	path, err := PathToPB(&Path{Steps: s.Path})
+	if err != nil {
+		return nil, err
+	}
+	return &colpb.StitchableSegment{
+		Id:       s.ID.ToRaw(),
+		Path:     path,
+		PathType: uint32(s.PathType),
+		AllocBw:  uint32(s.AllocBW),
+	}, nil
+}
+
+// StitchableFromPB converts a protobuf stitchable segment reservation into its
+// application type.
+func StitchableFromPB(pb *colpb.StitchableSegment) (*Stitchable, error) {
+	id, err := reservation.SegmentIDFromRaw(pb.Id)
+	if err != nil {
+		return nil, err
+	}
+	path, err := PathFromPB(pb.Path)
+	if err != nil {
+		return nil, err
+	}
+	pathType := reservation.PathType(pb.PathType)
+	if err := pathType.Validate(); err != nil {
+		return nil, err
+	}
+	bw := reservation.BWCls(pb.AllocBw)
+	if err := bw.Validate(); err != nil {
+		return nil, err
+	}
+	return &Stitchable{
+		ID:       *id,
+		Path:     path.Steps,
+		PathType: pathType,
+		AllocBW:  bw,
+	}, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstorage
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type segmentIndexStatus struct {
+	Idx         uint8     `json:"idx"`
+	State       string    `json:"state"`
+	Expiration  time.Time `json:"expiration"`
+	MinBWKbps   uint64    `json:"min_bw_kbps"`
+	MaxBWKbps   uint64    `json:"max_bw_kbps"`
+	AllocBWKbps uint64    `json:"alloc_bw_kbps"`
+}
+
+type segmentRsvStatus struct {
+	ID       string               `json:"id"`
+	BaseID   string               `json:"base_id,omitempty"`
+	PathType string               `json:"path_type"`
+	Ingress  uint16               `json:"ingress"`
+	Egress   uint16               `json:"egress"`
+	Active   bool                 `json:"active"`
+	Indices  []segmentIndexStatus `json:"indices"`
+}
+
+type e2eIndexStatus struct {
+	Idx         uint8     `json:"idx"`
+	Expiration  time.Time `json:"expiration"`
+	AllocBWKbps uint64    `json:"alloc_bw_kbps"`
+}
+
+type e2eRsvStatus struct {
+	ID          string           `json:"id"`
+	SegmentRsvs []string         `json:"segment_reservations"`
+	Indices     []e2eIndexStatus `json:"indices"`
+}
+
+// NewReservationsHandler returns an HTTP handler that serves the segment and e2e
+// reservations of the store as JSON.
+func NewReservationsHandler(store Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		segRsvs, err := store.ListSegmentReservations(r.Context())
+		if err != nil {
+			http.Error(w, "Unable to list segment reservations", http.StatusInternalServerError)
+			return
+		}
+		e2eRsvs, err := store.ListE2EReservations(r.Context())
+		if err != nil {
+			http.Error(w, "Unable to list e2e reservations", http.StatusInternalServerError)
+			return
+		}
+		rep := struct {
+			Segments []segmentRsvStatus `json:"segments"`
+			E2E      []e2eRsvStatus     `json:"e2e"`
+		}{
+			Segments: make([]segmentRsvStatus, 0, len(segRsvs)),
+			E2E:      make([]e2eRsvStatus, 0, len(e2eRsvs)),
+		}
+		for _, rsv := range segRsvs {
+			s := segmentRsvStatus{
+				ID:       rsv.ID.String(),
+				PathType: rsv.PathType.String(),
+				Ingress:  rsv.Ingress,
+				Egress:   rsv.Egress,
+				Active:   rsv.ActiveIndex() != nil,
+				Indices:  make([]segmentIndexStatus, 0, len(rsv.Indices)),
+			}
+			if rsv.BaseID != nil {
+				s.BaseID = rsv.BaseID.String()
+			}
+			for _, index := range rsv.Indices {
+				s.Indices = append(s.Indices, segmentIndexStatus{
+					Idx:         uint8(index.Idx),
+					State:       index.State().String(),
+					Expiration:  index.Expiration,
+					MinBWKbps:   index.MinBW.ToKbps(),
+					MaxBWKbps:   index.MaxBW.ToKbps(),
+					AllocBWKbps: index.AllocBW.ToKbps(),
+				})
+			}
+			rep.Segments = append(rep.Segments, s)
+		}
+		for _, rsv := range e2eRsvs {
+			s := e2eRsvStatus{
+				ID:          rsv.ID.String(),
+				SegmentRsvs: make([]string, 0, len(rsv.SegmentReservations)),
+				Indices:     make([]e2eIndexStatus, 0, len(rsv.Indices)),
+			}
+			for _, segRsv := range rsv.SegmentReservations {
+				s.SegmentRsvs = append(s.SegmentRsvs, segRsv.ID.String())
+			}
+			for _, index := range rsv.Indices {
+				s.Indices = append(s.Indices, e2eIndexStatus{
+					Idx:         uint8(index.Idx),
+					Expiration:  index.Expiration,
+					AllocBWKbps: index.AllocBW.ToKbps(),
+				})
+			}
+			rep.E2E = append(rep.E2E, s)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(rep); err != nil {
+			http.Error(w, "Unable to marshal response", http.StatusInternalServerError)
+		}
+	}
+}
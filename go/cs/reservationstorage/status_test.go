@@ -0,0 +1,83 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstorage_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestReservationsHandler(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	segRsv := newRsv(t, "00000001", 1, 2, 5)
+	e2eID, err := reservation.NewE2EID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("0123456789abcdef0123"))
+	require.NoError(t, err)
+	e2eRsv := &e2e.Reservation{
+		ID:                  *e2eID,
+		SegmentReservations: []*segment.Reservation{segRsv},
+	}
+	store := mock_reservationstorage.NewMockStore(mctrl)
+	store.EXPECT().ListSegmentReservations(gomock.Any()).Return(
+		[]*segment.Reservation{segRsv}, nil)
+	store.EXPECT().ListE2EReservations(gomock.Any()).Return([]*e2e.Reservation{e2eRsv}, nil)
+
+	w := httptest.NewRecorder()
+	handler := reservationstorage.NewReservationsHandler(store)
+	handler(w, httptest.NewRequest("GET", "/reservations", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var page struct {
+		Segments []struct {
+			ID      string `json:"id"`
+			Ingress uint16 `json:"ingress"`
+			Indices []struct {
+				State string `json:"state"`
+			} `json:"indices"`
+		} `json:"segments"`
+		E2E []struct {
+			ID          string   `json:"id"`
+			SegmentRsvs []string `json:"segment_reservations"`
+		} `json:"e2e"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	require.Len(t, page.Segments, 1)
+	require.Equal(t, "ff00:0:1-00000001", page.Segments[0].ID)
+	require.Equal(t, uint16(1), page.Segments[0].Ingress)
+	require.Len(t, page.Segments[0].Indices, 1)
+	require.Equal(t, "temporary", page.Segments[0].Indices[0].State)
+	require.Len(t, page.E2E, 1)
+	require.Equal(t, e2eID.String(), page.E2E[0].ID)
+	require.Equal(t, []string{"ff00:0:1-00000001"}, page.E2E[0].SegmentRsvs)
+
+	store.EXPECT().ListSegmentReservations(gomock.Any()).Return(nil, serrors.New("db"))
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest("GET", "/reservations", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -20,13 +20,23 @@ import (
 	base "github.com/scionproto/scion/go/cs/reservation"
 	"github.com/scionproto/scion/go/cs/reservation/e2e"
 	sgt "github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/infra/modules/cleaner"
+	"github.com/scionproto/scion/go/lib/serrors"
 )
 
+// ErrInvalidAuthenticator is returned by the store when the DRKey authenticator of a request
+// for this AS is missing or does not match the request.
+var ErrInvalidAuthenticator = serrors.New("invalid request authenticator")
+
 // Store is the interface to interact with the reservation store.
 type Store interface {
 	AdmitSegmentReservation(ctx context.Context, req *sgt.SetupReq) (
 		base.MessageWithPath, error)
+	AdmitTelescopicSegmentReservation(ctx context.Context, req *sgt.SetupTelesReq) (
+		base.MessageWithPath, error)
+	ProcessSegmentSetupResponse(ctx context.Context, resp *sgt.ResponseSetupSuccess) (
+		base.MessageWithPath, error)
 	ConfirmSegmentReservation(ctx context.Context, req *sgt.IndexConfirmationReq) (
 		base.MessageWithPath, error)
 	CleanupSegmentReservation(ctx context.Context, req *sgt.CleanupReq) (
@@ -35,13 +45,20 @@ type Store interface {
 		base.MessageWithPath, error)
 	AdmitE2EReservation(ctx context.Context, req e2e.SetupRequest) (
 		base.MessageWithPath, error)
+	ProcessE2ESetupResponse(ctx context.Context, resp *e2e.ResponseSetupSuccess) (
+		base.MessageWithPath, error)
 	CleanupE2EReservation(ctx context.Context, req *e2e.CleanupReq) (
 		base.MessageWithPath, error)
+	ListStitchableSegments(ctx context.Context, src, dst addr.IA) (
+		[]*sgt.Reservation, error)
+	ListSegmentReservations(ctx context.Context) ([]*sgt.Reservation, error)
+	ListE2EReservations(ctx context.Context) ([]*e2e.Reservation, error)
 
 	DeleteExpiredIndices(ctx context.Context) (int, error)
 }
 
-// TODO(juagargi) there is a number of functions missing: all regarding responses.
+// TODO(juagargi) there is a number of functions missing: all regarding responses, other
+// than the successful setups.
 
 // NewIndexCleaner creates a cleaner removing expired indices and reservations.
 func NewIndexCleaner(s Store) *cleaner.Cleaner {
@@ -0,0 +1,289 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkeystorage"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// AuthTimestampWindow is the maximum difference between the timestamp of a request and the
+// time it is validated. Older requests are rejected, and the ones within the window are
+// accepted only once.
+const AuthTimestampWindow = time.Minute
+
+// Tags identifying the kind of request in the authenticated input.
+const (
+	authTagSegmentSetup uint8 = iota + 1
+	authTagSegmentTelesSetup
+	authTagSegmentTeardown
+	authTagSegmentIndexConfirmation
+	authTagSegmentCleanup
+	authTagE2ESetup
+	authTagE2ECleanup
+)
+
+// ComputeAuthenticators is used by the initiator of a request to compute the authenticators
+// for every other AS in the path of the request. The authenticator for the AS at hop i is a
+// MAC over the immutable fields of the request, keyed with the level 1 DRKey
+// K_{AS_i -> initiator}, which is obtained from AS_i through the DRKey service store.
+func ComputeAuthenticators(ctx context.Context, keys drkeystorage.ServiceStore,
+	req base.AuthenticatedRequest) error {
+
+	input, err := authInput(req)
+	if err != nil {
+		return err
+	}
+	path := req.Path()
+	initiator := path.IA(0)
+	valTime := requestTimestamp(req)
+	authenticators := make([][]byte, path.NumberOfHops())
+	for i := 1; i < len(authenticators); i++ {
+		meta := drkey.Lvl1Meta{
+			SrcIA: path.IA(i),
+			DstIA: initiator,
+		}
+		key, err := keys.GetLvl1Key(ctx, meta, valTime)
+		if err != nil {
+			return serrors.WrapStr("obtaining DRKey for authenticator", err, "ia", meta.SrcIA)
+		}
+		if authenticators[i], err = computeMAC(key.Key, input); err != nil {
+			return err
+		}
+	}
+	req.SetAuthenticators(authenticators)
+	return nil
+}
+
+// validateAuthenticator checks the authenticator of this AS in the request, that the
+// request timestamp is within AuthTimestampWindow of now, and that the reservation belongs to
+// the AS that sent the request. Requests originating in this AS do not carry an authenticator
+// for it.
+func validateAuthenticator(keys drkeystorage.ServiceStore, req base.AuthenticatedRequest,
+	now time.Time) error {
+
+	path := req.Path()
+	current := path.IndexOfCurrentHop()
+	if current == 0 {
+		return nil
+	}
+	// the authenticator only proves who sent the request. Only the AS that owns the
+	// reservation can change it.
+	if asid := requestASID(req); asid != path.IA(0).A {
+		return serrors.WithCtx(reservationstorage.ErrInvalidAuthenticator,
+			"reason", "reservation not owned by initiator", "asid", asid,
+			"initiator", path.IA(0))
+	}
+	timestamp := requestTimestamp(req)
+	if d := now.Sub(timestamp); d > AuthTimestampWindow || d < -AuthTimestampWindow {
+		return serrors.WithCtx(reservationstorage.ErrInvalidAuthenticator,
+			"reason", "timestamp outside window", "timestamp", timestamp, "now", now)
+	}
+	authenticators := req.Authenticators()
+	if len(authenticators) != path.NumberOfHops() {
+		return serrors.WithCtx(reservationstorage.ErrInvalidAuthenticator,
+			"reason", "wrong number of authenticators",
+			"expected", path.NumberOfHops(), "actual", len(authenticators))
+	}
+	input, err := authInput(req)
+	if err != nil {
+		return err
+	}
+	// during the grace period of an epoch switch, the initiator can use the key of either epoch
+	lvl1Keys, err := keys.DeriveLvl1Keys(path.IA(0), timestamp)
+	if err != nil {
+		return serrors.WrapStr("deriving DRKey for authenticator", err)
+	}
+	for _, key := range lvl1Keys {
+		expected, err := computeMAC(key.Key, input)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare(expected, authenticators[current]) == 1 {
+			return nil
+		}
+	}
+	return serrors.WithCtx(reservationstorage.ErrInvalidAuthenticator, "initiator", path.IA(0))
+}
+
+func computeMAC(key drkey.DRKey, input []byte) ([]byte, error) {
+	mac, err := scrypto.InitMac(key)
+	if err != nil {
+		return nil, serrors.WrapStr("initializing MAC", err)
+	}
+	mac.Write(input)
+	return mac.Sum(nil), nil
+}
+
+// requestASID returns the AS that the reservation of the request belongs to.
+func requestASID(req base.AuthenticatedRequest) addr.AS {
+	switch r := req.(type) {
+	case *segment.SetupReq:
+		return r.ID.ASID
+	case *segment.SetupTelesReq:
+		return r.ID.ASID
+	case *segment.TeardownReq:
+		return r.ID.ASID
+	case *segment.IndexConfirmationReq:
+		return r.ID.ASID
+	case *segment.CleanupReq:
+		return r.ID.ASID
+	case e2e.SetupRequest:
+		return r.GetCommonSetupReq().ID.ASID
+	case *e2e.CleanupReq:
+		return r.ID.ASID
+	}
+	return 0
+}
+
+func requestTimestamp(req base.AuthenticatedRequest) time.Time {
+	switch r := req.(type) {
+	case *segment.SetupReq:
+		return r.Timestamp
+	case *segment.SetupTelesReq:
+		return r.Timestamp
+	case *segment.TeardownReq:
+		return r.Timestamp
+	case *segment.IndexConfirmationReq:
+		return r.Timestamp
+	case *segment.CleanupReq:
+		return r.Timestamp
+	case e2e.SetupRequest:
+		return r.GetCommonSetupReq().Timestamp
+	case *e2e.CleanupReq:
+		return r.Timestamp
+	}
+	return time.Time{}
+}
+
+// authInput returns the fields of the request that do not change along its path, together
+// with the ASes of the path and the interfaces of each of them. Fields updated by each AS,
+// such as allocation trails or the success of an e2e setup, are not part of it.
+func authInput(req base.AuthenticatedRequest) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	switch r := req.(type) {
+	case *segment.SetupReq:
+		buf.WriteByte(authTagSegmentSetup)
+		writeSegmentRequest(buf, &r.Request)
+		writeSegmentSetup(buf, r)
+	case *segment.SetupTelesReq:
+		buf.WriteByte(authTagSegmentTelesSetup)
+		writeSegmentRequest(buf, &r.Request)
+		writeSegmentSetup(buf, &r.SetupReq)
+		buf.Write(r.BaseID.ToRaw())
+	case *segment.TeardownReq:
+		buf.WriteByte(authTagSegmentTeardown)
+		writeSegmentRequest(buf, &r.Request)
+	case *segment.IndexConfirmationReq:
+		buf.WriteByte(authTagSegmentIndexConfirmation)
+		writeSegmentRequest(buf, &r.Request)
+		buf.WriteByte(byte(r.State))
+	case *segment.CleanupReq:
+		buf.WriteByte(authTagSegmentCleanup)
+		writeSegmentRequest(buf, &r.Request)
+	case e2e.SetupRequest:
+		setup := r.GetCommonSetupReq()
+		buf.WriteByte(authTagE2ESetup)
+		writeE2ERequest(buf, &setup.Request)
+		buf.WriteByte(byte(setup.RequestedBW))
+		buf.WriteByte(byte(len(setup.SegmentRsvs)))
+		for i, id := range setup.SegmentRsvs {
+			buf.Write(id.ToRaw())
+			buf.WriteByte(setup.SegmentRsvASCount[i])
+		}
+	case *e2e.CleanupReq:
+		buf.WriteByte(authTagE2ECleanup)
+		writeE2ERequest(buf, &r.Request)
+	default:
+		return nil, serrors.New("unsupported request type for authentication")
+	}
+	path := req.Path()
+	buf.WriteByte(byte(path.NumberOfHops()))
+	for i := 0; i < path.NumberOfHops(); i++ {
+		ingress, egress := path.Interfaces(i)
+		binary.Write(buf, binary.BigEndian, uint64(path.IA(i).IAInt()))
+		binary.Write(buf, binary.BigEndian, ingress)
+		binary.Write(buf, binary.BigEndian, egress)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeSegmentRequest(buf *bytes.Buffer, r *segment.Request) {
+	buf.Write(r.ID.ToRaw())
+	buf.WriteByte(byte(r.Index))
+	binary.Write(buf, binary.BigEndian, uint32(reservation.TickFromTime(r.Timestamp)))
+}
+
+func writeSegmentSetup(buf *bytes.Buffer, r *segment.SetupReq) {
+	buf.Write(r.InfoField.ToRaw())
+	buf.WriteByte(byte(r.MinBW))
+	buf.WriteByte(byte(r.MaxBW))
+	buf.WriteByte(byte(r.SplitCls))
+	buf.WriteByte(byte(r.PathProps))
+}
+
+func writeE2ERequest(buf *bytes.Buffer, r *e2e.Request) {
+	buf.Write(r.ID.ToRaw())
+	buf.WriteByte(byte(r.Index))
+	binary.Write(buf, binary.BigEndian, uint32(reservation.TickFromTime(r.Timestamp)))
+}
+
+// replayCache remembers the authenticators of the requests accepted within the last
+// AuthTimestampWindow, so that each of them is processed only once. The zero value is ready
+// to use.
+type replayCache struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time // authenticator -> end of the window of its request
+	nextGC time.Time
+}
+
+// add records the authenticator of a request with the given timestamp. It returns false if
+// the authenticator was already recorded.
+func (c *replayCache) add(authenticator []byte, timestamp, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen == nil {
+		c.seen = make(map[string]time.Time)
+	}
+	if !now.Before(c.nextGC) {
+		for k, end := range c.seen {
+			if end.Before(now) {
+				delete(c.seen, k)
+			}
+		}
+		c.nextGC = now.Add(AuthTimestampWindow)
+	}
+	key := string(authenticator)
+	if _, ok := c.seen[key]; ok {
+		return false
+	}
+	c.seen[key] = timestamp.Add(AuthTimestampWindow)
+	return true
+}
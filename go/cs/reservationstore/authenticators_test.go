@@ -0,0 +1,255 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/test"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend/mock_backend"
+	"github.com/scionproto/scion/go/cs/reservationstore"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkeystorage/mock_drkeystorage"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var pathIAs = []addr.IA{
+	xtest.MustParseIA("1-ff00:0:1"),
+	xtest.MustParseIA("1-ff00:0:2"),
+	xtest.MustParseIA("1-ff00:0:3"),
+}
+
+func TestAuthenticators(t *testing.T) {
+	cases := map[string]struct {
+		tamper func(base.AuthenticatedRequest)
+		valid  bool
+	}{
+		"valid": {
+			tamper: func(base.AuthenticatedRequest) {},
+			valid:  true,
+		},
+		"allocation trail is mutable": {
+			tamper: func(r base.AuthenticatedRequest) {
+				r.(*segment.SetupReq).AllocTrail[0].AllocBW = 3
+			},
+			valid: true,
+		},
+		"forged bandwidth": {
+			tamper: func(r base.AuthenticatedRequest) {
+				r.(*segment.SetupReq).MaxBW = 13
+			},
+		},
+		"forged interface of other AS": {
+			tamper: func(r base.AuthenticatedRequest) {
+				r.Path().(*test.TestColibriPath).IFIDs[2][0] = 5
+			},
+		},
+		"forged index": {
+			tamper: func(r base.AuthenticatedRequest) {
+				r.(*segment.SetupReq).Index = 2
+			},
+		},
+		"authenticator of other AS": {
+			tamper: func(r base.AuthenticatedRequest) {
+				a := r.Authenticators()
+				a[1] = a[2]
+			},
+		},
+		"missing authenticators": {
+			tamper: func(r base.AuthenticatedRequest) {
+				r.SetAuthenticators(nil)
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			req := newSetupReq(t)
+			computeAuthenticators(t, ctrl, req)
+			tc.tamper(req)
+			req.Path().(*test.TestColibriPath).CurrentHop = 1
+			db := mock_backend.NewMockDB(ctrl)
+			if tc.valid {
+				// the request is authentic, processing stops later at the DB.
+				db.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(
+					nil, serrors.New("test error"))
+			}
+			store := reservationstore.NewStore(db, nil, newVerifierStore(ctrl, 1), nil)
+			_, err := store.AdmitSegmentReservation(context.Background(), req)
+			require.Error(t, err)
+			require.Equal(t, !tc.valid,
+				errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+		})
+	}
+}
+
+func TestForgedRequestType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setup := newSetupReq(t)
+	computeAuthenticators(t, ctrl, setup)
+	// the authenticators of a setup are not valid for a teardown of the same reservation.
+	teardown := &segment.TeardownReq{Request: setup.Request}
+	teardown.Path().(*test.TestColibriPath).CurrentHop = 2
+	store := reservationstore.NewStore(nil, nil, newVerifierStore(ctrl, 2), nil)
+	_, err := store.TearDownSegmentReservation(context.Background(), teardown)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+
+	id, err := reservation.NewE2EID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("beefcafe000000000000"))
+	require.NoError(t, err)
+	r, err := e2e.NewRequest(time.Now(), id, 1, newPath())
+	require.NoError(t, err)
+	cleanup := &e2e.CleanupReq{Request: *r}
+	cleanup.SetAuthenticators(setup.Authenticators())
+	cleanup.Path().(*test.TestColibriPath).CurrentHop = 1
+	store = reservationstore.NewStore(nil, nil, newVerifierStore(ctrl, 1), nil)
+	_, err = store.CleanupE2EReservation(context.Background(), cleanup)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+}
+
+func TestAuthenticatorGracePeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	req := newSetupReq(t)
+	// the initiator computed the authenticators with the keys of the previous epoch.
+	computeAuthenticators(t, ctrl, req)
+	req.Path().(*test.TestColibriPath).CurrentHop = 1
+	current := lvl1Key(2)
+	current.SrcIA = pathIAs[1]
+
+	keys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	keys.EXPECT().DeriveLvl1Keys(pathIAs[0], gomock.Any()).Return(
+		[]drkey.Lvl1Key{current, lvl1Key(1)}, nil)
+	db := mock_backend.NewMockDB(ctrl)
+	db.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, serrors.New("test error"))
+	store := reservationstore.NewStore(db, nil, keys, nil)
+	_, err := store.AdmitSegmentReservation(context.Background(), req)
+	require.False(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+
+	// once the grace period is over, only the key of the current epoch is valid.
+	keys.EXPECT().DeriveLvl1Keys(pathIAs[0], gomock.Any()).Return(
+		[]drkey.Lvl1Key{current}, nil)
+	_, err = store.AdmitSegmentReservation(context.Background(), req)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+}
+
+func TestAuthenticatorTimestampWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// authentic, but too old: it could be replayed
+	req := newSetupReq(t)
+	req.Timestamp = time.Now().Add(-2 * reservationstore.AuthTimestampWindow)
+	computeAuthenticators(t, ctrl, req)
+	req.Path().(*test.TestColibriPath).CurrentHop = 1
+	store := reservationstore.NewStore(nil, nil, newVerifierStore(ctrl, 1), nil)
+	_, err := store.AdmitSegmentReservation(context.Background(), req)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+
+	// nor from the future
+	req = newSetupReq(t)
+	req.Timestamp = time.Now().Add(2 * reservationstore.AuthTimestampWindow)
+	computeAuthenticators(t, ctrl, req)
+	req.Path().(*test.TestColibriPath).CurrentHop = 1
+	_, err = store.AdmitSegmentReservation(context.Background(), req)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+}
+
+// lvl1Key returns the level 1 key from the AS at the given hop to the initiator.
+func lvl1Key(hop int) drkey.Lvl1Key {
+	return drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{
+			SrcIA: pathIAs[hop],
+			DstIA: pathIAs[0],
+		},
+		Key: drkey.DRKey(xtest.MustParseHexString("0123456789abcdef0123456789abcd0" +
+			string(rune('0'+hop)))),
+	}
+}
+
+// computeAuthenticators computes the authenticators as the initiator does.
+func computeAuthenticators(t *testing.T, ctrl *gomock.Controller,
+	req base.AuthenticatedRequest) {
+
+	keys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	keys.EXPECT().GetLvl1Key(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, meta drkey.Lvl1Meta, _ time.Time) (drkey.Lvl1Key, error) {
+			require.Equal(t, pathIAs[0], meta.DstIA)
+			for i, ia := range pathIAs {
+				if ia == meta.SrcIA {
+					return lvl1Key(i), nil
+				}
+			}
+			t.Fatalf("unexpected key request: %v", meta)
+			return drkey.Lvl1Key{}, nil
+		}).Times(len(pathIAs) - 1)
+	require.NoError(t, reservationstore.ComputeAuthenticators(context.Background(), keys, req))
+	require.Len(t, req.Authenticators(), len(pathIAs))
+}
+
+// newVerifierStore returns the DRKey store of the AS at the given hop.
+func newVerifierStore(ctrl *gomock.Controller, hop int) *mock_drkeystorage.MockServiceStore {
+	keys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	keys.EXPECT().DeriveLvl1Keys(pathIAs[0], gomock.Any()).Return(
+		[]drkey.Lvl1Key{lvl1Key(hop)}, nil).AnyTimes()
+	return keys
+}
+
+func newPath() *test.TestColibriPath {
+	return &test.TestColibriPath{
+		HopCount: len(pathIAs),
+		IAs:      pathIAs,
+		IFIDs:    [][2]uint16{{0, 1}, {2, 3}, {4, 0}},
+	}
+}
+
+func newSetupReq(t *testing.T) *segment.SetupReq {
+	id, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("beefcafe"))
+	require.NoError(t, err)
+	r, err := segment.NewRequest(time.Now(), id, 1, newPath())
+	require.NoError(t, err)
+	return &segment.SetupReq{
+		Request: *r,
+		InfoField: reservation.InfoField{
+			ExpirationTick: reservation.TickFromTime(time.Now().Add(time.Hour)),
+			BWCls:          5,
+			RLC:            3,
+			Idx:            1,
+			PathType:       reservation.UpPath,
+		},
+		MinBW:     2,
+		MaxBW:     5,
+		SplitCls:  4,
+		PathProps: reservation.StartLocal | reservation.EndTransfer,
+		// the allocation trail as it arrives at the AS at hop 1.
+		AllocTrail: reservation.AllocationBeads{{AllocBW: 5, MaxBW: 5}},
+	}
+}
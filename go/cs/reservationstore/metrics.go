@@ -0,0 +1,268 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
+)
+
+// Request types used as label values in the Requests metric.
+const (
+	SegmentSetup        = "segment_setup"
+	SegmentTelesSetup   = "segment_telescopic_setup"
+	SegmentConfirmation = "segment_index_confirmation"
+	SegmentCleanup      = "segment_cleanup"
+	SegmentTeardown     = "segment_teardown"
+	E2ESetup            = "e2e_setup"
+	E2ECleanup          = "e2e_cleanup"
+)
+
+// Metrics are the metrics exported by the reservation store. Nil metrics are not exported.
+type Metrics struct {
+	// Requests counts the requests per type and result. The result of a denied request is
+	// the reason of the denial, e.g. "err_insufficient_bandwidth".
+	Requests metrics.Counter
+	// GrantedBW observes the bandwidth in kbps granted to segment reservations, per ingress
+	// and egress interface.
+	GrantedBW metrics.Histogram
+	// ActiveRsvs is the number of reservations with an active index, per type.
+	ActiveRsvs metrics.Gauge
+	// ConfirmationLatency observes the seconds between the admission of a segment index and
+	// its confirmation.
+	ConfirmationLatency metrics.Histogram
+	// ExpiredIndices counts the expired indices removed by the cleanup.
+	ExpiredIndices metrics.Counter
+}
+
+type indexKey struct {
+	id  reservation.SegmentID
+	idx reservation.IndexNumber
+}
+
+type admittedIndex struct {
+	admitted   time.Time
+	expiration time.Time
+}
+
+// metricsStore is a reservation store that exports metrics.
+type metricsStore struct {
+	reservationstorage.Store
+	metrics Metrics
+
+	mu sync.Mutex
+	// the admitted segment indices not confirmed yet
+	pending map[indexKey]admittedIndex
+}
+
+// WithMetrics wraps the store into one that exports metrics. The number of active
+// reservations is updated after every cleanup of expired indices.
+func WithMetrics(store reservationstorage.Store, m Metrics) reservationstorage.Store {
+	return &metricsStore{
+		Store:   store,
+		metrics: m,
+		pending: make(map[indexKey]admittedIndex),
+	}
+}
+
+func (s *metricsStore) AdmitSegmentReservation(ctx context.Context, req *segment.SetupReq) (
+	base.MessageWithPath, error) {
+
+	msg, err := s.Store.AdmitSegmentReservation(ctx, req)
+	s.observeSegmentSetup(SegmentSetup, req, msg, err)
+	return msg, err
+}
+
+func (s *metricsStore) AdmitTelescopicSegmentReservation(ctx context.Context,
+	req *segment.SetupTelesReq) (base.MessageWithPath, error) {
+
+	msg, err := s.Store.AdmitTelescopicSegmentReservation(ctx, req)
+	s.observeSegmentSetup(SegmentTelesSetup, &req.SetupReq, msg, err)
+	return msg, err
+}
+
+func (s *metricsStore) ConfirmSegmentReservation(ctx context.Context,
+	req *segment.IndexConfirmationReq) (base.MessageWithPath, error) {
+
+	msg, err := s.Store.ConfirmSegmentReservation(ctx, req)
+	s.countRequest(SegmentConfirmation, msg, err)
+	if err == nil {
+		s.mu.Lock()
+		key := indexKey{id: req.ID, idx: req.Index}
+		if index, ok := s.pending[key]; ok {
+			delete(s.pending, key)
+			metrics.HistogramObserve(s.metrics.ConfirmationLatency,
+				time.Since(index.admitted).Seconds())
+		}
+		s.mu.Unlock()
+	}
+	return msg, err
+}
+
+func (s *metricsStore) CleanupSegmentReservation(ctx context.Context,
+	req *segment.CleanupReq) (base.MessageWithPath, error) {
+
+	msg, err := s.Store.CleanupSegmentReservation(ctx, req)
+	s.countRequest(SegmentCleanup, msg, err)
+	return msg, err
+}
+
+func (s *metricsStore) TearDownSegmentReservation(ctx context.Context,
+	req *segment.TeardownReq) (base.MessageWithPath, error) {
+
+	msg, err := s.Store.TearDownSegmentReservation(ctx, req)
+	s.countRequest(SegmentTeardown, msg, err)
+	return msg, err
+}
+
+func (s *metricsStore) AdmitE2EReservation(ctx context.Context, req e2e.SetupRequest) (
+	base.MessageWithPath, error) {
+
+	msg, err := s.Store.AdmitE2EReservation(ctx, req)
+	s.countRequest(E2ESetup, msg, err)
+	return msg, err
+}
+
+func (s *metricsStore) CleanupE2EReservation(ctx context.Context, req *e2e.CleanupReq) (
+	base.MessageWithPath, error) {
+
+	msg, err := s.Store.CleanupE2EReservation(ctx, req)
+	s.countRequest(E2ECleanup, msg, err)
+	return msg, err
+}
+
+func (s *metricsStore) DeleteExpiredIndices(ctx context.Context) (int, error) {
+	n, err := s.Store.DeleteExpiredIndices(ctx)
+	metrics.CounterAdd(s.metrics.ExpiredIndices, float64(n))
+	if err != nil {
+		return n, err
+	}
+	now := time.Now()
+	s.mu.Lock()
+	for key, index := range s.pending {
+		if index.expiration.Before(now) {
+			delete(s.pending, key)
+		}
+	}
+	s.mu.Unlock()
+	if err := s.updateActiveRsvs(ctx, now); err != nil {
+		log.FromCtx(ctx).Info("Cannot count the active COLIBRI reservations", "err", err)
+	}
+	return n, nil
+}
+
+func (s *metricsStore) observeSegmentSetup(reqType string, req *segment.SetupReq,
+	msg base.MessageWithPath, err error) {
+
+	s.countRequest(reqType, msg, err)
+	if err != nil || len(req.AllocTrail) == 0 {
+		return
+	}
+	granted := req.AllocTrail[len(req.AllocTrail)-1].AllocBW
+	metrics.HistogramObserve(metrics.HistogramWith(s.metrics.GrantedBW,
+		"ingress", strconv.Itoa(int(req.Ingress)), "egress", strconv.Itoa(int(req.Egress))),
+		float64(granted.ToKbps()))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[indexKey{id: req.ID, idx: req.InfoField.Idx}] = admittedIndex{
+		admitted:   time.Now(),
+		expiration: req.InfoField.ExpirationTick.ToTime(),
+	}
+}
+
+func (s *metricsStore) countRequest(reqType string, msg base.MessageWithPath, err error) {
+	metrics.CounterInc(metrics.CounterWith(s.metrics.Requests,
+		"type", reqType, prom.LabelResult, requestResult(msg, err)))
+}
+
+// updateActiveRsvs sets the number of segment reservations with an active index, per path
+// type, and the number of e2e reservations with a non expired index.
+func (s *metricsStore) updateActiveRsvs(ctx context.Context, now time.Time) error {
+	if s.metrics.ActiveRsvs == nil {
+		return nil
+	}
+	segRsvs, err := s.Store.ListSegmentReservations(ctx)
+	if err != nil {
+		return err
+	}
+	e2eRsvs, err := s.Store.ListE2EReservations(ctx)
+	if err != nil {
+		return err
+	}
+	active := make(map[string]int)
+	for _, pt := range []reservation.PathType{reservation.UpPath, reservation.DownPath,
+		reservation.CorePath, reservation.PeeringUpPath, reservation.PeeringDownPath} {
+
+		active[segmentRsvType(pt)] = 0
+	}
+	for _, rsv := range segRsvs {
+		if rsv.ActiveIndex() != nil {
+			active[segmentRsvType(rsv.PathType)]++
+		}
+	}
+	active["e2e"] = 0
+	for _, rsv := range e2eRsvs {
+		for _, index := range rsv.Indices {
+			if !index.Expiration.Before(now) {
+				active["e2e"]++
+				break
+			}
+		}
+	}
+	for rsvType, n := range active {
+		metrics.GaugeSet(metrics.GaugeWith(s.metrics.ActiveRsvs, "type", rsvType), float64(n))
+	}
+	return nil
+}
+
+func segmentRsvType(pt reservation.PathType) string {
+	return "segment_" + strings.ReplaceAll(pt.String(), " ", "_")
+}
+
+// requestResult returns the result label of a request, which for failed requests is the
+// reason the request was denied in this or a previous AS.
+func requestResult(msg base.MessageWithPath, err error) string {
+	var code reservation.ErrorCode
+	switch m := msg.(type) {
+	case base.FailureResponse:
+		code = m.Failure().Code
+	case *e2e.SetupReqFailure:
+		code = m.ErrorCode
+	default:
+		if err == nil {
+			return prom.Success
+		}
+		if errors.Is(err, reservationstorage.ErrInvalidAuthenticator) {
+			code = reservation.ErrorInvalidAuthenticator
+		}
+	}
+	if code == reservation.ErrorUnspecified {
+		return prom.ErrInternal
+	}
+	return "err_" + strings.ReplaceAll(code.String(), " ", "_")
+}
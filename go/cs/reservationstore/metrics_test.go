@@ -0,0 +1,161 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstore"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+func TestMetricsRequests(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	store := mock_reservationstorage.NewMockStore(mctrl)
+	requests := metrics.NewTestCounter()
+	s := reservationstore.WithMetrics(store, reservationstore.Metrics{Requests: requests})
+	ctx := context.Background()
+	req := newMetricsSetupReq()
+
+	store.EXPECT().AdmitSegmentReservation(gomock.Any(), req).Return(req, nil)
+	_, err := s.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+
+	failure := &segment.ResponseSetupFailure{ErrorCode: reservation.ErrorInsufficientBW}
+	store.EXPECT().AdmitSegmentReservation(gomock.Any(), req).Return(failure,
+		serrors.New("segment not admitted"))
+	_, err = s.AdmitSegmentReservation(ctx, req)
+	require.Error(t, err)
+
+	store.EXPECT().AdmitSegmentReservation(gomock.Any(), req).Return(nil,
+		serrors.WrapStr("error validating request", reservationstorage.ErrInvalidAuthenticator))
+	_, err = s.AdmitSegmentReservation(ctx, req)
+	require.Error(t, err)
+
+	e2eFailure := &e2e.SetupReqFailure{ErrorCode: reservation.ErrorExpired}
+	store.EXPECT().AdmitE2EReservation(gomock.Any(), gomock.Any()).Return(e2eFailure,
+		serrors.New("e2e setup request already expired"))
+	_, err = s.AdmitE2EReservation(ctx, &e2e.SetupReqSuccess{})
+	require.Error(t, err)
+
+	value := func(reqType, result string) float64 {
+		return metrics.CounterValue(requests.With("type", reqType, prom.LabelResult, result))
+	}
+	require.Equal(t, float64(1), value(reservationstore.SegmentSetup, prom.Success))
+	require.Equal(t, float64(1),
+		value(reservationstore.SegmentSetup, "err_insufficient_bandwidth"))
+	require.Equal(t, float64(1), value(reservationstore.SegmentSetup, "err_invalid_authenticator"))
+	require.Equal(t, float64(1), value(reservationstore.E2ESetup, "err_expired"))
+}
+
+func TestMetricsConfirmationLatency(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	store := mock_reservationstorage.NewMockStore(mctrl)
+	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "latency"}, nil)
+	s := reservationstore.WithMetrics(store, reservationstore.Metrics{
+		ConfirmationLatency: metrics.NewPromHistogram(latency),
+	})
+	ctx := context.Background()
+	req := newMetricsSetupReq()
+	confirm := &segment.IndexConfirmationReq{
+		Request: segment.Request{ID: req.ID, Index: req.InfoField.Idx},
+		State:   segment.IndexPending,
+	}
+
+	// not admitted by this store, so there is no latency to observe
+	store.EXPECT().ConfirmSegmentReservation(gomock.Any(), confirm).Return(confirm, nil)
+	_, err := s.ConfirmSegmentReservation(ctx, confirm)
+	require.NoError(t, err)
+	require.Equal(t, 0, testutil.CollectAndCount(latency))
+
+	store.EXPECT().AdmitSegmentReservation(gomock.Any(), req).Return(req, nil)
+	_, err = s.AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	store.EXPECT().ConfirmSegmentReservation(gomock.Any(), confirm).Return(confirm, nil)
+	_, err = s.ConfirmSegmentReservation(ctx, confirm)
+	require.NoError(t, err)
+	require.Equal(t, 1, testutil.CollectAndCount(latency))
+}
+
+func TestMetricsDeleteExpiredIndices(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	store := mock_reservationstorage.NewMockStore(mctrl)
+	expired := metrics.NewTestCounter()
+	active := metrics.NewTestGauge()
+	s := reservationstore.WithMetrics(store, reservationstore.Metrics{
+		ActiveRsvs:     active,
+		ExpiredIndices: expired,
+	})
+
+	activeRsv := segmenttest.NewReservation()
+	activeRsv.PathType = reservation.UpPath
+	idx, err := activeRsv.NewIndexAtSource(time.Now().Add(time.Minute), 1, 5, 5, 1,
+		reservation.UpPath)
+	require.NoError(t, err)
+	require.NoError(t, activeRsv.SetIndexConfirmed(idx))
+	require.NoError(t, activeRsv.SetIndexActive(idx))
+	inactiveRsv := segmenttest.NewReservation()
+	inactiveRsv.PathType = reservation.DownPath
+	e2eRsv := &e2e.Reservation{}
+	_, err = e2eRsv.NewIndex(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	store.EXPECT().DeleteExpiredIndices(gomock.Any()).Return(3, nil)
+	store.EXPECT().ListSegmentReservations(gomock.Any()).Return(
+		[]*segment.Reservation{activeRsv, inactiveRsv}, nil)
+	store.EXPECT().ListE2EReservations(gomock.Any()).Return([]*e2e.Reservation{e2eRsv}, nil)
+	n, err := s.DeleteExpiredIndices(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, float64(3), metrics.CounterValue(expired))
+	require.Equal(t, float64(1), metrics.GaugeValue(active.With("type", "segment_up")))
+	require.Equal(t, float64(0), metrics.GaugeValue(active.With("type", "segment_down")))
+	require.Equal(t, float64(1), metrics.GaugeValue(active.With("type", "e2e")))
+}
+
+func newMetricsSetupReq() *segment.SetupReq {
+	req := &segment.SetupReq{
+		InfoField: reservation.InfoField{
+			Idx:            1,
+			ExpirationTick: reservation.TickFromTime(time.Now().Add(time.Minute)),
+		},
+		AllocTrail: reservation.AllocationBeads{{AllocBW: 5, MaxBW: 5}},
+	}
+	req.ID = segmenttest.NewReservation().ID
+	req.Ingress = 1
+	req.Egress = 2
+	return req
+}
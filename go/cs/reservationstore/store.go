@@ -16,6 +16,8 @@ package reservationstore
 
 import (
 	"context"
+	"errors"
+	"hash"
 	"math"
 	"time"
 
@@ -25,23 +27,32 @@ import (
 	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
 	"github.com/scionproto/scion/go/cs/reservationstorage"
 	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/drkeystorage"
 	"github.com/scionproto/scion/go/lib/serrors"
 )
 
 // Store is the reservation store.
 type Store struct {
-	db       backend.DB         // aka reservation map
-	admitter admission.Admitter // the chosen admission entity
+	db         backend.DB                // aka reservation map
+	admitter   admission.Admitter        // the chosen admission entity
+	drkeys     drkeystorage.ServiceStore // used to validate request authenticators
+	macFactory func() hash.Hash          // computes the hop field MACs of the tokens
+	replays    replayCache               // authenticators of the recently accepted requests
 }
 
 var _ reservationstorage.Store = (*Store)(nil)
 
 // NewStore creates a new reservation store.
-func NewStore(db backend.DB, admitter admission.Admitter) *Store {
+func NewStore(db backend.DB, admitter admission.Admitter,
+	drkeys drkeystorage.ServiceStore, macFactory func() hash.Hash) *Store {
+
 	return &Store{
-		db:       db,
-		admitter: admitter,
+		db:         db,
+		admitter:   admitter,
+		drkeys:     drkeys,
+		macFactory: macFactory,
 	}
 }
 
@@ -50,7 +61,33 @@ func NewStore(db backend.DB, admitter admission.Admitter) *Store {
 func (s *Store) AdmitSegmentReservation(ctx context.Context, req *segment.SetupReq) (
 	base.MessageWithPath, error) {
 
-	if err := s.validateAuthenticators(&req.RequestMetadata); err != nil {
+	return s.admitSegmentReservation(ctx, req, req, nil)
+}
+
+// AdmitTelescopicSegmentReservation receives a setup/renewal request to admit a segment
+// reservation stacked on top of an existing base one. Both share the same ID prefix.
+// In the ASes where the base reservation exists with the same interfaces, the admission is
+// checked against the bandwidth of the base reservation instead of the link capacity.
+func (s *Store) AdmitTelescopicSegmentReservation(ctx context.Context,
+	req *segment.SetupTelesReq) (base.MessageWithPath, error) {
+
+	if req.ID.ASID != req.BaseID.ASID {
+		return nil, serrors.New("telescopic reservation does not share the ID prefix of its base",
+			"id", req.ID, "base_id", req.BaseID)
+	}
+	if req.ID == req.BaseID {
+		return nil, serrors.New("telescopic reservation cannot be its own base", "id", req.ID)
+	}
+	return s.admitSegmentReservation(ctx, req, &req.SetupReq, &req.BaseID)
+}
+
+// admitSegmentReservation admits a regular or telescopic segment setup/renewal request.
+// The message argument is the one received, and it is returned to be forwarded if admitted.
+// The baseID argument is non nil only for telescopic reservations.
+func (s *Store) admitSegmentReservation(ctx context.Context, msg base.MessageWithPath,
+	req *segment.SetupReq, baseID *reservation.SegmentID) (base.MessageWithPath, error) {
+
+	if err := s.validateAuthenticators(msg); err != nil {
 		return nil, serrors.WrapStr("error validating request", err, "id", req.ID)
 	}
 	if req.Path().IndexOfCurrentHop() != len(req.AllocTrail) {
@@ -66,6 +103,11 @@ func (s *Store) AdmitSegmentReservation(ctx context.Context, req *segment.SetupR
 		Response:    *response,
 		FailedSetup: req,
 	}
+	if req.InfoField.ExpirationTick.ToTime().Before(time.Now()) {
+		failedResponse.ErrorCode = reservation.ErrorExpired
+		return failedResponse, serrors.New("setup request already expired", "id", req.ID,
+			"expiration", req.InfoField.ExpirationTick.ToTime())
+	}
 
 	tx, err := s.db.BeginTransaction(ctx, nil)
 	if err != nil {
@@ -81,17 +123,36 @@ func (s *Store) AdmitSegmentReservation(ctx context.Context, req *segment.SetupR
 	}
 
 	if rsv != nil {
-		// renewal, ensure index is not used
+		// renewal, ensure the path is the same and the index is not used
+		if err := validateSegmentPath(rsv, &req.Request); err != nil {
+			return nil, err
+		}
 		index := rsv.Index(req.InfoField.Idx)
 		if index != nil {
 			return failedResponse, serrors.New("index from setup already in use",
 				"idx", req.InfoField.Idx, "id", req.ID)
 		}
+		if !sameBase(rsv.BaseID, baseID) {
+			failedResponse.ErrorCode = reservation.ErrorPolicyDenied
+			return failedResponse, serrors.New("renewal changes the base reservation",
+				"id", req.ID, "stored_base", rsv.BaseID, "requested_base", baseID)
+		}
 	} else {
 		// setup, create reservation and an index
 		rsv = segment.NewReservation()
 		rsv.ID = req.ID
-		err = tx.NewSegmentRsv(ctx, rsv)
+		rsv.Ingress = req.Ingress
+		rsv.Egress = req.Egress
+		rsv.PathType = req.InfoField.PathType
+		rsv.PathEndProps = req.PathProps
+		rsv.TrafficSplit = req.SplitCls
+		if baseID != nil {
+			id := *baseID
+			rsv.BaseID = &id
+		}
+		// the ID was chosen by the AS that started the reservation, and it is carried in the
+		// hop fields of the packets, so it must be kept.
+		err = tx.NewSegmentRsvWithID(ctx, rsv)
 		if err != nil {
 			return failedResponse, serrors.WrapStr(
 				"unable to create a new segment reservation in db", err,
@@ -102,25 +163,41 @@ func (s *Store) AdmitSegmentReservation(ctx context.Context, req *segment.SetupR
 	tok := &reservation.Token{InfoField: req.InfoField}
 	idx, err := rsv.NewIndexFromToken(tok, req.MinBW, req.MaxBW)
 	if err != nil {
+		failedResponse.ErrorCode = reservation.ErrorIndexLimitExceeded
 		return failedResponse, serrors.WrapStr("cannot create index from token", err,
 			"id", req.ID)
 	}
 	index := rsv.Index(idx)
+	index.Token = tok
 
 	// checkpath type compatibility with end properties
 	if err := rsv.PathEndProps.ValidateWithPathType(rsv.PathType); err != nil {
+		failedResponse.ErrorCode = reservation.ErrorPolicyDenied
 		return failedResponse, serrors.WrapStr("error validating end props and path type", err,
 			"id", req.ID)
 	}
 	// compute admission max BW
 	// TODO(juagargi) use the transaction also in the admitter
-	err = s.admitter.AdmitRsv(ctx, req)
+	err = s.admit(ctx, tx, req, baseID)
 	if err != nil {
+		failedResponse.ErrorCode = reservation.ErrorUnspecified
+		if errors.Is(err, admission.ErrAdmissionDenied) {
+			failedResponse.ErrorCode = reservation.ErrorInsufficientBW
+		}
 		return failedResponse, serrors.WrapStr("segment not admitted", err, "id", req.ID,
 			"index", req.Index)
 	}
 	// admitted; the request contains already the value inside the "allocation beads" of the rsv
 	index.AllocBW = req.AllocTrail[len(req.AllocTrail)-1].AllocBW
+	if req.IsLastAS() {
+		// the token carries the bandwidth granted by all ASes and the hop field of this AS.
+		// The rest of the hop fields are added by each AS on the reverse path.
+		tok.BWCls = minAllocBW(req.AllocTrail)
+		tok.HopFields = []reservation.HopField{
+			base.NewHopField(s.macFactory(), req.ID.ToRaw(), true, &tok.InfoField,
+				rsv.Ingress, rsv.Egress),
+		}
+	}
 
 	if err = tx.PersistSegmentRsv(ctx, rsv); err != nil {
 		return failedResponse, serrors.WrapStr("cannot persist segment reservation", err,
@@ -129,23 +206,83 @@ func (s *Store) AdmitSegmentReservation(ctx context.Context, req *segment.SetupR
 	if err := tx.Commit(); err != nil {
 		return failedResponse, serrors.WrapStr("cannot commit transaction", err, "id", req.ID)
 	}
+	s.trackUpdate(rsv)
 
 	if req.IsLastAS() {
-		// TODO(juagargi) update token here
 		return &segment.ResponseSetupSuccess{
 			Response: *morphSegmentResponseToSuccess(response),
 			Token:    *index.Token,
 		}, nil
 	}
 	// TODO(juagargi) refactor function
-	return req, nil
+	return msg, nil
+}
+
+// admit runs the admission for the request. If the request is telescopic and its base
+// reservation enters and leaves this AS through the same interfaces, the base bandwidth
+// is used as capacity. Otherwise the configured admitter decides.
+func (s *Store) admit(ctx context.Context, tx backend.Transaction, req *segment.SetupReq,
+	baseID *reservation.SegmentID) error {
+
+	if baseID == nil {
+		return s.admitter.AdmitRsv(ctx, req)
+	}
+	baseRsv, err := tx.GetSegmentRsvFromID(ctx, baseID)
+	if err != nil {
+		return serrors.WrapStr("cannot obtain base reservation", err, "base_id", baseID)
+	}
+	if baseRsv == nil || baseRsv.Ingress != req.Ingress || baseRsv.Egress != req.Egress {
+		// the telescopic reservation diverges from its base here
+		return s.admitter.AdmitRsv(ctx, req)
+	}
+	return admitOnBase(ctx, tx, req, baseRsv)
+}
+
+// ProcessSegmentSetupResponse adds the hop field of this AS to the token of a successful
+// segment setup response traveling in the reverse path, and stores the token in the index.
+func (s *Store) ProcessSegmentSetupResponse(ctx context.Context,
+	resp *segment.ResponseSetupSuccess) (base.MessageWithPath, error) {
+
+	tx, err := s.db.BeginTransaction(ctx, nil)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot create transaction", err, "id", resp.ID)
+	}
+	defer tx.Rollback()
+
+	rsv, err := tx.GetSegmentRsvFromID(ctx, &resp.ID)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot obtain segment reservation", err, "id", resp.ID)
+	}
+	if rsv == nil {
+		return nil, serrors.New("no segment reservation for response", "id", resp.ID)
+	}
+	index := rsv.Index(resp.Index)
+	if index == nil {
+		return nil, serrors.New("no index for response", "id", resp.ID, "idx", resp.Index)
+	}
+	hf := base.NewHopField(s.macFactory(), resp.ID.ToRaw(), true, &resp.Token.InfoField,
+		rsv.Ingress, rsv.Egress)
+	resp.Token.HopFields = append([]reservation.HopField{hf}, resp.Token.HopFields...)
+	tok := resp.Token
+	index.Token = &tok
+	index.AllocBW = tok.BWCls
+
+	if err := tx.PersistSegmentRsv(ctx, rsv); err != nil {
+		return nil, serrors.WrapStr("cannot persist segment reservation", err, "id", resp.ID)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, serrors.WrapStr("cannot commit transaction", err, "id", resp.ID)
+	}
+	s.trackUpdate(rsv)
+	return resp, nil
 }
 
 // ConfirmSegmentReservation changes the state of an index from temporary to confirmed.
+// If the request asks for the active state, the index is also activated.
 func (s *Store) ConfirmSegmentReservation(ctx context.Context, req *segment.IndexConfirmationReq) (
 	base.MessageWithPath, error) {
 
-	if err := s.validateAuthenticators(&req.RequestMetadata); err != nil {
+	if err := s.validateAuthenticators(req); err != nil {
 		return nil, serrors.WrapStr("error validating request", err, "id", req.ID)
 	}
 
@@ -154,8 +291,7 @@ func (s *Store) ConfirmSegmentReservation(ctx context.Context, req *segment.Inde
 		return nil, serrors.WrapStr("cannot construct response", err, "id", req.ID)
 	}
 	failedResponse := &segment.ResponseIndexConfirmationFailure{
-		Response:  *response,
-		ErrorCode: 1, // TODO(juagargi) specify error codes for every response
+		Response: *response,
 	}
 
 	tx, err := s.db.BeginTransaction(ctx, nil)
@@ -169,10 +305,29 @@ func (s *Store) ConfirmSegmentReservation(ctx context.Context, req *segment.Inde
 		return failedResponse, serrors.WrapStr("cannot obtain segment reservation", err,
 			"id", req.ID)
 	}
+	if rsv == nil || rsv.Index(req.Index) == nil {
+		failedResponse.ErrorCode = reservation.ErrorUnknownReservation
+		return failedResponse, serrors.New("segment reservation index not found", "id", req.ID,
+			"idx", req.Index)
+	}
+	if err := validateSegmentPath(rsv, &req.Request); err != nil {
+		return nil, err
+	}
+	if exp := rsv.Index(req.Index).Expiration; exp.Before(time.Now()) {
+		failedResponse.ErrorCode = reservation.ErrorExpired
+		return failedResponse, serrors.New("segment reservation index already expired",
+			"id", req.ID, "idx", req.Index, "expiration", exp)
+	}
 	if err := rsv.SetIndexConfirmed(req.Index); err != nil {
 		return failedResponse, serrors.WrapStr("cannot set index to confirmed", err,
 			"id", req.ID)
 	}
+	if req.State == segment.IndexActive {
+		if err := rsv.SetIndexActive(req.Index); err != nil {
+			return failedResponse, serrors.WrapStr("cannot activate index", err,
+				"id", req.ID)
+		}
+	}
 	if err = tx.PersistSegmentRsv(ctx, rsv); err != nil {
 		return failedResponse, serrors.WrapStr("cannot persist segment reservation", err,
 			"id", req.ID)
@@ -181,6 +336,7 @@ func (s *Store) ConfirmSegmentReservation(ctx context.Context, req *segment.Inde
 		return failedResponse, serrors.WrapStr("cannot commit transaction", err,
 			"id", req.ID)
 	}
+	s.trackUpdate(rsv)
 	if req.IsLastAS() {
 		return &segment.ResponseIndexConfirmationSuccess{
 			Response: *morphSegmentResponseToSuccess(response),
@@ -189,11 +345,12 @@ func (s *Store) ConfirmSegmentReservation(ctx context.Context, req *segment.Inde
 	return req, nil
 }
 
-// CleanupSegmentReservation deletes an index from a segment reservation.
+// CleanupSegmentReservation deletes an index whose setup failed from a segment reservation.
+// The previous indices, including the active one, are kept.
 func (s *Store) CleanupSegmentReservation(ctx context.Context, req *segment.CleanupReq) (
 	base.MessageWithPath, error) {
 
-	if err := s.validateAuthenticators(&req.RequestMetadata); err != nil {
+	if err := s.validateAuthenticators(req); err != nil {
 		return nil, serrors.WrapStr("error validating request", err, "id", req.ID)
 	}
 
@@ -202,8 +359,7 @@ func (s *Store) CleanupSegmentReservation(ctx context.Context, req *segment.Clea
 		return nil, serrors.WrapStr("cannot construct response", err, "id", req.ID)
 	}
 	failedResponse := &segment.ResponseCleanupFailure{
-		Response:  *response,
-		ErrorCode: 1,
+		Response: *response,
 	}
 
 	tx, err := s.db.BeginTransaction(ctx, nil)
@@ -217,7 +373,15 @@ func (s *Store) CleanupSegmentReservation(ctx context.Context, req *segment.Clea
 		return failedResponse, serrors.WrapStr("cannot obtain segment reservation", err,
 			"id", req.ID)
 	}
-	if err := rsv.RemoveIndex(req.Index); err != nil {
+	if rsv == nil {
+		failedResponse.ErrorCode = reservation.ErrorUnknownReservation
+		return failedResponse, serrors.New("segment reservation not found", "id", req.ID)
+	}
+	if err := validateSegmentPath(rsv, &req.Request); err != nil {
+		return nil, err
+	}
+	if err := rsv.RemoveTemporaryIndex(req.Index); err != nil {
+		failedResponse.ErrorCode = reservation.ErrorUnknownReservation
 		return failedResponse, serrors.WrapStr("cannot delete segment reservation index", err,
 			"id", req.ID, "index", req.Index)
 	}
@@ -229,6 +393,7 @@ func (s *Store) CleanupSegmentReservation(ctx context.Context, req *segment.Clea
 		return failedResponse, serrors.WrapStr("cannot commit transaction", err,
 			"id", req.ID)
 	}
+	s.trackUpdate(rsv)
 
 	if req.IsLastAS() {
 		return &segment.ResponseCleanupSuccess{
@@ -242,7 +407,7 @@ func (s *Store) CleanupSegmentReservation(ctx context.Context, req *segment.Clea
 func (s *Store) TearDownSegmentReservation(ctx context.Context, req *segment.TeardownReq) (
 	base.MessageWithPath, error) {
 
-	if err := s.validateAuthenticators(&req.RequestMetadata); err != nil {
+	if err := s.validateAuthenticators(req); err != nil {
 		return nil, serrors.WrapStr("error validating request", err, "id", req.ID)
 	}
 
@@ -251,8 +416,7 @@ func (s *Store) TearDownSegmentReservation(ctx context.Context, req *segment.Tea
 		return nil, serrors.WrapStr("cannot construct response", err, "id", req.ID)
 	}
 	failedResponse := &segment.ResponseTeardownFailure{
-		Response:  *response,
-		ErrorCode: 1,
+		Response: *response,
 	}
 
 	tx, err := s.db.BeginTransaction(ctx, nil)
@@ -261,6 +425,16 @@ func (s *Store) TearDownSegmentReservation(ctx context.Context, req *segment.Tea
 	}
 	defer tx.Rollback()
 
+	rsv, err := tx.GetSegmentRsvFromID(ctx, &req.ID)
+	if err != nil {
+		return failedResponse, serrors.WrapStr("cannot obtain segment reservation", err,
+			"id", req.ID)
+	}
+	if rsv != nil {
+		if err := validateSegmentPath(rsv, &req.Request); err != nil {
+			return nil, err
+		}
+	}
 	if err := tx.DeleteSegmentRsv(ctx, &req.ID); err != nil {
 		return failedResponse, serrors.WrapStr("cannot teardown reservation", err,
 			"id", req.ID)
@@ -269,6 +443,9 @@ func (s *Store) TearDownSegmentReservation(ctx context.Context, req *segment.Tea
 		return failedResponse, serrors.WrapStr("cannot commit transaction", err,
 			"id", req.ID)
 	}
+	if t, ok := s.admitter.(admission.ReservationTracker); ok {
+		t.RemoveRsv(&req.ID)
+	}
 
 	if req.IsLastAS() {
 		return &segment.ResponseTeardownSuccess{
@@ -283,7 +460,7 @@ func (s *Store) AdmitE2EReservation(ctx context.Context, request e2e.SetupReques
 	base.MessageWithPath, error) {
 
 	req := request.GetCommonSetupReq()
-	if err := s.validateAuthenticators(&req.RequestMetadata); err != nil {
+	if err := s.validateAuthenticators(request); err != nil {
 		return nil, serrors.WrapStr("error validating e2e request", err, "id", req.ID)
 	}
 
@@ -293,9 +470,8 @@ func (s *Store) AdmitE2EReservation(ctx context.Context, request e2e.SetupReques
 	}
 	var failedResponse base.MessageWithPath
 	failedResponse = &e2e.ResponseSetupFailure{
-		Response:  *response,
-		ErrorCode: 1,
-		MaxBWs:    req.AllocationTrail,
+		Response: *response,
+		MaxBWs:   req.AllocationTrail,
 	}
 
 	// sanity check: all successful requests are SetupReqSuccess. Failed ones are SetupReqFailure.
@@ -346,6 +522,16 @@ func (s *Store) AdmitE2EReservation(ctx context.Context, request e2e.SetupReques
 				return failedResponse, serrors.WrapStr("cannot get segment rsv for e2e admission",
 					err, "e2e_id", req.ID, "seg_id", id)
 			}
+			if r == nil {
+				setE2EErrorCode(failedResponse, reservation.ErrorUnknownReservation)
+				return failedResponse, serrors.New("segment rsv for e2e admission not found",
+					"e2e_id", req.ID, "seg_id", id)
+			}
+			if r.ActiveIndex() == nil {
+				setE2EErrorCode(failedResponse, reservation.ErrorExpired)
+				return failedResponse, serrors.New("segment rsv for e2e admission not active",
+					"e2e_id", req.ID, "seg_id", id)
+			}
 			rsv.SegmentReservations[i] = r
 		}
 	}
@@ -354,8 +540,18 @@ func (s *Store) AdmitE2EReservation(ctx context.Context, request e2e.SetupReques
 			"id", req.ID, "idx", req.Index)
 	}
 
-	idx, err := rsv.NewIndex(req.Timestamp)
+	expiration := req.Timestamp
+	if request.IsSuccessful() {
+		expiration = request.(*e2e.SetupReqSuccess).Token.ExpirationTick.ToTime()
+		if expiration.Before(time.Now()) {
+			setE2EErrorCode(failedResponse, reservation.ErrorExpired)
+			return failedResponse, serrors.New("e2e setup request already expired",
+				"e2e_id", req.ID, "expiration", expiration)
+		}
+	}
+	idx, err := rsv.NewIndex(expiration)
 	if err != nil {
+		setE2EErrorCode(failedResponse, reservation.ErrorIndexLimitExceeded)
 		return failedResponse, serrors.WrapStr("cannot create index in e2e admission", err,
 			"e2e_id", req.ID)
 	}
@@ -389,15 +585,22 @@ func (s *Store) AdmitE2EReservation(ctx context.Context, request e2e.SetupReques
 		}
 	}
 
+	// the failing AS is the first one in the trail not willing to grant the requested bw.
+	maxWillingToAlloc := bwClsNotAbove(free)
 	if !request.IsSuccessful() || req.RequestedBW.ToKbps() > free {
-		maxWillingToAlloc := reservation.BWClsFromBW(free)
+		code := reservation.ErrorInsufficientBW
+		if failed, ok := request.(*e2e.SetupReqFailure); ok {
+			code = failed.ErrorCode
+		}
 		if req.Location() == e2e.Destination {
 			asAResponse := failedResponse.(*e2e.ResponseSetupFailure)
 			asAResponse.MaxBWs = append(asAResponse.MaxBWs, maxWillingToAlloc)
+			asAResponse.ErrorCode = code
+			asAResponse.FailedHop = firstHopBelow(asAResponse.MaxBWs, req.RequestedBW)
 		} else {
 			asARequest := &e2e.SetupReqFailure{
 				SetupReq:  *req,
-				ErrorCode: 1,
+				ErrorCode: code,
 			}
 			asARequest.AllocationTrail = append(asARequest.AllocationTrail, maxWillingToAlloc)
 			failedResponse = asARequest
@@ -407,7 +610,18 @@ func (s *Store) AdmitE2EReservation(ctx context.Context, request e2e.SetupReques
 	}
 
 	// admitted so far
-	// TODO(juagargi) update token here
+	if req.Location() == e2e.Destination {
+		// the destination creates the token with its own hop field. The rest of the hop fields
+		// are added by each AS on the reverse path.
+		ingress, egress := e2eInterfaces(rsv)
+		tok := *index.Token
+		tok.BWCls = req.RequestedBW
+		tok.HopFields = []reservation.HopField{
+			base.NewHopField(s.macFactory(), req.ID.Suffix[:], false, &tok.InfoField,
+				ingress, egress),
+		}
+		index.Token = &tok
+	}
 	if err := tx.PersistE2ERsv(ctx, rsv); err != nil {
 		return failedResponse, serrors.WrapStr("cannot persist e2e reservation", err,
 			"id", req.ID)
@@ -425,19 +639,60 @@ func (s *Store) AdmitE2EReservation(ctx context.Context, request e2e.SetupReques
 			Token:    *index.Token,
 		}
 	} else {
-		msg = &e2e.SetupReqSuccess{
+		// the next AS finds its position in the path from the length of the trail.
+		success := &e2e.SetupReqSuccess{
 			SetupReq: *req,
 			Token:    *index.Token,
 		}
+		success.AllocationTrail = append(success.AllocationTrail, maxWillingToAlloc)
+		msg = success
 	}
 	return msg, nil
 }
 
+// ProcessE2ESetupResponse adds the hop field of this AS to the token of a successful e2e
+// setup response traveling in the reverse path, and stores the token in the index.
+func (s *Store) ProcessE2ESetupResponse(ctx context.Context, resp *e2e.ResponseSetupSuccess) (
+	base.MessageWithPath, error) {
+
+	tx, err := s.db.BeginTransaction(ctx, nil)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot create transaction", err, "id", resp.ID)
+	}
+	defer tx.Rollback()
+
+	rsv, err := tx.GetE2ERsvFromID(ctx, &resp.ID)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot obtain e2e reservation", err, "id", resp.ID)
+	}
+	if rsv == nil || len(rsv.SegmentReservations) == 0 {
+		return nil, serrors.New("no e2e reservation for response", "id", resp.ID)
+	}
+	index := rsv.Index(resp.Index)
+	if index == nil {
+		return nil, serrors.New("no index for response", "id", resp.ID, "idx", resp.Index)
+	}
+	ingress, egress := e2eInterfaces(rsv)
+	hf := base.NewHopField(s.macFactory(), resp.ID.Suffix[:], false, &resp.Token.InfoField,
+		ingress, egress)
+	resp.Token.HopFields = append([]reservation.HopField{hf}, resp.Token.HopFields...)
+	tok := resp.Token
+	index.Token = &tok
+
+	if err := tx.PersistE2ERsv(ctx, rsv); err != nil {
+		return nil, serrors.WrapStr("cannot persist e2e reservation", err, "id", resp.ID)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, serrors.WrapStr("cannot commit transaction", err, "id", resp.ID)
+	}
+	return resp, nil
+}
+
 // CleanupE2EReservation will remove an index from an e2e reservation.
 func (s *Store) CleanupE2EReservation(ctx context.Context, req *e2e.CleanupReq) (
 	base.MessageWithPath, error) {
 
-	if err := s.validateAuthenticators(&req.RequestMetadata); err != nil {
+	if err := s.validateAuthenticators(req); err != nil {
 		return nil, serrors.WrapStr("error validating request", err, "id", req.ID)
 	}
 
@@ -446,8 +701,7 @@ func (s *Store) CleanupE2EReservation(ctx context.Context, req *e2e.CleanupReq)
 		return nil, serrors.WrapStr("cannot construct response", err, "id", req.ID)
 	}
 	failedResponse := &e2e.ResponseCleanupFailure{
-		Response:  *response,
-		ErrorCode: 1,
+		Response: *response,
 	}
 
 	tx, err := s.db.BeginTransaction(ctx, nil)
@@ -461,7 +715,22 @@ func (s *Store) CleanupE2EReservation(ctx context.Context, req *e2e.CleanupReq)
 		return failedResponse, serrors.WrapStr("cannot obtain e2e reservation", err,
 			"id", req.ID)
 	}
+	if rsv == nil {
+		failedResponse.ErrorCode = reservation.ErrorUnknownReservation
+		return failedResponse, serrors.New("e2e reservation not found", "id", req.ID)
+	}
+	if len(rsv.SegmentReservations) > 0 {
+		ingress, egress := req.Path().IngressEgressIFIDs()
+		rsvIngress, rsvEgress := e2eInterfaces(rsv)
+		if ingress != rsvIngress || egress != rsvEgress {
+			return nil, serrors.WithCtx(reservationstorage.ErrInvalidAuthenticator,
+				"reason", "path differs from reservation", "id", req.ID,
+				"ingress", ingress, "egress", egress,
+				"rsv_ingress", rsvIngress, "rsv_egress", rsvEgress)
+		}
+	}
 	if err := rsv.RemoveIndex(req.Index); err != nil {
+		failedResponse.ErrorCode = reservation.ErrorUnknownReservation
 		return failedResponse, serrors.WrapStr("cannot delete e2e reservation index", err,
 			"id", req.ID, "index", req.Index)
 	}
@@ -483,15 +752,100 @@ func (s *Store) CleanupE2EReservation(ctx context.Context, req *e2e.CleanupReq)
 	return req, nil
 }
 
-// DeleteExpiredIndices will just call the DB's method to delete the expired indices.
+// ListStitchableSegments returns the segment reservations starting at src with an active index
+// that an e2e reservation to dst can use, i.e. all of them but the down reservations
+// ending at a different AS.
+func (s *Store) ListStitchableSegments(ctx context.Context, src, dst addr.IA) (
+	[]*segment.Reservation, error) {
+
+	rsvs, err := s.db.GetSegmentRsvsFromSrcDstIA(ctx, src, addr.IA{})
+	if err != nil {
+		return nil, serrors.WrapStr("cannot obtain segment reservations", err, "src", src)
+	}
+	stitchables := make([]*segment.Reservation, 0, len(rsvs))
+	for _, rsv := range rsvs {
+		if rsv.ActiveIndex() == nil {
+			continue
+		}
+		if rsv.PathType == reservation.DownPath && rsv.Path.GetDstIA() != dst {
+			continue
+		}
+		stitchables = append(stitchables, rsv)
+	}
+	return stitchables, nil
+}
+
+// ListSegmentReservations returns all the segment reservations stored in this AS.
+func (s *Store) ListSegmentReservations(ctx context.Context) ([]*segment.Reservation, error) {
+	rsvs, err := s.db.GetAllSegmentRsvs(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot obtain segment reservations", err)
+	}
+	return rsvs, nil
+}
+
+// ListE2EReservations returns all the e2e reservations stored in this AS.
+func (s *Store) ListE2EReservations(ctx context.Context) ([]*e2e.Reservation, error) {
+	rsvs, err := s.db.GetAllE2ERsvs(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot obtain e2e reservations", err)
+	}
+	return rsvs, nil
+}
+
+// DeleteExpiredIndices will call the DB's method to delete the expired indices, and remove
+// them also from the admission state.
 func (s *Store) DeleteExpiredIndices(ctx context.Context) (int, error) {
-	return s.db.DeleteExpiredIndices(ctx, time.Now())
+	now := time.Now()
+	n, err := s.db.DeleteExpiredIndices(ctx, now)
+	if err != nil {
+		return n, err
+	}
+	if t, ok := s.admitter.(admission.ReservationTracker); ok {
+		t.RemoveExpired(now)
+	}
+	return n, nil
+}
+
+// trackUpdate notifies the admission entity of a persisted segment reservation, if it
+// keeps its own state about them.
+func (s *Store) trackUpdate(rsv *segment.Reservation) {
+	if t, ok := s.admitter.(admission.ReservationTracker); ok {
+		t.UpdateRsv(rsv)
+	}
+}
+
+// validateAuthenticators checks that the authenticator for this AS is correct, and that the
+// request is recent and was not accepted before.
+func (s *Store) validateAuthenticators(msg base.MessageWithPath) error {
+	req, ok := msg.(base.AuthenticatedRequest)
+	if !ok {
+		return serrors.New("request cannot be authenticated")
+	}
+	now := time.Now()
+	if err := validateAuthenticator(s.drkeys, req, now); err != nil {
+		return err
+	}
+	current := req.Path().IndexOfCurrentHop()
+	if current == 0 {
+		return nil
+	}
+	if !s.replays.add(req.Authenticators()[current], requestTimestamp(req), now) {
+		return serrors.WithCtx(reservationstorage.ErrInvalidAuthenticator,
+			"reason", "replayed request")
+	}
+	return nil
 }
 
-// validateAuthenticators checks that the authenticators are correct.
-func (s *Store) validateAuthenticators(req *base.RequestMetadata) error {
-	// TODO(juagargi) validate request
-	// DRKey authentication of request (will be left undone for later)
+// validateSegmentPath checks that the request for an existing segment reservation traverses
+// this AS through the interfaces of the reservation.
+func validateSegmentPath(rsv *segment.Reservation, req *segment.Request) error {
+	if rsv.Ingress != req.Ingress || rsv.Egress != req.Egress {
+		return serrors.WithCtx(reservationstorage.ErrInvalidAuthenticator,
+			"reason", "path differs from reservation", "id", req.ID,
+			"ingress", req.Ingress, "egress", req.Egress,
+			"rsv_ingress", rsv.Ingress, "rsv_egress", rsv.Egress)
+	}
 	return nil
 }
 
@@ -527,6 +881,24 @@ func (s *Store) prepareFailureE2EResp(req *e2e.Request) (*e2e.Response, error) {
 	return response, nil
 }
 
+// setE2EErrorCode sets the error code of a failed e2e setup response.
+func setE2EErrorCode(msg base.MessageWithPath, code reservation.ErrorCode) {
+	if failure, ok := msg.(*e2e.ResponseSetupFailure); ok {
+		failure.ErrorCode = code
+	}
+}
+
+// firstHopBelow returns the index of the first AS in the trail not willing to grant the
+// requested bandwidth. It returns the last index if all of them were.
+func firstHopBelow(trail []reservation.BWCls, requested reservation.BWCls) uint8 {
+	for i, bw := range trail {
+		if bw < requested {
+			return uint8(i)
+		}
+	}
+	return uint8(len(trail) - 1)
+}
+
 func morphSegmentResponseToSuccess(resp *segment.Response) *segment.Response {
 	resp.Accepted = true
 	resp.FailedHop = 0
@@ -539,6 +911,79 @@ func morphE2EResponseToSuccess(resp *e2e.Response) *e2e.Response {
 	return resp
 }
 
+// admitOnBase admits a telescopic request using the allocated bandwidth of the active index
+// of the base reservation, shared among all the reservations stacked on top of it.
+func admitOnBase(ctx context.Context, tx backend.Transaction, req *segment.SetupReq,
+	baseRsv *segment.Reservation) error {
+
+	active := baseRsv.ActiveIndex()
+	if active == nil {
+		return serrors.New("base reservation has no active index", "base_id", baseRsv.ID)
+	}
+	stacked, err := tx.GetTelescopicSegmentRsvs(ctx, &baseRsv.ID)
+	if err != nil {
+		return serrors.WrapStr("cannot obtain reservations on base", err,
+			"base_id", baseRsv.ID)
+	}
+	var blocked uint64
+	for _, rsv := range stacked {
+		if rsv.ID != req.ID {
+			blocked += rsv.MaxBlockedBW()
+		}
+	}
+	var maxAlloc reservation.BWCls
+	if baseBW := active.AllocBW.ToKbps(); baseBW > blocked {
+		maxAlloc = bwClsNotAbove(baseBW - blocked)
+	}
+	bead := reservation.AllocationBead{
+		AllocBW: reservation.MinBWCls(maxAlloc, req.MaxBW),
+		MaxBW:   maxAlloc,
+	}
+	req.AllocTrail = append(req.AllocTrail, bead)
+	if maxAlloc < req.MinBW {
+		return serrors.WithCtx(admission.ErrAdmissionDenied, "maxalloc", maxAlloc,
+			"minbw", req.MinBW, "segment_id", req.ID, "base_id", baseRsv.ID)
+	}
+	return nil
+}
+
+// bwClsNotAbove returns the largest bandwidth class that does not exceed the bandwidth.
+func bwClsNotAbove(bwKbps uint64) reservation.BWCls {
+	if bwKbps == 0 {
+		return 0
+	}
+	cls := reservation.BWClsFromBW(bwKbps)
+	for cls > 0 && cls.ToKbps() > bwKbps {
+		cls--
+	}
+	return cls
+}
+
+func sameBase(a, b *reservation.SegmentID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// minAllocBW returns the bandwidth class granted by all the ASes in the allocation trail.
+func minAllocBW(beads reservation.AllocationBeads) reservation.BWCls {
+	var min reservation.BWCls = math.MaxUint8
+	for _, b := range beads {
+		if b.AllocBW < min {
+			min = b.AllocBW
+		}
+	}
+	return min
+}
+
+// e2eInterfaces returns the interfaces used by the e2e reservation in this AS: it enters
+// through the first segment reservation and leaves through the last one.
+func e2eInterfaces(rsv *e2e.Reservation) (uint16, uint16) {
+	return rsv.SegmentReservations[0].Ingress,
+		rsv.SegmentReservations[len(rsv.SegmentReservations)-1].Egress
+}
+
 func sumAllBW(rsvs []*e2e.Reservation) uint64 {
 	var accum uint64
 	for _, r := range rsvs {
@@ -550,6 +995,9 @@ func sumAllBW(rsvs []*e2e.Reservation) uint64 {
 func freeInSegRsv(ctx context.Context, tx backend.Transaction, segRsv *segment.Reservation) (
 	uint64, error) {
 
+	if segRsv.ActiveIndex() == nil {
+		return 0, nil
+	}
 	rsvs, err := tx.GetE2ERsvsOnSegRsv(ctx, &segRsv.ID)
 	if err != nil {
 		return 0, serrors.WrapStr("cannot obtain e2e reservations to compute free bw",
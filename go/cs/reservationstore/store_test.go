@@ -15,12 +15,563 @@
 package reservationstore
 
 import (
+	"context"
+	"errors"
+	"hash"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
+	"github.com/scionproto/scion/go/cs/reservation/sqlite"
+	"github.com/scionproto/scion/go/cs/reservation/test"
 	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend/mock_backend"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkeystorage/mock_drkeystorage"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
 )
 
 func TestStore(t *testing.T) {
 	var s reservationstorage.Store = &Store{}
 	_ = s
 }
+
+func TestProcessSegmentSetupResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	id, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("beefcafe"))
+	require.NoError(t, err)
+	rsv := segment.NewReservation()
+	rsv.ID = *id
+	rsv.Ingress = 3
+	rsv.Egress = 4
+	tok := newTestToken()
+	_, err = rsv.NewIndexFromToken(&tok, 1, 13)
+	require.NoError(t, err)
+	resp, err := segment.NewResponse(util.SecsToTime(1), id, tok.Idx, test.NewTestPath(),
+		true, 0)
+	require.NoError(t, err)
+	last := reservation.HopField{Ingress: 5, Egress: 0}
+	tok.BWCls = 9
+	tok.HopFields = []reservation.HopField{last}
+
+	tx := mock_backend.NewMockTransaction(ctrl)
+	tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), id).Return(rsv, nil)
+	tx.EXPECT().PersistSegmentRsv(gomock.Any(), rsv).DoAndReturn(
+		func(_ context.Context, rsv *segment.Reservation) error {
+			index := rsv.Index(tok.Idx)
+			require.Len(t, index.Token.HopFields, 2)
+			require.Equal(t, reservation.BWCls(9), index.AllocBW)
+			return nil
+		})
+	tx.EXPECT().Commit()
+	tx.EXPECT().Rollback()
+	db := mock_backend.NewMockDB(ctrl)
+	db.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(tx, nil)
+
+	s := NewStore(db, nil, nil, newTestMacFactory(t))
+	msg, err := s.ProcessSegmentSetupResponse(context.Background(),
+		&segment.ResponseSetupSuccess{Response: *resp, Token: tok})
+	require.NoError(t, err)
+	require.IsType(t, &segment.ResponseSetupSuccess{}, msg)
+	newTok := msg.(*segment.ResponseSetupSuccess).Token
+	require.Len(t, newTok.HopFields, 2)
+	require.Equal(t, last, newTok.HopFields[1])
+	checkHopField(t, id.ToRaw(), true, &newTok, 3, 4)
+}
+
+func TestProcessE2ESetupResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	id, err := reservation.NewE2EID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("0123456789abcdef0123"))
+	require.NoError(t, err)
+	up := segment.NewReservation()
+	up.Ingress = 3
+	core := segment.NewReservation()
+	core.Egress = 4
+	rsv := &e2e.Reservation{
+		ID:                  *id,
+		SegmentReservations: []*segment.Reservation{up, core},
+	}
+	idx, err := rsv.NewIndex(util.SecsToTime(1))
+	require.NoError(t, err)
+	resp, err := e2e.NewResponse(util.SecsToTime(1), id, idx, test.NewTestPath(), true, 0)
+	require.NoError(t, err)
+	tok := newTestToken()
+	tok.HopFields = []reservation.HopField{{Ingress: 5, Egress: 0}}
+
+	tx := mock_backend.NewMockTransaction(ctrl)
+	tx.EXPECT().GetE2ERsvFromID(gomock.Any(), id).Return(rsv, nil)
+	tx.EXPECT().PersistE2ERsv(gomock.Any(), rsv).DoAndReturn(
+		func(_ context.Context, rsv *e2e.Reservation) error {
+			require.Len(t, rsv.Index(idx).Token.HopFields, 2)
+			return nil
+		})
+	tx.EXPECT().Commit()
+	tx.EXPECT().Rollback()
+	db := mock_backend.NewMockDB(ctrl)
+	db.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(tx, nil)
+
+	s := NewStore(db, nil, nil, newTestMacFactory(t))
+	msg, err := s.ProcessE2ESetupResponse(context.Background(),
+		&e2e.ResponseSetupSuccess{Response: *resp, Token: tok})
+	require.NoError(t, err)
+	require.IsType(t, &e2e.ResponseSetupSuccess{}, msg)
+	newTok := msg.(*e2e.ResponseSetupSuccess).Token
+	require.Len(t, newTok.HopFields, 2)
+	// the e2e reservation enters through the up segment and leaves through the core one.
+	checkHopField(t, id.Suffix[:], false, &newTok, 3, 4)
+}
+
+func TestSegmentSetupTwoASes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	ias := []addr.IA{xtest.MustParseIA("1-ff00:0:1"), xtest.MustParseIA("1-ff00:0:2")}
+	// the ID chosen by the initiator, not the one the DB of the other AS would allocate.
+	id, err := reservation.NewSegmentID(ias[0].A, xtest.MustParseHexString("beefcafe"))
+	require.NoError(t, err)
+	lvl1 := drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{SrcIA: ias[1], DstIA: ias[0]},
+		Key:      drkey.DRKey(xtest.MustParseHexString("0123456789abcdef0123456789abcdef")),
+	}
+	initiatorKeys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	initiatorKeys.EXPECT().GetLvl1Key(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		lvl1, nil).AnyTimes()
+	transitKeys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	transitKeys.EXPECT().DeriveLvl1Keys(ias[0], gomock.Any()).Return(
+		[]drkey.Lvl1Key{lvl1}, nil).AnyTimes()
+	macFactories := []func() hash.Hash{
+		newMacFactory(t, "testkey_AS1_xxxx"),
+		newMacFactory(t, "testkey_AS2_xxxx"),
+	}
+	stores := []*Store{
+		NewStore(newSQLiteDB(t), &grantingAdmitter{}, initiatorKeys, macFactories[0]),
+		NewStore(newSQLiteDB(t), &grantingAdmitter{}, transitKeys, macFactories[1]),
+	}
+	path := &test.TestColibriPath{HopCount: 2, IAs: ias, Ingress: 0, Egress: 1}
+
+	r, err := segment.NewRequest(time.Now(), id, 1, path)
+	require.NoError(t, err)
+	setup := &segment.SetupReq{
+		Request: *r,
+		InfoField: reservation.InfoField{
+			ExpirationTick: reservation.TickFromTime(time.Now().Add(time.Hour)),
+			BWCls:          5,
+			RLC:            3,
+			Idx:            1,
+			PathType:       reservation.UpPath,
+		},
+		MinBW:     2,
+		MaxBW:     5,
+		SplitCls:  4,
+		PathProps: reservation.StartLocal | reservation.EndLocal,
+	}
+	require.NoError(t, ComputeAuthenticators(ctx, initiatorKeys, setup))
+	_, err = stores[0].AdmitSegmentReservation(ctx, setup)
+	require.NoError(t, err)
+	path.CurrentHop, path.Ingress, path.Egress = 1, 1, 0
+	setup.Ingress, setup.Egress = path.IngressEgressIFIDs()
+	msg, err := stores[1].AdmitSegmentReservation(ctx, setup)
+	require.NoError(t, err)
+	require.IsType(t, &segment.ResponseSetupSuccess{}, msg)
+	resp := msg.(*segment.ResponseSetupSuccess)
+	path.CurrentHop = 0
+	msg, err = stores[0].ProcessSegmentSetupResponse(ctx, resp)
+	require.NoError(t, err)
+	tok := msg.(*segment.ResponseSetupSuccess).Token
+	require.Len(t, tok.HopFields, 2)
+
+	// the hop field of every AS is computed over the suffix carried in the packets.
+	p := base.DataPlanePath(id.ToRaw(), true, &tok)
+	for i, macFactory := range macFactories {
+		require.NoError(t, colibri.VerifyMAC(macFactory(), &p.InfoField, p.HopFields[i]),
+			"hop %d", i)
+	}
+
+	r, err = segment.NewRequest(time.Now(), id, 1, path)
+	require.NoError(t, err)
+	confirm := &segment.IndexConfirmationReq{Request: *r, State: segment.IndexActive}
+	require.NoError(t, ComputeAuthenticators(ctx, initiatorKeys, confirm))
+	ifids := [][2]uint16{{0, 1}, {1, 0}}
+	for i, s := range stores {
+		// each AS handles the request with its own interfaces.
+		path.CurrentHop = i
+		confirm.Ingress, confirm.Egress = ifids[i][0], ifids[i][1]
+		_, err := s.ConfirmSegmentReservation(ctx, confirm)
+		require.NoError(t, err, "AS %d", i)
+		rsv, err := s.db.GetSegmentRsvFromID(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, rsv, "AS %d", i)
+		require.NotNil(t, rsv.ActiveIndex(), "AS %d", i)
+	}
+}
+
+func TestRequestsForOtherReservations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	owner := xtest.MustParseIA("1-ff00:0:1")
+	local := xtest.MustParseIA("1-ff00:0:2")
+	other := xtest.MustParseIA("1-ff00:0:3")
+	lvl1Keys := map[addr.IA]drkey.Lvl1Key{
+		owner: {
+			Lvl1Meta: drkey.Lvl1Meta{SrcIA: local, DstIA: owner},
+			Key:      drkey.DRKey(xtest.MustParseHexString("0123456789abcdef0123456789abcdef")),
+		},
+		other: {
+			Lvl1Meta: drkey.Lvl1Meta{SrcIA: local, DstIA: other},
+			Key:      drkey.DRKey(xtest.MustParseHexString("fedcba9876543210fedcba9876543210")),
+		},
+	}
+	initiatorKeys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	initiatorKeys.EXPECT().GetLvl1Key(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, meta drkey.Lvl1Meta, _ time.Time) (drkey.Lvl1Key, error) {
+			return lvl1Keys[meta.DstIA], nil
+		}).AnyTimes()
+	localKeys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	localKeys.EXPECT().DeriveLvl1Keys(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ia addr.IA, _ time.Time) ([]drkey.Lvl1Key, error) {
+			return []drkey.Lvl1Key{lvl1Keys[ia]}, nil
+		}).AnyTimes()
+
+	// the reservation of the owner enters the local AS through interface 1.
+	id, err := reservation.NewSegmentID(owner.A, xtest.MustParseHexString("beefcafe"))
+	require.NoError(t, err)
+	rsv := segment.NewReservation()
+	rsv.ID = *id
+	rsv.Ingress = 1
+	rsv.PathType = reservation.UpPath
+	tok := newTestToken()
+	tok.ExpirationTick = reservation.TickFromTime(time.Now().Add(time.Hour))
+	_, err = rsv.NewIndexFromToken(&tok, 1, 13)
+	require.NoError(t, err)
+	db := newSQLiteDB(t)
+	require.NoError(t, db.NewSegmentRsvWithID(ctx, rsv))
+	s := NewStore(db, &grantingAdmitter{}, localKeys, newTestMacFactory(t))
+
+	// newRequest returns a request for the reservation sent by the initiator to the local AS,
+	// entering it through the ingress interface.
+	newRequest := func(initiator addr.IA, ingress uint16) *segment.Request {
+		path := &test.TestColibriPath{
+			HopCount: 2,
+			IAs:      []addr.IA{initiator, local},
+			IFIDs:    [][2]uint16{{0, ingress}, {ingress, 0}},
+			Ingress:  ingress,
+		}
+		r, err := segment.NewRequest(time.Now(), id, tok.Idx, path)
+		require.NoError(t, err)
+		return r
+	}
+	authenticate := func(req base.AuthenticatedRequest) {
+		require.NoError(t, ComputeAuthenticators(ctx, initiatorKeys, req))
+		req.Path().(*test.TestColibriPath).CurrentHop = 1
+	}
+	requireStored := func() {
+		stored, err := db.GetSegmentRsvFromID(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+	}
+
+	// another AS cannot remove the reservation, even with its own valid authenticators.
+	teardown := &segment.TeardownReq{Request: *newRequest(other, 1)}
+	authenticate(teardown)
+	_, err = s.TearDownSegmentReservation(ctx, teardown)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+	requireStored()
+	cleanup := &segment.CleanupReq{Request: *newRequest(other, 1)}
+	authenticate(cleanup)
+	_, err = s.CleanupSegmentReservation(ctx, cleanup)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+	requireStored()
+	// nor can the owner through another path.
+	teardown = &segment.TeardownReq{Request: *newRequest(owner, 2)}
+	authenticate(teardown)
+	_, err = s.TearDownSegmentReservation(ctx, teardown)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+	requireStored()
+
+	teardown = &segment.TeardownReq{Request: *newRequest(owner, 1)}
+	authenticate(teardown)
+	_, err = s.TearDownSegmentReservation(ctx, teardown)
+	require.NoError(t, err)
+	stored, err := db.GetSegmentRsvFromID(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+	// the same request is accepted only once.
+	_, err = s.TearDownSegmentReservation(ctx, teardown)
+	require.True(t, errors.Is(err, reservationstorage.ErrInvalidAuthenticator), err)
+}
+
+func TestAdmitSegmentReservationErrorCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	ias := []addr.IA{xtest.MustParseIA("1-ff00:0:1"), xtest.MustParseIA("1-ff00:0:2")}
+	keys := mock_drkeystorage.NewMockServiceStore(ctrl)
+	keys.EXPECT().GetLvl1Key(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		drkey.Lvl1Key{Key: drkey.DRKey(xtest.MustParseHexString(
+			"0123456789abcdef0123456789abcdef"))}, nil).AnyTimes()
+	path := &test.TestColibriPath{HopCount: 2, IAs: ias, Ingress: 0, Egress: 1}
+
+	cases := map[string]struct {
+		admitErr error
+		code     reservation.ErrorCode
+	}{
+		"denied": {
+			admitErr: serrors.WithCtx(admission.ErrAdmissionDenied, "maxalloc", 1),
+			code:     reservation.ErrorInsufficientBW,
+		},
+		"internal failure": {
+			admitErr: serrors.New("cannot list all reservations"),
+			code:     reservation.ErrorUnspecified,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id, err := reservation.NewSegmentID(ias[0].A, xtest.MustParseHexString("beefcafe"))
+			require.NoError(t, err)
+			r, err := segment.NewRequest(time.Now(), id, 1, path)
+			require.NoError(t, err)
+			setup := &segment.SetupReq{
+				Request: *r,
+				InfoField: reservation.InfoField{
+					ExpirationTick: reservation.TickFromTime(time.Now().Add(time.Hour)),
+					BWCls:          5,
+					RLC:            3,
+					Idx:            1,
+					PathType:       reservation.UpPath,
+				},
+				MinBW:     2,
+				MaxBW:     5,
+				SplitCls:  4,
+				PathProps: reservation.StartLocal | reservation.EndLocal,
+			}
+			require.NoError(t, ComputeAuthenticators(ctx, keys, setup))
+			s := NewStore(newSQLiteDB(t), failingAdmitter{err: tc.admitErr}, keys,
+				newMacFactory(t, "testkey_AS1_xxxx"))
+			msg, err := s.AdmitSegmentReservation(ctx, setup)
+			require.Error(t, err)
+			require.IsType(t, &segment.ResponseSetupFailure{}, msg)
+			require.Equal(t, tc.code, msg.(*segment.ResponseSetupFailure).ErrorCode)
+		})
+	}
+}
+
+func TestDeleteExpiredIndicesTracked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var dbNow time.Time
+	db := mock_backend.NewMockDB(ctrl)
+	db.EXPECT().DeleteExpiredIndices(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, now time.Time) (int, error) {
+			dbNow = now
+			return 1, nil
+		})
+	tracker := &testTracker{}
+	s := NewStore(db, tracker, nil, nil)
+	n, err := s.DeleteExpiredIndices(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	// the admission state expires the same indices as the DB
+	require.Equal(t, dbNow, tracker.expired)
+}
+
+func TestAdmitTelescopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	baseID, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("00000001"))
+	require.NoError(t, err)
+	baseRsv := segment.NewReservation()
+	baseRsv.ID = *baseID
+	baseRsv.Ingress = 1
+	baseRsv.Egress = 2
+	tok := newTestToken() // allocates BW class 13
+	_, err = baseRsv.NewIndexFromToken(&tok, 1, 13)
+	require.NoError(t, err)
+	require.NoError(t, baseRsv.SetIndexConfirmed(tok.Idx))
+	require.NoError(t, baseRsv.SetIndexActive(tok.Idx))
+	// another reservation on the same base, blocking half of the base bandwidth
+	other := segment.NewReservation()
+	other.ID = *baseID
+	other.ID.Suffix[3] = 2
+	other.BaseID = baseID
+	tok.BWCls = 11
+	_, err = other.NewIndexFromToken(&tok, 1, 11)
+	require.NoError(t, err)
+
+	newReq := func(minBW, maxBW reservation.BWCls) *segment.SetupReq {
+		req := &segment.SetupReq{MinBW: minBW, MaxBW: maxBW}
+		req.ID = *baseID
+		req.ID.Suffix[3] = 3
+		req.Ingress = 1
+		req.Egress = 2
+		return req
+	}
+	admitter := &testTracker{}
+	s := NewStore(nil, admitter, nil, nil)
+	ctx := context.Background()
+
+	// base not in this AS: regular admission
+	tx := mock_backend.NewMockTransaction(ctrl)
+	tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), baseID).Return(nil, nil)
+	err = s.admit(ctx, tx, newReq(1, 13), baseID)
+	require.NoError(t, err)
+	require.Equal(t, 1, admitter.admitted)
+	// diverging from the base in this AS: regular admission
+	baseRsv.Egress = 3
+	tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), baseID).Return(baseRsv, nil)
+	err = s.admit(ctx, tx, newReq(1, 13), baseID)
+	require.NoError(t, err)
+	require.Equal(t, 2, admitter.admitted)
+	baseRsv.Egress = 2
+	// on top of the base: only what the other reservation doesn't block is available
+	tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), baseID).Return(baseRsv, nil).Times(2)
+	tx.EXPECT().GetTelescopicSegmentRsvs(gomock.Any(), baseID).Return(
+		[]*segment.Reservation{other}, nil).Times(2)
+	req := newReq(1, 13)
+	err = s.admit(ctx, tx, req, baseID)
+	require.NoError(t, err)
+	require.Equal(t, 2, admitter.admitted)
+	require.Len(t, req.AllocTrail, 1)
+	require.Equal(t, reservation.BWCls(11), req.AllocTrail[0].MaxBW)
+	require.Equal(t, reservation.BWCls(11), req.AllocTrail[0].AllocBW)
+	req = newReq(12, 13)
+	err = s.admit(ctx, tx, req, baseID)
+	require.True(t, errors.Is(err, admission.ErrAdmissionDenied), err)
+	require.Len(t, req.AllocTrail, 1)
+	// the base must be active
+	tok.Idx++
+	notActive := segment.NewReservation()
+	notActive.ID = *baseID
+	notActive.Ingress = 1
+	notActive.Egress = 2
+	_, err = notActive.NewIndexFromToken(&tok, 1, 13)
+	require.NoError(t, err)
+	tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), baseID).Return(notActive, nil)
+	err = s.admit(ctx, tx, newReq(1, 13), baseID)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, admission.ErrAdmissionDenied), err)
+}
+
+func TestAdmitTelescopicSegmentReservationPrefix(t *testing.T) {
+	s := NewStore(nil, nil, nil, nil)
+	req := &segment.SetupTelesReq{}
+	req.ID.ASID = xtest.MustParseAS("ff00:0:1")
+	req.BaseID.ASID = xtest.MustParseAS("ff00:0:2")
+	_, err := s.AdmitTelescopicSegmentReservation(context.Background(), req)
+	require.Error(t, err)
+	req.BaseID = req.ID
+	_, err = s.AdmitTelescopicSegmentReservation(context.Background(), req)
+	require.Error(t, err)
+}
+
+func TestBWClsNotAbove(t *testing.T) {
+	require.Equal(t, reservation.BWCls(0), bwClsNotAbove(0))
+	for cls := reservation.BWCls(1); cls < 63; cls++ {
+		require.Equal(t, cls, bwClsNotAbove(cls.ToKbps()), cls)
+		require.Equal(t, cls, bwClsNotAbove(cls.ToKbps()+1), cls)
+		require.Equal(t, cls-1, bwClsNotAbove(cls.ToKbps()-1), cls)
+	}
+}
+
+func TestFirstHopBelow(t *testing.T) {
+	trail := []reservation.BWCls{13, 11, 9}
+	require.Equal(t, uint8(1), firstHopBelow(trail, 12))
+	require.Equal(t, uint8(2), firstHopBelow(trail, 10))
+	// the request failed in the last AS without any trail value below the requested one
+	require.Equal(t, uint8(2), firstHopBelow(trail, 9))
+}
+
+// testTracker is an admitter that records the notifications from the store.
+type testTracker struct {
+	expired  time.Time
+	admitted int
+}
+
+var _ admission.ReservationTracker = (*testTracker)(nil)
+
+func (tr *testTracker) AdmitRsv(context.Context, *segment.SetupReq) error {
+	tr.admitted++
+	return nil
+}
+func (*testTracker) UpdateRsv(*segment.Reservation)   {}
+func (*testTracker) RemoveRsv(*reservation.SegmentID) {}
+func (tr *testTracker) RemoveExpired(now time.Time)   { tr.expired = now }
+
+// grantingAdmitter grants the maximum requested bandwidth.
+type grantingAdmitter struct{}
+
+func (grantingAdmitter) AdmitRsv(_ context.Context, req *segment.SetupReq) error {
+	req.AllocTrail = append(req.AllocTrail, reservation.AllocationBead{
+		AllocBW: req.MaxBW,
+		MaxBW:   req.MaxBW,
+	})
+	return nil
+}
+
+// failingAdmitter fails the admission of all requests with err.
+type failingAdmitter struct {
+	err error
+}
+
+func (a failingAdmitter) AdmitRsv(context.Context, *segment.SetupReq) error {
+	return a.err
+}
+
+func newSQLiteDB(t *testing.T) backend.DB {
+	db, err := sqlite.New("file::memory:")
+	require.NoError(t, err)
+	return db
+}
+
+func newTestToken() reservation.Token {
+	return reservation.Token{
+		InfoField: reservation.InfoField{
+			ExpirationTick: 12,
+			BWCls:          13,
+			RLC:            4,
+			Idx:            2,
+			PathType:       reservation.CorePath,
+		},
+	}
+}
+
+func newTestMacFactory(t *testing.T) func() hash.Hash {
+	return newMacFactory(t, "testkey_xxxxxxxx")
+}
+
+func newMacFactory(t *testing.T, key string) func() hash.Hash {
+	return func() hash.Hash {
+		mac, err := scrypto.InitMac([]byte(key))
+		require.NoError(t, err)
+		return mac
+	}
+}
+
+// checkHopField checks that the first hop field of the token belongs to this AS.
+func checkHopField(t *testing.T, suffix []byte, segment bool, tok *reservation.Token,
+	ingress, egress uint16) {
+
+	p := base.DataPlanePath(suffix, segment, tok)
+	require.Equal(t, ingress, p.HopFields[0].IngressID)
+	require.Equal(t, egress, p.HopFields[0].EgressID)
+	require.NoError(t, colibri.VerifyMAC(newTestMacFactory(t)(), &p.InfoField,
+		p.HopFields[0]))
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -92,6 +93,15 @@ func (id *SegmentID) String() string {
 	return fmt.Sprintf("%s-%x", id.ASID, id.Suffix)
 }
 
+// SegmentIDFromString parses a segment ID in the format returned by String.
+func SegmentIDFromString(s string) (*SegmentID, error) {
+	as, suffix, err := idFromString(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewSegmentID(as, suffix)
+}
+
 // E2EID identifies a COLIBRI E2E reservation. The suffix is different for each
 // reservation for any given AS.
 type E2EID struct {
@@ -113,6 +123,15 @@ func NewE2EID(AS addr.AS, suffix []byte) (*E2EID, error) {
 	return &id, nil
 }
 
+// E2EIDFromString parses an E2E ID in the format returned by String.
+func E2EIDFromString(s string) (*E2EID, error) {
+	as, suffix, err := idFromString(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewE2EID(as, suffix)
+}
+
 // E2EIDFromRawBuffers constructs a E2DID from two separate buffers.
 func E2EIDFromRawBuffers(ASID, suffix []byte) (*E2EID, error) {
 	if len(ASID) < 6 || len(suffix) < 10 {
@@ -150,6 +169,27 @@ func (id *E2EID) ToRaw() []byte {
 	return buf
 }
 
+func (id *E2EID) String() string {
+	return fmt.Sprintf("%s-%x", id.ASID, id.Suffix)
+}
+
+// idFromString splits a reservation ID in its AS and suffix parts.
+func idFromString(s string) (addr.AS, []byte, error) {
+	i := strings.LastIndex(s, "-")
+	if i < 0 {
+		return 0, nil, serrors.New("reservation ID without suffix", "id", s)
+	}
+	as, err := addr.ASFromString(s[:i])
+	if err != nil {
+		return 0, nil, serrors.WrapStr("parsing reservation ID AS", err, "id", s)
+	}
+	suffix, err := hex.DecodeString(s[i+1:])
+	if err != nil {
+		return 0, nil, serrors.WrapStr("parsing reservation ID suffix", err, "id", s)
+	}
+	return as, suffix, nil
+}
+
 // Tick represents a slice of time of 4 seconds.
 type Tick uint32
 
@@ -261,6 +301,25 @@ func (pt PathType) Validate() error {
 	return nil
 }
 
+func (pt PathType) String() string {
+	switch pt {
+	case DownPath:
+		return "down"
+	case UpPath:
+		return "up"
+	case PeeringDownPath:
+		return "peering down"
+	case PeeringUpPath:
+		return "peering up"
+	case E2EPath:
+		return "e2e"
+	case CorePath:
+		return "core"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(pt))
+	}
+}
+
 // InfoField is used in the reservation token and segment request data.
 // 0B       1        2        3        4        5        6        7
 // +--------+--------+--------+--------+--------+--------+--------+--------+
@@ -455,8 +514,8 @@ func (bs AllocationBeads) MinMax() BWCls {
 	return min
 }
 
-// HopField is a COLIBRI HopField.
-// TODO(juagargi) move to slayers
+// HopField is a COLIBRI HopField. Its serialization is identical to the one of the HopField
+// in the COLIBRI data plane path type, see slayers/path/colibri.
 type HopField struct {
 	Ingress uint16
 	Egress  uint16
@@ -582,3 +641,58 @@ func (t *Token) ToRaw() []byte {
 	}
 	return buff
 }
+
+// ErrorCode identifies why an AS rejected a COLIBRI request.
+type ErrorCode uint8
+
+const (
+	// ErrorUnspecified is used for failures not covered by any other code.
+	ErrorUnspecified ErrorCode = iota
+	// ErrorInsufficientBW means the AS cannot grant the requested bandwidth.
+	ErrorInsufficientBW
+	// ErrorInvalidAuthenticator means the request authenticator for the AS is not valid.
+	ErrorInvalidAuthenticator
+	// ErrorUnknownReservation means the AS does not know the reservation or its index.
+	ErrorUnknownReservation
+	// ErrorIndexLimitExceeded means no more indices can be added to the reservation.
+	ErrorIndexLimitExceeded
+	// ErrorExpired means the request refers to an expired reservation or index.
+	ErrorExpired
+	// ErrorPolicyDenied means the request is not allowed by the policies of the AS.
+	ErrorPolicyDenied
+)
+
+func (c ErrorCode) String() string {
+	switch c {
+	case ErrorUnspecified:
+		return "unspecified"
+	case ErrorInsufficientBW:
+		return "insufficient bandwidth"
+	case ErrorInvalidAuthenticator:
+		return "invalid authenticator"
+	case ErrorUnknownReservation:
+		return "unknown reservation"
+	case ErrorIndexLimitExceeded:
+		return "index limit exceeded"
+	case ErrorExpired:
+		return "expired"
+	case ErrorPolicyDenied:
+		return "policy denied"
+	default:
+		return fmt.Sprintf("unknown error code %d", uint8(c))
+	}
+}
+
+// Failure describes the rejection of a COLIBRI request by an AS in the reservation path.
+// It is also an error, so that it can be returned to the initiator of the request.
+type Failure struct {
+	Code      ErrorCode
+	FailedHop uint8   // index of the failing AS in the reservation path
+	FailedIA  addr.IA // the failing AS
+	MaxBW     BWCls   // the maximum bandwidth the failing AS could have granted
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("COLIBRI request rejected at hop %d (%s): %s, max bw class %d",
+		f.FailedHop, f.FailedIA, f.Code, f.MaxBW)
+}
@@ -61,6 +61,23 @@ func TestSegmentIDString(t *testing.T) {
 	}
 }
 
+func TestIDFromString(t *testing.T) {
+	segID, err := SegmentIDFromString("ff00:0:1101-facecafe")
+	require.NoError(t, err)
+	require.Equal(t, mustParseSegmentID("ff0000001101facecafe"), *segID)
+	e2eID, err := E2EIDFromString("ffaa:0:1101-facecafedeadbeeff00d")
+	require.NoError(t, err)
+	require.Equal(t, "ffaa:0:1101-facecafedeadbeeff00d", e2eID.String())
+	for _, s := range []string{"", "ff00:0:1101", "ff00:0:1101-cafe", "ff00:0:1101-nothex!",
+		"1-ff00:0:1101-facecafe"} {
+
+		_, err := SegmentIDFromString(s)
+		require.Error(t, err, s)
+	}
+	_, err = E2EIDFromString("ff00:0:1101-facecafe")
+	require.Error(t, err)
+}
+
 func TestE2EIDFromRaw(t *testing.T) {
 	raw := xtest.MustParseHexString("ffaa00001101facecafedeadbeeff00d")
 	id, err := E2EIDFromRaw(raw)
@@ -457,6 +474,12 @@ func TestTokenToRaw(t *testing.T) {
 	require.Equal(t, raw, tok.ToRaw())
 }
 
+func TestErrorCodeString(t *testing.T) {
+	require.Equal(t, "insufficient bandwidth", ErrorInsufficientBW.String())
+	require.Equal(t, "policy denied", ErrorPolicyDenied.String())
+	require.Equal(t, "unknown error code 42", ErrorCode(42).String())
+}
+
 func newInfoField() InfoField {
 	return InfoField{
 		ExpirationTick: 384555855,
@@ -0,0 +1,102 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	dkpb "github.com/scionproto/scion/go/pkg/proto/drkey"
+)
+
+// DSReq represents a delegation secret request from an endhost to a CS.
+type DSReq struct {
+	Protocol string
+	ValTime  time.Time
+	SrcIA    addr.IA
+	DstIA    addr.IA
+}
+
+// DSReqToProtoRequest parses the DSReq to a protobuf DSRequest.
+func DSReqToProtoRequest(req DSReq) (*dkpb.DRKeyDSRequest, error) {
+	valTime, err := ptypes.TimestampProto(req.ValTime)
+	if err != nil {
+		return nil, err
+	}
+	return &dkpb.DRKeyDSRequest{
+		Protocol: req.Protocol,
+		ValTime:  valTime,
+		SrcIa:    uint64(req.SrcIA.IAInt()),
+		DstIa:    uint64(req.DstIA.IAInt()),
+	}, nil
+}
+
+// RequestToDSReq parses the protobuf DSRequest to a DSReq.
+func RequestToDSReq(req *dkpb.DRKeyDSRequest) (DSReq, error) {
+	valTime, err := ptypes.Timestamp(req.ValTime)
+	if err != nil {
+		return DSReq{}, err
+	}
+	return DSReq{
+		Protocol: req.Protocol,
+		ValTime:  valTime,
+		SrcIA:    addr.IAInt(req.SrcIa).IA(),
+		DstIA:    addr.IAInt(req.DstIa).IA(),
+	}, nil
+}
+
+// DSToDSResp builds a DSResponse provided a given delegation secret.
+func DSToDSResp(ds drkey.DelegationSecret) (*dkpb.DRKeyDSResponse, error) {
+	epochBegin, err := ptypes.TimestampProto(ds.Epoch.NotBefore)
+	if err != nil {
+		return nil, err
+	}
+	epochEnd, err := ptypes.TimestampProto(ds.Epoch.NotAfter)
+	if err != nil {
+		return nil, err
+	}
+	now, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &dkpb.DRKeyDSResponse{
+		EpochBegin: epochBegin,
+		EpochEnd:   epochEnd,
+		Ds:         []byte(ds.Key),
+		Timestamp:  now,
+	}, nil
+}
+
+// GetDSFromReply extracts the delegation secret from the reply.
+func GetDSFromReply(rep *dkpb.DRKeyDSResponse, req DSReq) (drkey.DelegationSecret, error) {
+	epochBegin, err := ptypes.Timestamp(rep.EpochBegin)
+	if err != nil {
+		return drkey.DelegationSecret{}, err
+	}
+	epochEnd, err := ptypes.Timestamp(rep.EpochEnd)
+	if err != nil {
+		return drkey.DelegationSecret{}, err
+	}
+	return drkey.DelegationSecret{
+		Protocol: req.Protocol,
+		Epoch:    drkey.NewEpoch(uint32(epochBegin.Unix()), uint32(epochEnd.Unix())),
+		SrcIA:    req.SrcIA,
+		DstIA:    req.DstIA,
+		Key:      drkey.DRKey(rep.Ds),
+	}, nil
+}
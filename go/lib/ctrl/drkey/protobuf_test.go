@@ -227,3 +227,36 @@ func TestKeyToLvl2Resp(t *testing.T) {
 	targetResp.Timestamp = resp.Timestamp
 	assert.Equal(t, targetResp, resp)
 }
+
+func TestDSRequestRoundTrip(t *testing.T) {
+	req := ctrl.DSReq{
+		Protocol: "piskes",
+		ValTime:  util.SecsToTime(10).UTC(),
+		SrcIA:    xtest.MustParseIA("1-ff00:0:1"),
+		DstIA:    xtest.MustParseIA("1-ff00:0:2"),
+	}
+	pbReq, err := ctrl.DSReqToProtoRequest(req)
+	require.NoError(t, err)
+	parsed, err := ctrl.RequestToDSReq(pbReq)
+	require.NoError(t, err)
+	assert.Equal(t, req, parsed)
+}
+
+func TestDSRespRoundTrip(t *testing.T) {
+	ds := drkey.DelegationSecret{
+		Protocol: "piskes",
+		Epoch:    drkey.NewEpoch(0, 1),
+		SrcIA:    xtest.MustParseIA("1-ff00:0:1"),
+		DstIA:    xtest.MustParseIA("1-ff00:0:2"),
+		Key:      xtest.MustParseHexString("47bfbb7d94706dc9e79825e5a837b006"),
+	}
+	rep, err := ctrl.DSToDSResp(ds)
+	require.NoError(t, err)
+	parsed, err := ctrl.GetDSFromReply(rep, ctrl.DSReq{
+		Protocol: ds.Protocol,
+		SrcIA:    ds.SrcIA,
+		DstIA:    ds.DstIA,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ds, parsed)
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	// DefaultEpochDuration is the default duration of the secret values and derived keys.
+	DefaultEpochDuration = 24 * time.Hour
+	// DefaultGracePeriod is the default time after the end of an epoch during which its keys
+	// are still accepted.
+	DefaultGracePeriod = 5 * time.Minute
+)
+
+// EpochConfig configures the epochs of the secret values derived from the AS master key.
+// The end of the epoch is part of the derivation, so all the services deriving secret values
+// from the same master key, i.e. the control service and the routers, must be configured
+// with the same values. Otherwise their keys will not match.
+type EpochConfig struct {
+	// EpochDuration is the duration of the secret values and derived keys.
+	EpochDuration util.DurWrap `toml:"epoch_duration,omitempty"`
+	// GracePeriod is the overlap of consecutive epochs, during which the keys of both epochs
+	// are valid. It is nil if not configured, as zero disables the overlap.
+	GracePeriod *util.DurWrap `toml:"grace_period,omitempty"`
+}
+
+// InitDefaults initializes values of unset keys.
+func (cfg *EpochConfig) InitDefaults() {
+	if cfg.EpochDuration.Duration == 0 {
+		cfg.EpochDuration.Duration = DefaultEpochDuration
+	}
+	if cfg.GracePeriod == nil {
+		cfg.GracePeriod = &util.DurWrap{Duration: DefaultGracePeriod}
+	}
+}
+
+// Validate validates that the grace period is shorter than the epochs, which last at least
+// one second.
+func (cfg *EpochConfig) Validate() error {
+	if cfg.EpochDuration.Duration < time.Second {
+		return serrors.New("epoch_duration must be at least 1s",
+			"epoch_duration", cfg.EpochDuration)
+	}
+	if grace := cfg.Grace(); grace < 0 || grace >= cfg.EpochDuration.Duration {
+		return serrors.New("grace_period must be shorter than epoch_duration",
+			"grace_period", grace, "epoch_duration", cfg.EpochDuration)
+	}
+	return nil
+}
+
+// Grace returns the configured grace period, or the default one if not configured.
+func (cfg *EpochConfig) Grace() time.Duration {
+	if cfg.GracePeriod == nil {
+		return DefaultGracePeriod
+	}
+	return cfg.GracePeriod.Duration
+}
@@ -29,6 +29,8 @@ type BaseDB interface {
 }
 
 // Lvl1DB is the drkey database interface for level 1.
+// The epochs of consecutive keys can overlap; the getters return the newest key valid at
+// valTime.
 type Lvl1DB interface {
 	BaseDB
 	GetLvl1Key(ctx context.Context, key Lvl1Meta, valTime uint32) (Lvl1Key, error)
@@ -39,6 +41,8 @@ type Lvl1DB interface {
 }
 
 // Lvl2DB is the drkey database interface for level 2.
+// The epochs of consecutive keys can overlap; the getters return the newest key valid at
+// valTime.
 type Lvl2DB interface {
 	BaseDB
 	GetLvl2Key(ctx context.Context, key Lvl2Meta, valTime uint32) (Lvl2Key, error)
@@ -0,0 +1,352 @@
+// Copyright 2019 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dbtest contains the test suite that all the implementations of the DRKey databases
+// must pass.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	timeOffset = 10 * 60 // 10 minutes
+)
+
+var (
+	// DefaultTimeout is the default timeout for running the test harness.
+	DefaultTimeout = 5 * time.Second
+
+	asMasterPassword = []byte("0123456789012345")
+	rawSrcIA         = []byte{0xF0, 0x11, 0xF2, 0x33, 0x44, 0x55, 0x66, 0x77}
+	rawDstIA         = []byte{0xF0, 0x11, 0xF2, 0x33, 0x44, 0x55, 0x66, 0x88}
+	srcHostIP        = net.IPv4(192, 168, 1, 37)
+	dstHostIP        = net.IPv4(192, 168, 1, 38)
+)
+
+// TestableLvl1DB extends the level 1 DB interface with methods that are needed for testing.
+type TestableLvl1DB interface {
+	drkey.Lvl1DB
+	// Prepare should reset the internal state so that the db is empty and is ready to be tested.
+	Prepare(*testing.T, context.Context)
+}
+
+// TestableLvl2DB extends the level 2 DB interface with methods that are needed for testing.
+type TestableLvl2DB interface {
+	drkey.Lvl2DB
+	// Prepare should reset the internal state so that the db is empty and is ready to be tested.
+	Prepare(*testing.T, context.Context)
+}
+
+// RunLvl1 should be used to test any implementation of the drkey.Lvl1DB interface.
+func RunLvl1(t *testing.T, db TestableLvl1DB) {
+	tests := map[string]func(*testing.T, context.Context, drkey.Lvl1DB){
+		"insert and remove":  testLvl1,
+		"overlapping epochs": testLvl1OverlappingEpochs,
+		"mentioned src ASes": testGetMentionedASes,
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancelF := context.WithTimeout(context.Background(), DefaultTimeout)
+			defer cancelF()
+			db.Prepare(t, ctx)
+			test(t, ctx, db)
+		})
+	}
+}
+
+// RunLvl2 should be used to test any implementation of the drkey.Lvl2DB interface.
+func RunLvl2(t *testing.T, db TestableLvl2DB) {
+	tests := map[string]func(*testing.T, context.Context, drkey.Lvl2DB){
+		"insert and remove":  testLvl2,
+		"overlapping epochs": testLvl2OverlappingEpochs,
+		"updated epoch end":  testLvl2UpdatedEpochEnd,
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancelF := context.WithTimeout(context.Background(), DefaultTimeout)
+			defer cancelF()
+			db.Prepare(t, ctx)
+			test(t, ctx, db)
+		})
+	}
+}
+
+func testLvl1(t *testing.T, ctx context.Context, db drkey.Lvl1DB) {
+	epoch := drkey.Epoch{
+		Validity: cppki.Validity{
+			NotBefore: time.Now(),
+			NotAfter:  time.Now().Add(timeOffset * time.Second),
+		},
+	}
+	sv, err := drkey.DeriveSV(drkey.SVMeta{Epoch: epoch}, asMasterPassword)
+	require.NoError(t, err)
+
+	drkeyLvl1, err := protocol.DeriveLvl1(drkey.Lvl1Meta{
+		Epoch: epoch,
+		SrcIA: addr.IAFromRaw(rawSrcIA),
+		DstIA: addr.IAFromRaw(rawDstIA)}, sv)
+	require.NoError(t, err)
+
+	err = db.InsertLvl1Key(ctx, drkeyLvl1)
+	require.NoError(t, err)
+	// same key again. It should be okay.
+	err = db.InsertLvl1Key(ctx, drkeyLvl1)
+	require.NoError(t, err)
+
+	newKey, err := db.GetLvl1Key(ctx, drkeyLvl1.Lvl1Meta, util.TimeToSecs(time.Now()))
+	require.NoError(t, err)
+	require.Equal(t, drkeyLvl1.Key, newKey.Key)
+
+	_, err = db.GetLvl1Key(ctx, drkeyLvl1.Lvl1Meta,
+		util.TimeToSecs(time.Now().Add(2*timeOffset*time.Second)))
+	require.Equal(t, sql.ErrNoRows, err)
+
+	rows, err := db.RemoveOutdatedLvl1Keys(ctx,
+		util.TimeToSecs(time.Now().Add(-timeOffset*time.Second)))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, rows)
+
+	rows, err = db.RemoveOutdatedLvl1Keys(ctx,
+		util.TimeToSecs(time.Now().Add(2*timeOffset*time.Second)))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, rows)
+}
+
+func testLvl2(t *testing.T, ctx context.Context, db drkey.Lvl2DB) {
+	srcIA := addr.IAFromRaw(rawSrcIA)
+	dstIA := addr.IAFromRaw(rawDstIA)
+	epoch := drkey.Epoch{
+		Validity: cppki.Validity{
+			NotBefore: time.Now(),
+			NotAfter:  time.Now().Add(timeOffset * time.Second),
+		},
+	}
+	sv, err := drkey.DeriveSV(drkey.SVMeta{Epoch: epoch}, asMasterPassword)
+	require.NoError(t, err)
+	drkeyLvl1, err := protocol.DeriveLvl1(drkey.Lvl1Meta{
+		Epoch: epoch,
+		SrcIA: srcIA,
+		DstIA: dstIA,
+	}, sv)
+	require.NoError(t, err)
+
+	standardImpl := protocol.Standard{}
+	drkeyLvl2, err := standardImpl.DeriveLvl2(drkey.Lvl2Meta{
+		KeyType:  drkey.Host2Host,
+		Protocol: "test",
+		Epoch:    epoch,
+		SrcIA:    srcIA,
+		DstIA:    dstIA,
+		SrcHost:  addr.HostFromIP(srcHostIP),
+		DstHost:  addr.HostFromIP(dstHostIP),
+	}, drkeyLvl1)
+	require.NoError(t, err)
+
+	err = db.InsertLvl2Key(ctx, drkeyLvl2)
+	require.NoError(t, err)
+	err = db.InsertLvl2Key(ctx, drkeyLvl2)
+	require.NoError(t, err)
+
+	newKey, err := db.GetLvl2Key(ctx, drkeyLvl2.Lvl2Meta, util.TimeToSecs(time.Now()))
+	require.NoError(t, err)
+	require.Equal(t, drkeyLvl2.Key, newKey.Key)
+
+	otherHost := drkeyLvl2.Lvl2Meta
+	otherHost.DstHost = addr.HostFromIP(srcHostIP)
+	_, err = db.GetLvl2Key(ctx, otherHost, util.TimeToSecs(time.Now()))
+	require.Equal(t, sql.ErrNoRows, err)
+
+	rows, err := db.RemoveOutdatedLvl2Keys(ctx,
+		util.TimeToSecs(time.Now().Add(-timeOffset*time.Second)))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, rows)
+
+	rows, err = db.RemoveOutdatedLvl2Keys(ctx,
+		util.TimeToSecs(time.Now().Add(2*timeOffset*time.Second)))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, rows)
+}
+
+func testLvl1OverlappingEpochs(t *testing.T, ctx context.Context, db drkey.Lvl1DB) {
+	// the epochs [0,15) and [10,25) overlap in [10,15)
+	keys := make([]drkey.Lvl1Key, 2)
+	for i := range keys {
+		epoch := drkey.NewEpoch(uint32(i*10), uint32(i*10+15))
+		sv, err := drkey.DeriveSV(drkey.SVMeta{Epoch: epoch}, asMasterPassword)
+		require.NoError(t, err)
+		keys[i], err = protocol.DeriveLvl1(drkey.Lvl1Meta{
+			Epoch: epoch,
+			SrcIA: addr.IAFromRaw(rawSrcIA),
+			DstIA: addr.IAFromRaw(rawDstIA)}, sv)
+		require.NoError(t, err)
+		err = db.InsertLvl1Key(ctx, keys[i])
+		require.NoError(t, err)
+	}
+	testCases := map[string]struct {
+		valTime  uint32
+		expected drkey.Lvl1Key
+	}{
+		"only previous": {valTime: 9, expected: keys[0]},
+		"overlap":       {valTime: 12, expected: keys[1]},
+		"only current":  {valTime: 20, expected: keys[1]},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			k, err := db.GetLvl1Key(ctx, keys[0].Lvl1Meta, tc.valTime)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.Key, k.Key)
+			require.True(t, tc.expected.Epoch.Equal(k.Epoch))
+		})
+	}
+}
+
+func testGetMentionedASes(t *testing.T, ctx context.Context, db drkey.Lvl1DB) {
+	pairsL1 := [][]interface{}{
+		{"1-ff00:0:111", "1-ff00:0:112", 1},
+		{"1-ff00:0:111", "1-ff00:0:110", 10},
+		{"2-ff00:0:211", "1-ff00:0:113", 1},
+	}
+	for _, p := range pairsL1 {
+		srcIA, _ := addr.IAFromString(p[0].(string))
+		dstIA, _ := addr.IAFromString(p[1].(string))
+		begin := time.Unix(0, 0)
+		epoch := drkey.Epoch{
+			Validity: cppki.Validity{
+				NotBefore: begin,
+				NotAfter:  begin.Add(time.Duration(p[2].(int)) * time.Second),
+			},
+		}
+		sv, err := drkey.DeriveSV(drkey.SVMeta{Epoch: epoch}, asMasterPassword)
+		require.NoError(t, err)
+
+		key, err := protocol.DeriveLvl1(drkey.Lvl1Meta{
+			Epoch: epoch,
+			SrcIA: srcIA,
+			DstIA: dstIA,
+		}, sv)
+		require.NoError(t, err)
+
+		err = db.InsertLvl1Key(ctx, key)
+		require.NoError(t, err)
+	}
+
+	list, err := db.GetLvl1SrcASes(ctx)
+	require.NoError(t, err)
+
+	expected := []addr.IA{
+		ia("1-ff00:0:111"),
+		ia("2-ff00:0:211"),
+	}
+
+	require.Equal(t, expected, list)
+
+	list, err = db.GetValidLvl1SrcASes(ctx, 3)
+	require.NoError(t, err)
+
+	expected = []addr.IA{
+		ia("1-ff00:0:111"),
+	}
+	require.Equal(t, expected, list)
+}
+
+func testLvl2OverlappingEpochs(t *testing.T, ctx context.Context, db drkey.Lvl2DB) {
+	// the epochs [0,15) and [10,25) overlap in [10,15)
+	keys := make([]drkey.Lvl2Key, 2)
+	for i := range keys {
+		keys[i] = drkey.Lvl2Key{
+			Lvl2Meta: drkey.Lvl2Meta{
+				KeyType:  drkey.AS2Host,
+				Protocol: "test",
+				Epoch:    drkey.NewEpoch(uint32(i*10), uint32(i*10+15)),
+				SrcIA:    addr.IAFromRaw(rawSrcIA),
+				DstIA:    addr.IAFromRaw(rawDstIA),
+				SrcHost:  addr.HostNone{},
+				DstHost:  addr.HostFromIP(dstHostIP),
+			},
+			Key: drkey.DRKey{byte(i), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		}
+		require.NoError(t, db.InsertLvl2Key(ctx, keys[i]))
+	}
+	testCases := map[string]struct {
+		valTime  uint32
+		expected drkey.Lvl2Key
+	}{
+		"only previous": {valTime: 9, expected: keys[0]},
+		"overlap":       {valTime: 12, expected: keys[1]},
+		"only current":  {valTime: 20, expected: keys[1]},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			k, err := db.GetLvl2Key(ctx, keys[0].Lvl2Meta, tc.valTime)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.Key, k.Key)
+			require.True(t, tc.expected.Epoch.Equal(k.Epoch))
+		})
+	}
+}
+
+func testLvl2UpdatedEpochEnd(t *testing.T, ctx context.Context, db drkey.Lvl2DB) {
+	// a key whose end is limited when handed out is fetched again with another end.
+	key := drkey.Lvl2Key{
+		Lvl2Meta: drkey.Lvl2Meta{
+			KeyType:  drkey.AS2Host,
+			Protocol: "test",
+			Epoch:    drkey.NewEpoch(0, 10),
+			SrcIA:    addr.IAFromRaw(rawSrcIA),
+			DstIA:    addr.IAFromRaw(rawDstIA),
+			SrcHost:  addr.HostNone{},
+			DstHost:  addr.HostFromIP(dstHostIP),
+		},
+		Key: drkey.DRKey{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	}
+	require.NoError(t, db.InsertLvl2Key(ctx, key))
+	_, err := db.GetLvl2Key(ctx, key.Lvl2Meta, 15)
+	require.Equal(t, sql.ErrNoRows, err)
+
+	key.Epoch = drkey.NewEpoch(0, 20)
+	require.NoError(t, db.InsertLvl2Key(ctx, key))
+	k, err := db.GetLvl2Key(ctx, key.Lvl2Meta, 15)
+	require.NoError(t, err)
+	require.True(t, key.Epoch.Equal(k.Epoch))
+
+	key.Epoch = drkey.NewEpoch(0, 12)
+	require.NoError(t, db.InsertLvl2Key(ctx, key))
+	_, err = db.GetLvl2Key(ctx, key.Lvl2Meta, 15)
+	require.Equal(t, sql.ErrNoRows, err)
+
+	rows, err := db.RemoveOutdatedLvl2Keys(ctx, 12)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, rows)
+}
+
+func ia(iaStr string) addr.IA {
+	ia, err := addr.IAFromString(iaStr)
+	if err != nil {
+		panic("Invalid value")
+	}
+	return ia
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkeydbmem_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/dbtest"
+	"github.com/scionproto/scion/go/lib/drkey/drkeydbmem"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+type testLvl1DB struct {
+	*drkeydbmem.Lvl1Backend
+}
+
+func (b *testLvl1DB) Prepare(*testing.T, context.Context) {
+	b.Lvl1Backend = drkeydbmem.NewLvl1Backend(0)
+}
+
+type testLvl2DB struct {
+	*drkeydbmem.Lvl2Backend
+}
+
+func (b *testLvl2DB) Prepare(*testing.T, context.Context) {
+	b.Lvl2Backend = drkeydbmem.NewLvl2Backend(0)
+}
+
+func TestLvl1DB(t *testing.T) {
+	dbtest.RunLvl1(t, &testLvl1DB{})
+}
+
+func TestLvl2DB(t *testing.T) {
+	dbtest.RunLvl2(t, &testLvl2DB{})
+}
+
+func TestEviction(t *testing.T) {
+	ctx := context.Background()
+	db := drkeydbmem.NewLvl1Backend(3)
+	newKey := func(src string, begin, end uint32) drkey.Lvl1Key {
+		return drkey.Lvl1Key{
+			Lvl1Meta: drkey.Lvl1Meta{
+				Epoch: drkey.NewEpoch(begin, end),
+				SrcIA: xtest.MustParseIA(src),
+				DstIA: xtest.MustParseIA("1-ff00:0:1"),
+			},
+			Key: xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+		}
+	}
+	keys := []drkey.Lvl1Key{
+		newKey("1-ff00:0:2", 0, 30),
+		newKey("1-ff00:0:3", 0, 10),
+		newKey("1-ff00:0:4", 0, 20),
+	}
+	for _, k := range keys {
+		require.NoError(t, db.InsertLvl1Key(ctx, k))
+	}
+	// the key expiring first is evicted to make room for the new one
+	require.NoError(t, db.InsertLvl1Key(ctx, newKey("1-ff00:0:5", 0, 40)))
+	_, err := db.GetLvl1Key(ctx, keys[1].Lvl1Meta, 5)
+	require.Error(t, err)
+	ases, err := db.GetLvl1SrcASes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []addr.IA{
+		xtest.MustParseIA("1-ff00:0:2"),
+		xtest.MustParseIA("1-ff00:0:4"),
+		xtest.MustParseIA("1-ff00:0:5"),
+	}, ases)
+
+	n, err := db.RemoveOutdatedLvl1Keys(ctx, 30)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, n)
+	ases, err = db.GetLvl1SrcASes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []addr.IA{xtest.MustParseIA("1-ff00:0:5")}, ases)
+}
+
+// TestConcurrentReplace checks that keys can be read while they are replaced. Run it with the
+// race detector.
+func TestConcurrentReplace(t *testing.T) {
+	ctx := context.Background()
+	db := drkeydbmem.NewLvl2Backend(0)
+	newKey := func(end uint32) drkey.Lvl2Key {
+		return drkey.Lvl2Key{
+			Lvl2Meta: drkey.Lvl2Meta{
+				KeyType:  drkey.AS2AS,
+				Protocol: "scmp",
+				Epoch:    drkey.NewEpoch(0, end),
+				SrcIA:    xtest.MustParseIA("1-ff00:0:2"),
+				DstIA:    xtest.MustParseIA("1-ff00:0:1"),
+			},
+			Key: xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+		}
+	}
+	require.NoError(t, db.InsertLvl2Key(ctx, newKey(20)))
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			// the same key, fetched again with another epoch end
+			assert.NoError(t, db.InsertLvl2Key(ctx, newKey(uint32(20+i%2))))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			k, err := db.GetLvl2Key(ctx, newKey(20).Lvl2Meta, 5)
+			assert.NoError(t, err)
+			assert.EqualValues(t, 0, k.Epoch.NotBefore.Unix())
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkeydbmem
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+)
+
+var _ drkey.Lvl1DB = (*Lvl1Backend)(nil)
+
+type lvl1ID struct {
+	srcIA addr.IA
+	dstIA addr.IA
+}
+
+// Lvl1Backend implements a level 1 drkey DB in memory.
+type Lvl1Backend struct {
+	baseBackend
+	keys *keyStore
+}
+
+// NewLvl1Backend creates a database that holds at most maxEntries keys. If maxEntries is not
+// positive, DefaultMaxEntries is used.
+func NewLvl1Backend(maxEntries int) *Lvl1Backend {
+	return &Lvl1Backend{
+		keys: newKeyStore(maxEntries),
+	}
+}
+
+// GetLvl1Key returns the first level DRKey valid at valTime. If the epochs of several keys
+// overlap at that time, the key of the newest epoch is returned. If there is no such key,
+// sql.ErrNoRows is returned.
+func (b *Lvl1Backend) GetLvl1Key(_ context.Context, key drkey.Lvl1Meta,
+	valTime uint32) (drkey.Lvl1Key, error) {
+
+	v, ok := b.keys.get(lvl1ID{srcIA: key.SrcIA, dstIA: key.DstIA}, valTime)
+	if !ok {
+		return drkey.Lvl1Key{}, sql.ErrNoRows
+	}
+	return v.(drkey.Lvl1Key), nil
+}
+
+// InsertLvl1Key inserts a first level DRKey, unless a key for the same epoch exists.
+func (b *Lvl1Backend) InsertLvl1Key(_ context.Context, key drkey.Lvl1Key) error {
+	b.keys.insert(lvl1ID{srcIA: key.SrcIA, dstIA: key.DstIA},
+		uint32(key.Epoch.NotBefore.Unix()), uint32(key.Epoch.NotAfter.Unix()), key, false)
+	return nil
+}
+
+// RemoveOutdatedLvl1Keys removes the first level DRKeys whose epoch ends at or before the
+// cutoff.
+func (b *Lvl1Backend) RemoveOutdatedLvl1Keys(_ context.Context, cutoff uint32) (int64, error) {
+	return b.keys.removeOutdated(cutoff), nil
+}
+
+// GetLvl1SrcASes returns a list of distinct ASes seen in the SRC of a level 1 key.
+func (b *Lvl1Backend) GetLvl1SrcASes(_ context.Context) ([]addr.IA, error) {
+	return b.srcASes(func(*entry) bool { return true }), nil
+}
+
+// GetValidLvl1SrcASes returns a list of distinct IAs that have a still valid level 1 key
+// If the level 1 key is still valid according to valTime, its src IA will be in the list
+func (b *Lvl1Backend) GetValidLvl1SrcASes(_ context.Context, valTime uint32) ([]addr.IA,
+	error) {
+
+	return b.srcASes(func(e *entry) bool {
+		return e.begin <= valTime && valTime < e.end
+	}), nil
+}
+
+func (b *Lvl1Backend) srcASes(filter func(*entry) bool) []addr.IA {
+	seen := make(map[addr.IA]struct{})
+	ases := []addr.IA{}
+	b.keys.forEach(func(e *entry) {
+		srcIA := e.id.(lvl1ID).srcIA
+		if _, ok := seen[srcIA]; ok || !filter(e) {
+			return
+		}
+		seen[srcIA] = struct{}{}
+		ases = append(ases, srcIA)
+	})
+	sort.Slice(ases, func(i, j int) bool { return ases[i].IAInt() < ases[j].IAInt() })
+	return ases
+}
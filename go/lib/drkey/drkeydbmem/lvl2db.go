@@ -0,0 +1,95 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkeydbmem
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+)
+
+var _ drkey.Lvl2DB = (*Lvl2Backend)(nil)
+
+type lvl2ID struct {
+	protocol string
+	keyType  drkey.Lvl2KeyType
+	srcIA    addr.IA
+	dstIA    addr.IA
+	srcHost  string
+	dstHost  string
+}
+
+func newLvl2ID(meta drkey.Lvl2Meta) lvl2ID {
+	return lvl2ID{
+		protocol: meta.Protocol,
+		keyType:  meta.KeyType,
+		srcIA:    meta.SrcIA,
+		dstIA:    meta.DstIA,
+		srcHost:  hostID(meta.SrcHost),
+		dstHost:  hostID(meta.DstHost),
+	}
+}
+
+// hostID returns a comparable representation of the host.
+func hostID(host addr.HostAddr) string {
+	if host == nil {
+		host = addr.HostNone{}
+	}
+	return string(append([]byte{byte(host.Type())}, host.Pack()...))
+}
+
+// Lvl2Backend implements a level 2 drkey DB in memory.
+type Lvl2Backend struct {
+	baseBackend
+	keys *keyStore
+}
+
+// NewLvl2Backend creates a database that holds at most maxEntries keys. If maxEntries is not
+// positive, DefaultMaxEntries is used.
+func NewLvl2Backend(maxEntries int) *Lvl2Backend {
+	return &Lvl2Backend{
+		keys: newKeyStore(maxEntries),
+	}
+}
+
+// GetLvl2Key returns the second level DRKey valid at valTime. If the epochs of several keys
+// overlap at that time, the key of the newest epoch is returned. If there is no such key,
+// sql.ErrNoRows is returned.
+func (b *Lvl2Backend) GetLvl2Key(_ context.Context, key drkey.Lvl2Meta,
+	valTime uint32) (drkey.Lvl2Key, error) {
+
+	v, ok := b.keys.get(newLvl2ID(key), valTime)
+	if !ok {
+		return drkey.Lvl2Key{}, sql.ErrNoRows
+	}
+	return v.(drkey.Lvl2Key), nil
+}
+
+// InsertLvl2Key inserts a second level DRKey. A key with the same epoch begin is replaced,
+// since the end of the epoch of a key can be limited when it is handed out, and differ when
+// the key is fetched again.
+func (b *Lvl2Backend) InsertLvl2Key(_ context.Context, key drkey.Lvl2Key) error {
+	b.keys.insert(newLvl2ID(key.Lvl2Meta),
+		uint32(key.Epoch.NotBefore.Unix()), uint32(key.Epoch.NotAfter.Unix()), key, true)
+	return nil
+}
+
+// RemoveOutdatedLvl2Keys removes the second level DRKeys whose epoch ends at or before the
+// cutoff.
+func (b *Lvl2Backend) RemoveOutdatedLvl2Keys(_ context.Context, cutoff uint32) (int64, error) {
+	return b.keys.removeOutdated(cutoff), nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drkeydbmem implements the DRKey databases in memory. The number of keys they hold
+// is bounded: when full, the keys that expire first are evicted to make room for new ones.
+package drkeydbmem
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// DefaultMaxEntries is the default maximum number of keys in a database.
+const DefaultMaxEntries = 100000
+
+// entry is a key stored in the database, valid in [begin, end).
+type entry struct {
+	id    interface{}
+	begin uint32
+	end   uint32
+	value interface{}
+	// index of the entry in the expiry heap
+	index int
+}
+
+// expiryHeap orders the entries by the end of their epoch.
+type expiryHeap []*entry
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].end < h[j].end }
+
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expiryHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
+
+// keyStore keeps the keys indexed by an identifier, e.g. the source and destination IAs, and
+// the beginning of their epoch. It is safe for concurrent use.
+type keyStore struct {
+	maxEntries int
+
+	mu      sync.RWMutex
+	entries map[interface{}][]*entry
+	expiry  expiryHeap
+}
+
+func newKeyStore(maxEntries int) *keyStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &keyStore{
+		maxEntries: maxEntries,
+		entries:    make(map[interface{}][]*entry),
+	}
+}
+
+// get returns the value of the key with the newest epoch valid at valTime. The value is read
+// while holding the lock, as the entries are updated in place when a key is replaced.
+func (s *keyStore) get(id interface{}, valTime uint32) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var newest *entry
+	for _, e := range s.entries[id] {
+		if e.begin <= valTime && valTime < e.end && (newest == nil || e.begin > newest.begin) {
+			newest = e
+		}
+	}
+	if newest == nil {
+		return nil, false
+	}
+	return newest.value, true
+}
+
+// insert adds the key. If a key with the same identifier and epoch begin already exists, it is
+// replaced if replace is set, and kept otherwise. If the store is full, the keys that expire
+// first are evicted.
+func (s *keyStore) insert(id interface{}, begin, end uint32, value interface{}, replace bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries[id] {
+		if e.begin == begin {
+			if !replace {
+				return
+			}
+			e.end = end
+			e.value = value
+			heap.Fix(&s.expiry, e.index)
+			return
+		}
+	}
+	for len(s.expiry) >= s.maxEntries {
+		s.remove(heap.Pop(&s.expiry).(*entry))
+	}
+	e := &entry{id: id, begin: begin, end: end, value: value}
+	s.entries[id] = append(s.entries[id], e)
+	heap.Push(&s.expiry, e)
+}
+
+// removeOutdated removes the keys whose epoch ends at or before cutoff.
+func (s *keyStore) removeOutdated(cutoff uint32) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for len(s.expiry) > 0 && s.expiry[0].end <= cutoff {
+		s.remove(heap.Pop(&s.expiry).(*entry))
+		n++
+	}
+	return n
+}
+
+// forEach calls f with every stored entry.
+func (s *keyStore) forEach(f func(*entry)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, e := range s.expiry {
+		f(e)
+	}
+}
+
+// remove removes the entry from the index. The caller must have removed it from the heap.
+func (s *keyStore) remove(e *entry) {
+	list := s.entries[e.id]
+	for i, other := range list {
+		if other == e {
+			list = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+	if len(list) == 0 {
+		delete(s.entries, e.id)
+	} else {
+		s.entries[e.id] = list
+	}
+}
+
+// baseBackend implements drkey.BaseDB for the memory databases.
+type baseBackend struct{}
+
+// Close does nothing.
+func (baseBackend) Close() error {
+	return nil
+}
+
+// SetMaxOpenConns does nothing, there are no connections to memory databases.
+func (baseBackend) SetMaxOpenConns(int) {}
+
+// SetMaxIdleConns does nothing, there are no connections to memory databases.
+func (baseBackend) SetMaxIdleConns(int) {}
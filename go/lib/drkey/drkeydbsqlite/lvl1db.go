@@ -142,10 +142,12 @@ const getLvl1Key = `
 SELECT EpochBegin, EpochEnd, Key FROM DRKeyLvl1
 WHERE SrcIsdID=? AND SrcAsID=? AND DstIsdID=? AND DstAsID=?
 AND EpochBegin<=? AND ?<EpochEnd
+ORDER BY EpochBegin DESC LIMIT 1
 `
 
 // GetLvl1Key takes an pointer to a first level DRKey and a timestamp at which the DRKey should be
-// valid and returns the corresponding first level DRKey.
+// valid and returns the corresponding first level DRKey. If the epochs of several keys overlap
+// at that time, the key of the newest epoch is returned.
 func (b *Lvl1Backend) GetLvl1Key(ctx context.Context, key drkey.Lvl1Meta,
 	valTime uint32) (drkey.Lvl1Key, error) {
 
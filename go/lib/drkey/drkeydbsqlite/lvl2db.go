@@ -83,11 +83,13 @@ SELECT EpochBegin, EpochEnd, Key
 FROM DRKeyLvl2 WHERE Protocol=? AND Type=? AND SrcIsdID=? AND SrcAsID=? AND
 DstIsdID=? AND DstAsID=? AND SrcHostIP=? AND DstHostIP=?
 AND EpochBegin<=? AND ?<EpochEnd
+ORDER BY EpochBegin DESC LIMIT 1
 `
 
 // GetLvl2Key takes a source, destination and additional ISD-AS, a source, destination and
 // additional host, and a timestamp at which the DRKey should be valid and
-// returns a second level DRKey of the request type
+// returns a second level DRKey of the request type. If the epochs of several keys overlap
+// at that time, the key of the newest epoch is returned.
 func (b *Lvl2Backend) GetLvl2Key(ctx context.Context, key drkey.Lvl2Meta,
 	valTime uint32) (drkey.Lvl2Key, error) {
 
@@ -120,12 +122,14 @@ func (b *Lvl2Backend) GetLvl2Key(ctx context.Context, key drkey.Lvl2Meta,
 }
 
 const insertLvl2Key = `
-INSERT OR IGNORE INTO DRKeyLvl2 (Protocol, Type, SrcIsdID, SrcAsID, DstIsdID, DstAsID,
+INSERT OR REPLACE INTO DRKeyLvl2 (Protocol, Type, SrcIsdID, SrcAsID, DstIsdID, DstAsID,
 SrcHostIP, DstHostIP, EpochBegin, EpochEnd, Key)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
-// InsertLvl2Key inserts a second-level DRKey.
+// InsertLvl2Key inserts a second-level DRKey. A key with the same epoch begin replaces the
+// stored one, since the end of the epoch of a key can be limited when it is handed out, and
+// differ when the key is fetched again.
 func (b *Lvl2Backend) InsertLvl2Key(ctx context.Context, key drkey.Lvl2Key) error {
 	_, err := b.insertLvl2KeyStmt.ExecContext(ctx, key.Protocol, key.KeyType, key.SrcIA.I,
 		key.SrcIA.A, key.DstIA.I, key.DstIA.A, key.SrcHost, key.DstHost,
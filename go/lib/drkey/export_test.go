@@ -14,11 +14,7 @@
 
 package drkey
 
-import (
-	"time"
-
-	"github.com/scionproto/scion/go/lib/drkey"
-)
+import "time"
 
 func (c *SecretValueStore) SetTimeNowFunction(f func() time.Time) {
 	c.mutex.Lock()
@@ -31,6 +27,6 @@ func (c *SecretValueStore) CleanExpired() {
 	c.cleanExpired()
 }
 
-func (c *SecretValueStore) Cache() map[int64]drkey.SV {
+func (c *SecretValueStore) Cache() map[int64]SV {
 	return c.cache
 }
@@ -16,6 +16,7 @@ package drkey
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/scionproto/scion/go/lib/addr"
 )
@@ -30,6 +31,19 @@ const (
 	Host2Host
 )
 
+func (t Lvl2KeyType) String() string {
+	switch t {
+	case AS2AS:
+		return "AS2AS"
+	case AS2Host:
+		return "AS2Host"
+	case Host2Host:
+		return "Host2Host"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", uint8(t))
+	}
+}
+
 // Lvl2Meta represents the information about a level 2 DRKey, without the key itself.
 type Lvl2Meta struct {
 	KeyType  Lvl2KeyType
@@ -0,0 +1,127 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Derivation types of the configured protocols.
+const (
+	// StandardType derives all the level 2 keys from the level 1 key.
+	StandardType = "standard"
+	// DelegatedType derives the AS2Host and Host2Host keys from the DS.
+	DelegatedType = "delegated"
+)
+
+const protocolsSample = `
+# The DRKey protocols served, by name. The keys of protocols not declared here
+# are not derived, and requests for them are rejected. If no protocol is
+# declared, the built-in protocols (piskes and scmp) are served.
+#
+# derivation: the derivation of the level 2 keys, "standard" or "delegated".
+# (default "standard")
+# delegation: whether delegation secrets of the protocol can be obtained by the
+# authorized hosts. (default false)
+# key_lifetime: if set, the level 2 keys and delegation secrets handed out are
+# only valid for this long, instead of the rest of their epoch. (default "0s")
+piskes = { derivation = "delegated", delegation = true, key_lifetime = "1h" }
+scmp = { derivation = "standard" }
+`
+
+// ProtocolConfig declares a DRKey protocol.
+type ProtocolConfig struct {
+	// Derivation is the derivation type of the protocol, StandardType or DelegatedType.
+	Derivation string `toml:"derivation,omitempty"`
+	// Delegation allows handing out the delegation secrets of the protocol.
+	Delegation bool `toml:"delegation,omitempty"`
+	// KeyLifetime bounds the validity of the keys handed out. Zero means the whole epoch.
+	KeyLifetime util.DurWrap `toml:"key_lifetime,omitempty"`
+}
+
+// ProtocolsConfig declares the DRKey protocols, by name. If no protocol is declared, the
+// built-in protocols are used (see DefaultRegistry).
+type ProtocolsConfig map[string]ProtocolConfig
+
+var _ (config.Config) = (*ProtocolsConfig)(nil)
+
+// InitDefaults sets the standard derivation for the protocols without derivation.
+func (cfg *ProtocolsConfig) InitDefaults() {
+	if *cfg == nil {
+		*cfg = make(ProtocolsConfig)
+	}
+	for name, p := range *cfg {
+		if p.Derivation == "" {
+			p.Derivation = StandardType
+			(*cfg)[name] = p
+		}
+	}
+}
+
+// Validate validates the derivation types and key lifetimes.
+func (cfg *ProtocolsConfig) Validate() error {
+	for name, p := range *cfg {
+		if name == "" {
+			return serrors.New("empty DRKey protocol name")
+		}
+		switch p.Derivation {
+		case StandardType, DelegatedType:
+		default:
+			return serrors.New("unknown derivation type", "protocol", name,
+				"derivation", p.Derivation)
+		}
+		if lt := p.KeyLifetime.Duration; lt != 0 && lt < time.Second {
+			return serrors.New("key_lifetime must be zero or at least 1s", "protocol", name,
+				"key_lifetime", p.KeyLifetime)
+		}
+	}
+	return nil
+}
+
+// Sample writes a config sample to the writer.
+func (cfg *ProtocolsConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, protocolsSample)
+}
+
+// ConfigName is the key in the toml file.
+func (cfg *ProtocolsConfig) ConfigName() string {
+	return "protocols"
+}
+
+// Registry returns the registry of the configured protocols, or the DefaultRegistry if no
+// protocol is configured. The configuration must be valid.
+func (cfg ProtocolsConfig) Registry() *Registry {
+	if len(cfg) == 0 {
+		return DefaultRegistry()
+	}
+	protocols := make(map[string]Protocol, len(cfg))
+	for name, p := range cfg {
+		var der Derivation = standardProtocol{name: name}
+		if p.Derivation == DelegatedType {
+			der = delegatedProtocol{name: name}
+		}
+		protocols[name] = Protocol{
+			Derivation:  der,
+			Delegation:  p.Delegation,
+			KeyLifetime: p.KeyLifetime.Duration,
+		}
+	}
+	return NewRegistry(protocols)
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ErrUndeclaredProtocol indicates that a protocol is not declared in the registry.
+var ErrUndeclaredProtocol = serrors.New("undeclared DRKey protocol")
+
+// Protocol is a DRKey protocol declared in a Registry.
+type Protocol struct {
+	Derivation
+	// Delegation indicates whether the delegation secrets (AS2AS keys) of the protocol can be
+	// handed out to the authorized hosts.
+	Delegation bool
+	// KeyLifetime, if not zero, bounds the validity of the level 2 keys and delegation secrets
+	// of the protocol handed out to hosts. Hosts have to fetch them again afterwards.
+	KeyLifetime time.Duration
+}
+
+// DelegatedDerivation returns the derivation of the protocol from a DS, if it has one.
+func (p Protocol) DelegatedDerivation() (DelegatedDerivation, bool) {
+	der, ok := p.Derivation.(DelegatedDerivation)
+	return der, ok
+}
+
+// LimitEpoch returns the epoch of a key of the protocol handed out at valTime, i.e. the epoch
+// ending at most KeyLifetime after valTime.
+func (p Protocol) LimitEpoch(epoch drkey.Epoch, valTime time.Time) drkey.Epoch {
+	if p.KeyLifetime == 0 {
+		return epoch
+	}
+	// epochs are encoded with a precision of one second.
+	end := valTime.Add(p.KeyLifetime).Truncate(time.Second).UTC()
+	if end.Before(epoch.NotAfter) {
+		epoch.NotAfter = end
+	}
+	return epoch
+}
+
+// Registry contains the DRKey protocols served by a service. The keys of protocols not
+// declared in it must not be derived nor handed out.
+type Registry struct {
+	protocols map[string]Protocol
+}
+
+// NewRegistry returns a registry declaring the given protocols, by name.
+func NewRegistry(protocols map[string]Protocol) *Registry {
+	r := &Registry{protocols: make(map[string]Protocol, len(protocols))}
+	for name, p := range protocols {
+		r.protocols[name] = p
+	}
+	return r
+}
+
+// DefaultRegistry returns the registry used when no protocols are configured. It declares all
+// the KnownDerivations, with delegation allowed and no key lifetime.
+func DefaultRegistry() *Registry {
+	protocols := make(map[string]Protocol, len(KnownDerivations))
+	for name, der := range KnownDerivations {
+		protocols[name] = Protocol{
+			Derivation: der,
+			Delegation: true,
+		}
+	}
+	return &Registry{protocols: protocols}
+}
+
+// Lookup returns the protocol with the given name. If the protocol is not declared, an error
+// wrapping ErrUndeclaredProtocol is returned. A nil registry behaves as the DefaultRegistry.
+func (r *Registry) Lookup(name string) (Protocol, error) {
+	if r == nil {
+		r = DefaultRegistry()
+	}
+	p, ok := r.protocols[name]
+	if !ok {
+		return Protocol{}, serrors.WithCtx(ErrUndeclaredProtocol,
+			"protocol", name, "declared", r.Names())
+	}
+	return p, nil
+}
+
+// Names returns the sorted names of the declared protocols.
+func (r *Registry) Names() []string {
+	if r == nil {
+		r = DefaultRegistry()
+	}
+	names := make([]string, 0, len(r.protocols))
+	for name := range r.protocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// standardProtocol is a configured protocol using the standard derivation.
+type standardProtocol struct {
+	name string
+}
+
+func (p standardProtocol) Name() string {
+	return p.name
+}
+
+func (p standardProtocol) DeriveLvl2(meta drkey.Lvl2Meta, key drkey.Lvl1Key) (
+	drkey.Lvl2Key, error) {
+
+	return Standard{}.DeriveLvl2(meta, key)
+}
+
+// delegatedProtocol is a configured protocol using the delegated derivation.
+type delegatedProtocol struct {
+	name string
+}
+
+func (p delegatedProtocol) Name() string {
+	return p.name
+}
+
+func (p delegatedProtocol) DeriveLvl2(meta drkey.Lvl2Meta, key drkey.Lvl1Key) (
+	drkey.Lvl2Key, error) {
+
+	return Delegated{}.DeriveLvl2(meta, key)
+}
+
+func (p delegatedProtocol) DeriveLvl2FromDS(meta drkey.Lvl2Meta, ds drkey.DelegationSecret) (
+	drkey.Lvl2Key, error) {
+
+	return Delegated{}.DeriveLvl2FromDS(meta, ds)
+}
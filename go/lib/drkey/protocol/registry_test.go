@@ -0,0 +1,149 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+)
+
+func TestProtocolsConfigSample(t *testing.T) {
+	var sample bytes.Buffer
+	var cfg ProtocolsConfig
+	cfg.Sample(&sample, nil, nil)
+	meta, err := toml.Decode(sample.String(), &cfg)
+	require.NoError(t, err)
+	require.Empty(t, meta.Undecoded())
+	cfg.InitDefaults()
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, ProtocolConfig{Derivation: StandardType}, cfg["scmp"])
+	assert.Equal(t, DelegatedType, cfg["piskes"].Derivation)
+	assert.True(t, cfg["piskes"].Delegation)
+	assert.Equal(t, time.Hour, cfg["piskes"].KeyLifetime.Duration)
+}
+
+func TestProtocolsConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		sample    string
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"empty": {
+			assertErr: assert.NoError,
+		},
+		"default derivation": {
+			sample:    `foo = { delegation = true }`,
+			assertErr: assert.NoError,
+		},
+		"unknown derivation": {
+			sample:    `foo = { derivation = "fancy" }`,
+			assertErr: assert.Error,
+		},
+		"sub-second lifetime": {
+			sample:    `foo = { key_lifetime = "10ms" }`,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			var cfg ProtocolsConfig
+			_, err := toml.Decode(tc.sample, &cfg)
+			require.NoError(t, err)
+			cfg.InitDefaults()
+			tc.assertErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	cfg := ProtocolsConfig{
+		"foo": {Derivation: StandardType},
+		"bar": {Derivation: DelegatedType, Delegation: true},
+	}
+	r := cfg.Registry()
+	assert.Equal(t, []string{"bar", "foo"}, r.Names())
+
+	_, err := r.Lookup("piskes")
+	assert.True(t, errors.Is(err, ErrUndeclaredProtocol), err)
+
+	lvl1 := getLvl1(t)
+	meta := drkey.Lvl2Meta{
+		Protocol: "foo",
+		KeyType:  drkey.AS2Host,
+		SrcIA:    lvl1.SrcIA,
+		DstIA:    lvl1.DstIA,
+		DstHost:  addr.HostFromIPStr("127.0.0.1"),
+	}
+	foo, err := r.Lookup("foo")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", foo.Name())
+	assert.False(t, foo.Delegation)
+	_, ok := foo.DelegatedDerivation()
+	assert.False(t, ok)
+	key, err := foo.DeriveLvl2(meta, lvl1)
+	require.NoError(t, err)
+	expected, err := Standard{}.DeriveLvl2(meta, lvl1)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Key, key.Key)
+
+	meta.Protocol = "bar"
+	bar, err := r.Lookup("bar")
+	require.NoError(t, err)
+	assert.True(t, bar.Delegation)
+	_, ok = bar.DelegatedDerivation()
+	assert.True(t, ok)
+	key, err = bar.DeriveLvl2(meta, lvl1)
+	require.NoError(t, err)
+	expected, err = Delegated{}.DeriveLvl2(meta, lvl1)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Key, key.Key)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	var cfg ProtocolsConfig
+	for _, r := range []*Registry{nil, cfg.Registry()} {
+		for name, der := range KnownDerivations {
+			p, err := r.Lookup(name)
+			require.NoError(t, err)
+			assert.Equal(t, der, p.Derivation)
+			assert.True(t, p.Delegation)
+		}
+		_, err := r.Lookup("undeclared")
+		assert.True(t, errors.Is(err, ErrUndeclaredProtocol), err)
+	}
+}
+
+func TestLimitEpoch(t *testing.T) {
+	epoch := drkey.NewEpoch(1000, 2000)
+	valTime := time.Unix(1500, 500)
+
+	p := Protocol{}
+	assert.Equal(t, epoch, p.LimitEpoch(epoch, valTime))
+
+	p.KeyLifetime = 100 * time.Second
+	assert.Equal(t, drkey.NewEpoch(1000, 1600), p.LimitEpoch(epoch, valTime))
+
+	p.KeyLifetime = time.Hour
+	assert.Equal(t, epoch, p.LimitEpoch(epoch, valTime))
+}
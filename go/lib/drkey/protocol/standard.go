@@ -22,11 +22,9 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 )
 
-// TODO(juagargi) the standard derivation (in this file) and delegated one will be just
-// functions: won't implement any interface in particular. The way to configure
-// a new protocol will look like the SCMP or PISKES protocols: new type with their functions, and
-// registration with the correct name. No configuration will be allowed to change this (the
-// configuration file will not include any mapping protocol->derivation).
+// The built-in protocols, like SCMP or PISKES, register their derivation by name in
+// KnownDerivations. Deployments can instead declare their protocols and the derivation each of
+// them uses in a ProtocolsConfig.
 
 // Standard implements the level 2 drkey derivation from level 1, without DS.
 type Standard struct{}
@@ -19,7 +19,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 )
@@ -28,7 +27,7 @@ import (
 type SecretValueStore struct {
 	// TODO(jordiSubira): simplify class to be more consistent with keeping current and next
 	// secret values.
-	cache map[int64]drkey.SV
+	cache map[int64]SV
 	mutex sync.Mutex
 
 	keyDuration  time.Duration
@@ -39,7 +38,7 @@ type SecretValueStore struct {
 // NewSecretValueStore creates a new SecretValueStore and initializes the cleaner.
 func NewSecretValueStore(keyDuration time.Duration) *SecretValueStore {
 	m := &SecretValueStore{
-		cache:        make(map[int64]drkey.SV),
+		cache:        make(map[int64]SV),
 		keyDuration:  keyDuration,
 		stopCleaning: make(chan bool),
 		timeNowFcn:   time.Now,
@@ -53,7 +52,7 @@ func NewSecretValueStore(keyDuration time.Duration) *SecretValueStore {
 }
 
 // Get returns the element, and an indicator of its presence.
-func (m *SecretValueStore) Get(idx int64) (drkey.SV, bool) {
+func (m *SecretValueStore) Get(idx int64) (SV, bool) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -62,7 +61,7 @@ func (m *SecretValueStore) Get(idx int64) (drkey.SV, bool) {
 }
 
 // Set sets the key, and registers this element in this shard.
-func (m *SecretValueStore) Set(idx int64, key drkey.SV) {
+func (m *SecretValueStore) Set(idx int64, key SV) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -99,43 +98,84 @@ func (m *SecretValueStore) startCleaner() {
 	}
 }
 
-// SecretValueFactory stores the secret value
+// SecretValueFactory stores the secret value.
+// The secret value of epoch n is valid in [n*keyDuration, (n+1)*keyDuration + gracePeriod),
+// i.e. during the grace period after an epoch switch both the previous and the current secret
+// values, and the keys derived from them, are valid.
 type SecretValueFactory struct {
 	keyDuration time.Duration
+	gracePeriod time.Duration
 	masterKey   []byte
 	keyMap      *SecretValueStore
 	mapMutex    sync.Mutex
 }
 
 // NewSecretValueFactory return a default initialized SecretValueFactory.
+// The grace period must be shorter than the key duration.
 func NewSecretValueFactory(masterKey []byte,
-	keyDuration time.Duration) *SecretValueFactory {
+	keyDuration, gracePeriod time.Duration) *SecretValueFactory {
 
 	s := &SecretValueFactory{
 		masterKey:   masterKey,
 		keyDuration: keyDuration,
+		gracePeriod: gracePeriod,
 	}
 	s.keyMap = NewSecretValueStore(s.keyDuration)
 	return s
 }
 
-// GetSecretValue derives or reuses the secret value for this time stamp.
-func (s *SecretValueFactory) GetSecretValue(t time.Time) (drkey.SV, error) {
+// GetSecretValue derives or reuses the secret value of the epoch that starts last before
+// this time stamp.
+func (s *SecretValueFactory) GetSecretValue(t time.Time) (SV, error) {
 	s.mapMutex.Lock()
 	defer s.mapMutex.Unlock()
 
-	duration := int64(s.keyDuration / time.Second) // duration in seconds
-	idx := t.Unix() / duration
+	return s.getSecretValue(t.Unix() / s.durationSecs())
+}
+
+// GetSecretValues returns the secret values valid at this time stamp, the newest first. These
+// are the value of the current epoch and, during the grace period, the one of the previous
+// epoch.
+func (s *SecretValueFactory) GetSecretValues(t time.Time) ([]SV, error) {
+	s.mapMutex.Lock()
+	defer s.mapMutex.Unlock()
+
+	idx := t.Unix() / s.durationSecs()
+	k, err := s.getSecretValue(idx)
+	if err != nil {
+		return nil, err
+	}
+	svs := []SV{k}
+	if idx > 0 && t.Unix() < idx*s.durationSecs()+int64(s.gracePeriod/time.Second) {
+		if k, err = s.getSecretValue(idx - 1); err != nil {
+			return nil, err
+		}
+		svs = append(svs, k)
+	}
+	return svs, nil
+}
+
+func (s *SecretValueFactory) durationSecs() int64 {
+	return int64(s.keyDuration / time.Second)
+}
+
+// getSecretValue derives or reuses the secret value of the epoch with this index.
+// The caller must hold the mapMutex.
+func (s *SecretValueFactory) getSecretValue(idx int64) (SV, error) {
 	k, found := s.keyMap.Get(idx)
 	if !found {
+		duration := s.durationSecs()
 		begin := uint32(idx * duration)
 		end := begin + uint32(duration)
-		epoch := drkey.NewEpoch(begin, end)
+		// the value is derived from the epoch without the grace period, so that it does not
+		// change with it. The grace period only extends its validity.
+		epoch := NewEpoch(begin, end)
 		var err error
-		k, err = drkey.DeriveSV(drkey.SVMeta{Epoch: epoch}, s.masterKey)
+		k, err = DeriveSV(SVMeta{Epoch: epoch}, s.masterKey)
 		if err != nil {
-			return drkey.SV{}, serrors.WrapStr("Cannot establish the DRKey secret value", err)
+			return SV{}, serrors.WrapStr("Cannot establish the DRKey secret value", err)
 		}
+		k.Epoch = NewEpoch(begin, end+uint32(s.gracePeriod/time.Second))
 		s.keyMap.Set(idx, k)
 	}
 	return k, nil
@@ -25,7 +25,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/util"
-	csdrkey "github.com/scionproto/scion/go/pkg/cs/drkey"
+	"github.com/scionproto/scion/go/lib/xtest"
 )
 
 // waitCondWithTimeout waits for the condition cond and return true, or timeout and return false.
@@ -50,7 +50,7 @@ func TestSecretValueStoreTicker(t *testing.T) {
 	var m sync.Mutex
 	cond := sync.NewCond(&m)
 	m.Lock()
-	c := csdrkey.NewSecretValueStore(time.Millisecond)
+	c := drkey.NewSecretValueStore(time.Millisecond)
 	// This timeNowFcn is used to mock time.Now() to test expiring entries in the tests below.
 	// This _has_ to be called by the cleanup function. Therefore, we can (ab-)use this to check
 	// that the background cleaner is indeed running.
@@ -64,7 +64,7 @@ func TestSecretValueStoreTicker(t *testing.T) {
 }
 
 func TestSecretValueStore(t *testing.T) {
-	c := csdrkey.NewSecretValueStore(time.Hour)
+	c := drkey.NewSecretValueStore(time.Hour)
 	var now atomic.Value
 	testTimeNowFunc := func() time.Time {
 		return now.Load().(time.Time)
@@ -101,11 +101,11 @@ func TestSecretValueStore(t *testing.T) {
 
 func TestSecretValueFactory(t *testing.T) {
 	master := []byte{}
-	fac := csdrkey.NewSecretValueFactory(master, 10*time.Second)
+	fac := drkey.NewSecretValueFactory(master, 10*time.Second, 0)
 	_, err := fac.GetSecretValue(time.Now())
 	require.Error(t, err)
 	master = []byte{0, 1, 2, 3}
-	fac = csdrkey.NewSecretValueFactory(master, 10*time.Second)
+	fac = drkey.NewSecretValueFactory(master, 10*time.Second, 0)
 	k, err := fac.GetSecretValue(util.SecsToTime(10))
 	require.NoError(t, err)
 	require.EqualValues(t, 10, k.Epoch.NotBefore.Unix())
@@ -126,3 +126,43 @@ func TestSecretValueFactory(t *testing.T) {
 	require.NotEqual(t, savedCurrSV.Key, k.Key)
 	require.Equal(t, savedCurrSV.Epoch.NotAfter, k.Epoch.NotBefore)
 }
+
+func TestSecretValueFactoryGracePeriod(t *testing.T) {
+	fac := drkey.NewSecretValueFactory([]byte{0, 1, 2, 3}, 10*time.Second, 3*time.Second)
+	prev, err := fac.GetSecretValue(util.SecsToTime(19))
+	require.NoError(t, err)
+	require.EqualValues(t, 10, prev.Epoch.NotBefore.Unix())
+	require.EqualValues(t, 23, prev.Epoch.NotAfter.Unix())
+	curr, err := fac.GetSecretValue(util.SecsToTime(20))
+	require.NoError(t, err)
+	require.EqualValues(t, 20, curr.Epoch.NotBefore.Unix())
+	require.EqualValues(t, 33, curr.Epoch.NotAfter.Unix())
+
+	// the grace period does not change the values, only their validity.
+	require.Equal(t, xtest.MustParseHexString("9d0450ad9e2adc5e36f948bf92cd384d"),
+		[]byte(prev.Key))
+	noGrace, err := drkey.NewSecretValueFactory([]byte{0, 1, 2, 3}, 10*time.Second, 0).
+		GetSecretValue(util.SecsToTime(19))
+	require.NoError(t, err)
+	require.Equal(t, noGrace.Key, prev.Key)
+
+	testCases := map[string]struct {
+		valTime  int64
+		expected []drkey.SV
+	}{
+		"before switch": {valTime: 19, expected: []drkey.SV{prev}},
+		"grace period":  {valTime: 22, expected: []drkey.SV{curr, prev}},
+		"after grace":   {valTime: 23, expected: []drkey.SV{curr}},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			svs, err := fac.GetSecretValues(time.Unix(tc.valTime, 0))
+			require.NoError(t, err)
+			require.Len(t, svs, len(tc.expected))
+			for i, sv := range svs {
+				require.Equal(t, tc.expected[i].Key, sv.Key)
+				require.True(t, sv.Epoch.Contains(time.Unix(tc.valTime, 0)))
+			}
+		})
+	}
+}
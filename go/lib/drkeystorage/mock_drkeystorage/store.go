@@ -51,6 +51,21 @@ func (mr *MockSecretValueFactoryMockRecorder) GetSecretValue(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockSecretValueFactory)(nil).GetSecretValue), arg0)
 }
 
+// GetSecretValues mocks base method
+func (m *MockSecretValueFactory) GetSecretValues(arg0 time.Time) ([]drkey.SV, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretValues", arg0)
+	ret0, _ := ret[0].([]drkey.SV)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretValues indicates an expected call of GetSecretValues
+func (mr *MockSecretValueFactoryMockRecorder) GetSecretValues(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValues", reflect.TypeOf((*MockSecretValueFactory)(nil).GetSecretValues), arg0)
+}
+
 // MockBaseStore is a mock of BaseStore interface
 type MockBaseStore struct {
 	ctrl     *gomock.Controller
@@ -142,6 +157,21 @@ func (mr *MockServiceStoreMockRecorder) DeriveLvl1(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveLvl1", reflect.TypeOf((*MockServiceStore)(nil).DeriveLvl1), arg0, arg1)
 }
 
+// DeriveLvl1Keys mocks base method
+func (m *MockServiceStore) DeriveLvl1Keys(arg0 addr.IA, arg1 time.Time) ([]drkey.Lvl1Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeriveLvl1Keys", arg0, arg1)
+	ret0, _ := ret[0].([]drkey.Lvl1Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeriveLvl1Keys indicates an expected call of DeriveLvl1Keys
+func (mr *MockServiceStoreMockRecorder) DeriveLvl1Keys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveLvl1Keys", reflect.TypeOf((*MockServiceStore)(nil).DeriveLvl1Keys), arg0, arg1)
+}
+
 // GetLvl1Key mocks base method
 func (m *MockServiceStore) GetLvl1Key(arg0 context.Context, arg1 drkey.Lvl1Meta, arg2 time.Time) (drkey.Lvl1Key, error) {
 	m.ctrl.T.Helper()
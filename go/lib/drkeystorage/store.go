@@ -24,8 +24,13 @@ import (
 )
 
 // SecretValueFactory has the functionality to store secret values.
+// The epochs of consecutive secret values can overlap for a grace period, during which both
+// secret values are valid.
 type SecretValueFactory interface {
+	// GetSecretValue returns the secret value of the newest epoch valid at the given time.
 	GetSecretValue(time.Time) (drkey.SV, error)
+	// GetSecretValues returns all the secret values valid at the given time, the newest first.
+	GetSecretValues(time.Time) ([]drkey.SV, error)
 }
 
 // BaseStore is the common base for any drkey store.
@@ -39,6 +44,9 @@ type BaseStore interface {
 type ServiceStore interface {
 	BaseStore
 	DeriveLvl1(dstIA addr.IA, valTime time.Time) (drkey.Lvl1Key, error)
+	// DeriveLvl1Keys returns the level 1 keys of all the epochs valid at valTime, the newest
+	// first. Receivers use them to accept keys of the previous epoch during its grace period.
+	DeriveLvl1Keys(dstIA addr.IA, valTime time.Time) ([]drkey.Lvl1Key, error)
 	GetLvl1Key(ctx context.Context, meta drkey.Lvl1Meta, valTime time.Time) (drkey.Lvl1Key, error)
 	KnownASes(ctx context.Context) ([]addr.IA, error)
 }
@@ -26,6 +26,15 @@ import (
 // no database exists a new database is be created. If the schema version of the
 // stored database is different from schemaVersion, an error is returned.
 func NewSqlite(path string, schema string, schemaVersion int) (*sql.DB, error) {
+	return NewSqliteWithMigrations(path, schema, schemaVersion, nil)
+}
+
+// NewSqliteWithMigrations is like NewSqlite, but a stored database with an older schema
+// version is upgraded with the migrations: migrations[v] are the statements upgrading the
+// schema from version v to v+1. If a migration is missing, an error is returned.
+func NewSqliteWithMigrations(path string, schema string, schemaVersion int,
+	migrations map[int]string) (*sql.DB, error) {
+
 	var err error
 	if path == "" {
 		return nil, serrors.New("Empty path not allowed for sqlite")
@@ -53,9 +62,14 @@ func NewSqlite(path string, schema string, schemaVersion int) (*sql.DB, error) {
 		if err = setup(db, schema, schemaVersion, path); err != nil {
 			return nil, err
 		}
+	} else if existingVersion < schemaVersion && migrations != nil {
+		if err = migrate(db, existingVersion, schemaVersion, migrations, path); err != nil {
+			return nil, err
+		}
 	} else if existingVersion != schemaVersion {
-		return nil, serrors.New("Database schema version mismatch",
+		err = serrors.New("Database schema version mismatch",
 			"expected", schemaVersion, "have", existingVersion, "path", path)
+		return nil, err
 	}
 	return db, nil
 }
@@ -120,3 +134,30 @@ func setup(db *sql.DB, schema string, schemaVersion int, path string) error {
 	}
 	return nil
 }
+
+// migrate upgrades the schema from version from to version to, in a single transaction.
+func migrate(db *sql.DB, from, to int, migrations map[int]string, path string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return serrors.WrapStr("Failed to start schema migration", err, "path", path)
+	}
+	defer tx.Rollback()
+	for v := from; v < to; v++ {
+		stmts, ok := migrations[v]
+		if !ok {
+			return serrors.New("Database schema version mismatch, no migration",
+				"expected", to, "have", v, "path", path)
+		}
+		if _, err := tx.Exec(stmts); err != nil {
+			return serrors.WrapStr("Failed to migrate SQLite database", err,
+				"from", v, "to", v+1, "path", path)
+		}
+	}
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", to)); err != nil {
+		return serrors.WrapStr("Failed to write schema version", err, "path", path)
+	}
+	if err := tx.Commit(); err != nil {
+		return serrors.WrapStr("Failed to commit schema migration", err, "path", path)
+	}
+	return nil
+}
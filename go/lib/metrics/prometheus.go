@@ -37,6 +37,15 @@ func NewPromCounter(cv *prometheus.CounterVec) Counter {
 	return kitprom.NewCounter(cv)
 }
 
+// NewPromHistogram wraps a prometheus histogram vector as a histogram.
+// Returns nil if hv is nil.
+func NewPromHistogram(hv *prometheus.HistogramVec) Histogram {
+	if hv == nil {
+		return nil
+	}
+	return kitprom.NewHistogram(hv)
+}
+
 // NewPromCounterFrom creates a wrapped prometheus counter.
 func NewPromCounterFrom(opts prometheus.CounterOpts, labelNames []string) Counter {
 	return kitprom.NewCounterFrom(opts, labelNames)
@@ -17,9 +17,12 @@ package sciond
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -37,6 +40,55 @@ type ASInfo struct {
 	MTU uint16
 }
 
+// ColibriReservation is an index of a COLIBRI e2e reservation.
+type ColibriReservation struct {
+	ID    reservation.E2EID
+	Index reservation.IndexNumber
+	Token reservation.Token
+	// Path is the COLIBRI path using the reservation.
+	Path snet.Path
+}
+
+// ColibriReservations are the COLIBRI reservations stored in the local AS.
+type ColibriReservations struct {
+	Segments []ColibriSegmentRsv
+	E2E      []ColibriE2ERsv
+}
+
+// ColibriSegmentRsv is a COLIBRI segment reservation stored in the local AS.
+type ColibriSegmentRsv struct {
+	ID       reservation.SegmentID
+	BaseID   *reservation.SegmentID // the base reservation if telescopic, nil otherwise
+	PathType reservation.PathType
+	Ingress  uint16
+	Egress   uint16
+	// Path is only known if the reservation was set up by the local AS.
+	Path    []ColibriPathStep
+	Indices []ColibriIndex
+}
+
+// ColibriE2ERsv is a COLIBRI e2e reservation stored in the local AS.
+type ColibriE2ERsv struct {
+	ID       reservation.E2EID
+	Segments []reservation.SegmentID // the stitched segment reservations
+	Indices  []ColibriIndex
+}
+
+// ColibriIndex is an index of a COLIBRI reservation.
+type ColibriIndex struct {
+	Idx        reservation.IndexNumber
+	Expiration time.Time
+	AllocBW    reservation.BWCls
+	State      string // the state of a segment reservation index, empty for e2e ones
+}
+
+// ColibriPathStep is an AS in the path of a COLIBRI reservation.
+type ColibriPathStep struct {
+	IA      addr.IA
+	Ingress uint16
+	Egress  uint16
+}
+
 type Querier struct {
 	Connector Connector
 	IA        addr.IA
@@ -51,6 +103,8 @@ type RevHandler struct {
 	Connector Connector
 }
 
+var _ snet.AuthenticatedRevocationHandler = RevHandler{}
+
 func (h RevHandler) RevokeRaw(ctx context.Context, rawSRevInfo common.RawBytes) {
 	err := h.Connector.RevNotificationFromRaw(ctx, rawSRevInfo)
 	if err != nil {
@@ -58,6 +112,20 @@ func (h RevHandler) RevokeRaw(ctx context.Context, rawSRevInfo common.RawBytes)
 	}
 }
 
+// RevokeAuthenticated sends the revocation to sciond together with the authenticated SCMP
+// message, so that sciond can verify it.
+func (h RevHandler) RevokeAuthenticated(ctx context.Context, rawSRevInfo common.RawBytes,
+	auth *snet.SCMPAuthentication) {
+
+	sRevInfo, err := path_mgmt.NewSignedRevInfoFromRaw(rawSRevInfo)
+	if err == nil {
+		err = h.Connector.RevNotificationAuthenticated(ctx, sRevInfo, auth)
+	}
+	if err != nil {
+		log.FromCtx(ctx).Error("Revocation notification to sciond failed", "err", err)
+	}
+}
+
 // TopoQuerier can be used to get topology information from sciond.
 type TopoQuerier struct {
 	Connector Connector
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/drkey"
@@ -176,11 +177,39 @@ func (c connector) RevNotification(ctx context.Context,
 	panic("not implemented")
 }
 
+func (c connector) RevNotificationAuthenticated(ctx context.Context,
+	sRevInfo *path_mgmt.SignedRevInfo, auth *snet.SCMPAuthentication) error {
+
+	panic("not implemented")
+}
+
 func (c connector) DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
 	valTime time.Time) (drkey.Lvl2Key, error) {
 	panic("not implemented")
 }
 
+func (c connector) ColibriSetupRsv(ctx context.Context, dst addr.IA,
+	bw reservation.BWCls) (*sciond.ColibriReservation, error) {
+
+	panic("not implemented")
+}
+
+func (c connector) ColibriRenewRsv(ctx context.Context, id *reservation.E2EID,
+	bw reservation.BWCls) (*sciond.ColibriReservation, error) {
+
+	panic("not implemented")
+}
+
+func (c connector) ColibriCleanupRsv(ctx context.Context, id *reservation.E2EID,
+	idx reservation.IndexNumber) error {
+
+	panic("not implemented")
+}
+
+func (c connector) ColibriListRsvs(ctx context.Context) (*sciond.ColibriReservations, error) {
+	panic("not implemented")
+}
+
 func (c connector) Close(ctx context.Context) error {
 	return nil
 }
@@ -17,22 +17,26 @@ package sciond
 import (
 	"context"
 	"net"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/common"
 	dkctrl "github.com/scionproto/scion/go/lib/ctrl/drkey"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/path"
 	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/topology"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
 	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
 )
 
@@ -158,20 +162,38 @@ func (c grpcConn) RevNotificationFromRaw(ctx context.Context, b []byte) error {
 }
 
 func (c grpcConn) RevNotification(ctx context.Context, sRevInfo *path_mgmt.SignedRevInfo) error {
+	return c.notifyInterfaceDown(ctx, sRevInfo, nil)
+}
+
+func (c grpcConn) RevNotificationAuthenticated(ctx context.Context,
+	sRevInfo *path_mgmt.SignedRevInfo, auth *snet.SCMPAuthentication) error {
+
+	return c.notifyInterfaceDown(ctx, sRevInfo, auth)
+}
+
+func (c grpcConn) notifyInterfaceDown(ctx context.Context, sRevInfo *path_mgmt.SignedRevInfo,
+	auth *snet.SCMPAuthentication) error {
+
 	revInfo, err := sRevInfo.RevInfo()
 	if err != nil {
 		c.metrics.incIfDown(err)
 		return serrors.WrapStr("extracting rev info", err)
 	}
-
-	client := sdpb.NewDaemonServiceClient(c.conn)
-	_, err = client.NotifyInterfaceDown(ctx, &sdpb.NotifyInterfaceDownRequest{
+	req := &sdpb.NotifyInterfaceDownRequest{
 		Id:    uint64(revInfo.IfID),
 		IsdAs: uint64(revInfo.RawIsdas),
-	})
+	}
+	if auth != nil {
+		if req.ScmpAuthentication, err = scmpAuthenticationToPB(auth); err != nil {
+			c.metrics.incIfDown(err)
+			return err
+		}
+	}
+
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	_, err = client.NotifyInterfaceDown(ctx, req)
 	c.metrics.incIfDown(err)
 	return err
-
 }
 
 func (c grpcConn) DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
@@ -197,6 +219,60 @@ func (c grpcConn) DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
 	return lvl2Key, nil
 }
 
+func (c grpcConn) ColibriSetupRsv(ctx context.Context, dst addr.IA,
+	bw reservation.BWCls) (*ColibriReservation, error) {
+
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	reply, err := client.ColibriSetup(ctx, &sdpb.ColibriSetupRequest{
+		DstIsdAs:    uint64(dst.IAInt()),
+		RequestedBw: uint32(bw),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reply.Failure != nil {
+		return nil, colibriFailureFromPB(reply.Failure)
+	}
+	return colibriReservationFromPB(reply.Reservation)
+}
+
+func (c grpcConn) ColibriRenewRsv(ctx context.Context, id *reservation.E2EID,
+	bw reservation.BWCls) (*ColibriReservation, error) {
+
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	reply, err := client.ColibriRenew(ctx, &sdpb.ColibriRenewRequest{
+		Id:          id.ToRaw(),
+		RequestedBw: uint32(bw),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reply.Failure != nil {
+		return nil, colibriFailureFromPB(reply.Failure)
+	}
+	return colibriReservationFromPB(reply.Reservation)
+}
+
+func (c grpcConn) ColibriCleanupRsv(ctx context.Context, id *reservation.E2EID,
+	idx reservation.IndexNumber) error {
+
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	_, err := client.ColibriCleanup(ctx, &sdpb.ColibriCleanupRequest{
+		Id:    id.ToRaw(),
+		Index: uint32(idx),
+	})
+	return err
+}
+
+func (c grpcConn) ColibriListRsvs(ctx context.Context) (*ColibriReservations, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	reply, err := client.ColibriList(ctx, &sdpb.ColibriListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return colibriReservationsFromPB(reply.Reservations)
+}
+
 func (c grpcConn) Close(_ context.Context) error {
 	return c.conn.Close()
 }
@@ -270,6 +346,135 @@ func convertPath(p *sdpb.Path, dst addr.IA) (path.Path, error) {
 	}, nil
 }
 
+func colibriReservationFromPB(rsv *sdpb.ColibriReservation) (*ColibriReservation, error) {
+	if rsv == nil || rsv.Path == nil || len(rsv.Path.Interfaces) == 0 {
+		return nil, serrors.New("missing COLIBRI reservation")
+	}
+	// the destination is the AS of the last interface
+	dst := addr.IAInt(rsv.Path.Interfaces[len(rsv.Path.Interfaces)-1].IsdAs).IA()
+	id, err := reservation.E2EIDFromRaw(rsv.Id)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing reservation ID", err)
+	}
+	tok, err := reservation.TokenFromRaw(rsv.Token)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing reservation token", err)
+	}
+	p, err := convertPath(rsv.Path, dst)
+	if err != nil {
+		return nil, err
+	}
+	p.SPath.Type = colibri.PathType
+	return &ColibriReservation{
+		ID:    *id,
+		Index: reservation.IndexNumber(rsv.Index),
+		Token: *tok,
+		Path:  p,
+	}, nil
+}
+
+func colibriReservationsFromPB(pb *colpb.ListReservationsResponse) (
+	*ColibriReservations, error) {
+
+	if pb == nil {
+		return &ColibriReservations{}, nil
+	}
+	rsvs := &ColibriReservations{
+		Segments: make([]ColibriSegmentRsv, len(pb.Segments)),
+		E2E:      make([]ColibriE2ERsv, len(pb.E2E)),
+	}
+	for i, s := range pb.Segments {
+		id, err := reservation.SegmentIDFromRaw(s.Id)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing segment reservation ID", err)
+		}
+		rsv := ColibriSegmentRsv{
+			ID:       *id,
+			PathType: reservation.PathType(s.PathType),
+			Ingress:  uint16(s.Ingress),
+			Egress:   uint16(s.Egress),
+			Indices:  colibriIndicesFromPB(s.Indices),
+		}
+		if len(s.BaseId) > 0 {
+			if rsv.BaseID, err = reservation.SegmentIDFromRaw(s.BaseId); err != nil {
+				return nil, serrors.WrapStr("parsing base reservation ID", err)
+			}
+		}
+		for _, step := range s.GetPath().GetSteps() {
+			rsv.Path = append(rsv.Path, ColibriPathStep{
+				IA:      addr.IAInt(step.IsdAs).IA(),
+				Ingress: uint16(step.Ingress),
+				Egress:  uint16(step.Egress),
+			})
+		}
+		rsvs.Segments[i] = rsv
+	}
+	for i, e := range pb.E2E {
+		id, err := reservation.E2EIDFromRaw(e.Id)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing e2e reservation ID", err)
+		}
+		segs := make([]reservation.SegmentID, len(e.SegmentIds))
+		for j, raw := range e.SegmentIds {
+			segID, err := reservation.SegmentIDFromRaw(raw)
+			if err != nil {
+				return nil, serrors.WrapStr("parsing segment reservation ID", err)
+			}
+			segs[j] = *segID
+		}
+		rsvs.E2E[i] = ColibriE2ERsv{
+			ID:       *id,
+			Segments: segs,
+			Indices:  colibriIndicesFromPB(e.Indices),
+		}
+	}
+	return rsvs, nil
+}
+
+func colibriIndicesFromPB(pb []*colpb.ReservationIndex) []ColibriIndex {
+	indices := make([]ColibriIndex, len(pb))
+	for i, idx := range pb {
+		indices[i] = ColibriIndex{
+			Idx:        reservation.IndexNumber(idx.Index),
+			Expiration: time.Unix(idx.GetExpiration().GetSeconds(), 0),
+			AllocBW:    reservation.BWCls(idx.AllocBw),
+		}
+		if idx.State != colpb.IndexState_INDEX_STATE_UNSPECIFIED {
+			indices[i].State = strings.ToLower(
+				strings.TrimPrefix(idx.State.String(), "INDEX_STATE_"))
+		}
+	}
+	return indices
+}
+
+func colibriFailureFromPB(f *colpb.Failure) *reservation.Failure {
+	return &reservation.Failure{
+		Code:      reservation.ErrorCode(f.ErrorCode),
+		FailedHop: uint8(f.FailedHop),
+		FailedIA:  addr.IAInt(f.FailedIsdAs).IA(),
+		MaxBW:     reservation.BWCls(f.MaxBw),
+	}
+}
+
+func scmpAuthenticationToPB(auth *snet.SCMPAuthentication) (*sdpb.SCMPAuthentication, error) {
+	var ip net.IP
+	switch h := auth.DstHost.(type) {
+	case addr.HostIPv4:
+		ip = h.IP()
+	case addr.HostIPv6:
+		ip = h.IP()
+	default:
+		return nil, serrors.New("unsupported SCMP destination", "host", auth.DstHost)
+	}
+	return &sdpb.SCMPAuthentication{
+		SourceIsdAs:     uint64(auth.SrcIA.IAInt()),
+		DestinationHost: ip,
+		Message:         auth.Message,
+		OptionType:      uint32(auth.OptType),
+		OptionData:      auth.OptData,
+	}, nil
+}
+
 func linkTypeFromPB(lt sdpb.LinkType) snet.LinkType {
 	switch lt {
 	case sdpb.LinkType_LINK_TYPE_DIRECT:
@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	addr "github.com/scionproto/scion/go/lib/addr"
+	reservation "github.com/scionproto/scion/go/lib/colibri/reservation"
 	common "github.com/scionproto/scion/go/lib/common"
 	path_mgmt "github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	drkey "github.com/scionproto/scion/go/lib/drkey"
@@ -70,6 +71,65 @@ func (mr *MockConnectorMockRecorder) Close(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockConnector)(nil).Close), arg0)
 }
 
+// ColibriCleanupRsv mocks base method
+func (m *MockConnector) ColibriCleanupRsv(arg0 context.Context, arg1 *reservation.E2EID, arg2 reservation.IndexNumber) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriCleanupRsv", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ColibriCleanupRsv indicates an expected call of ColibriCleanupRsv
+func (mr *MockConnectorMockRecorder) ColibriCleanupRsv(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriCleanupRsv", reflect.TypeOf((*MockConnector)(nil).ColibriCleanupRsv), arg0, arg1, arg2)
+}
+
+// ColibriListRsvs mocks base method
+func (m *MockConnector) ColibriListRsvs(arg0 context.Context) (*sciond.ColibriReservations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriListRsvs", arg0)
+	ret0, _ := ret[0].(*sciond.ColibriReservations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ColibriListRsvs indicates an expected call of ColibriListRsvs
+func (mr *MockConnectorMockRecorder) ColibriListRsvs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriListRsvs", reflect.TypeOf((*MockConnector)(nil).ColibriListRsvs), arg0)
+}
+
+// ColibriRenewRsv mocks base method
+func (m *MockConnector) ColibriRenewRsv(arg0 context.Context, arg1 *reservation.E2EID, arg2 reservation.BWCls) (*sciond.ColibriReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriRenewRsv", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*sciond.ColibriReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ColibriRenewRsv indicates an expected call of ColibriRenewRsv
+func (mr *MockConnectorMockRecorder) ColibriRenewRsv(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriRenewRsv", reflect.TypeOf((*MockConnector)(nil).ColibriRenewRsv), arg0, arg1, arg2)
+}
+
+// ColibriSetupRsv mocks base method
+func (m *MockConnector) ColibriSetupRsv(arg0 context.Context, arg1 addr.IA, arg2 reservation.BWCls) (*sciond.ColibriReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriSetupRsv", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*sciond.ColibriReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ColibriSetupRsv indicates an expected call of ColibriSetupRsv
+func (mr *MockConnectorMockRecorder) ColibriSetupRsv(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriSetupRsv", reflect.TypeOf((*MockConnector)(nil).ColibriSetupRsv), arg0, arg1, arg2)
+}
+
 // DRKeyGetLvl2Key mocks base method
 func (m *MockConnector) DRKeyGetLvl2Key(arg0 context.Context, arg1 drkey.Lvl2Meta, arg2 time.Time) (drkey.Lvl2Key, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +204,20 @@ func (mr *MockConnectorMockRecorder) RevNotification(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevNotification", reflect.TypeOf((*MockConnector)(nil).RevNotification), arg0, arg1)
 }
 
+// RevNotificationAuthenticated mocks base method
+func (m *MockConnector) RevNotificationAuthenticated(arg0 context.Context, arg1 *path_mgmt.SignedRevInfo, arg2 *snet.SCMPAuthentication) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevNotificationAuthenticated", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevNotificationAuthenticated indicates an expected call of RevNotificationAuthenticated
+func (mr *MockConnectorMockRecorder) RevNotificationAuthenticated(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevNotificationAuthenticated", reflect.TypeOf((*MockConnector)(nil).RevNotificationAuthenticated), arg0, arg1, arg2)
+}
+
 // RevNotificationFromRaw mocks base method
 func (m *MockConnector) RevNotificationFromRaw(arg0 context.Context, arg1 []byte) error {
 	m.ctrl.T.Helper()
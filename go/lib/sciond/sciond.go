@@ -33,6 +33,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/drkey"
@@ -96,9 +97,27 @@ type Connector interface {
 	RevNotificationFromRaw(ctx context.Context, b []byte) error
 	// RevNotification sends a RevocationInfo message to SCIOND.
 	RevNotification(ctx context.Context, sRevInfo *path_mgmt.SignedRevInfo) error
+	// RevNotificationAuthenticated sends a RevocationInfo message to SCIOND, together with
+	// the authenticated SCMP message it was created from.
+	RevNotificationAuthenticated(ctx context.Context, sRevInfo *path_mgmt.SignedRevInfo,
+		auth *snet.SCMPAuthentication) error
 	// DRKeyGetLvl2Key sends a DRKey Lvl2Key request to SCIOND
 	DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
 		valTime time.Time) (drkey.Lvl2Key, error)
+	// ColibriSetupRsv requests from SCIOND a new COLIBRI e2e reservation to dst. If an AS in
+	// the path rejects the request, the returned error is a *reservation.Failure. The
+	// reservation is owned by the host of the caller: only applications on that host can
+	// renew and clean it up, through the same SCIOND.
+	ColibriSetupRsv(ctx context.Context, dst addr.IA,
+		bw reservation.BWCls) (*ColibriReservation, error)
+	// ColibriRenewRsv requests from SCIOND a new index for an existing COLIBRI e2e reservation.
+	ColibriRenewRsv(ctx context.Context, id *reservation.E2EID,
+		bw reservation.BWCls) (*ColibriReservation, error)
+	// ColibriCleanupRsv requests from SCIOND to remove an index of a COLIBRI e2e reservation.
+	ColibriCleanupRsv(ctx context.Context, id *reservation.E2EID,
+		idx reservation.IndexNumber) error
+	// ColibriListRsvs requests from SCIOND the COLIBRI reservations stored in the local AS.
+	ColibriListRsvs(ctx context.Context) (*ColibriReservations, error)
 	// Close shuts down the connection to a SCIOND server.
 	Close(ctx context.Context) error
 }
@@ -31,6 +31,14 @@ type OptionType uint8
 const (
 	OptTypePad1 OptionType = iota
 	OptTypePadN
+	// OptTypeSPSE is the end-to-end option carrying a SCION Packet Security Extension.
+	OptTypeSPSE
+	// OptTypeScmpAuthDRKey is the end-to-end option carrying an SCMP authenticator computed
+	// with a DRKey.
+	OptTypeScmpAuthDRKey
+	// OptTypeScmpAuthHashTree is the end-to-end option carrying an SCMP authenticator made of
+	// a signed hash tree root and the proof of the message.
+	OptTypeScmpAuthHashTree
 )
 
 type tlvOption struct {
@@ -139,7 +147,7 @@ func serializeTLVOptions(buf []byte, options []*tlvOption, fixLengths bool) int
 		length += opt.length(fixLengths)
 	}
 	if fixLengths {
-		pad := length % LineLen
+		pad := (LineLen - length%LineLen) % LineLen
 		if pad != 0 {
 			if !dryrun {
 				serializeTLVOptionPadding(buf[length-2:], pad)
@@ -98,6 +98,19 @@ func TestSerializeTLVOptions(t *testing.T) {
 	assert.Equal(t, rawTLVOptionsYX, b, "Serialize OptY|OptX")
 }
 
+func TestSerializeTLVOptionsTrailingPadding(t *testing.T) {
+	opt := slayers.TLVOption{
+		OptType: 0x3e,
+		OptData: []byte{0x11, 0x22, 0x33, 0x44, 0x55},
+	}
+	l := slayers.SerializeTLVOptions(nil, []*slayers.TLVOption{&opt}, true)
+	b := make([]byte, l)
+	slayers.SerializeTLVOptions(b, []*slayers.TLVOption{&opt}, true)
+	// the option ends 1 byte after a line, the remaining 3 bytes are padded with PadN.
+	expected := []byte{0x3e, 0x05, 0x11, 0x22, 0x33, 0x44, 0x55, 0x01, 0x01, 0x00}
+	assert.Equal(t, expected, b)
+}
+
 func TestHopByHopExtnSerialize(t *testing.T) {
 	hbh := slayers.HopByHopExtn{}
 	hbh.NextHdr = common.L4UDP
@@ -0,0 +1,175 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+)
+
+// PathType denotes the COLIBRI path type identifier.
+const PathType path.Type = 4
+
+// MinLen is the length of a COLIBRI path without hop fields.
+const MinLen = TimestampLen + InfoLen
+
+// MaxHops is the maximum number of hop fields in a COLIBRI path.
+const MaxHops = 64
+
+// RegisterPath registers the COLIBRI path type globally.
+func RegisterPath() {
+	path.RegisterPath(path.Metadata{
+		Type: PathType,
+		Desc: "COLIBRI",
+		New: func() path.Path {
+			return &Path{}
+		},
+	})
+}
+
+// Path is the COLIBRI path type. It consists of the packet timestamp, one InfoField describing
+// the reservation, and one HopField per AS on the reservation path:
+//
+//   +-----------+-----------+------------+-----+------------+
+//   | Timestamp | InfoField | HopField 0 | ... | HopField n |
+//   +-----------+-----------+------------+-----+------------+
+//
+// The number of hop fields is given by InfoField.HFCount.
+type Path struct {
+	// Timestamp is the packet timestamp.
+	Timestamp Timestamp
+	// InfoField contains the reservation information common to all hops.
+	InfoField InfoField
+	// HopFields contains one HopField per AS on the path, in reservation direction.
+	HopFields []*HopField
+}
+
+// DecodeFromBytes fully decodes the COLIBRI path into the corresponding fields.
+func (p *Path) DecodeFromBytes(data []byte) error {
+	if len(data) < MinLen {
+		return serrors.New("COLIBRI path raw too short", "expected", MinLen,
+			"actual", len(data))
+	}
+	if err := p.Timestamp.DecodeFromBytes(data[:TimestampLen]); err != nil {
+		return err
+	}
+	offset := TimestampLen
+	if err := p.InfoField.DecodeFromBytes(data[offset : offset+InfoLen]); err != nil {
+		return err
+	}
+	offset += InfoLen
+	numHops := int(p.InfoField.HFCount)
+	if numHops == 0 || numHops > MaxHops {
+		return serrors.New("invalid number of hop fields", "hf_count", numHops,
+			"max", MaxHops)
+	}
+	if p.InfoField.CurrHF >= p.InfoField.HFCount {
+		return serrors.New("current hop field out of range", "curr_hf", p.InfoField.CurrHF,
+			"hf_count", p.InfoField.HFCount)
+	}
+	if minLen := p.Len(); len(data) < minLen {
+		return serrors.New("COLIBRI path raw too short", "expected", minLen,
+			"actual", len(data))
+	}
+	p.HopFields = make([]*HopField, numHops)
+	for i := 0; i < numHops; i++ {
+		hop := &HopField{}
+		if err := hop.DecodeFromBytes(data[offset : offset+HopLen]); err != nil {
+			return err
+		}
+		p.HopFields[i] = hop
+		offset += HopLen
+	}
+	return nil
+}
+
+// SerializeTo writes the path to a slice. The slice must be big enough to hold the entire data,
+// otherwise an error is returned. The HFCount of the InfoField must match the number of hop
+// fields.
+func (p *Path) SerializeTo(b []byte) error {
+	if int(p.InfoField.HFCount) != len(p.HopFields) {
+		return serrors.New("hop field count mismatch", "hf_count", p.InfoField.HFCount,
+			"hop_fields", len(p.HopFields))
+	}
+	if len(b) < p.Len() {
+		return serrors.New("buffer too small to serialize path", "expected", p.Len(),
+			"actual", len(b))
+	}
+	if err := p.Timestamp.SerializeTo(b[:TimestampLen]); err != nil {
+		return err
+	}
+	offset := TimestampLen
+	if err := p.InfoField.SerializeTo(b[offset : offset+InfoLen]); err != nil {
+		return err
+	}
+	offset += InfoLen
+	for _, hop := range p.HopFields {
+		if err := hop.SerializeTo(b[offset : offset+HopLen]); err != nil {
+			return err
+		}
+		offset += HopLen
+	}
+	return nil
+}
+
+// Reverse reverses a COLIBRI path. The order of the hop fields is reversed, the R flag is
+// toggled and the current hop field index is adjusted. The ingress and egress interfaces of the
+// hop fields are kept in reservation direction, so that the MACs remain valid.
+func (p *Path) Reverse() (path.Path, error) {
+	if len(p.HopFields) == 0 {
+		return nil, serrors.New("cannot reverse COLIBRI path without hop fields")
+	}
+	for i, j := 0, len(p.HopFields)-1; i < j; i, j = i+1, j-1 {
+		p.HopFields[i], p.HopFields[j] = p.HopFields[j], p.HopFields[i]
+	}
+	p.InfoField.R = !p.InfoField.R
+	p.InfoField.CurrHF = uint8(len(p.HopFields)) - p.InfoField.CurrHF - 1
+	return p, nil
+}
+
+// Len returns the length of the path in bytes.
+func (p *Path) Len() int {
+	return MinLen + int(p.InfoField.HFCount)*HopLen
+}
+
+// Type returns the COLIBRI path type identifier.
+func (p *Path) Type() path.Type {
+	return PathType
+}
+
+// CurrentHopField returns the hop field pointed at by CurrHF.
+func (p *Path) CurrentHopField() (*HopField, error) {
+	idx := int(p.InfoField.CurrHF)
+	if idx >= len(p.HopFields) {
+		return nil, serrors.New("current hop field out of range", "curr_hf", idx,
+			"hop_fields", len(p.HopFields))
+	}
+	return p.HopFields[idx], nil
+}
+
+// IsLastHop returns whether CurrHF points to the last hop field of the path.
+func (p *Path) IsLastHop() bool {
+	return int(p.InfoField.CurrHF) == len(p.HopFields)-1
+}
+
+// IncPath increments the current hop field index.
+func (p *Path) IncPath() error {
+	if p.IsLastHop() {
+		return serrors.New("path already at destination", "curr_hf", p.InfoField.CurrHF,
+			"hop_fields", len(p.HopFields))
+	}
+	p.InfoField.CurrHF++
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+func TestSerializeDecode(t *testing.T) {
+	want := newPath()
+	b := make([]byte, want.Len())
+	require.NoError(t, want.SerializeTo(b))
+	assert.Equal(t, colibri.MinLen+3*colibri.HopLen, len(b))
+
+	got := &colibri.Path{}
+	require.NoError(t, got.DecodeFromBytes(b))
+	assert.Equal(t, want, got)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	p := newPath()
+	b := make([]byte, p.Len())
+	require.NoError(t, p.SerializeTo(b))
+
+	cases := map[string]func() []byte{
+		"too short": func() []byte {
+			return b[:colibri.MinLen-1]
+		},
+		"missing hop fields": func() []byte {
+			return b[:len(b)-1]
+		},
+		"no hop fields": func() []byte {
+			raw := append([]byte(nil), b...)
+			raw[colibri.TimestampLen+2] = 0
+			return raw
+		},
+		"current hop out of range": func() []byte {
+			raw := append([]byte(nil), b...)
+			raw[colibri.TimestampLen+1] = 3
+			return raw
+		},
+	}
+	for name, raw := range cases {
+		name, raw := name, raw
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := &colibri.Path{}
+			assert.Error(t, got.DecodeFromBytes(raw()))
+		})
+	}
+}
+
+func TestSerializeCountMismatch(t *testing.T) {
+	p := newPath()
+	p.InfoField.HFCount = 2
+	assert.Error(t, p.SerializeTo(make([]byte, 1024)))
+}
+
+func TestReverse(t *testing.T) {
+	p := newPath()
+	p.InfoField.CurrHF = 0
+	rev, err := p.Reverse()
+	require.NoError(t, err)
+	r := rev.(*colibri.Path)
+	assert.True(t, r.InfoField.R)
+	assert.Equal(t, uint8(2), r.InfoField.CurrHF)
+	assert.Equal(t, uint16(3), r.HopFields[0].IngressID)
+	assert.Equal(t, uint16(0), r.HopFields[2].IngressID)
+
+	// reversing twice yields the original path.
+	rev, err = r.Reverse()
+	require.NoError(t, err)
+	want := newPath()
+	want.InfoField.CurrHF = 0
+	assert.Equal(t, want, rev)
+}
+
+func TestIncPath(t *testing.T) {
+	p := newPath()
+	p.InfoField.CurrHF = 0
+	require.NoError(t, p.IncPath())
+	hf, err := p.CurrentHopField()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(1), hf.IngressID)
+	require.NoError(t, p.IncPath())
+	assert.True(t, p.IsLastHop())
+	assert.Error(t, p.IncPath())
+}
+
+func TestRegistered(t *testing.T) {
+	colibri.RegisterPath()
+	p, err := path.NewPath(colibri.PathType)
+	require.NoError(t, err)
+	assert.IsType(t, &colibri.Path{}, p)
+	assert.Panics(t, colibri.RegisterPath)
+}
+
+func TestTimestamp(t *testing.T) {
+	exp := time.Unix(1000, 0)
+	now := exp.Add(-10*time.Second - 5*time.Microsecond)
+	ts, err := colibri.NewTimestamp(exp, now, 42)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10000005), ts.TsRel)
+	assert.Equal(t, now, ts.Time(exp))
+
+	_, err = colibri.NewTimestamp(exp, exp.Add(time.Second), 0)
+	assert.Error(t, err)
+	_, err = colibri.NewTimestamp(exp.Add(2*time.Hour), now, 0)
+	assert.Error(t, err)
+}
+
+func newPath() *colibri.Path {
+	return &colibri.Path{
+		Timestamp: colibri.Timestamp{
+			TsRel: 0x01020304,
+			PckID: 0x05060708,
+		},
+		InfoField: colibri.InfoField{
+			C:           true,
+			S:           true,
+			CurrHF:      1,
+			HFCount:     3,
+			ResIDSuffix: [12]byte{0xbe, 0xef, 0xca, 0xfe},
+			ExpTick:     0x11223344,
+			BwCls:       13,
+			RLC:         4,
+			Idx:         2,
+			PathType:    3,
+		},
+		HopFields: []*colibri.HopField{
+			{IngressID: 0, EgressID: 1, Mac: [4]byte{1, 2, 3, 4}},
+			{IngressID: 1, EgressID: 2, Mac: [4]byte{5, 6, 7, 8}},
+			{IngressID: 3, EgressID: 0, Mac: [4]byte{9, 10, 11, 12}},
+		},
+	}
+}
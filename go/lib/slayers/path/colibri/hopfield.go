@@ -0,0 +1,74 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// HopLen is the size of a COLIBRI HopField in bytes.
+	HopLen = 8
+	// MacLen is the size of the MAC of each COLIBRI HopField.
+	MacLen = 4
+)
+
+// HopField is the HopField used in the COLIBRI path type.
+//
+// The Hop Field has the following format:
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |           IngressID           |            EgressID           |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                              MAC                              |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+type HopField struct {
+	// IngressID is the ingress interface ID in reservation direction.
+	IngressID uint16
+	// EgressID is the egress interface ID in reservation direction.
+	EgressID uint16
+	// Mac is the 4-byte Message Authentication Code to authenticate the HopField.
+	Mac [MacLen]byte
+}
+
+// DecodeFromBytes populates the fields from a raw buffer. The buffer must be of length >=
+// colibri.HopLen
+func (h *HopField) DecodeFromBytes(raw []byte) error {
+	if len(raw) < HopLen {
+		return serrors.New("COLIBRI HopField raw too short", "expected", HopLen,
+			"actual", len(raw))
+	}
+	h.IngressID = binary.BigEndian.Uint16(raw[0:2])
+	h.EgressID = binary.BigEndian.Uint16(raw[2:4])
+	copy(h.Mac[:], raw[4:8])
+	return nil
+}
+
+// SerializeTo writes the fields into the provided buffer. The buffer must be of length >=
+// colibri.HopLen
+func (h *HopField) SerializeTo(b []byte) error {
+	if len(b) < HopLen {
+		return serrors.New("buffer for COLIBRI HopField too short", "expected", HopLen,
+			"actual", len(b))
+	}
+	binary.BigEndian.PutUint16(b[0:2], h.IngressID)
+	binary.BigEndian.PutUint16(b[2:4], h.EgressID)
+	copy(b[4:8], h.Mac[:])
+	return nil
+}
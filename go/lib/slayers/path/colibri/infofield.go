@@ -0,0 +1,131 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// InfoLen is the size of a COLIBRI InfoField in bytes.
+const InfoLen = 24
+
+// ResIDSuffixLen is the length of the reservation ID suffix carried in the InfoField.
+// Segment reservation suffixes are shorter and padded with zeroes.
+const ResIDSuffixLen = 12
+
+// InfoField is the InfoField used in the COLIBRI path type.
+//
+// InfoField has the following format:
+//
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |C R S r r r r r|    CurrHF     |    HFCount    |      RSV      |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                                                               |
+//   +                                                               +
+//   |                          ResIDSuffix                          |
+//   +                                                               +
+//   |                                                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                            ExpTick                            |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |     BwCls     |      RLC      |  Idx  |r| PT  |      RSV      |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+type InfoField struct {
+	// C is the control flag. It is set for COLIBRI control plane packets.
+	C bool
+	// R is the reverse flag. It is set if the packet travels against the reservation direction.
+	R bool
+	// S is the segment flag. It is set if the hop fields belong to a segment reservation,
+	// and unset if they belong to an E2E reservation.
+	S bool
+	// CurrHF is the index of the current hop field.
+	CurrHF uint8
+	// HFCount is the number of hop fields in the path.
+	HFCount uint8
+	// ResIDSuffix is the suffix of the reservation ID. The AS part of the ID is not carried.
+	ResIDSuffix [ResIDSuffixLen]byte
+	// ExpTick is the expiration tick of the reservation (4 seconds per tick).
+	ExpTick uint32
+	// BwCls is the bandwidth class of the reservation.
+	BwCls uint8
+	// RLC is the request latency class of the reservation.
+	RLC uint8
+	// Idx is the index number of the reservation (4 bits).
+	Idx uint8
+	// PathType is the reservation path type (3 bits).
+	PathType uint8
+}
+
+// DecodeFromBytes populates the fields from a raw buffer. The buffer must be of length >=
+// colibri.InfoLen
+func (inf *InfoField) DecodeFromBytes(raw []byte) error {
+	if len(raw) < InfoLen {
+		return serrors.New("COLIBRI InfoField raw too short", "expected", InfoLen,
+			"actual", len(raw))
+	}
+	inf.C = raw[0]&0x80 == 0x80
+	inf.R = raw[0]&0x40 == 0x40
+	inf.S = raw[0]&0x20 == 0x20
+	inf.CurrHF = raw[1]
+	inf.HFCount = raw[2]
+	copy(inf.ResIDSuffix[:], raw[4:16])
+	inf.ExpTick = binary.BigEndian.Uint32(raw[16:20])
+	inf.BwCls = raw[20]
+	inf.RLC = raw[21]
+	inf.Idx = raw[22] >> 4
+	inf.PathType = raw[22] & 0x7
+	return nil
+}
+
+// SerializeTo writes the fields into the provided buffer. The buffer must be of length >=
+// colibri.InfoLen
+func (inf *InfoField) SerializeTo(b []byte) error {
+	if len(b) < InfoLen {
+		return serrors.New("buffer for COLIBRI InfoField too short", "expected", InfoLen,
+			"actual", len(b))
+	}
+	b[0] = 0
+	if inf.C {
+		b[0] |= 0x80
+	}
+	if inf.R {
+		b[0] |= 0x40
+	}
+	if inf.S {
+		b[0] |= 0x20
+	}
+	b[1] = inf.CurrHF
+	b[2] = inf.HFCount
+	b[3] = 0
+	copy(b[4:16], inf.ResIDSuffix[:])
+	binary.BigEndian.PutUint32(b[16:20], inf.ExpTick)
+	b[20] = inf.BwCls
+	b[21] = inf.RLC
+	b[22] = inf.Idx<<4 | inf.PathType&0x7
+	b[23] = 0
+	return nil
+}
+
+// Expiration returns the expiration time of the reservation.
+func (inf *InfoField) Expiration() time.Time {
+	return reservation.Tick(inf.ExpTick).ToTime()
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// MACInputLen is the length of the input to the hop field MAC computation.
+const MACInputLen = 32
+
+// MAC computes the 4-byte MAC of the hop field, using the AS-local hash h. The MAC covers the
+// reservation ID suffix, the reservation properties of the InfoField, the S flag and the
+// interfaces of the hop field. The C and R flags, CurrHF and the packet timestamp are not
+// covered, as they change while the packet travels.
+func MAC(h hash.Hash, info *InfoField, hf *HopField) [MacLen]byte {
+	h.Reset()
+	input := MACInput(info, hf)
+	// Write must not return an error: https://godoc.org/hash#Hash
+	if _, err := h.Write(input); err != nil {
+		panic(err)
+	}
+	var mac [MacLen]byte
+	copy(mac[:], h.Sum(nil))
+	return mac
+}
+
+// VerifyMAC verifies that the MAC in the hop field is correct.
+func VerifyMAC(h hash.Hash, info *InfoField, hf *HopField) error {
+	expectedMac := MAC(h, info, hf)
+	if subtle.ConstantTimeCompare(hf.Mac[:], expectedMac[:]) == 0 {
+		return serrors.New("COLIBRI MAC",
+			"expected", fmt.Sprintf("%x", expectedMac),
+			"actual", fmt.Sprintf("%x", hf.Mac))
+	}
+	return nil
+}
+
+// MACInput returns the input to the MAC computation of the hop field.
+//
+// The input has the following format:
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                                                               |
+//   +                                                               +
+//   |                          ResIDSuffix                          |
+//   +                                                               +
+//   |                                                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                            ExpTick                            |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |     BwCls     |      RLC      |  Idx  |r| PT  |r r S r r r r r|
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |           IngressID           |            EgressID           |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                             zero                              |
+//   +                                                               +
+//   |                                                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+func MACInput(info *InfoField, hf *HopField) []byte {
+	input := make([]byte, MACInputLen)
+	copy(input[0:12], info.ResIDSuffix[:])
+	binary.BigEndian.PutUint32(input[12:16], info.ExpTick)
+	input[16] = info.BwCls
+	input[17] = info.RLC
+	input[18] = info.Idx<<4 | info.PathType&0x7
+	if info.S {
+		input[19] = 0x20
+	}
+	binary.BigEndian.PutUint16(input[20:22], hf.IngressID)
+	binary.BigEndian.PutUint16(input[22:24], hf.EgressID)
+	return input
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+func TestMAC(t *testing.T) {
+	h, err := scrypto.InitMac([]byte("testkey_xxxxxxxx"))
+	require.NoError(t, err)
+	p := newPath()
+	hf := p.HopFields[1]
+	hf.Mac = colibri.MAC(h, &p.InfoField, hf)
+	assert.NoError(t, colibri.VerifyMAC(h, &p.InfoField, hf))
+
+	// the mutable fields are not covered by the MAC.
+	p.InfoField.C = false
+	p.InfoField.R = true
+	p.InfoField.CurrHF = 2
+	assert.NoError(t, colibri.VerifyMAC(h, &p.InfoField, hf))
+
+	cases := map[string]func(*colibri.InfoField, *colibri.HopField){
+		"suffix":   func(inf *colibri.InfoField, _ *colibri.HopField) { inf.ResIDSuffix[0]++ },
+		"exp tick": func(inf *colibri.InfoField, _ *colibri.HopField) { inf.ExpTick++ },
+		"bw class": func(inf *colibri.InfoField, _ *colibri.HopField) { inf.BwCls++ },
+		"index":    func(inf *colibri.InfoField, _ *colibri.HopField) { inf.Idx++ },
+		"S flag":   func(inf *colibri.InfoField, _ *colibri.HopField) { inf.S = false },
+		"ingress":  func(_ *colibri.InfoField, hf *colibri.HopField) { hf.IngressID++ },
+		"egress":   func(_ *colibri.InfoField, hf *colibri.HopField) { hf.EgressID++ },
+		"mac":      func(_ *colibri.InfoField, hf *colibri.HopField) { hf.Mac[0]++ },
+	}
+	for name, modify := range cases {
+		t.Run(name, func(t *testing.T) {
+			info := p.InfoField
+			hop := *hf
+			modify(&info, &hop)
+			assert.Error(t, colibri.VerifyMAC(h, &info, &hop))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// TimestampLen is the size of the COLIBRI packet timestamp in bytes.
+const TimestampLen = 8
+
+// Timestamp is the packet timestamp of a COLIBRI packet.
+//
+// The Timestamp has the following format:
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                             TsRel                             |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                             PckID                             |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+type Timestamp struct {
+	// TsRel is the time between the creation of the packet and the expiration of the
+	// reservation, in microseconds.
+	TsRel uint32
+	// PckID identifies the packet among those sent by the same source with the same TsRel.
+	PckID uint32
+}
+
+// NewTimestamp returns the timestamp of a packet created at time now, for a reservation
+// expiring at time exp. It returns an error if the reservation is already expired or
+// expires too far in the future to be represented.
+func NewTimestamp(exp, now time.Time, pckID uint32) (Timestamp, error) {
+	rel := exp.Sub(now)
+	if rel < 0 {
+		return Timestamp{}, serrors.New("reservation already expired", "expiration", exp,
+			"now", now)
+	}
+	micros := rel / time.Microsecond
+	if micros > 1<<32-1 {
+		return Timestamp{}, serrors.New("expiration too far in the future",
+			"expiration", exp, "now", now)
+	}
+	return Timestamp{TsRel: uint32(micros), PckID: pckID}, nil
+}
+
+// Time returns the absolute creation time of the packet, given the expiration time of the
+// reservation.
+func (ts *Timestamp) Time(exp time.Time) time.Time {
+	return exp.Add(-time.Duration(ts.TsRel) * time.Microsecond)
+}
+
+// DecodeFromBytes populates the fields from a raw buffer. The buffer must be of length >=
+// colibri.TimestampLen
+func (ts *Timestamp) DecodeFromBytes(raw []byte) error {
+	if len(raw) < TimestampLen {
+		return serrors.New("COLIBRI Timestamp raw too short", "expected", TimestampLen,
+			"actual", len(raw))
+	}
+	ts.TsRel = binary.BigEndian.Uint32(raw[0:4])
+	ts.PckID = binary.BigEndian.Uint32(raw[4:8])
+	return nil
+}
+
+// SerializeTo writes the fields into the provided buffer. The buffer must be of length >=
+// colibri.TimestampLen
+func (ts *Timestamp) SerializeTo(b []byte) error {
+	if len(b) < TimestampLen {
+		return serrors.New("buffer for COLIBRI Timestamp too short", "expected",
+			TimestampLen, "actual", len(b))
+	}
+	binary.BigEndian.PutUint32(b[0:4], ts.TsRel)
+	binary.BigEndian.PutUint32(b[4:8], ts.PckID)
+	return nil
+}
@@ -16,6 +16,7 @@ package snet
 
 import (
 	"context"
+	"errors"
 	"net"
 	"time"
 
@@ -87,7 +88,8 @@ type SCMPHandler interface {
 
 // DefaultSCMPHandler handles SCMP messages received from the network. If a
 // revocation handler is configured, it is informed of any received interface
-// down messages.
+// down messages. Revocation handlers implementing AuthenticatedRevocationHandler
+// also get the authentication of the messages that carry one.
 type DefaultSCMPHandler struct {
 	// RevocationHandler manages revocations received via SCMP. If nil, the
 	// handler is not called.
@@ -106,7 +108,7 @@ func (h DefaultSCMPHandler) Handle(pkt *Packet) error {
 	switch scmp.Type() {
 	case slayers.SCMPTypeExternalInterfaceDown:
 		msg := pkt.Payload.(SCMPExternalInterfaceDown)
-		return h.handleSCMPRev(typeCode, &path_mgmt.RevInfo{
+		return h.handleSCMPRev(pkt, typeCode, &path_mgmt.RevInfo{
 			IfID:         common.IFIDType(msg.Interface),
 			RawIsdas:     msg.IA.IAInt(),
 			RawTimestamp: util.TimeToSecs(time.Now()),
@@ -114,7 +116,7 @@ func (h DefaultSCMPHandler) Handle(pkt *Packet) error {
 		})
 	case slayers.SCMPTypeInternalConnectivityDown:
 		msg := pkt.Payload.(SCMPInternalConnectivityDown)
-		return h.handleSCMPRev(typeCode, &path_mgmt.RevInfo{
+		return h.handleSCMPRev(pkt, typeCode, &path_mgmt.RevInfo{
 			IfID:         common.IFIDType(msg.Egress),
 			RawIsdas:     msg.IA.IAInt(),
 			RawTimestamp: util.TimeToSecs(time.Now()),
@@ -127,7 +129,7 @@ func (h DefaultSCMPHandler) Handle(pkt *Packet) error {
 	}
 }
 
-func (h *DefaultSCMPHandler) handleSCMPRev(typeCode slayers.SCMPTypeCode,
+func (h *DefaultSCMPHandler) handleSCMPRev(pkt *Packet, typeCode slayers.SCMPTypeCode,
 	revInfo *path_mgmt.RevInfo) error {
 
 	sRev, err := path_mgmt.NewSignedRevInfo(revInfo)
@@ -139,7 +141,23 @@ func (h *DefaultSCMPHandler) handleSCMPRev(typeCode slayers.SCMPTypeCode,
 		return serrors.WrapStr("packing signed rev info", err)
 	}
 	if h.RevocationHandler != nil {
-		h.RevocationHandler.RevokeRaw(context.TODO(), raw)
+		h.revoke(pkt, raw)
 	}
 	return &OpError{typeCode: typeCode, revInfo: revInfo}
 }
+
+// revoke passes the revocation to the revocation handler, together with the authentication of
+// the SCMP message if the handler accepts it.
+func (h *DefaultSCMPHandler) revoke(pkt *Packet, raw common.RawBytes) {
+	if authHandler, ok := h.RevocationHandler.(AuthenticatedRevocationHandler); ok {
+		auth, err := NewSCMPAuthentication(pkt)
+		if err == nil {
+			authHandler.RevokeAuthenticated(context.TODO(), raw, auth)
+			return
+		}
+		if !errors.Is(err, ErrNoSCMPAuthentication) {
+			log.Debug("Extracting SCMP authentication", "err", err)
+		}
+	}
+	h.RevocationHandler.RevokeRaw(context.TODO(), raw)
+}
@@ -16,15 +16,39 @@ package snet
 
 import (
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 )
 
 var NewScionConnWriter = newScionConnWriter
 
+const (
+	MaxSPSEKeys    = maxSPSEKeys
+	MaxSPSEFetches = maxSPSEFetches
+)
+
 func NewScionConnBase(localIA addr.IA, listen *net.UDPAddr) *scionConnBase {
 	return &scionConnBase{
 		listen:   listen,
 		scionNet: &SCIONNetwork{LocalIA: localIA},
 	}
 }
+
+func (a *SPSEAuthenticator) Authenticate(pkt *PacketInfo) error {
+	return a.authenticate(pkt)
+}
+
+func (a *SPSEAuthenticator) Verify(pkt *PacketInfo) error {
+	return a.verify(pkt)
+}
+
+// ExpireFetches lets the verifier fetch all its cached keys again, as if the last fetches
+// happened long ago.
+func (v *SCMPVerifier) ExpireFetches() {
+	v.keys.mtx.Lock()
+	defer v.keys.mtx.Unlock()
+	for _, e := range v.keys.keys {
+		e.Value.(*lvl2KeyEntry).fetched = time.Time{}
+	}
+}
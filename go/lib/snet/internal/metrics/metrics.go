@@ -30,6 +30,8 @@ const (
 	subRead            = "read"
 	subWrite           = "write"
 	subSCMPError       = "scmp_error"
+	subSCMPAuth        = "scmp_auth"
+	subSPSE            = "spse"
 	subDispatcherError = "dispatcher_error"
 	subParseError      = "parse_error"
 )
@@ -49,6 +51,8 @@ type metrics struct {
 	writePackets     prometheus.Counter
 	parseErrors      prometheus.Counter
 	scmpErrors       prometheus.Counter
+	scmpDropped      prometheus.Counter
+	spseReplays      prometheus.Counter
 	dispatcherErrors prometheus.Counter
 }
 
@@ -70,6 +74,10 @@ func newMetrics() metrics {
 			"Total number of packets written"),
 		scmpErrors: prom.NewCounter(Namespace, subSCMPError, "total",
 			"Total number of SCMP errors"),
+		scmpDropped: prom.NewCounter(Namespace, subSCMPAuth, "dropped_total",
+			"Total number of SCMP errors dropped because they are not authenticated"),
+		spseReplays: prom.NewCounter(Namespace, subSPSE, "replays_total",
+			"Total number of SPSE packets rejected as replays or for a stale timestamp"),
 		dispatcherErrors: prom.NewCounter(Namespace, subDispatcherError, "total",
 			"Total number of dispatcher errors"),
 		parseErrors: prom.NewCounter(Namespace, subParseError, "total",
@@ -122,6 +130,18 @@ func (m metrics) SCMPErrors() prometheus.Counter {
 	return m.scmpErrors
 }
 
+// SCMPDropped returns the counter of the SCMP errors dropped because they are not
+// authenticated.
+func (m metrics) SCMPDropped() prometheus.Counter {
+	return m.scmpDropped
+}
+
+// SPSEReplays returns the counter of the SPSE packets rejected as replays or for a stale
+// timestamp.
+func (m metrics) SPSEReplays() prometheus.Counter {
+	return m.spseReplays
+}
+
 // ParseErrors returns the parse errors counter.
 func (m metrics) ParseErrors() prometheus.Counter {
 	return m.parseErrors
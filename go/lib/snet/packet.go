@@ -256,14 +256,15 @@ type Packet struct {
 func (p *Packet) Decode() error {
 	var (
 		scionLayer slayers.SCION
+		e2eLayer   slayers.EndToEndExtn
 		udpLayer   slayers.UDP
 		scmpLayer  slayers.SCMP
 	)
 	parser := gopacket.NewDecodingLayerParser(
-		slayers.LayerTypeSCION, &scionLayer, &udpLayer, &scmpLayer,
+		slayers.LayerTypeSCION, &scionLayer, &e2eLayer, &udpLayer, &scmpLayer,
 	)
 	parser.IgnoreUnsupported = true
-	decoded := make([]gopacket.LayerType, 3)
+	decoded := make([]gopacket.LayerType, 4)
 	if err := parser.DecodeLayers(p.Bytes, &decoded); err != nil {
 		return err
 	}
@@ -292,6 +293,12 @@ func (p *Packet) Decode() error {
 	}
 	p.Destination = SCIONAddress{IA: scionLayer.DstIA, Host: dstHost}
 	p.Source = SCIONAddress{IA: scionLayer.SrcIA, Host: srcHost}
+	p.E2EOptions = nil
+	for _, l := range decoded {
+		if l == slayers.LayerTypeEndToEndExtn {
+			p.E2EOptions = e2eLayer.Options
+		}
+	}
 	// A path of length 4 is an empty path, because it only contains the mandatory
 	// minimal header.
 	if l := scionLayer.Path.Len(); l > 4 {
@@ -465,7 +472,14 @@ func (p *Packet) Serialize() error {
 	}
 
 	packetLayers = append(packetLayers, &scionLayer)
-	packetLayers = append(packetLayers, p.Payload.toLayers(&scionLayer)...)
+	l4Layers := p.Payload.toLayers(&scionLayer)
+	if len(p.E2EOptions) > 0 {
+		e2e := &slayers.EndToEndExtn{Options: p.E2EOptions}
+		e2e.NextHdr = scionLayer.NextHdr
+		scionLayer.NextHdr = common.End2EndClass
+		packetLayers = append(packetLayers, e2e)
+	}
+	packetLayers = append(packetLayers, l4Layers...)
 
 	buffer := gopacket.NewSerializeBuffer()
 	options := gopacket.SerializeOptions{
@@ -497,6 +511,9 @@ type PacketInfo struct {
 	// If the source and destination are in different ASes but the path is
 	// nil or empty, an error is returned during serialization.
 	Path spath.Path
+	// E2EOptions are the options of the end-to-end extension header. If empty, the packet has
+	// no end-to-end extension. The option data of decoded packets references the raw packet.
+	E2EOptions []*slayers.EndToEndOption
 	// Payload is the Payload of the message.
 	Payload Payload
 }
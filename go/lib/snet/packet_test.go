@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/slayers/path"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
@@ -91,6 +92,35 @@ func TestPacketSerializeDecodeLoop(t *testing.T) {
 				},
 			},
 		},
+		"UDP packet with E2E option": {
+			PacketInfo: snet.PacketInfo{
+				Destination: snet.SCIONAddress{
+					IA:   xtest.MustParseIA("1-ff00:0:110"),
+					Host: addr.HostIPv4(net.ParseIP("127.0.0.2").To4()),
+				},
+				Source: snet.SCIONAddress{
+					IA:   xtest.MustParseIA("1-ff00:0:112"),
+					Host: addr.HostIPv4(net.ParseIP("127.0.0.1").To4()),
+				},
+				Path: spath.Path{
+					Raw:  rawSP,
+					Type: scion.PathType,
+				},
+				E2EOptions: []*slayers.EndToEndOption{
+					{
+						OptType:      slayers.OptTypeSPSE,
+						OptDataLen:   8,
+						ActualLength: 10,
+						OptData:      []byte("e2e data"),
+					},
+				},
+				Payload: snet.UDPPayload{
+					SrcPort: 25,
+					DstPort: 1925,
+					Payload: []byte("hello packet"),
+				},
+			},
+		},
 		"SCMP EchoRequest": {
 			PacketInfo: snet.PacketInfo{
 				Destination: snet.SCIONAddress{
@@ -67,9 +67,22 @@ func (c *scionConnReader) read(b []byte) (int, *UDPAddr, error) {
 		Bytes: Bytes(c.buffer),
 	}
 	var lastHop net.UDPAddr
-	err := c.conn.ReadFrom(&pkt, &lastHop)
-	if err != nil {
-		return 0, nil, err
+	for {
+		err := c.conn.ReadFrom(&pkt, &lastHop)
+		if err != nil {
+			return 0, nil, err
+		}
+		auth := c.base.scionNet.SPSE
+		if auth == nil {
+			break
+		}
+		err = auth.verify(&pkt.PacketInfo)
+		if err == nil {
+			break
+		}
+		if auth.Policy == SPSEReport {
+			return 0, nil, err
+		}
 	}
 
 	var n int
@@ -93,7 +106,7 @@ func (c *scionConnReader) read(b []byte) (int, *UDPAddr, error) {
 
 		// Extract path
 		remote.Path = pkt.Path.Copy()
-		if err = remote.Path.Reverse(); err != nil {
+		if err := remote.Path.Reverse(); err != nil {
 			return 0, nil, serrors.WrapStr("Unable to reverse path on received packet", err)
 		}
 
@@ -0,0 +1,315 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/snet/internal/metrics"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+)
+
+// ErrNoSCMPAuthentication indicates that an SCMP message carries no authentication extension.
+var ErrNoSCMPAuthentication = serrors.New("SCMP message not authenticated")
+
+// SCMPAuthPolicy defines how the SCMP error messages are handled depending on their
+// authentication. SCMP informational messages are never authenticated, they are always
+// handled.
+type SCMPAuthPolicy int
+
+const (
+	// SCMPAuthIgnore handles all SCMP error messages without checking their authentication.
+	SCMPAuthIgnore SCMPAuthPolicy = iota
+	// SCMPAuthPrefer drops the SCMP error messages with an invalid authentication. The
+	// messages without authentication are handled, except that the ones reporting an
+	// interface down do not invalidate paths: they are only reported to the application.
+	SCMPAuthPrefer
+	// SCMPAuthRequire drops the SCMP error messages without a valid authentication.
+	SCMPAuthRequire
+)
+
+// ParseSCMPAuthPolicy parses the policy from its name: "ignore", "prefer" or "require". The
+// empty string is parsed as SCMPAuthIgnore.
+func ParseSCMPAuthPolicy(s string) (SCMPAuthPolicy, error) {
+	switch s {
+	case "", "ignore":
+		return SCMPAuthIgnore, nil
+	case "prefer":
+		return SCMPAuthPrefer, nil
+	case "require":
+		return SCMPAuthRequire, nil
+	default:
+		return 0, serrors.New("unknown SCMP authentication policy", "policy", s)
+	}
+}
+
+func (p SCMPAuthPolicy) String() string {
+	switch p {
+	case SCMPAuthIgnore:
+		return "ignore"
+	case SCMPAuthPrefer:
+		return "prefer"
+	case SCMPAuthRequire:
+		return "require"
+	default:
+		return "unknown"
+	}
+}
+
+// SCMPAuthentication is an SCMP message with its authentication, i.e. everything needed to
+// verify the message again, e.g. in the SCION Daemon.
+type SCMPAuthentication struct {
+	// SrcIA is the AS that sent the message.
+	SrcIA addr.IA
+	// DstIA is the destination AS of the message.
+	DstIA addr.IA
+	// DstHost is the destination host of the message.
+	DstHost addr.HostAddr
+	// Message is the raw SCMP message, i.e. the SCMP header and payload.
+	Message []byte
+	// OptType is the type of the end-to-end option authenticating the message, either
+	// slayers.OptTypeScmpAuthDRKey or slayers.OptTypeScmpAuthHashTree.
+	OptType slayers.OptionType
+	// OptData is the data of the end-to-end option.
+	OptData []byte
+}
+
+// NewSCMPAuthentication extracts the SCMP message and its authentication from the packet. If
+// the packet carries no SCMP authentication option, an error wrapping ErrNoSCMPAuthentication
+// is returned.
+func NewSCMPAuthentication(pkt *Packet) (*SCMPAuthentication, error) {
+	var opt *slayers.EndToEndOption
+	for _, o := range pkt.E2EOptions {
+		if o.OptType == slayers.OptTypeScmpAuthDRKey ||
+			o.OptType == slayers.OptTypeScmpAuthHashTree {
+
+			opt = o
+			break
+		}
+	}
+	if opt == nil {
+		return nil, ErrNoSCMPAuthentication
+	}
+	var (
+		scionLayer slayers.SCION
+		e2eLayer   slayers.EndToEndExtn
+		scmpLayer  slayers.SCMP
+	)
+	parser := gopacket.NewDecodingLayerParser(
+		slayers.LayerTypeSCION, &scionLayer, &e2eLayer, &scmpLayer,
+	)
+	parser.IgnoreUnsupported = true
+	decoded := make([]gopacket.LayerType, 3)
+	if err := parser.DecodeLayers(pkt.Bytes, &decoded); err != nil {
+		return nil, err
+	}
+	if len(decoded) == 0 || decoded[len(decoded)-1] != slayers.LayerTypeSCMP {
+		return nil, serrors.New("packet does not contain an SCMP message")
+	}
+	msg := make([]byte, 0, len(scmpLayer.Contents)+len(scmpLayer.Payload))
+	msg = append(append(msg, scmpLayer.Contents...), scmpLayer.Payload...)
+	return &SCMPAuthentication{
+		SrcIA:   pkt.Source.IA,
+		DstIA:   pkt.Destination.IA,
+		DstHost: pkt.Destination.Host,
+		Message: msg,
+		OptType: opt.OptType,
+		OptData: append([]byte(nil), opt.OptData...),
+	}, nil
+}
+
+// SCMPVerifier verifies the authentication of SCMP messages. The SCMPAuthDRKey extension is
+// verified with the AS-to-host DRKey of the scmp protocol from the source AS to the
+// destination host, the SCMPAuthHashTree extension with the AS certificate of the source AS.
+// During the grace period after an epoch switch, the DRKeys of both epochs are accepted.
+//
+// An SCMPVerifier must not be copied after first use.
+type SCMPVerifier struct {
+	// Keys fetches the DRKeys. If nil, the DRKey extensions are not accepted.
+	Keys SPSEKeyFetcher
+	// HashTree verifies the hash tree extensions. If nil, they are not accepted.
+	HashTree *scmp_auth.HashTreeVerifier
+	// FetchTimeout bounds the time to fetch a key. If zero, DefaultSPSEFetchTimeout is used.
+	FetchTimeout time.Duration
+
+	keys lvl2KeyCache
+}
+
+// Verify checks the authentication of the SCMP message. If the message is not authenticated,
+// an error wrapping ErrUnauthenticated is returned.
+func (v *SCMPVerifier) Verify(ctx context.Context, auth *SCMPAuthentication) error {
+	switch auth.OptType {
+	case slayers.OptTypeScmpAuthDRKey:
+		if v.Keys == nil {
+			return serrors.WithCtx(ErrUnauthenticated, "reason", "DRKey extension not accepted")
+		}
+		extn, err := scmp_auth.DRKeyExtnFromRaw(auth.OptData)
+		if err != nil {
+			return serrors.Wrap(ErrUnauthenticated, err)
+		}
+		if extn.Direction != scmp_auth.AsToHost {
+			return serrors.WithCtx(ErrUnauthenticated, "reason", "unsupported direction",
+				"direction", extn.Direction)
+		}
+		if !isUnicastHost(auth.DstHost) {
+			return serrors.WithCtx(ErrUnauthenticated, "reason", "no unicast destination",
+				"dst", auth.DstHost)
+		}
+		meta := drkey.Lvl2Meta{
+			KeyType:  drkey.AS2Host,
+			Protocol: scmp_auth.DRKeyProtocol,
+			SrcIA:    auth.SrcIA,
+			DstIA:    auth.DstIA,
+			DstHost:  auth.DstHost,
+		}
+		// The router authenticates with the key of the newest epoch, while the cache can hold
+		// the key of the previous one, still valid during the grace period. Every key valid
+		// now is tried and, if none matches, the newest key is fetched again.
+		now := time.Now()
+		keys, err := v.keys.getAll(v.Keys, v.FetchTimeout, meta, now)
+		if err != nil {
+			return serrors.Wrap(ErrUnauthenticated, err)
+		}
+		for _, key := range keys {
+			if err = extn.Verify(key.Key, auth.Message); err == nil {
+				return nil
+			}
+		}
+		key, fresh, fetchErr := v.keys.refresh(v.Keys, v.FetchTimeout, meta, now)
+		if fetchErr != nil {
+			return serrors.Wrap(ErrUnauthenticated, fetchErr)
+		}
+		if fresh {
+			err = extn.Verify(key.Key, auth.Message)
+		}
+		if err != nil {
+			return serrors.Wrap(ErrUnauthenticated, err, "src", auth.SrcIA)
+		}
+		return nil
+	case slayers.OptTypeScmpAuthHashTree:
+		if v.HashTree == nil {
+			return serrors.WithCtx(ErrUnauthenticated,
+				"reason", "hash tree extension not accepted")
+		}
+		extn, err := scmp_auth.HashTreeExtnFromRaw(auth.OptData)
+		if err != nil {
+			return serrors.Wrap(ErrUnauthenticated, err)
+		}
+		if err := v.HashTree.Verify(ctx, auth.SrcIA, auth.Message, extn); err != nil {
+			return serrors.Wrap(ErrUnauthenticated, err, "src", auth.SrcIA)
+		}
+		return nil
+	default:
+		return serrors.WithCtx(ErrUnauthenticated, "reason", "unknown option",
+			"type", auth.OptType)
+	}
+}
+
+// AuthenticatedRevocationHandler is a RevocationHandler that forwards the authentication of
+// the SCMP messages reporting interfaces down, e.g. to a SCION Daemon that only accepts
+// authenticated notifications.
+type AuthenticatedRevocationHandler interface {
+	RevocationHandler
+	// RevokeAuthenticated handles a revocation created from the authenticated SCMP message.
+	RevokeAuthenticated(ctx context.Context, rawSRevInfo common.RawBytes,
+		auth *SCMPAuthentication)
+}
+
+// VerifyingSCMPHandler checks the authentication of the SCMP error messages before passing
+// them to the wrapped handler, as defined by the policy. In particular, with the prefer and
+// require policies the messages reporting an interface down only invalidate paths if they are
+// authenticated by the AS of the interface.
+type VerifyingSCMPHandler struct {
+	// Verifier verifies the authentication of the messages.
+	Verifier *SCMPVerifier
+	// Policy defines which messages are handled.
+	Policy SCMPAuthPolicy
+	// Handler handles the messages accepted by the policy, e.g. a DefaultSCMPHandler.
+	Handler SCMPHandler
+}
+
+func (h *VerifyingSCMPHandler) Handle(pkt *Packet) error {
+	scmp, ok := pkt.Payload.(SCMPPayload)
+	if !ok {
+		return serrors.New("scmp handler invoked with non-scmp packet", "pkt", pkt)
+	}
+	typeCode := slayers.CreateSCMPTypeCode(scmp.Type(), scmp.Code())
+	if h.Policy == SCMPAuthIgnore || typeCode.InfoMsg() {
+		return h.Handler.Handle(pkt)
+	}
+	err := h.verify(pkt)
+	switch {
+	case err == nil:
+		return h.Handler.Handle(pkt)
+	case h.Policy == SCMPAuthPrefer && errors.Is(err, ErrNoSCMPAuthentication):
+		if isInterfaceDown(scmp) {
+			metrics.M.SCMPErrors().Inc()
+			return &OpError{typeCode: typeCode}
+		}
+		return h.Handler.Handle(pkt)
+	default:
+		metrics.M.SCMPDropped().Inc()
+		log.Debug("Dropping unauthenticated SCMP message", "scmp", typeCode,
+			"src", pkt.Source, "err", err)
+		return nil
+	}
+}
+
+// verify checks that the SCMP message in the packet is authenticated, and that the messages
+// reporting an interface down originate in the AS of the interface.
+func (h *VerifyingSCMPHandler) verify(pkt *Packet) error {
+	switch msg := pkt.Payload.(type) {
+	case SCMPExternalInterfaceDown:
+		if !msg.IA.Equal(pkt.Source.IA) {
+			return serrors.WithCtx(ErrUnauthenticated, "reason", "interface of other AS",
+				"src", pkt.Source.IA, "ia", msg.IA)
+		}
+	case SCMPInternalConnectivityDown:
+		if !msg.IA.Equal(pkt.Source.IA) {
+			return serrors.WithCtx(ErrUnauthenticated, "reason", "interface of other AS",
+				"src", pkt.Source.IA, "ia", msg.IA)
+		}
+	}
+	auth, err := NewSCMPAuthentication(pkt)
+	if err != nil {
+		return err
+	}
+	if h.Verifier == nil {
+		return serrors.WithCtx(ErrUnauthenticated, "reason", "no verifier")
+	}
+	timeout := h.Verifier.FetchTimeout
+	if timeout == 0 {
+		timeout = DefaultSPSEFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return h.Verifier.Verify(ctx, auth)
+}
+
+func isInterfaceDown(scmp SCMPPayload) bool {
+	t := scmp.Type()
+	return t == slayers.SCMPTypeExternalInterfaceDown ||
+		t == slayers.SCMPTypeInternalConnectivityDown
+}
@@ -0,0 +1,396 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+type staticKeys []crypto.PublicKey
+
+func (k staticKeys) ASKeys(context.Context, addr.IA, []byte,
+	time.Time) ([]crypto.PublicKey, error) {
+
+	return k, nil
+}
+
+type countingHandler struct {
+	handled int
+}
+
+func (h *countingHandler) Handle(*snet.Packet) error {
+	h.handled++
+	return nil
+}
+
+type revocationRecorder struct {
+	raw  int
+	auth []*snet.SCMPAuthentication
+}
+
+func (r *revocationRecorder) RevokeRaw(context.Context, common.RawBytes) {
+	r.raw++
+}
+
+func (r *revocationRecorder) RevokeAuthenticated(_ context.Context, _ common.RawBytes,
+	auth *snet.SCMPAuthentication) {
+
+	r.auth = append(r.auth, auth)
+}
+
+// epochKeyFetcher returns the keys of the epoch it is set to. Epoch 0 ends, and epoch 1
+// starts, within a grace period around the creation time, and the keys of both epochs differ.
+type epochKeyFetcher struct {
+	base  time.Time
+	epoch int
+	calls int
+}
+
+func (f *epochKeyFetcher) DRKeyGetLvl2Key(_ context.Context, meta drkey.Lvl2Meta,
+	_ time.Time) (drkey.Lvl2Key, error) {
+
+	f.calls++
+	lvl1 := drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{
+			SrcIA: meta.SrcIA,
+			DstIA: meta.DstIA,
+		},
+		Key: xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+	}
+	lvl1.Key[0] += byte(f.epoch)
+	begin := f.base.Add(time.Duration(f.epoch-1) * time.Hour)
+	lvl1.Epoch.NotBefore = begin.Add(-time.Minute)
+	lvl1.Epoch.NotAfter = begin.Add(time.Hour + time.Minute)
+	meta.Epoch = lvl1.Epoch
+	return protocol.Standard{}.DeriveLvl2(meta, lvl1)
+}
+
+// scmpAuthSender authenticates SCMP messages as the router of the source AS does.
+type scmpAuthSender struct {
+	keys   snet.SPSEKeyFetcher
+	signer *scmp_auth.HashTreeSigner
+}
+
+func (s scmpAuthSender) newPacket(payload snet.Payload) *snet.Packet {
+	return &snet.Packet{
+		Bytes: make(snet.Bytes, 1500),
+		PacketInfo: snet.PacketInfo{
+			Destination: snet.SCIONAddress{
+				IA:   xtest.MustParseIA("1-ff00:0:110"),
+				Host: addr.HostFromIP(net.IPv4(127, 0, 0, 2)),
+			},
+			Source: snet.SCIONAddress{
+				IA:   xtest.MustParseIA("1-ff00:0:112"),
+				Host: addr.HostFromIP(net.IPv4(127, 0, 0, 1)),
+			},
+			Path:    spath.Path{},
+			Payload: payload,
+		},
+	}
+}
+
+// receive serializes the packet and decodes it as the receiver does.
+func (s scmpAuthSender) receive(t *testing.T, pkt *snet.Packet) *snet.Packet {
+	require.NoError(t, pkt.Serialize())
+	received := &snet.Packet{Bytes: append(snet.Bytes{}, pkt.Bytes...)}
+	require.NoError(t, received.Decode())
+	return received
+}
+
+// message returns the raw SCMP message of the packet, as authenticated by the sender.
+func (s scmpAuthSender) message(t *testing.T, pkt *snet.Packet,
+	optType slayers.OptionType) []byte {
+
+	pkt.E2EOptions = []*slayers.EndToEndOption{{OptType: optType, OptData: []byte{0}}}
+	auth, err := snet.NewSCMPAuthentication(s.receive(t, pkt))
+	require.NoError(t, err)
+	return auth.Message
+}
+
+func (s scmpAuthSender) withDRKey(t *testing.T, pkt *snet.Packet) *snet.Packet {
+	msg := s.message(t, pkt, slayers.OptTypeScmpAuthDRKey)
+	key, err := s.keys.DRKeyGetLvl2Key(context.Background(), drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: scmp_auth.DRKeyProtocol,
+		SrcIA:    pkt.Source.IA,
+		DstIA:    pkt.Destination.IA,
+		DstHost:  pkt.Destination.Host,
+	}, time.Now())
+	require.NoError(t, err)
+	extn := scmp_auth.NewDRKeyExtn()
+	require.NoError(t, extn.SetDirection(scmp_auth.AsToHost))
+	require.NoError(t, extn.Authenticate(key.Key, msg))
+	e2e := &slayers.EndToEndExtn{}
+	require.NoError(t, scmp_auth.EncodeDRKeyExtn(e2e, extn))
+	pkt.E2EOptions = e2e.Options
+	return s.receive(t, pkt)
+}
+
+func (s scmpAuthSender) withHashTree(t *testing.T, pkt *snet.Packet) *snet.Packet {
+	msg := s.message(t, pkt, slayers.OptTypeScmpAuthHashTree)
+	extns, err := s.signer.Sign([][]byte{msg})
+	require.NoError(t, err)
+	e2e := &slayers.EndToEndExtn{}
+	require.NoError(t, scmp_auth.EncodeHashTreeExtn(e2e, extns[0]))
+	pkt.E2EOptions = e2e.Options
+	return s.receive(t, pkt)
+}
+
+func TestVerifyingSCMPHandler(t *testing.T) {
+	_, asKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sender := scmpAuthSender{
+		keys: &keyFetcher{},
+		signer: &scmp_auth.HashTreeSigner{
+			Signer:       asKey,
+			SubjectKeyID: make([]byte, scmp_auth.SubjectKeyIDLength),
+		},
+	}
+	forger := scmpAuthSender{
+		signer: &scmp_auth.HashTreeSigner{
+			Signer:       otherKey,
+			SubjectKeyID: make([]byte, scmp_auth.SubjectKeyIDLength),
+		},
+	}
+	verifier := &snet.SCMPVerifier{
+		Keys:     &keyFetcher{},
+		HashTree: &scmp_auth.HashTreeVerifier{Keys: staticKeys{asKey.Public()}},
+	}
+	ifDown := func() snet.Payload {
+		return snet.SCMPExternalInterfaceDown{
+			IA:        xtest.MustParseIA("1-ff00:0:112"),
+			Interface: 4,
+			Payload:   []byte("quoted packet"),
+		}
+	}
+
+	testCases := map[string]struct {
+		Packet func(t *testing.T) *snet.Packet
+		// Handled lists, per policy, whether the packet is passed to the wrapped handler.
+		Handled map[snet.SCMPAuthPolicy]bool
+		// OpError lists the policies under which an OpError is returned without handling.
+		OpError map[snet.SCMPAuthPolicy]bool
+	}{
+		"drkey": {
+			Packet: func(t *testing.T) *snet.Packet {
+				return sender.withDRKey(t, sender.newPacket(ifDown()))
+			},
+			Handled: map[snet.SCMPAuthPolicy]bool{
+				snet.SCMPAuthIgnore: true, snet.SCMPAuthPrefer: true, snet.SCMPAuthRequire: true,
+			},
+		},
+		"hash tree": {
+			Packet: func(t *testing.T) *snet.Packet {
+				return sender.withHashTree(t, sender.newPacket(ifDown()))
+			},
+			Handled: map[snet.SCMPAuthPolicy]bool{
+				snet.SCMPAuthIgnore: true, snet.SCMPAuthPrefer: true, snet.SCMPAuthRequire: true,
+			},
+		},
+		"forged hash tree": {
+			Packet: func(t *testing.T) *snet.Packet {
+				return forger.withHashTree(t, forger.newPacket(ifDown()))
+			},
+			Handled: map[snet.SCMPAuthPolicy]bool{snet.SCMPAuthIgnore: true},
+		},
+		"modified message": {
+			Packet: func(t *testing.T) *snet.Packet {
+				pkt := sender.newPacket(ifDown())
+				options := sender.withDRKey(t, pkt).E2EOptions
+				msg := ifDown().(snet.SCMPExternalInterfaceDown)
+				msg.Interface = 5
+				pkt.Payload, pkt.E2EOptions = msg, options
+				return sender.receive(t, pkt)
+			},
+			Handled: map[snet.SCMPAuthPolicy]bool{snet.SCMPAuthIgnore: true},
+		},
+		"interface of other AS": {
+			Packet: func(t *testing.T) *snet.Packet {
+				msg := ifDown().(snet.SCMPExternalInterfaceDown)
+				msg.IA = xtest.MustParseIA("1-ff00:0:111")
+				return sender.withDRKey(t, sender.newPacket(msg))
+			},
+			Handled: map[snet.SCMPAuthPolicy]bool{snet.SCMPAuthIgnore: true},
+		},
+		"unauthenticated interface down": {
+			Packet: func(t *testing.T) *snet.Packet {
+				return sender.receive(t, sender.newPacket(ifDown()))
+			},
+			Handled: map[snet.SCMPAuthPolicy]bool{snet.SCMPAuthIgnore: true},
+			OpError: map[snet.SCMPAuthPolicy]bool{snet.SCMPAuthPrefer: true},
+		},
+		"unauthenticated error": {
+			Packet: func(t *testing.T) *snet.Packet {
+				return sender.receive(t, sender.newPacket(snet.SCMPDestinationUnreachable{
+					Payload: []byte("quoted packet"),
+				}))
+			},
+			Handled: map[snet.SCMPAuthPolicy]bool{
+				snet.SCMPAuthIgnore: true, snet.SCMPAuthPrefer: true,
+			},
+		},
+		"informational": {
+			Packet: func(t *testing.T) *snet.Packet {
+				return sender.receive(t, sender.newPacket(snet.SCMPEchoReply{
+					Identifier: 1,
+					SeqNumber:  2,
+				}))
+			},
+			Handled: map[snet.SCMPAuthPolicy]bool{
+				snet.SCMPAuthIgnore: true, snet.SCMPAuthPrefer: true, snet.SCMPAuthRequire: true,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			pkt := tc.Packet(t)
+			for _, policy := range []snet.SCMPAuthPolicy{snet.SCMPAuthIgnore,
+				snet.SCMPAuthPrefer, snet.SCMPAuthRequire} {
+
+				t.Run(policy.String(), func(t *testing.T) {
+					inner := &countingHandler{}
+					h := &snet.VerifyingSCMPHandler{
+						Verifier: verifier,
+						Policy:   policy,
+						Handler:  inner,
+					}
+					err := h.Handle(pkt)
+					var opErr *snet.OpError
+					if tc.OpError[policy] {
+						require.True(t, errors.As(err, &opErr), "err %v", err)
+						assert.Nil(t, opErr.RevInfo())
+					} else {
+						assert.NoError(t, err)
+					}
+					expected := 0
+					if tc.Handled[policy] {
+						expected = 1
+					}
+					assert.Equal(t, expected, inner.handled)
+				})
+			}
+		})
+	}
+}
+
+func TestDefaultSCMPHandlerAuthentication(t *testing.T) {
+	sender := scmpAuthSender{keys: &keyFetcher{}}
+	msg := snet.SCMPExternalInterfaceDown{
+		IA:        xtest.MustParseIA("1-ff00:0:112"),
+		Interface: 4,
+	}
+
+	t.Run("authenticated", func(t *testing.T) {
+		revocations := &revocationRecorder{}
+		h := snet.DefaultSCMPHandler{RevocationHandler: revocations}
+		pkt := sender.withDRKey(t, sender.newPacket(msg))
+		var opErr *snet.OpError
+		require.True(t, errors.As(h.Handle(pkt), &opErr))
+		assert.NotNil(t, opErr.RevInfo())
+		assert.Equal(t, 0, revocations.raw)
+		require.Len(t, revocations.auth, 1)
+		auth := revocations.auth[0]
+		assert.Equal(t, slayers.OptTypeScmpAuthDRKey, auth.OptType)
+		assert.Equal(t, pkt.Source.IA, auth.SrcIA)
+		assert.NoError(t, (&snet.SCMPVerifier{Keys: &keyFetcher{}}).Verify(
+			context.Background(), auth))
+	})
+	t.Run("unauthenticated", func(t *testing.T) {
+		revocations := &revocationRecorder{}
+		h := snet.DefaultSCMPHandler{RevocationHandler: revocations}
+		var opErr *snet.OpError
+		require.True(t, errors.As(h.Handle(sender.receive(t, sender.newPacket(msg))), &opErr))
+		assert.Equal(t, 1, revocations.raw)
+		assert.Empty(t, revocations.auth)
+	})
+}
+
+func TestSCMPVerifierEpochSwitch(t *testing.T) {
+	routerKeys := &epochKeyFetcher{base: time.Now()}
+	sender := scmpAuthSender{keys: routerKeys}
+	hostKeys := &epochKeyFetcher{base: routerKeys.base}
+	v := &snet.SCMPVerifier{Keys: hostKeys}
+	msg := snet.SCMPExternalInterfaceDown{
+		IA:        xtest.MustParseIA("1-ff00:0:112"),
+		Interface: 4,
+	}
+	verify := func(t *testing.T) error {
+		auth, err := snet.NewSCMPAuthentication(sender.withDRKey(t, sender.newPacket(msg)))
+		require.NoError(t, err)
+		return v.Verify(context.Background(), auth)
+	}
+
+	// The host caches the key of the previous epoch, e.g. fetched before the switch.
+	require.NoError(t, verify(t))
+	assert.Equal(t, 1, hostKeys.calls)
+
+	// The router switches to the new epoch, both keys are valid during the grace period.
+	routerKeys.epoch, hostKeys.epoch = 1, 1
+	v.ExpireFetches()
+	assert.NoError(t, verify(t))
+	assert.Equal(t, 2, hostKeys.calls)
+	routerKeys.epoch = 0
+	assert.NoError(t, verify(t))
+	routerKeys.epoch = 1
+	assert.NoError(t, verify(t))
+	assert.Equal(t, 2, hostKeys.calls)
+
+	// Messages with an invalid MAC do not trigger a fetch for every message.
+	routerKeys.epoch = 2
+	v.ExpireFetches()
+	for i := 0; i < 3; i++ {
+		err := verify(t)
+		assert.True(t, errors.Is(err, snet.ErrUnauthenticated), err)
+	}
+	assert.Equal(t, 3, hostKeys.calls)
+}
+
+func TestParseSCMPAuthPolicy(t *testing.T) {
+	for _, policy := range []snet.SCMPAuthPolicy{snet.SCMPAuthIgnore, snet.SCMPAuthPrefer,
+		snet.SCMPAuthRequire} {
+
+		parsed, err := snet.ParseSCMPAuthPolicy(policy.String())
+		assert.NoError(t, err)
+		assert.Equal(t, policy, parsed)
+	}
+	parsed, err := snet.ParseSCMPAuthPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, snet.SCMPAuthIgnore, parsed)
+	_, err = snet.ParseSCMPAuthPolicy("always")
+	assert.Error(t, err)
+}
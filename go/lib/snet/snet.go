@@ -59,6 +59,9 @@ var _ Network = (*SCIONNetwork)(nil)
 type SCIONNetwork struct {
 	LocalIA    addr.IA
 	Dispatcher PacketDispatcherService
+	// SPSE, if set, authenticates the UDP packets sent and received by the connections of the
+	// network with the SCION Packet Security Extension.
+	SPSE *SPSEAuthenticator
 }
 
 // NewNetwork creates a new networking context.
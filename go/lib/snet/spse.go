@@ -0,0 +1,413 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/snet/internal/metrics"
+	"github.com/scionproto/scion/go/lib/spse"
+)
+
+const (
+	// DefaultSPSEFetchTimeout is the default time to fetch a DRKey for SPSE.
+	DefaultSPSEFetchTimeout = 2 * time.Second
+	// DefaultSPSEMaxSkew is the default maximum difference between the timestamp of a
+	// received packet and the local time.
+	DefaultSPSEMaxSkew = time.Minute
+	// maxSPSEKeys is the maximum number of DRKeys cached by an SPSEAuthenticator or an
+	// SCMPVerifier.
+	maxSPSEKeys = 1024
+	// maxSPSEFetches is the maximum number of DRKeys fetched concurrently by an
+	// SPSEAuthenticator or an SCMPVerifier.
+	maxSPSEFetches = 16
+	// minLvl2KeyRefetch is the minimum time between two fetches of the same DRKey after a
+	// message failed to verify with the cached keys.
+	minLvl2KeyRefetch = time.Second
+)
+
+// ErrUnauthenticated indicates that a received packet is not correctly authenticated with the
+// SCION Packet Security Extension.
+var ErrUnauthenticated = serrors.New("packet not authenticated")
+
+// SPSEKeyFetcher obtains the DRKeys used to authenticate packets, e.g. the SCION Daemon
+// connector.
+type SPSEKeyFetcher interface {
+	DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+		valTime time.Time) (drkey.Lvl2Key, error)
+}
+
+// SPSEPolicy defines how the received packets that are not authenticated are handled.
+type SPSEPolicy int
+
+const (
+	// SPSEDrop drops the packets that are not authenticated.
+	SPSEDrop SPSEPolicy = iota
+	// SPSEReport drops the packets that are not authenticated, and returns an error wrapping
+	// ErrUnauthenticated from the read call.
+	SPSEReport
+)
+
+// SPSEAuthenticator authenticates the UDP packets of connections with the SCION Packet
+// Security Extension (SPSE). Every outgoing packet carries an SPSE end-to-end option with the
+// current time and an AES-CMAC computed with the Host2Host DRKey from the source to the
+// destination host, valid at that time. Incoming packets are verified with the same key.
+//
+// The authenticated input is the security mode, the metadata with the timestamp, the UDP
+// source and destination ports and the payload; the addresses are bound by the key. If a
+// ReplayFilter is set, the authenticated packets that are replayed or whose timestamp is not
+// fresh are rejected as well.
+//
+// An SPSEAuthenticator must not be copied after first use.
+type SPSEAuthenticator struct {
+	// Keys fetches the Host2Host DRKeys.
+	Keys SPSEKeyFetcher
+	// Protocol is the DRKey protocol of the keys.
+	Protocol string
+	// Policy is the handling of unauthenticated packets.
+	Policy SPSEPolicy
+	// FetchTimeout bounds the time to fetch a key. If zero, DefaultSPSEFetchTimeout is used.
+	FetchTimeout time.Duration
+	// MaxSkew is the maximum difference between the timestamp of a received packet and the
+	// local time. The packets outside of it are rejected before their key is fetched. If
+	// zero, DefaultSPSEMaxSkew is used.
+	MaxSkew time.Duration
+	// ReplayFilter, if set, rejects the replayed packets and the packets with a timestamp
+	// outside of its acceptance window.
+	ReplayFilter *spse.ReplayFilter
+
+	keys lvl2KeyCache
+}
+
+// authenticate adds the SPSE option to the packet.
+func (a *SPSEAuthenticator) authenticate(pkt *PacketInfo) error {
+	udp, ok := pkt.Payload.(UDPPayload)
+	if !ok {
+		return serrors.New("SPSE only supports UDP packets", "type", common.TypeOf(pkt.Payload))
+	}
+	extn, err := spse.NewExtn(spse.AesCMac)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	extn.SetTimestamp(now)
+	key, err := a.key(pkt.Source, pkt.Destination, now)
+	if err != nil {
+		return err
+	}
+	if err := extn.Authenticate(key.Key, spseInput(udp)); err != nil {
+		return err
+	}
+	e2e := slayers.EndToEndExtn{Options: pkt.E2EOptions}
+	if err := spse.EncodeExtn(&e2e, extn); err != nil {
+		return err
+	}
+	pkt.E2EOptions = e2e.Options
+	return nil
+}
+
+// verify checks the SPSE option of the packet. If the packet is not authenticated, an error
+// wrapping ErrUnauthenticated is returned.
+func (a *SPSEAuthenticator) verify(pkt *PacketInfo) error {
+	udp, ok := pkt.Payload.(UDPPayload)
+	if !ok {
+		return serrors.WithCtx(ErrUnauthenticated, "reason", "not UDP",
+			"type", common.TypeOf(pkt.Payload))
+	}
+	extn, err := spse.DecodeExtn(&slayers.EndToEndExtn{Options: pkt.E2EOptions})
+	if err != nil {
+		return serrors.Wrap(ErrUnauthenticated, err)
+	}
+	if extn.SecMode != spse.AesCMac {
+		return serrors.WithCtx(ErrUnauthenticated, "reason", "unsupported mode",
+			"mode", extn.SecMode)
+	}
+	// The timestamp is checked before fetching the key, so that packets with spoofed
+	// sources and arbitrary timestamps cannot trigger fetches for any epoch.
+	if skew := time.Since(extn.Timestamp()); skew > a.maxSkew() || skew < -a.maxSkew() {
+		return serrors.WithCtx(ErrUnauthenticated, "reason", "timestamp not fresh",
+			"timestamp", extn.Timestamp())
+	}
+	if a.ReplayFilter != nil {
+		if err := a.ReplayFilter.CheckTimestamp(extn.Timestamp()); err != nil {
+			metrics.M.SPSEReplays().Inc()
+			return serrors.Wrap(ErrUnauthenticated, err)
+		}
+	}
+	key, err := a.key(pkt.Source, pkt.Destination, extn.Timestamp())
+	if err != nil {
+		return serrors.Wrap(ErrUnauthenticated, err)
+	}
+	if err := extn.Verify(key.Key, spseInput(udp)); err != nil {
+		return serrors.Wrap(ErrUnauthenticated, err, "src", pkt.Source)
+	}
+	if a.ReplayFilter != nil {
+		err := a.ReplayFilter.Check(pkt.Source.IA, pkt.Source.Host.String(), key.Epoch,
+			extn.Timestamp(), extn.Authenticator)
+		if err != nil {
+			metrics.M.SPSEReplays().Inc()
+			return serrors.Wrap(ErrUnauthenticated, err)
+		}
+	}
+	return nil
+}
+
+func (a *SPSEAuthenticator) maxSkew() time.Duration {
+	if a.MaxSkew == 0 {
+		return DefaultSPSEMaxSkew
+	}
+	return a.MaxSkew
+}
+
+// key returns the Host2Host key from src to dst valid at valTime, from the cache or fetched.
+func (a *SPSEAuthenticator) key(src, dst SCIONAddress, valTime time.Time) (drkey.Lvl2Key,
+	error) {
+
+	if !isUnicastHost(src.Host) || !isUnicastHost(dst.Host) {
+		return drkey.Lvl2Key{}, serrors.New("SPSE requires unicast host addresses",
+			"src", src, "dst", dst)
+	}
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.Host2Host,
+		Protocol: a.Protocol,
+		SrcIA:    src.IA,
+		DstIA:    dst.IA,
+		SrcHost:  src.Host,
+		DstHost:  dst.Host,
+	}
+	key, err := a.keys.get(a.Keys, a.FetchTimeout, meta, valTime)
+	if err != nil {
+		return drkey.Lvl2Key{}, serrors.WrapStr("fetching SPSE key", err)
+	}
+	return key, nil
+}
+
+// lvl2KeyCache caches the level 2 DRKeys obtained from a SPSEKeyFetcher. Several keys, of
+// overlapping epochs, can be cached for the same source and destination. At most maxSPSEKeys
+// sources and destinations are cached, the least recently used ones are evicted. At most
+// maxSPSEFetches keys are fetched concurrently, further fetches fail immediately.
+type lvl2KeyCache struct {
+	mtx  sync.Mutex
+	keys map[lvl2KeyID]*list.Element
+	// lru holds the *lvl2KeyEntry, the most recently used first.
+	lru      list.List
+	fetching int
+}
+
+type lvl2KeyID struct {
+	keyType          drkey.Lvl2KeyType
+	protocol         string
+	srcIA, dstIA     addr.IA
+	srcHost, dstHost string
+}
+
+type lvl2KeyEntry struct {
+	id lvl2KeyID
+	// keys are the cached keys, the newest epoch last.
+	keys []drkey.Lvl2Key
+	// fetched is the time of the last fetch.
+	fetched time.Time
+}
+
+func newLvl2KeyID(meta drkey.Lvl2Meta) lvl2KeyID {
+	id := lvl2KeyID{
+		keyType:  meta.KeyType,
+		protocol: meta.Protocol,
+		srcIA:    meta.SrcIA,
+		dstIA:    meta.DstIA,
+	}
+	if meta.SrcHost != nil {
+		id.srcHost = meta.SrcHost.String()
+	}
+	if meta.DstHost != nil {
+		id.dstHost = meta.DstHost.String()
+	}
+	return id
+}
+
+// get returns the key described by meta valid at valTime, from the cache or fetched within
+// the timeout. If several cached keys are valid, the one of the newest epoch is returned. If
+// the timeout is zero, DefaultSPSEFetchTimeout is used.
+func (c *lvl2KeyCache) get(fetcher SPSEKeyFetcher, timeout time.Duration, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	keys, err := c.getAll(fetcher, timeout, meta, valTime)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	return keys[len(keys)-1], nil
+}
+
+// getAll returns the cached keys described by meta valid at valTime, the newest epoch last.
+// If none is cached, the key is fetched within the timeout.
+func (c *lvl2KeyCache) getAll(fetcher SPSEKeyFetcher, timeout time.Duration,
+	meta drkey.Lvl2Meta, valTime time.Time) ([]drkey.Lvl2Key, error) {
+
+	id := newLvl2KeyID(meta)
+	c.mtx.Lock()
+	var keys []drkey.Lvl2Key
+	if e := c.lookup(id); e != nil {
+		for _, k := range e.keys {
+			if k.Epoch.Contains(valTime) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	c.mtx.Unlock()
+	if len(keys) > 0 {
+		return keys, nil
+	}
+	key, err := c.fetch(fetcher, timeout, id, meta, valTime)
+	if err != nil {
+		return nil, err
+	}
+	return []drkey.Lvl2Key{key}, nil
+}
+
+// refresh fetches the key described by meta valid at valTime, unless it was fetched less than
+// minLvl2KeyRefetch ago. It returns true if the fetched key was not cached yet, e.g. because
+// the source started to use the key of a new epoch.
+func (c *lvl2KeyCache) refresh(fetcher SPSEKeyFetcher, timeout time.Duration,
+	meta drkey.Lvl2Meta, valTime time.Time) (drkey.Lvl2Key, bool, error) {
+
+	id := newLvl2KeyID(meta)
+	c.mtx.Lock()
+	var cached []drkey.Lvl2Key
+	if e := c.lookup(id); e != nil {
+		if time.Since(e.fetched) < minLvl2KeyRefetch {
+			c.mtx.Unlock()
+			return drkey.Lvl2Key{}, false, nil
+		}
+		cached = append(cached, e.keys...)
+	}
+	c.mtx.Unlock()
+	key, err := c.fetch(fetcher, timeout, id, meta, valTime)
+	if err != nil {
+		return drkey.Lvl2Key{}, false, err
+	}
+	for _, k := range cached {
+		if k.Epoch.Equal(key.Epoch) {
+			return key, false, nil
+		}
+	}
+	return key, true, nil
+}
+
+// lookup returns the entry of the ID and marks it as the most recently used, or nil if it is
+// not cached. The caller must hold the lock.
+func (c *lvl2KeyCache) lookup(id lvl2KeyID) *lvl2KeyEntry {
+	elem, ok := c.keys[id]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*lvl2KeyEntry)
+}
+
+// fetch fetches the key within the timeout and adds it to the cache.
+func (c *lvl2KeyCache) fetch(fetcher SPSEKeyFetcher, timeout time.Duration, id lvl2KeyID,
+	meta drkey.Lvl2Meta, valTime time.Time) (drkey.Lvl2Key, error) {
+
+	c.mtx.Lock()
+	if c.fetching >= maxSPSEFetches {
+		c.mtx.Unlock()
+		return drkey.Lvl2Key{}, serrors.New("too many concurrent DRKey fetches")
+	}
+	c.fetching++
+	c.mtx.Unlock()
+	defer func() {
+		c.mtx.Lock()
+		c.fetching--
+		c.mtx.Unlock()
+	}()
+
+	if timeout == 0 {
+		timeout = DefaultSPSEFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	key, err := fetcher.DRKeyGetLvl2Key(ctx, meta, valTime)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+
+	now := time.Now()
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.keys == nil {
+		c.keys = make(map[lvl2KeyID]*list.Element)
+	}
+	e := c.lookup(id)
+	if e == nil {
+		if len(c.keys) >= maxSPSEKeys {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.keys, oldest.Value.(*lvl2KeyEntry).id)
+		}
+		e = &lvl2KeyEntry{id: id}
+		c.keys[id] = c.lru.PushFront(e)
+	}
+	e.fetched = now
+	e.add(key, now)
+	return key, nil
+}
+
+// add adds the key, if its epoch is not cached yet, and removes the expired keys.
+func (e *lvl2KeyEntry) add(key drkey.Lvl2Key, now time.Time) {
+	keys := make([]drkey.Lvl2Key, 0, len(e.keys)+1)
+	found := false
+	for _, k := range e.keys {
+		if k.Epoch.Equal(key.Epoch) {
+			found = true
+		}
+		if k.Epoch.NotAfter.After(now) {
+			keys = append(keys, k)
+		}
+	}
+	if !found {
+		keys = append(keys, key)
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].Epoch.NotBefore.Before(keys[j].Epoch.NotBefore)
+		})
+	}
+	e.keys = keys
+}
+
+// spseInput returns the authenticated part of the UDP packet.
+func spseInput(udp UDPPayload) []byte {
+	input := make([]byte, 4+len(udp.Payload))
+	binary.BigEndian.PutUint16(input[0:2], udp.SrcPort)
+	binary.BigEndian.PutUint16(input[2:4], udp.DstPort)
+	copy(input[4:], udp.Payload)
+	return input
+}
+
+func isUnicastHost(h addr.HostAddr) bool {
+	if h == nil {
+		return false
+	}
+	t := h.Type()
+	return t == addr.HostTypeIPv4 || t == addr.HostTypeIPv6
+}
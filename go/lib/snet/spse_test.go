@@ -0,0 +1,255 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/spse"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+// keyFetcher derives the keys from a fixed level 1 key, as the daemons of both ends do.
+type keyFetcher struct {
+	calls int
+}
+
+func (f *keyFetcher) DRKeyGetLvl2Key(_ context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	f.calls++
+	lvl1 := drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{
+			Epoch: drkey.Epoch{},
+			SrcIA: meta.SrcIA,
+			DstIA: meta.DstIA,
+		},
+		Key: xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+	}
+	lvl1.Epoch.NotBefore = valTime.Add(-time.Hour)
+	lvl1.Epoch.NotAfter = valTime.Add(time.Hour)
+	meta.Epoch = lvl1.Epoch
+	return protocol.Standard{}.DeriveLvl2(meta, lvl1)
+}
+
+// blockingFetcher blocks the fetches until release is closed.
+type blockingFetcher struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (f *blockingFetcher) DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	f.started <- struct{}{}
+	<-f.release
+	return (&keyFetcher{}).DRKeyGetLvl2Key(ctx, meta, valTime)
+}
+
+func TestSPSEConcurrentFetches(t *testing.T) {
+	fetcher := &blockingFetcher{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	a := &snet.SPSEAuthenticator{Keys: fetcher, Protocol: "scmp"}
+	newPkt := func(i int) *snet.PacketInfo {
+		return &snet.PacketInfo{
+			Destination: snet.SCIONAddress{
+				IA:   xtest.MustParseIA("1-ff00:0:110"),
+				Host: addr.HostFromIP(net.IPv4(10, 0, 0, byte(i))),
+			},
+			Source: snet.SCIONAddress{
+				IA:   xtest.MustParseIA("1-ff00:0:112"),
+				Host: addr.HostFromIP(net.IPv4(127, 0, 0, 1)),
+			},
+			Payload: snet.UDPPayload{SrcPort: 25, DstPort: 1925},
+		}
+	}
+	errs := make(chan error, snet.MaxSPSEFetches)
+	for i := 0; i < snet.MaxSPSEFetches; i++ {
+		go func(i int) { errs <- a.Authenticate(newPkt(i)) }(i)
+		<-fetcher.started
+	}
+	// all fetches are in progress, further ones fail without waiting.
+	assert.Error(t, a.Authenticate(newPkt(snet.MaxSPSEFetches)))
+	close(fetcher.release)
+	for i := 0; i < snet.MaxSPSEFetches; i++ {
+		assert.NoError(t, <-errs)
+	}
+}
+
+func TestSPSEAuthenticator(t *testing.T) {
+	scionP := scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{SegLen: [3]uint8{2, 0, 0}},
+			NumINF:   1,
+			NumHops:  2,
+		},
+		InfoFields: []*path.InfoField{{ConsDir: true}},
+		HopFields:  []*path.HopField{{ConsEgress: 4}, {ConsIngress: 1}},
+	}
+	rawSP := make([]byte, scionP.Len())
+	require.NoError(t, scionP.SerializeTo(rawSP))
+	newPacket := func() *snet.Packet {
+		return &snet.Packet{
+			Bytes: make(snet.Bytes, 1500),
+			PacketInfo: snet.PacketInfo{
+				Destination: snet.SCIONAddress{
+					IA:   xtest.MustParseIA("1-ff00:0:110"),
+					Host: addr.HostFromIP(net.IPv4(127, 0, 0, 2)),
+				},
+				Source: snet.SCIONAddress{
+					IA:   xtest.MustParseIA("1-ff00:0:112"),
+					Host: addr.HostFromIP(net.IPv4(127, 0, 0, 1)),
+				},
+				Path: spath.Path{Raw: rawSP, Type: scion.PathType},
+				Payload: snet.UDPPayload{
+					SrcPort: 25,
+					DstPort: 1925,
+					Payload: []byte("hello packet"),
+				},
+			},
+		}
+	}
+	// receive serializes the packet and decodes it as the receiver does.
+	receive := func(t *testing.T, pkt *snet.Packet) *snet.Packet {
+		require.NoError(t, pkt.Serialize())
+		received := &snet.Packet{Bytes: append(snet.Bytes{}, pkt.Bytes...)}
+		require.NoError(t, received.Decode())
+		return received
+	}
+
+	senderKeys, receiverKeys := &keyFetcher{}, &keyFetcher{}
+	sender := &snet.SPSEAuthenticator{Keys: senderKeys, Protocol: "scmp"}
+	receiver := &snet.SPSEAuthenticator{Keys: receiverKeys, Protocol: "scmp"}
+
+	t.Run("authenticated", func(t *testing.T) {
+		pkt := newPacket()
+		require.NoError(t, sender.Authenticate(&pkt.PacketInfo))
+		require.Len(t, pkt.E2EOptions, 1)
+		assert.Equal(t, slayers.OptTypeSPSE, pkt.E2EOptions[0].OptType)
+		assert.NoError(t, receiver.Verify(&receive(t, pkt).PacketInfo))
+	})
+	t.Run("modified payload", func(t *testing.T) {
+		pkt := newPacket()
+		require.NoError(t, sender.Authenticate(&pkt.PacketInfo))
+		received := receive(t, pkt)
+		udp := received.Payload.(snet.UDPPayload)
+		udp.Payload = []byte("other packet")
+		received.Payload = udp
+		err := receiver.Verify(&received.PacketInfo)
+		assert.True(t, errors.Is(err, snet.ErrUnauthenticated), err)
+	})
+	t.Run("other source", func(t *testing.T) {
+		pkt := newPacket()
+		require.NoError(t, sender.Authenticate(&pkt.PacketInfo))
+		pkt.Source.Host = addr.HostFromIP(net.IPv4(127, 0, 0, 3))
+		err := receiver.Verify(&receive(t, pkt).PacketInfo)
+		assert.True(t, errors.Is(err, snet.ErrUnauthenticated), err)
+	})
+	t.Run("not authenticated", func(t *testing.T) {
+		err := receiver.Verify(&receive(t, newPacket()).PacketInfo)
+		assert.True(t, errors.Is(err, snet.ErrUnauthenticated), err)
+	})
+	t.Run("replayed", func(t *testing.T) {
+		filtering := &snet.SPSEAuthenticator{
+			Keys:         &keyFetcher{},
+			Protocol:     "scmp",
+			ReplayFilter: &spse.ReplayFilter{},
+		}
+		pkt := newPacket()
+		require.NoError(t, sender.Authenticate(&pkt.PacketInfo))
+		received := receive(t, pkt)
+		require.NoError(t, filtering.Verify(&received.PacketInfo))
+		err := filtering.Verify(&received.PacketInfo)
+		assert.True(t, errors.Is(err, snet.ErrUnauthenticated), err)
+		assert.True(t, errors.Is(err, spse.ErrReplay), err)
+		// the receiver without filter accepts the replay.
+		assert.NoError(t, receiver.Verify(&received.PacketInfo))
+	})
+	t.Run("stale timestamp", func(t *testing.T) {
+		// staleFrom returns a packet authenticated with a timestamp in the past. Its MAC is
+		// invalid, but it must be rejected before fetching the key to verify it.
+		staleFrom := func(t *testing.T, age time.Duration) *snet.PacketInfo {
+			pkt := newPacket()
+			require.NoError(t, sender.Authenticate(&pkt.PacketInfo))
+			extn, err := spse.DecodeExtn(&slayers.EndToEndExtn{Options: pkt.E2EOptions})
+			require.NoError(t, err)
+			extn.SetTimestamp(time.Now().Add(-age))
+			e2e := &slayers.EndToEndExtn{}
+			require.NoError(t, spse.EncodeExtn(e2e, extn))
+			pkt.E2EOptions = e2e.Options
+			return &receive(t, pkt).PacketInfo
+		}
+		keys := &keyFetcher{}
+		fresh := &snet.SPSEAuthenticator{Keys: keys, Protocol: "scmp"}
+		err := fresh.Verify(staleFrom(t, time.Hour))
+		assert.True(t, errors.Is(err, snet.ErrUnauthenticated), err)
+		filtering := &snet.SPSEAuthenticator{
+			Keys:         keys,
+			Protocol:     "scmp",
+			ReplayFilter: &spse.ReplayFilter{},
+		}
+		err = filtering.Verify(staleFrom(t, 30*time.Second))
+		assert.True(t, errors.Is(err, spse.ErrStaleTimestamp), err)
+		assert.Equal(t, 0, keys.calls)
+	})
+	t.Run("least recently used keys evicted", func(t *testing.T) {
+		keys := &keyFetcher{}
+		cached := &snet.SPSEAuthenticator{Keys: keys, Protocol: "scmp"}
+		senders := &snet.SPSEAuthenticator{Keys: &keyFetcher{}, Protocol: "scmp"}
+		verifyFrom := func(t *testing.T, i int) {
+			pkt := newPacket()
+			pkt.Source.Host = addr.HostFromIP(net.IPv4(10, 0, byte(i>>8), byte(i)))
+			require.NoError(t, senders.Authenticate(&pkt.PacketInfo))
+			require.NoError(t, cached.Verify(&receive(t, pkt).PacketInfo))
+		}
+		for i := 0; i < snet.MaxSPSEKeys; i++ {
+			verifyFrom(t, i)
+		}
+		verifyFrom(t, 0)
+		require.Equal(t, snet.MaxSPSEKeys, keys.calls)
+		// the new source evicts the least recently used one, i.e. the second one.
+		verifyFrom(t, snet.MaxSPSEKeys)
+		verifyFrom(t, 0)
+		assert.Equal(t, snet.MaxSPSEKeys+1, keys.calls)
+		verifyFrom(t, 1)
+		assert.Equal(t, snet.MaxSPSEKeys+2, keys.calls)
+	})
+	t.Run("SVC destination", func(t *testing.T) {
+		pkt := newPacket()
+		pkt.Destination.Host = addr.SvcCS
+		assert.Error(t, sender.Authenticate(&pkt.PacketInfo))
+	})
+	// the keys are cached: the sender always uses the same key, the receiver fetches another
+	// one only for the packet claiming another source.
+	assert.Equal(t, 1, senderKeys.calls)
+	assert.Equal(t, 2, receiverKeys.calls)
+}
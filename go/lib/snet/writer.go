@@ -92,6 +92,12 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 		},
 	}
 
+	if auth := c.base.scionNet.SPSE; auth != nil {
+		if err := auth.authenticate(&pkt.PacketInfo); err != nil {
+			return 0, serrors.WrapStr("authenticating packet", err)
+		}
+	}
+
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 	if err := c.conn.WriteTo(pkt, nextHop); err != nil {
@@ -0,0 +1,105 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spse
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"hash"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ErrInvalidAuthenticator indicates that the authenticator of an extension does not match the
+// authenticated data.
+var ErrInvalidAuthenticator = serrors.New("invalid SPSE authenticator")
+
+// ExtnFromRaw parses an extension from the bytes written by Extn.Write.
+func ExtnFromRaw(b []byte) (*Extn, error) {
+	if len(b) < SecModeLength {
+		return nil, serrors.New("buffer too short", "expected min", SecModeLength,
+			"actual", len(b))
+	}
+	s, err := NewExtn(SecMode(b[0]))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != s.Len() {
+		return nil, serrors.New("invalid SPSE length", "mode", s.SecMode,
+			"expected", s.Len(), "actual", len(b))
+	}
+	authOffset := SecModeLength + len(s.Metadata)
+	copy(s.Metadata, b[SecModeLength:authOffset])
+	copy(s.Authenticator, b[authOffset:])
+	return s, nil
+}
+
+// Timestamp returns the timestamp of the metadata, in seconds since the Unix epoch.
+func (s *Extn) Timestamp() time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint32(s.Metadata[:TimestampLength])), 0)
+}
+
+// SetTimestamp sets the timestamp of the metadata, with a precision of one second.
+func (s *Extn) SetTimestamp(t time.Time) {
+	binary.BigEndian.PutUint32(s.Metadata[:TimestampLength], uint32(t.Unix()))
+}
+
+// Authenticate computes the authenticator over the security mode, the metadata and the input
+// with the key, and sets it in the extension. The metadata must be set beforehand.
+func (s *Extn) Authenticate(key, input []byte) error {
+	auth, err := s.computeAuthenticator(key, input)
+	if err != nil {
+		return err
+	}
+	return s.SetAuthenticator(auth)
+}
+
+// Verify checks that the authenticator of the extension was computed over the input with the
+// key. If it was not, an error wrapping ErrInvalidAuthenticator is returned.
+func (s *Extn) Verify(key, input []byte) error {
+	auth, err := s.computeAuthenticator(key, input)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(auth, s.Authenticator) != 1 {
+		return serrors.WithCtx(ErrInvalidAuthenticator, "mode", s.SecMode)
+	}
+	return nil
+}
+
+// computeAuthenticator computes the authenticator of the symmetric security modes, AES-CMAC
+// and HMAC-SHA256.
+func (s *Extn) computeAuthenticator(key, input []byte) ([]byte, error) {
+	var mac hash.Hash
+	switch s.SecMode {
+	case AesCMac:
+		var err error
+		if mac, err = scrypto.InitMac(key); err != nil {
+			return nil, err
+		}
+	case HmacSha256:
+		mac = hmac.New(sha256.New, key)
+	default:
+		return nil, serrors.New("unsupported SecMode for authentication", "SecMode", s.SecMode)
+	}
+	mac.Write([]byte{uint8(s.SecMode)})
+	mac.Write(s.Metadata)
+	mac.Write(input)
+	return mac.Sum(nil), nil
+}
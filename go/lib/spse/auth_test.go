@@ -0,0 +1,80 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spse_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/spse"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestExtnFromRaw(t *testing.T) {
+	for _, mode := range []spse.SecMode{spse.AesCMac, spse.HmacSha256, spse.Ed25519,
+		spse.GcmAes128} {
+
+		t.Run(mode.String(), func(t *testing.T) {
+			extn, err := spse.NewExtn(mode)
+			require.NoError(t, err)
+			extn.SetTimestamp(time.Unix(1600000000, 0))
+			for i := range extn.Authenticator {
+				extn.Authenticator[i] = byte(i)
+			}
+			raw, err := extn.Pack()
+			require.NoError(t, err)
+			parsed, err := spse.ExtnFromRaw(raw)
+			require.NoError(t, err)
+			assert.Equal(t, extn, parsed)
+			assert.Equal(t, time.Unix(1600000000, 0), parsed.Timestamp())
+
+			_, err = spse.ExtnFromRaw(raw[:len(raw)-1])
+			assert.Error(t, err)
+		})
+	}
+	_, err := spse.ExtnFromRaw(nil)
+	assert.Error(t, err)
+	_, err = spse.ExtnFromRaw([]byte{0xff, 0, 0, 0, 0})
+	assert.Error(t, err)
+}
+
+func TestAuthenticate(t *testing.T) {
+	key := xtest.MustParseHexString("c584cad32613547c64823c756651b6f5")
+	input := []byte("authenticated payload")
+	for _, mode := range []spse.SecMode{spse.AesCMac, spse.HmacSha256} {
+		t.Run(mode.String(), func(t *testing.T) {
+			extn, err := spse.NewExtn(mode)
+			require.NoError(t, err)
+			extn.SetTimestamp(time.Now())
+			require.NoError(t, extn.Authenticate(key, input))
+			require.NoError(t, extn.Verify(key, input))
+
+			err = extn.Verify(key, []byte("other payload"))
+			assert.True(t, errors.Is(err, spse.ErrInvalidAuthenticator), err)
+
+			// the metadata is authenticated, too.
+			extn.SetTimestamp(time.Now().Add(time.Hour))
+			err = extn.Verify(key, input)
+			assert.True(t, errors.Is(err, spse.ErrInvalidAuthenticator), err)
+		})
+	}
+	extn, err := spse.NewExtn(spse.Ed25519)
+	require.NoError(t, err)
+	assert.Error(t, extn.Authenticate(key, input))
+}
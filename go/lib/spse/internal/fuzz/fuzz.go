@@ -0,0 +1,122 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzz
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/spse"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+)
+
+// For additional help, see: go/lib/slayers/internal/fuzz/README.md
+
+// Fuzz fuzzes the decoding of the SPSE options of an end-to-end extension.
+func Fuzz(data []byte) int {
+	var e2e slayers.EndToEndExtn
+	if err := e2e.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
+		return 0
+	}
+	decoded := 0
+	if extn, err := spse.DecodeExtn(&e2e); err == nil {
+		var out slayers.EndToEndExtn
+		if err := spse.EncodeExtn(&out, extn); err != nil {
+			panic(fmt.Sprintf("cannot encode decoded SPSE %v", err))
+		}
+		decoded += FuzzExtn(out.Options[0].OptData)
+	}
+	if extn, err := scmp_auth.DecodeDRKeyExtn(&e2e); err == nil {
+		var out slayers.EndToEndExtn
+		if err := scmp_auth.EncodeDRKeyExtn(&out, extn); err != nil {
+			panic(fmt.Sprintf("cannot encode decoded SCMPAuthDRKey %v", err))
+		}
+		decoded += FuzzDRKeyExtn(out.Options[0].OptData)
+	}
+	if extn, err := scmp_auth.DecodeHashTreeExtn(&e2e); err == nil {
+		var out slayers.EndToEndExtn
+		if err := scmp_auth.EncodeHashTreeExtn(&out, extn); err != nil {
+			panic(fmt.Sprintf("cannot encode decoded SCMPAuthHashTree %v", err))
+		}
+		decoded += FuzzHashTreeExtn(out.Options[0].OptData)
+	}
+	if decoded == 0 {
+		return 0
+	}
+	return 1
+}
+
+// FuzzExtn is the fuzzing target for the SPSE option data.
+func FuzzExtn(data []byte) int {
+	extn, err := spse.ExtnFromRaw(data)
+	if err != nil {
+		return 0
+	}
+	raw, err := extn.Pack()
+	if err != nil {
+		panic(fmt.Sprintf("cannot pack SPSE %v", err))
+	}
+	reparsed, err := spse.ExtnFromRaw(raw)
+	if err != nil {
+		panic(fmt.Sprintf("cannot parse packed SPSE %v", err))
+	}
+	checkEqual(extn, reparsed)
+	return 1
+}
+
+// FuzzDRKeyExtn is the fuzzing target for the SCMPAuthDRKey option data.
+func FuzzDRKeyExtn(data []byte) int {
+	extn, err := scmp_auth.DRKeyExtnFromRaw(data)
+	if err != nil {
+		return 0
+	}
+	raw, err := extn.Pack()
+	if err != nil {
+		panic(fmt.Sprintf("cannot pack SCMPAuthDRKey %v", err))
+	}
+	reparsed, err := scmp_auth.DRKeyExtnFromRaw(raw)
+	if err != nil {
+		panic(fmt.Sprintf("cannot parse packed SCMPAuthDRKey %v", err))
+	}
+	checkEqual(extn, reparsed)
+	return 1
+}
+
+// FuzzHashTreeExtn is the fuzzing target for the SCMPAuthHashTree option data.
+func FuzzHashTreeExtn(data []byte) int {
+	extn, err := scmp_auth.HashTreeExtnFromRaw(data)
+	if err != nil {
+		return 0
+	}
+	raw, err := extn.Pack()
+	if err != nil {
+		panic(fmt.Sprintf("cannot pack SCMPAuthHashTree %v", err))
+	}
+	reparsed, err := scmp_auth.HashTreeExtnFromRaw(raw)
+	if err != nil {
+		panic(fmt.Sprintf("cannot parse packed SCMPAuthHashTree %v", err))
+	}
+	checkEqual(extn, reparsed)
+	return 1
+}
+
+func checkEqual(parsed, reparsed interface{}) {
+	if !reflect.DeepEqual(parsed, reparsed) {
+		panic(fmt.Sprintf("packed extension differs: parsed %v reparsed %v", parsed, reparsed))
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzz
+
+import (
+	"testing"
+)
+
+// This file contains convenience functions for debugging crashers.
+// Replace the 'replace-me' with the quoted crasher
+// and debug the panicking test.
+
+func TestFuzz(t *testing.T) {
+	data := []byte("replace-me")
+	Fuzz(data)
+}
+
+func TestFuzzExtn(t *testing.T) {
+	data := []byte("replace-me")
+	FuzzExtn(data)
+}
+
+func TestFuzzDRKeyExtn(t *testing.T) {
+	data := []byte("replace-me")
+	FuzzDRKeyExtn(data)
+}
+
+func TestFuzzHashTreeExtn(t *testing.T) {
+	data := []byte("replace-me")
+	FuzzHashTreeExtn(data)
+}
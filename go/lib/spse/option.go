@@ -0,0 +1,83 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spse
+
+import (
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+)
+
+// MaxOptionDataLength is the maximum length of the data of an end-to-end option, and thus of
+// an extension carried in one.
+const MaxOptionDataLength = 255
+
+// ErrNoOption indicates that an end-to-end extension has no option of the requested type.
+var ErrNoOption = serrors.New("option not found")
+
+// FindOption returns the first option of the given type in the end-to-end extension. If there
+// is none, an error wrapping ErrNoOption is returned.
+func FindOption(e2e *slayers.EndToEndExtn, t slayers.OptionType) (*slayers.EndToEndOption,
+	error) {
+
+	if e2e != nil {
+		for _, opt := range e2e.Options {
+			if opt.OptType == t {
+				return opt, nil
+			}
+		}
+	}
+	return nil, serrors.WithCtx(ErrNoOption, "type", t)
+}
+
+// SetOption sets an option with the given type and data in the end-to-end extension. An
+// existing option of the same type is replaced, otherwise the option is appended.
+func SetOption(e2e *slayers.EndToEndExtn, t slayers.OptionType, data []byte) error {
+	if len(data) > MaxOptionDataLength {
+		return serrors.New("option data too long", "type", t,
+			"max", MaxOptionDataLength, "actual", len(data))
+	}
+	opt := &slayers.EndToEndOption{
+		OptType:    t,
+		OptDataLen: uint8(len(data)),
+		OptData:    data,
+	}
+	for i, existing := range e2e.Options {
+		if existing.OptType == t {
+			e2e.Options[i] = opt
+			return nil
+		}
+	}
+	e2e.Options = append(e2e.Options, opt)
+	return nil
+}
+
+// DecodeExtn decodes the SPSE option of the end-to-end extension. If there is none, an error
+// wrapping ErrNoOption is returned.
+func DecodeExtn(e2e *slayers.EndToEndExtn) (*Extn, error) {
+	opt, err := FindOption(e2e, slayers.OptTypeSPSE)
+	if err != nil {
+		return nil, err
+	}
+	return ExtnFromRaw(opt.OptData)
+}
+
+// EncodeExtn sets the extension as the SPSE option of the end-to-end extension.
+func EncodeExtn(e2e *slayers.EndToEndExtn, s *Extn) error {
+	raw, err := s.Pack()
+	if err != nil {
+		return err
+	}
+	return SetOption(e2e, slayers.OptTypeSPSE, raw)
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spse_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/spse"
+)
+
+func TestEncodeDecodeExtn(t *testing.T) {
+	extn, err := spse.NewExtn(spse.AesCMac)
+	require.NoError(t, err)
+	extn.SetTimestamp(time.Unix(1600000000, 0))
+	copy(extn.Authenticator, "0123456789abcdef")
+
+	e2e := slayers.EndToEndExtn{
+		Options: []*slayers.EndToEndOption{{OptType: 0x3e, OptData: []byte("other")}},
+	}
+	require.NoError(t, spse.EncodeExtn(&e2e, extn))
+	// encoding again replaces the option.
+	require.NoError(t, spse.EncodeExtn(&e2e, extn))
+	require.Len(t, e2e.Options, 2)
+
+	// the extension travels in a serialized end-to-end extension header.
+	e2e.NextHdr = common.L4UDP
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, e2e.SerializeTo(buf, gopacket.SerializeOptions{FixLengths: true}))
+	var decodedE2E slayers.EndToEndExtn
+	require.NoError(t, decodedE2E.DecodeFromBytes(buf.Bytes(), gopacket.NilDecodeFeedback))
+	decoded, err := spse.DecodeExtn(&decodedE2E)
+	require.NoError(t, err)
+	assert.Equal(t, extn, decoded)
+
+	_, err = spse.DecodeExtn(&slayers.EndToEndExtn{})
+	assert.True(t, errors.Is(err, spse.ErrNoOption), err)
+	_, err = spse.DecodeExtn(&slayers.EndToEndExtn{
+		Options: []*slayers.EndToEndOption{{OptType: slayers.OptTypeSPSE, OptData: []byte{0}}},
+	})
+	assert.Error(t, err)
+}
+
+func TestSetOption(t *testing.T) {
+	var e2e slayers.EndToEndExtn
+	require.NoError(t, spse.SetOption(&e2e, slayers.OptTypeSPSE, []byte{1}))
+	require.NoError(t, spse.SetOption(&e2e, slayers.OptTypeScmpAuthDRKey, []byte{2}))
+	require.NoError(t, spse.SetOption(&e2e, slayers.OptTypeSPSE, []byte{3}))
+	require.Len(t, e2e.Options, 2)
+	opt, err := spse.FindOption(&e2e, slayers.OptTypeSPSE)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3}, opt.OptData)
+	assert.Error(t, spse.SetOption(&e2e, slayers.OptTypeSPSE,
+		make([]byte, spse.MaxOptionDataLength+1)))
+}
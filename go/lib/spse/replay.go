@@ -0,0 +1,290 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spse
+
+import (
+	"hash/maphash"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// DefaultReplayWindow is the default acceptance window of a ReplayFilter.
+	DefaultReplayWindow = 5 * time.Second
+	// DefaultReplaySources is the default number of sources tracked by a ReplayFilter.
+	DefaultReplaySources = 1024
+	// DefaultReplayFilterBits is the default size in bits of the bloom filter of the
+	// authenticators received in one second from one source.
+	DefaultReplayFilterBits = 8192
+
+	// replayHashes is the number of bits set per authenticator in the bloom filters.
+	replayHashes = 3
+)
+
+var (
+	// ErrReplay indicates that a packet with the same authenticator was already received.
+	ErrReplay = serrors.New("replayed SPSE packet")
+	// ErrStaleTimestamp indicates that the timestamp of a packet is outside of the acceptance
+	// window.
+	ErrStaleTimestamp = serrors.New("SPSE timestamp outside of acceptance window")
+)
+
+// ReplayFilter detects replayed packets authenticated with the SCION Packet Security
+// Extension. It is meant to be used by the receivers of the packets, end hosts as well as
+// routers, after the authenticator has been verified.
+//
+// A packet is accepted if its timestamp is within the acceptance window around the current
+// time, and its authenticator has not been seen before. The authenticators are recorded per
+// source host and DRKey epoch, in a sliding window of bloom filters with one filter per second
+// of the acceptance window. A filter is cleared when its second leaves the window. The bloom
+// filters may report false positives, i.e. drop packets that are not replays; their
+// probability grows with the number of packets per second and source, and decreases with
+// FilterBits.
+//
+// The memory is bounded by MaxSources * (2 * Window + 1) * FilterBits / 8 bytes. If more
+// sources are active, the source with the oldest packets is evicted, and from then on the
+// packets of the sources of the same AS without state and with timestamps not newer than the
+// ones of the evicted source are rejected, since they might be replays. The sources of other
+// ASes are not affected, so that an AS sending from many hosts only hinders itself. At most
+// MaxSources ASes are tracked this way; beyond, the oldest one applies to all sources.
+//
+// CheckTimestamp should be called before verifying the authenticator, so that stale packets
+// are rejected before their key is fetched, and Check after it.
+//
+// A ReplayFilter must not be copied after first use.
+type ReplayFilter struct {
+	// Window is the maximum difference between the timestamp of a packet and the current
+	// time, rounded up to seconds. If zero, DefaultReplayWindow is used.
+	Window time.Duration
+	// MaxSources is the maximum number of tracked sources. If zero, DefaultReplaySources is
+	// used.
+	MaxSources int
+	// FilterBits is the size in bits of a bloom filter, rounded up to a multiple of 64. If
+	// zero, DefaultReplayFilterBits is used.
+	FilterBits int
+	// Rejected, if set, counts the rejected packets, with the label "reason" set to "replay"
+	// or "stale".
+	Rejected metrics.Counter
+
+	mtx     sync.Mutex
+	seed    maphash.Seed
+	sources map[replayKey]*replayState
+	// watermarks are the newest timestamps of the evicted sources, per AS.
+	watermarks map[addr.IA]int64
+	// watermark is the newest timestamp of the watermarks that were dropped, it applies to
+	// all ASes.
+	watermark int64
+}
+
+type replayKey struct {
+	ia                  addr.IA
+	host                string
+	notBefore, notAfter int64
+}
+
+// replayState is the sliding window of a source. The slot of timestamp t is at index
+// t mod len(slots). Since there are 2 * window + 1 slots and only timestamps within the
+// window are accepted, a slot holding another timestamp than the one of the packet holds an
+// older timestamp that left the window.
+type replayState struct {
+	newest int64
+	slots  []replaySlot
+}
+
+type replaySlot struct {
+	ts   int64
+	bits []uint64
+}
+
+// CheckTimestamp checks that the timestamp is within the acceptance window. If not, an error
+// wrapping ErrStaleTimestamp is returned.
+func (f *ReplayFilter) CheckTimestamp(timestamp time.Time) error {
+	window := f.windowSecs()
+	if f.stale(timestamp.Unix(), time.Now().Unix(), window) {
+		return serrors.WithCtx(ErrStaleTimestamp, "timestamp", timestamp, "window", window)
+	}
+	return nil
+}
+
+// stale returns whether the timestamp is outside of the window around now, and counts it as
+// rejected if so.
+func (f *ReplayFilter) stale(ts, now, window int64) bool {
+	if ts < now-window || ts > now+window {
+		metrics.CounterInc(metrics.CounterWith(f.Rejected, "reason", "stale"))
+		return true
+	}
+	return false
+}
+
+// Check checks that the packet with the timestamp and the authenticator, sent by the source
+// host in the source AS with a key of the epoch, is not a replay, and records it. The host
+// identifies the sending host within its AS, e.g. its address. If the packet is rejected, an
+// error wrapping ErrReplay or ErrStaleTimestamp is returned.
+func (f *ReplayFilter) Check(srcIA addr.IA, srcHost string, epoch drkey.Epoch,
+	timestamp time.Time, authenticator []byte) error {
+
+	ts, now, window := timestamp.Unix(), time.Now().Unix(), f.windowSecs()
+	if f.stale(ts, now, window) {
+		return serrors.WithCtx(ErrStaleTimestamp, "src_ia", srcIA, "src_host", srcHost,
+			"timestamp", timestamp, "window", window)
+	}
+	key := replayKey{
+		ia:        srcIA,
+		host:      srcHost,
+		notBefore: epoch.NotBefore.Unix(),
+		notAfter:  epoch.NotAfter.Unix(),
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.sources == nil {
+		f.seed = maphash.MakeSeed()
+		f.sources = make(map[replayKey]*replayState)
+		f.watermarks = make(map[addr.IA]int64)
+	}
+	state, ok := f.sources[key]
+	if !ok {
+		if ts <= f.watermark || ts <= f.watermarks[srcIA] {
+			metrics.CounterInc(metrics.CounterWith(f.Rejected, "reason", "replay"))
+			return serrors.WithCtx(ErrReplay, "src_ia", srcIA, "src_host", srcHost,
+				"reason", "source evicted")
+		}
+		f.makeRoom(now - window)
+		state = &replayState{slots: make([]replaySlot, 2*window+1)}
+		f.sources[key] = state
+	}
+	slot := &state.slots[ts%int64(len(state.slots))]
+	if slot.ts != ts || slot.bits == nil {
+		// The timestamp of the slot left the window, see replayState.
+		if slot.bits == nil {
+			slot.bits = make([]uint64, f.filterWords())
+		}
+		for i := range slot.bits {
+			slot.bits[i] = 0
+		}
+		slot.ts = ts
+	}
+	if !slot.add(f.hash(authenticator)) {
+		metrics.CounterInc(metrics.CounterWith(f.Rejected, "reason", "replay"))
+		return serrors.WithCtx(ErrReplay, "src_ia", srcIA, "src_host", srcHost,
+			"timestamp", timestamp)
+	}
+	if ts > state.newest {
+		state.newest = ts
+	}
+	return nil
+}
+
+// makeRoom removes the sources without timestamps newer than oldest, and if there are still
+// too many sources, it evicts the source with the oldest newest timestamp and raises the
+// watermark of its AS.
+func (f *ReplayFilter) makeRoom(oldest int64) {
+	maxSources := f.MaxSources
+	if maxSources == 0 {
+		maxSources = DefaultReplaySources
+	}
+	if len(f.sources) < maxSources {
+		return
+	}
+	for key, state := range f.sources {
+		if state.newest < oldest {
+			delete(f.sources, key)
+		}
+	}
+	// The watermarks older than the window are not needed, such timestamps are stale.
+	for ia, watermark := range f.watermarks {
+		if watermark < oldest {
+			delete(f.watermarks, ia)
+		}
+	}
+	for len(f.sources) >= maxSources {
+		var evict replayKey
+		var evictNewest int64 = -1
+		for key, state := range f.sources {
+			if evictNewest < 0 || state.newest < evictNewest {
+				evict, evictNewest = key, state.newest
+			}
+		}
+		delete(f.sources, evict)
+		if evictNewest > f.watermarks[evict.ia] {
+			f.raiseWatermark(evict.ia, evictNewest, maxSources)
+		}
+	}
+}
+
+// raiseWatermark sets the watermark of the AS. If too many ASes have a watermark, the oldest
+// one is dropped and applied to all ASes instead.
+func (f *ReplayFilter) raiseWatermark(ia addr.IA, watermark int64, maxSources int) {
+	if _, ok := f.watermarks[ia]; !ok && len(f.watermarks) >= maxSources {
+		var drop addr.IA
+		var dropWatermark int64 = -1
+		for ia, watermark := range f.watermarks {
+			if dropWatermark < 0 || watermark < dropWatermark {
+				drop, dropWatermark = ia, watermark
+			}
+		}
+		delete(f.watermarks, drop)
+		if dropWatermark > f.watermark {
+			f.watermark = dropWatermark
+		}
+	}
+	f.watermarks[ia] = watermark
+}
+
+func (f *ReplayFilter) hash(authenticator []byte) uint64 {
+	var h maphash.Hash
+	h.SetSeed(f.seed)
+	// maphash.Hash.Write never fails.
+	_, _ = h.Write(authenticator)
+	return h.Sum64()
+}
+
+func (f *ReplayFilter) windowSecs() int64 {
+	window := f.Window
+	if window == 0 {
+		window = DefaultReplayWindow
+	}
+	return int64((window + time.Second - 1) / time.Second)
+}
+
+func (f *ReplayFilter) filterWords() int {
+	bits := f.FilterBits
+	if bits == 0 {
+		bits = DefaultReplayFilterBits
+	}
+	return (bits + 63) / 64
+}
+
+// add sets the bits of the hash in the bloom filter. It returns false if they were all set
+// already.
+func (s *replaySlot) add(h uint64) bool {
+	n := uint64(len(s.bits) * 64)
+	h1, h2 := h&0xffffffff, h>>32|1
+	added := false
+	for i := uint64(0); i < replayHashes; i++ {
+		bit := (h1 + i*h2) % n
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if s.bits[word]&mask == 0 {
+			added = true
+			s.bits[word] |= mask
+		}
+	}
+	return added
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spse_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/spse"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestReplayFilter(t *testing.T) {
+	now := time.Now()
+	epoch := drkey.Epoch{Validity: cppki.Validity{
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(time.Hour),
+	}}
+	nextEpoch := drkey.Epoch{Validity: cppki.Validity{
+		NotBefore: epoch.NotAfter,
+		NotAfter:  epoch.NotAfter.Add(2 * time.Hour),
+	}}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	authenticator := func(i int) []byte {
+		return []byte(fmt.Sprintf("authenticator %04d", i))
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		rejected := metrics.NewTestCounter()
+		f := &spse.ReplayFilter{Rejected: rejected}
+		require.NoError(t, f.Check(ia, "src", epoch, now, authenticator(1)))
+		err := f.Check(ia, "src", epoch, now, authenticator(1))
+		assert.True(t, errors.Is(err, spse.ErrReplay), "err %v", err)
+		assert.Equal(t, float64(1), metrics.CounterValue(rejected.With("reason", "replay")))
+
+		assert.NoError(t, f.Check(ia, "src", epoch, now, authenticator(2)))
+		assert.NoError(t, f.Check(ia, "other src", epoch, now, authenticator(1)))
+		assert.NoError(t, f.Check(ia, "src", nextEpoch, now, authenticator(1)))
+		assert.NoError(t, f.Check(ia, "src", epoch, now.Add(-time.Second), authenticator(1)))
+	})
+	t.Run("stale", func(t *testing.T) {
+		rejected := metrics.NewTestCounter()
+		f := &spse.ReplayFilter{Window: 2 * time.Second, Rejected: rejected}
+		for _, ts := range []time.Time{now.Add(-time.Minute), now.Add(time.Minute)} {
+			err := f.Check(ia, "src", epoch, ts, authenticator(1))
+			assert.True(t, errors.Is(err, spse.ErrStaleTimestamp), "err %v", err)
+		}
+		assert.Equal(t, float64(2), metrics.CounterValue(rejected.With("reason", "stale")))
+		assert.True(t, errors.Is(f.CheckTimestamp(now.Add(-time.Minute)), spse.ErrStaleTimestamp))
+		assert.NoError(t, f.CheckTimestamp(now))
+		assert.NoError(t, f.Check(ia, "src", epoch, now.Add(-time.Second), authenticator(1)))
+		assert.NoError(t, f.Check(ia, "src", epoch, now.Add(time.Second), authenticator(1)))
+	})
+	t.Run("sliding window", func(t *testing.T) {
+		// The timestamps are within the window even if the clock moves to the next second.
+		f := &spse.ReplayFilter{Window: 2 * time.Second}
+		for i := 0; i < 3; i++ {
+			ts := now.Add(time.Duration(i-1) * time.Second)
+			require.NoError(t, f.Check(ia, "src", epoch, ts, authenticator(i)))
+		}
+		for i := 0; i < 3; i++ {
+			ts := now.Add(time.Duration(i-1) * time.Second)
+			err := f.Check(ia, "src", epoch, ts, authenticator(i))
+			assert.True(t, errors.Is(err, spse.ErrReplay), "err %v", err)
+		}
+	})
+	t.Run("many packets", func(t *testing.T) {
+		f := &spse.ReplayFilter{}
+		for i := 0; i < 200; i++ {
+			require.NoError(t, f.Check(ia, "src", epoch, now, authenticator(i)), "packet %d", i)
+		}
+		for i := 0; i < 200; i++ {
+			assert.Error(t, f.Check(ia, "src", epoch, now, authenticator(i)), "packet %d", i)
+		}
+	})
+	t.Run("evicted source", func(t *testing.T) {
+		f := &spse.ReplayFilter{MaxSources: 2}
+		require.NoError(t, f.Check(ia, "src 1", epoch, now.Add(-time.Second), authenticator(1)))
+		require.NoError(t, f.Check(ia, "src 2", epoch, now, authenticator(1)))
+		require.NoError(t, f.Check(ia, "src 3", epoch, now, authenticator(1)))
+		// src 1 is evicted, its packets of the evicted seconds are rejected.
+		err := f.Check(ia, "src 1", epoch, now.Add(-time.Second), authenticator(1))
+		assert.True(t, errors.Is(err, spse.ErrReplay), "err %v", err)
+		err = f.Check(ia, "src 2", epoch, now, authenticator(1))
+		assert.True(t, errors.Is(err, spse.ErrReplay), "err %v", err)
+	})
+	t.Run("evicted by other AS", func(t *testing.T) {
+		other := xtest.MustParseIA("1-ff00:0:111")
+		f := &spse.ReplayFilter{MaxSources: 2}
+		require.NoError(t, f.Check(ia, "src 1", epoch, now.Add(-time.Second), authenticator(1)))
+		// the other AS sends from many hosts, evicting the sources of both ASes.
+		for i := 0; i < 3; i++ {
+			host := fmt.Sprintf("host %d", i)
+			require.NoError(t, f.Check(other, host, epoch, now, authenticator(1)))
+		}
+		// the new sources of the other AS are rejected, but not the ones of the first AS.
+		err := f.Check(other, "host 3", epoch, now, authenticator(1))
+		assert.True(t, errors.Is(err, spse.ErrReplay), "err %v", err)
+		assert.NoError(t, f.Check(ia, "src 2", epoch, now, authenticator(1)))
+	})
+}
@@ -28,25 +28,30 @@ package scmp_auth
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"fmt"
 
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/spse"
 )
 
-//var _ common.Extension = (*DRKeyExtn)(nil)
-
 // DRKeyExtn is an implementation of the SCMPAuthDRKey extension.
-// It is used to authenticate scmp messages.
+// It is used to authenticate scmp messages. It is carried in the end-to-end option of type
+// slayers.OptTypeScmpAuthDRKey, see DecodeDRKeyExtn and EncodeDRKeyExtn.
 type DRKeyExtn struct {
 	*spse.BaseExtn
 	// Direction indicates which key has been used during authentication.
 	Direction Dir
-	// MAC is the mac of the SCION Packet with CurrHF and CurrINF set to zero.
+	// MAC is the mac of the SCMP message, see Authenticate.
 	MAC common.RawBytes
 }
 
+// DRKeyProtocol is the DRKey protocol of the keys authenticating SCMP messages with the
+// SCMPAuthDRKey extension.
+const DRKeyProtocol = "scmp"
+
 const (
 	DirectionLength = 1
 	PaddingLength   = 3
@@ -93,7 +98,7 @@ func NewDRKeyExtn() *DRKeyExtn {
 	return s
 }
 
-func (s DRKeyExtn) SetDirection(dir Dir) error {
+func (s *DRKeyExtn) SetDirection(dir Dir) error {
 	if dir > HostToHostReversed {
 		return serrors.New("Invalid direction", "dir", dir)
 	}
@@ -101,7 +106,7 @@ func (s DRKeyExtn) SetDirection(dir Dir) error {
 	return nil
 }
 
-func (s DRKeyExtn) SetMAC(mac common.RawBytes) error {
+func (s *DRKeyExtn) SetMAC(mac common.RawBytes) error {
 	if len(mac) != MACLength {
 		return serrors.New("Invalid MAC size",
 			"expected", MACLength, "actual", len(mac))
@@ -110,6 +115,43 @@ func (s DRKeyExtn) SetMAC(mac common.RawBytes) error {
 	return nil
 }
 
+// ErrInvalidMAC indicates that the MAC of the extension does not match the SCMP message.
+var ErrInvalidMAC = serrors.New("invalid SCMPAuthDRKey MAC")
+
+// Authenticate computes the MAC over the security mode, the direction and the SCMP message,
+// i.e. the serialized SCMP header and payload, with the DRKey of the direction. It sets the
+// MAC in the extension. The SCMP checksum binds the MAC to the addresses of the packet.
+func (s *DRKeyExtn) Authenticate(key, scmp []byte) error {
+	mac, err := s.computeMAC(key, scmp)
+	if err != nil {
+		return err
+	}
+	return s.SetMAC(mac)
+}
+
+// Verify checks that the MAC of the extension was computed over the SCMP message with the
+// key. If it was not, an error wrapping ErrInvalidMAC is returned.
+func (s *DRKeyExtn) Verify(key, scmp []byte) error {
+	mac, err := s.computeMAC(key, scmp)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(mac, s.MAC) != 1 {
+		return serrors.WithCtx(ErrInvalidMAC, "dir", s.Direction)
+	}
+	return nil
+}
+
+func (s *DRKeyExtn) computeMAC(key, scmp []byte) ([]byte, error) {
+	mac, err := scrypto.InitMac(key)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write([]byte{uint8(s.SecMode), uint8(s.Direction)})
+	mac.Write(scmp)
+	return mac.Sum(nil), nil
+}
+
 func (s *DRKeyExtn) Write(b common.RawBytes) error {
 	if len(b) < s.Len() {
 		return serrors.New("Buffer too short",
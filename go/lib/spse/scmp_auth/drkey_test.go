@@ -0,0 +1,46 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestDRKeyExtnAuthenticate(t *testing.T) {
+	key := xtest.MustParseHexString("c584cad32613547c64823c756651b6f5")
+	scmp := []byte("scmp header and payload")
+
+	extn := scmp_auth.NewDRKeyExtn()
+	require.NoError(t, extn.SetDirection(scmp_auth.AsToHost))
+	require.NoError(t, extn.Authenticate(key, scmp))
+	require.NoError(t, extn.Verify(key, scmp))
+
+	err := extn.Verify(key, []byte("other scmp message"))
+	assert.True(t, errors.Is(err, scmp_auth.ErrInvalidMAC), err)
+	otherKey := xtest.MustParseHexString("00000000000000000000000000000000")
+	err = extn.Verify(otherKey, scmp)
+	assert.True(t, errors.Is(err, scmp_auth.ErrInvalidMAC), err)
+	// the direction is authenticated, too.
+	require.NoError(t, extn.SetDirection(scmp_auth.HostToHost))
+	err = extn.Verify(key, scmp)
+	assert.True(t, errors.Is(err, scmp_auth.ErrInvalidMAC), err)
+}
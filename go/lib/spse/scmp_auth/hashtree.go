@@ -18,6 +18,12 @@
 //    +--------+--------+--------+--------+--------+--------+--------+--------+
 //    | xxxxxxxxxxxxxxxxxxxxxxxx |  0x05  | Height |reserved|      Order      |
 //    +--------+--------+--------+--------+--------+--------+--------+--------+
+//    |             Timestamp             |           SubjectKeyID            |
+//    +--------+--------+--------+--------+--------+--------+--------+--------+
+//    |                        SubjectKeyID (continued)                       |
+//    +--------+--------+--------+--------+--------+--------+--------+--------+
+//    |                        SubjectKeyID (continued)                       |
+//    +--------+--------+--------+--------+--------+--------+--------+--------+
 //    |                               Signature (8 lines)                     |
 //    +--------+--------+--------+--------+--------+--------+--------+--------+
 //    |                               Hashes (height * 2)                     |
@@ -28,6 +34,7 @@ package scmp_auth
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/scionproto/scion/go/lib/common"
@@ -35,10 +42,9 @@ import (
 	"github.com/scionproto/scion/go/lib/spse"
 )
 
-//var _ common.Extension = (*HashTreeExtn)(nil)
-
 // HashTreeExtn is the implementation of the SCMPAuthHashTree extension.
-// It is used to authenticate scmp messages.
+// It is used to authenticate scmp messages. It is carried in the end-to-end option of type
+// slayers.OptTypeScmpAuthHashTree, see DecodeHashTreeExtn and EncodeHashTreeExtn.
 type HashTreeExtn struct {
 	*spse.BaseExtn
 	// Height is the height of the hash tree. Max height is 16.
@@ -46,26 +52,34 @@ type HashTreeExtn struct {
 	// Order is a bit vector. The bit at index i is associated with hash i.
 	// 0 (1) indicates hash i shall be used as left (right) input.
 	Order common.RawBytes
-	// Signature is the signature of the root hash.
+	// Timestamp is the time the root was signed, in seconds since the Unix epoch.
+	Timestamp uint32
+	// SubjectKeyID identifies the AS certificate of the key that signed the root.
+	SubjectKeyID common.RawBytes
+	// Signature is the signature of the root hash, see HashTreeSigner.
 	Signature common.RawBytes
-	// Hashes are the hashes to verify the proof.
-	// At index 0 is the leaf hash. At index height is the root hash.
+	// Hashes are the hashes to verify the proof. At index 0 is the sibling of the leaf
+	// hash, at index height-1 the sibling of the child of the root on the path.
 	Hashes common.RawBytes
 }
 
 const (
 	MaxHeight = 16
 
-	HeightLength    = 1
-	ReservedLength  = 1
-	OrderLength     = 2
-	SignatureLength = 64
-	HashLength      = 16
-
-	HeightOffset    = spse.SecModeLength
-	OrderOffset     = HeightOffset + ReservedLength + HeightLength
-	SignatureOffset = OrderOffset + OrderLength
-	HashesOffset    = SignatureOffset + SignatureLength
+	HeightLength       = 1
+	ReservedLength     = 1
+	OrderLength        = 2
+	TimestampLength    = 4
+	SubjectKeyIDLength = 20
+	SignatureLength    = 64
+	HashLength         = 16
+
+	HeightOffset       = spse.SecModeLength
+	OrderOffset        = HeightOffset + ReservedLength + HeightLength
+	TimestampOffset    = OrderOffset + OrderLength
+	SubjectKeyIDOffset = TimestampOffset + TimestampLength
+	SignatureOffset    = SubjectKeyIDOffset + SubjectKeyIDLength
+	HashesOffset       = SignatureOffset + SignatureLength
 )
 
 func NewHashTreeExtn(height uint8) (*HashTreeExtn, error) {
@@ -78,12 +92,13 @@ func NewHashTreeExtn(height uint8) (*HashTreeExtn, error) {
 
 	extn.Height = height
 	extn.Order = make(common.RawBytes, OrderLength)
+	extn.SubjectKeyID = make(common.RawBytes, SubjectKeyIDLength)
 	extn.Signature = make(common.RawBytes, SignatureLength)
 	extn.Hashes = make(common.RawBytes, int(height)*HashLength)
 	return extn, nil
 }
 
-func (s HashTreeExtn) SetOrder(order common.RawBytes) error {
+func (s *HashTreeExtn) SetOrder(order common.RawBytes) error {
 	if len(order) != OrderLength {
 		return serrors.New("Invalid order length",
 			"expected", OrderLength, "actual", len(order))
@@ -93,7 +108,16 @@ func (s HashTreeExtn) SetOrder(order common.RawBytes) error {
 
 }
 
-func (s HashTreeExtn) SetSignature(signature common.RawBytes) error {
+func (s *HashTreeExtn) SetSubjectKeyID(keyID common.RawBytes) error {
+	if len(keyID) != SubjectKeyIDLength {
+		return serrors.New("Invalid subject key ID length",
+			"expected", SubjectKeyIDLength, "actual", len(keyID))
+	}
+	copy(s.SubjectKeyID, keyID)
+	return nil
+}
+
+func (s *HashTreeExtn) SetSignature(signature common.RawBytes) error {
 	if len(signature) != SignatureLength {
 		return serrors.New("Invalid signature length",
 			"expected", SignatureLength, "actual", len(signature))
@@ -103,7 +127,7 @@ func (s HashTreeExtn) SetSignature(signature common.RawBytes) error {
 
 }
 
-func (s HashTreeExtn) SetHashes(hashes common.RawBytes) error {
+func (s *HashTreeExtn) SetHashes(hashes common.RawBytes) error {
 	if len(hashes) != len(s.Hashes) {
 		return serrors.New("Invalid hashes length",
 			"expected", len(s.Hashes), "actual", len(hashes))
@@ -120,7 +144,10 @@ func (s *HashTreeExtn) Write(b common.RawBytes) error {
 	}
 	b[0] = uint8(s.SecMode)
 	b[HeightOffset] = s.Height
-	copy(b[OrderOffset:SignatureOffset], s.Order)
+	b[HeightOffset+HeightLength] = 0
+	copy(b[OrderOffset:TimestampOffset], s.Order)
+	binary.BigEndian.PutUint32(b[TimestampOffset:SubjectKeyIDOffset], s.Timestamp)
+	copy(b[SubjectKeyIDOffset:SignatureOffset], s.SubjectKeyID)
 	copy(b[SignatureOffset:HashesOffset], s.Signature)
 	copy(b[HashesOffset:], s.Hashes)
 	return nil
@@ -143,6 +170,8 @@ func (s *HashTreeExtn) String() string {
 	fmt.Fprintf(buf, "AuthHashTreeExtn (%dB): SecMode: %d\n", s.Len(), s.SecMode)
 	fmt.Fprintf(buf, " Height: %x", s.Height)
 	fmt.Fprintf(buf, " Order: %s", s.Order)
+	fmt.Fprintf(buf, " Timestamp: %d", s.Timestamp)
+	fmt.Fprintf(buf, " SubjectKeyID: %s", s.SubjectKeyID)
 	fmt.Fprintf(buf, " Signature: %s", s.Signature)
 	fmt.Fprintf(buf, " Hashes: %s", s.Hashes)
 	return buf.String()
@@ -0,0 +1,115 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/spse"
+)
+
+// MaxOptionHeight is the maximum height of a hash tree extension that fits in an end-to-end
+// option.
+const MaxOptionHeight = (spse.MaxOptionDataLength - HashesOffset) / HashLength
+
+// DRKeyExtnFromRaw parses a DRKey extension from the bytes written by DRKeyExtn.Write.
+func DRKeyExtnFromRaw(b []byte) (*DRKeyExtn, error) {
+	if len(b) != DRKeyTotalLength {
+		return nil, serrors.New("invalid SCMPAuthDRKey length",
+			"expected", DRKeyTotalLength, "actual", len(b))
+	}
+	if spse.SecMode(b[0]) != spse.ScmpAuthDRKey {
+		return nil, serrors.New("invalid SecMode code", "expected", spse.ScmpAuthDRKey,
+			"actual", spse.SecMode(b[0]))
+	}
+	s := NewDRKeyExtn()
+	if err := s.SetDirection(Dir(b[DirectionOffset])); err != nil {
+		return nil, err
+	}
+	copy(s.MAC, b[MACOffset:DRKeyTotalLength])
+	return s, nil
+}
+
+// HashTreeExtnFromRaw parses a hash tree extension from the bytes written by
+// HashTreeExtn.Write.
+func HashTreeExtnFromRaw(b []byte) (*HashTreeExtn, error) {
+	if len(b) < HashesOffset {
+		return nil, serrors.New("buffer too short", "expected min", HashesOffset,
+			"actual", len(b))
+	}
+	if spse.SecMode(b[0]) != spse.ScmpAuthHashTree {
+		return nil, serrors.New("invalid SecMode code", "expected", spse.ScmpAuthHashTree,
+			"actual", spse.SecMode(b[0]))
+	}
+	s, err := NewHashTreeExtn(b[HeightOffset])
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != s.Len() {
+		return nil, serrors.New("invalid SCMPAuthHashTree length", "height", s.Height,
+			"expected", s.Len(), "actual", len(b))
+	}
+	copy(s.Order, b[OrderOffset:TimestampOffset])
+	s.Timestamp = binary.BigEndian.Uint32(b[TimestampOffset:SubjectKeyIDOffset])
+	copy(s.SubjectKeyID, b[SubjectKeyIDOffset:SignatureOffset])
+	copy(s.Signature, b[SignatureOffset:HashesOffset])
+	copy(s.Hashes, b[HashesOffset:])
+	return s, nil
+}
+
+// DecodeDRKeyExtn decodes the SCMPAuthDRKey option of the end-to-end extension. If there is
+// none, an error wrapping spse.ErrNoOption is returned.
+func DecodeDRKeyExtn(e2e *slayers.EndToEndExtn) (*DRKeyExtn, error) {
+	opt, err := spse.FindOption(e2e, slayers.OptTypeScmpAuthDRKey)
+	if err != nil {
+		return nil, err
+	}
+	return DRKeyExtnFromRaw(opt.OptData)
+}
+
+// EncodeDRKeyExtn sets the extension as the SCMPAuthDRKey option of the end-to-end extension.
+func EncodeDRKeyExtn(e2e *slayers.EndToEndExtn, s *DRKeyExtn) error {
+	raw, err := s.Pack()
+	if err != nil {
+		return err
+	}
+	return spse.SetOption(e2e, slayers.OptTypeScmpAuthDRKey, raw)
+}
+
+// DecodeHashTreeExtn decodes the SCMPAuthHashTree option of the end-to-end extension. If
+// there is none, an error wrapping spse.ErrNoOption is returned.
+func DecodeHashTreeExtn(e2e *slayers.EndToEndExtn) (*HashTreeExtn, error) {
+	opt, err := spse.FindOption(e2e, slayers.OptTypeScmpAuthHashTree)
+	if err != nil {
+		return nil, err
+	}
+	return HashTreeExtnFromRaw(opt.OptData)
+}
+
+// EncodeHashTreeExtn sets the extension as the SCMPAuthHashTree option of the end-to-end
+// extension. Trees higher than MaxOptionHeight do not fit in an option.
+func EncodeHashTreeExtn(e2e *slayers.EndToEndExtn, s *HashTreeExtn) error {
+	if s.Height > MaxOptionHeight {
+		return serrors.New("hash tree too high for an end-to-end option",
+			"height", s.Height, "max", MaxOptionHeight)
+	}
+	raw, err := s.Pack()
+	if err != nil {
+		return err
+	}
+	return spse.SetOption(e2e, slayers.OptTypeScmpAuthHashTree, raw)
+}
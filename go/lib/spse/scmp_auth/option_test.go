@@ -0,0 +1,120 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/spse"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+)
+
+// roundTrip serializes and decodes the end-to-end extension.
+func roundTrip(t *testing.T, e2e *slayers.EndToEndExtn) *slayers.EndToEndExtn {
+	e2e.NextHdr = common.L4SCMP
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, e2e.SerializeTo(buf, gopacket.SerializeOptions{FixLengths: true}))
+	var decoded slayers.EndToEndExtn
+	require.NoError(t, decoded.DecodeFromBytes(buf.Bytes(), gopacket.NilDecodeFeedback))
+	return &decoded
+}
+
+func TestEncodeDecodeDRKeyExtn(t *testing.T) {
+	extn := scmp_auth.NewDRKeyExtn()
+	require.NoError(t, extn.SetDirection(scmp_auth.AsToHost))
+	require.NoError(t, extn.SetMAC([]byte("0123456789abcdef")))
+
+	var e2e slayers.EndToEndExtn
+	require.NoError(t, scmp_auth.EncodeDRKeyExtn(&e2e, extn))
+	decoded, err := scmp_auth.DecodeDRKeyExtn(roundTrip(t, &e2e))
+	require.NoError(t, err)
+	assert.Equal(t, extn, decoded)
+
+	_, err = scmp_auth.DecodeHashTreeExtn(&e2e)
+	assert.True(t, errors.Is(err, spse.ErrNoOption), err)
+}
+
+func TestDRKeyExtnFromRaw(t *testing.T) {
+	raw, err := scmp_auth.NewDRKeyExtn().Pack()
+	require.NoError(t, err)
+	_, err = scmp_auth.DRKeyExtnFromRaw(raw)
+	require.NoError(t, err)
+
+	_, err = scmp_auth.DRKeyExtnFromRaw(raw[:len(raw)-1])
+	assert.Error(t, err)
+	invalidMode := append([]byte{}, raw...)
+	invalidMode[0] = uint8(spse.ScmpAuthHashTree)
+	_, err = scmp_auth.DRKeyExtnFromRaw(invalidMode)
+	assert.Error(t, err)
+	invalidDir := append([]byte{}, raw...)
+	invalidDir[scmp_auth.DirectionOffset] = 0xff
+	_, err = scmp_auth.DRKeyExtnFromRaw(invalidDir)
+	assert.Error(t, err)
+}
+
+func TestEncodeDecodeHashTreeExtn(t *testing.T) {
+	extn, err := scmp_auth.NewHashTreeExtn(3)
+	require.NoError(t, err)
+	require.NoError(t, extn.SetOrder([]byte{0x00, 0x05}))
+	extn.Timestamp = 1600000000
+	keyID := make([]byte, scmp_auth.SubjectKeyIDLength)
+	keyID[0] = 0xcc
+	require.NoError(t, extn.SetSubjectKeyID(keyID))
+	signature := make([]byte, scmp_auth.SignatureLength)
+	signature[0] = 0xaa
+	require.NoError(t, extn.SetSignature(signature))
+	hashes := make([]byte, 3*scmp_auth.HashLength)
+	hashes[0] = 0xbb
+	require.NoError(t, extn.SetHashes(hashes))
+
+	var e2e slayers.EndToEndExtn
+	require.NoError(t, scmp_auth.EncodeHashTreeExtn(&e2e, extn))
+	decoded, err := scmp_auth.DecodeHashTreeExtn(roundTrip(t, &e2e))
+	require.NoError(t, err)
+	assert.Equal(t, extn, decoded)
+
+	high, err := scmp_auth.NewHashTreeExtn(scmp_auth.MaxOptionHeight + 1)
+	require.NoError(t, err)
+	assert.Error(t, scmp_auth.EncodeHashTreeExtn(&e2e, high))
+}
+
+func TestHashTreeExtnFromRaw(t *testing.T) {
+	extn, err := scmp_auth.NewHashTreeExtn(2)
+	require.NoError(t, err)
+	raw, err := extn.Pack()
+	require.NoError(t, err)
+	_, err = scmp_auth.HashTreeExtnFromRaw(raw)
+	require.NoError(t, err)
+
+	_, err = scmp_auth.HashTreeExtnFromRaw(raw[:len(raw)-1])
+	assert.Error(t, err)
+	_, err = scmp_auth.HashTreeExtnFromRaw(raw[:scmp_auth.HashesOffset-1])
+	assert.Error(t, err)
+	invalidHeight := append([]byte{}, raw...)
+	invalidHeight[scmp_auth.HeightOffset] = scmp_auth.MaxHeight + 1
+	_, err = scmp_auth.HashTreeExtnFromRaw(invalidHeight)
+	assert.Error(t, err)
+	invalidMode := append([]byte{}, raw...)
+	invalidMode[0] = uint8(spse.ScmpAuthDRKey)
+	_, err = scmp_auth.HashTreeExtnFromRaw(invalidMode)
+	assert.Error(t, err)
+}
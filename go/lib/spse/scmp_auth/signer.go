@@ -0,0 +1,180 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth
+
+import (
+	"crypto"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// DefaultSignerWindow is the default time a HashTreeSigner collects messages for.
+const DefaultSignerWindow = 50 * time.Millisecond
+
+// HashTreeSigner authenticates SCMP messages in batches. The messages of a batch are the
+// leaves of a hash tree whose root is signed once with the AS key. Every message is then
+// authenticated by a hash tree extension that carries the root signature and the inclusion
+// proof of the message, so that a single signature covers up to 2^MaxHeight messages.
+//
+// It is meant for the services that hold the AS key. The routers do not have it and
+// authenticate their SCMP messages with the SCMPAuthDRKey extension instead, see
+// router.SCMPAuthenticator.
+type HashTreeSigner struct {
+	// Signer is the AS private key. Ed25519 and ECDSA P-256 keys are supported.
+	Signer crypto.Signer
+	// SubjectKeyID is the subject key ID of the AS certificate of the key.
+	SubjectKeyID []byte
+	// Window is the time the messages are collected for before a batch is signed. If zero,
+	// DefaultSignerWindow is used.
+	Window time.Duration
+	// MaxHeight is the maximum height of the hash trees. A batch is signed as soon as it
+	// contains 2^MaxHeight messages. If zero, MaxOptionHeight is used.
+	MaxHeight uint8
+
+	mu    sync.Mutex
+	batch []pendingMsg
+	timer *time.Timer
+}
+
+type pendingMsg struct {
+	scmp []byte
+	done func(*HashTreeExtn, error)
+}
+
+// Add adds the SCMP message to the current batch. Once the batch is signed, done is called
+// with the extension that authenticates the message, or with the error that occurred. done
+// is called on the goroutine that signs the batch, it must not block.
+func (s *HashTreeSigner) Add(scmp []byte, done func(*HashTreeExtn, error)) {
+	s.mu.Lock()
+	s.batch = append(s.batch, pendingMsg{scmp: append([]byte(nil), scmp...), done: done})
+	if len(s.batch) < 1<<s.maxHeight() {
+		if s.timer == nil {
+			s.timer = time.AfterFunc(s.window(), s.Flush)
+		}
+		s.mu.Unlock()
+		return
+	}
+	batch := s.takeBatch()
+	s.mu.Unlock()
+	s.signBatch(batch)
+}
+
+// Flush signs the current batch without waiting for the window to end.
+func (s *HashTreeSigner) Flush() {
+	s.mu.Lock()
+	batch := s.takeBatch()
+	s.mu.Unlock()
+	s.signBatch(batch)
+}
+
+// Sign builds the hash tree over the SCMP messages, signs its root and returns the extensions
+// that authenticate the messages, in the same order.
+func (s *HashTreeSigner) Sign(msgs [][]byte) ([]*HashTreeExtn, error) {
+	if len(msgs) == 0 {
+		return nil, serrors.New("no messages to sign")
+	}
+	if len(msgs) > 1<<s.maxHeight() {
+		return nil, serrors.New("too many messages", "count", len(msgs),
+			"max", 1<<s.maxHeight())
+	}
+	if s.Signer == nil {
+		return nil, serrors.New("signer not set")
+	}
+	height := treeHeight(len(msgs))
+	leaves := make([][]byte, 0, len(msgs))
+	for _, msg := range msgs {
+		leaves = append(leaves, leafHash(msg))
+	}
+	levels := buildTree(leaves, height)
+
+	tmpl, err := NewHashTreeExtn(height)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Timestamp = util.TimeToSecs(time.Now())
+	if err := tmpl.SetSubjectKeyID(s.SubjectKeyID); err != nil {
+		return nil, err
+	}
+	signature, err := signRoot(s.Signer, tmpl.signatureInput(levels[height][0]))
+	if err != nil {
+		return nil, serrors.WrapStr("signing hash tree root", err)
+	}
+	if err := tmpl.SetSignature(signature); err != nil {
+		return nil, err
+	}
+	extns := make([]*HashTreeExtn, 0, len(msgs))
+	for i := range msgs {
+		extn, err := NewHashTreeExtn(height)
+		if err != nil {
+			return nil, err
+		}
+		extn.Timestamp = tmpl.Timestamp
+		copy(extn.SubjectKeyID, tmpl.SubjectKeyID)
+		copy(extn.Signature, tmpl.Signature)
+		extn.setProof(levels, i)
+		extns = append(extns, extn)
+	}
+	return extns, nil
+}
+
+// takeBatch removes the current batch and stops its timer. The caller must hold the lock.
+func (s *HashTreeSigner) takeBatch() []pendingMsg {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	batch := s.batch
+	s.batch = nil
+	return batch
+}
+
+func (s *HashTreeSigner) signBatch(batch []pendingMsg) {
+	if len(batch) == 0 {
+		return
+	}
+	msgs := make([][]byte, 0, len(batch))
+	for _, p := range batch {
+		msgs = append(msgs, p.scmp)
+	}
+	extns, err := s.Sign(msgs)
+	for i, p := range batch {
+		if err != nil {
+			p.done(nil, err)
+			continue
+		}
+		p.done(extns[i], nil)
+	}
+}
+
+func (s *HashTreeSigner) window() time.Duration {
+	if s.Window == 0 {
+		return DefaultSignerWindow
+	}
+	return s.Window
+}
+
+func (s *HashTreeSigner) maxHeight() uint8 {
+	switch {
+	case s.MaxHeight == 0:
+		return MaxOptionHeight
+	case s.MaxHeight > MaxHeight:
+		return MaxHeight
+	default:
+		return s.MaxHeight
+	}
+}
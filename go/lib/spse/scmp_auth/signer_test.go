@@ -0,0 +1,196 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+type staticKeys []crypto.PublicKey
+
+func (k staticKeys) ASKeys(context.Context, addr.IA, []byte,
+	time.Time) ([]crypto.PublicKey, error) {
+
+	return k, nil
+}
+
+func scmpMsgs(n int) [][]byte {
+	msgs := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		msgs = append(msgs, []byte(fmt.Sprintf("scmp message %d", i)))
+	}
+	return msgs
+}
+
+func TestHashTreeSignerSign(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyID := make([]byte, scmp_auth.SubjectKeyIDLength)
+	ia := xtest.MustParseIA("1-ff00:0:110")
+
+	testCases := map[string]struct {
+		Signer crypto.Signer
+		Count  int
+		Height uint8
+	}{
+		"ed25519 single message": {Signer: edKey, Count: 1, Height: 0},
+		"ed25519 full tree":      {Signer: edKey, Count: 8, Height: 3},
+		"ed25519 partial tree":   {Signer: edKey, Count: 5, Height: 3},
+		"ecdsa partial tree":     {Signer: ecKey, Count: 11, Height: 4},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			signer := &scmp_auth.HashTreeSigner{Signer: tc.Signer, SubjectKeyID: keyID}
+			msgs := scmpMsgs(tc.Count)
+			extns, err := signer.Sign(msgs)
+			require.NoError(t, err)
+			require.Len(t, extns, tc.Count)
+
+			verifier := &scmp_auth.HashTreeVerifier{
+				Keys:   staticKeys{tc.Signer.Public()},
+				MaxAge: time.Minute,
+			}
+			for i, extn := range extns {
+				assert.Equal(t, tc.Height, extn.Height)
+				assert.NoError(t, verifier.Verify(context.Background(), ia, msgs[i], extn))
+				if tc.Count > 1 {
+					other := msgs[(i+1)%tc.Count]
+					assert.Error(t, verifier.Verify(context.Background(), ia, other, extn))
+				}
+			}
+		})
+	}
+	t.Run("too many messages", func(t *testing.T) {
+		signer := &scmp_auth.HashTreeSigner{Signer: edKey, SubjectKeyID: keyID, MaxHeight: 2}
+		_, err := signer.Sign(scmpMsgs(5))
+		assert.Error(t, err)
+	})
+	t.Run("no messages", func(t *testing.T) {
+		signer := &scmp_auth.HashTreeSigner{Signer: edKey, SubjectKeyID: keyID}
+		_, err := signer.Sign(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestHashTreeSignerAdd(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	verifier := &scmp_auth.HashTreeVerifier{Keys: staticKeys{key.Public()}}
+
+	type result struct {
+		extn *scmp_auth.HashTreeExtn
+		err  error
+	}
+	add := func(signer *scmp_auth.HashTreeSigner, msgs [][]byte) []chan result {
+		results := make([]chan result, 0, len(msgs))
+		for _, msg := range msgs {
+			ch := make(chan result, 1)
+			signer.Add(msg, func(extn *scmp_auth.HashTreeExtn, err error) {
+				ch <- result{extn: extn, err: err}
+			})
+			results = append(results, ch)
+		}
+		return results
+	}
+
+	t.Run("window", func(t *testing.T) {
+		signer := &scmp_auth.HashTreeSigner{
+			Signer:       key,
+			SubjectKeyID: make([]byte, scmp_auth.SubjectKeyIDLength),
+			Window:       10 * time.Millisecond,
+		}
+		msgs := scmpMsgs(3)
+		results := add(signer, msgs)
+		var signature common.RawBytes
+		for i, ch := range results {
+			select {
+			case r := <-ch:
+				require.NoError(t, r.err)
+				assert.NoError(t, verifier.Verify(context.Background(), ia, msgs[i], r.extn))
+				if signature == nil {
+					signature = r.extn.Signature
+				}
+				assert.Equal(t, signature, r.extn.Signature, "single signature per batch")
+			case <-time.After(time.Second):
+				t.Fatal("batch not signed after window")
+			}
+		}
+	})
+	t.Run("full batch", func(t *testing.T) {
+		signer := &scmp_auth.HashTreeSigner{
+			Signer:       key,
+			SubjectKeyID: make([]byte, scmp_auth.SubjectKeyIDLength),
+			Window:       time.Hour,
+			MaxHeight:    2,
+		}
+		msgs := scmpMsgs(4)
+		results := add(signer, msgs)
+		for i, ch := range results {
+			select {
+			case r := <-ch:
+				require.NoError(t, r.err)
+				assert.Equal(t, uint8(2), r.extn.Height)
+				assert.NoError(t, verifier.Verify(context.Background(), ia, msgs[i], r.extn))
+			default:
+				t.Fatal("full batch not signed")
+			}
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		signer := &scmp_auth.HashTreeSigner{
+			Signer:       key,
+			SubjectKeyID: make([]byte, scmp_auth.SubjectKeyIDLength),
+			Window:       time.Millisecond,
+			MaxHeight:    3,
+		}
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				msgs := scmpMsgs(10)
+				for i, ch := range add(signer, msgs) {
+					r := <-ch
+					assert.NoError(t, r.err)
+					assert.NoError(t, verifier.Verify(context.Background(), ia, msgs[i],
+						r.extn))
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"math/big"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ErrInvalidSignature indicates that the root signature of a hash tree extension does not
+// verify.
+var ErrInvalidSignature = serrors.New("invalid hash tree signature")
+
+const (
+	// leafPrefix and nodePrefix separate the leaf hashes from the inner node hashes, so that an
+	// inner node cannot be passed off as an SCMP message.
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+	// signaturePrefix separates the signatures of hash tree roots from other signatures made
+	// with the AS key.
+	signaturePrefix = "SCMP auth hash tree root"
+)
+
+// leafHash returns the hash of the leaf of an SCMP message.
+func leafHash(scmp []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(scmp)
+	return h.Sum(nil)[:HashLength]
+}
+
+// nodeHash returns the hash of an inner node with the given children.
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)[:HashLength]
+}
+
+// treeHeight returns the height of the smallest hash tree with at least n leaves.
+func treeHeight(n int) uint8 {
+	var height uint8
+	for 1<<height < n {
+		height++
+	}
+	return height
+}
+
+// buildTree builds the hash tree of the given height over the leaves. The missing leaves are
+// filled with zero hashes. Level 0 of the result are the leaves, level height the root.
+func buildTree(leaves [][]byte, height uint8) [][][]byte {
+	levels := make([][][]byte, height+1)
+	levels[0] = make([][]byte, 1<<height)
+	copy(levels[0], leaves)
+	for i := len(leaves); i < len(levels[0]); i++ {
+		levels[0][i] = make([]byte, HashLength)
+	}
+	for l := 1; l <= int(height); l++ {
+		below := levels[l-1]
+		levels[l] = make([][]byte, len(below)/2)
+		for i := range levels[l] {
+			levels[l][i] = nodeHash(below[2*i], below[2*i+1])
+		}
+	}
+	return levels
+}
+
+// setProof sets the order and the hashes of the extension to the inclusion proof of the leaf
+// at the given index.
+func (s *HashTreeExtn) setProof(levels [][][]byte, index int) {
+	var order uint16
+	for l := 0; l < int(s.Height); l++ {
+		sibling := index ^ 1
+		if sibling > index {
+			order |= 1 << uint(l)
+		}
+		copy(s.Hashes[l*HashLength:], levels[l][sibling])
+		index >>= 1
+	}
+	binary.BigEndian.PutUint16(s.Order, order)
+}
+
+// Root computes the root of the hash tree from the SCMP message and the inclusion proof
+// carried in the extension.
+func (s *HashTreeExtn) Root(scmp []byte) ([]byte, error) {
+	if s.Height > MaxHeight {
+		return nil, serrors.New("Invalid height", "height", s.Height, "max height", MaxHeight)
+	}
+	if len(s.Order) != OrderLength {
+		return nil, serrors.New("Invalid order length",
+			"expected", OrderLength, "actual", len(s.Order))
+	}
+	if len(s.Hashes) != int(s.Height)*HashLength {
+		return nil, serrors.New("Invalid hashes length",
+			"expected", int(s.Height)*HashLength, "actual", len(s.Hashes))
+	}
+	order := binary.BigEndian.Uint16(s.Order)
+	hash := leafHash(scmp)
+	for l := 0; l < int(s.Height); l++ {
+		sibling := s.Hashes[l*HashLength : (l+1)*HashLength]
+		if order&(1<<uint(l)) == 0 {
+			hash = nodeHash(sibling, hash)
+		} else {
+			hash = nodeHash(hash, sibling)
+		}
+	}
+	return hash, nil
+}
+
+// signatureInput returns the data covered by the root signature: the root and the timestamp,
+// height and subject key ID of the extension.
+func (s *HashTreeExtn) signatureInput(root []byte) []byte {
+	input := make([]byte, 0, len(signaturePrefix)+TimestampLength+HeightLength+
+		len(s.SubjectKeyID)+len(root))
+	input = append(input, signaturePrefix...)
+	input = append(input, make([]byte, TimestampLength)...)
+	binary.BigEndian.PutUint32(input[len(signaturePrefix):], s.Timestamp)
+	input = append(input, s.Height)
+	input = append(input, s.SubjectKeyID...)
+	return append(input, root...)
+}
+
+// signRoot signs the input with the signer. Ed25519 signatures are used as is, ECDSA P-256
+// signatures are encoded as the concatenation of r and s.
+func signRoot(signer crypto.Signer, input []byte) ([]byte, error) {
+	switch pub := signer.Public().(type) {
+	case ed25519.PublicKey:
+		return signer.Sign(rand.Reader, input, crypto.Hash(0))
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, serrors.New("unsupported curve", "curve", pub.Curve.Params().Name)
+		}
+		digest := sha256.Sum256(input)
+		raw, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		var sig struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(raw, &sig); err != nil {
+			return nil, serrors.WrapStr("parsing ECDSA signature", err)
+		}
+		out := make([]byte, SignatureLength)
+		r, s := sig.R.Bytes(), sig.S.Bytes()
+		copy(out[SignatureLength/2-len(r):], r)
+		copy(out[SignatureLength-len(s):], s)
+		return out, nil
+	default:
+		return nil, serrors.New("unsupported key type", "type", common.TypeOf(pub))
+	}
+}
+
+// verifyRoot verifies the signature of the input, see signRoot.
+func verifyRoot(key crypto.PublicKey, input, signature []byte) error {
+	if len(signature) != SignatureLength {
+		return serrors.WithCtx(ErrInvalidSignature, "len", len(signature))
+	}
+	switch pub := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, input, signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return serrors.New("unsupported curve", "curve", pub.Curve.Params().Name)
+		}
+		digest := sha256.Sum256(input)
+		r := new(big.Int).SetBytes(signature[:SignatureLength/2])
+		s := new(big.Int).SetBytes(signature[SignatureLength/2:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return serrors.New("unsupported key type", "type", common.TypeOf(pub))
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth
+
+import (
+	"context"
+	"crypto"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	// DefaultHashTreeMaxAge is the default maximum age of a root signature. It bounds the time
+	// during which an authenticated SCMP message can be replayed.
+	DefaultHashTreeMaxAge = 10 * time.Second
+	// maxClockSkew is the maximum time a root signature can be dated in the future, to
+	// tolerate the clock skew between the signing router and the verifier.
+	maxClockSkew = time.Second
+	// maxVerifiedRoots is the number of verified roots a HashTreeVerifier remembers.
+	maxVerifiedRoots = 128
+)
+
+// ASKeyProvider provides the public keys of AS certificates.
+type ASKeyProvider interface {
+	// ASKeys returns the public keys of the verified AS certificates of the AS with the given
+	// subject key ID that are valid at the given time.
+	ASKeys(ctx context.Context, ia addr.IA, subjectKeyID []byte,
+		date time.Time) ([]crypto.PublicKey, error)
+}
+
+// HashTreeVerifier verifies SCMP messages authenticated with hash tree extensions. The roots
+// that have been verified are remembered, so that the messages of a batch only cost a single
+// signature verification.
+type HashTreeVerifier struct {
+	// Keys provides the keys of the AS certificates.
+	Keys ASKeyProvider
+	// MaxAge is the maximum age of a root signature. If zero, DefaultHashTreeMaxAge is used.
+	MaxAge time.Duration
+
+	mu       sync.Mutex
+	verified map[string]struct{}
+}
+
+// Verify checks that the SCMP message originated in the given AS is authenticated by the
+// extension.
+func (v *HashTreeVerifier) Verify(ctx context.Context, ia addr.IA, scmp []byte,
+	extn *HashTreeExtn) error {
+
+	signed := util.SecsToTime(extn.Timestamp)
+	now := time.Now()
+	if now.Sub(signed) > v.maxAge() {
+		return serrors.New("hash tree signature expired", "timestamp", signed,
+			"max_age", v.maxAge())
+	}
+	if signed.Sub(now) > maxClockSkew {
+		return serrors.New("hash tree signature in the future", "timestamp", signed)
+	}
+	root, err := extn.Root(scmp)
+	if err != nil {
+		return err
+	}
+	input := extn.signatureInput(root)
+	key := ia.String() + string(input) + string(extn.Signature)
+	if v.isVerified(key) {
+		return nil
+	}
+	if v.Keys == nil {
+		return serrors.New("no AS key provider")
+	}
+	keys, err := v.Keys.ASKeys(ctx, ia, extn.SubjectKeyID, signed)
+	if err != nil {
+		return serrors.WrapStr("fetching AS keys", err, "ia", ia)
+	}
+	if len(keys) == 0 {
+		return serrors.New("no AS key found", "ia", ia)
+	}
+	errs := serrors.List{}
+	for _, pub := range keys {
+		err := verifyRoot(pub, input, extn.Signature)
+		if err == nil {
+			v.addVerified(key)
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return serrors.WithCtx(ErrInvalidSignature, "ia", ia, "errors", errs.ToError())
+}
+
+func (v *HashTreeVerifier) maxAge() time.Duration {
+	if v.MaxAge == 0 {
+		return DefaultHashTreeMaxAge
+	}
+	return v.MaxAge
+}
+
+func (v *HashTreeVerifier) isVerified(key string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	_, ok := v.verified[key]
+	return ok
+}
+
+func (v *HashTreeVerifier) addVerified(key string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.verified == nil || len(v.verified) >= maxVerifiedRoots {
+		v.verified = make(map[string]struct{})
+	}
+	v.verified[key] = struct{}{}
+}
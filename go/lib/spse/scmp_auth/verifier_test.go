@@ -0,0 +1,154 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmp_auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestHashTreeVerifierVerify(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	msgs := scmpMsgs(6)
+	signer := &scmp_auth.HashTreeSigner{
+		Signer:       key,
+		SubjectKeyID: make([]byte, scmp_auth.SubjectKeyIDLength),
+	}
+
+	sign := func(t *testing.T) *scmp_auth.HashTreeExtn {
+		extns, err := signer.Sign(msgs)
+		require.NoError(t, err)
+		return extns[2]
+	}
+	testCases := map[string]struct {
+		Modify       func(extn *scmp_auth.HashTreeExtn)
+		Keys         staticKeys
+		MaxAge       time.Duration
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Modify:       func(*scmp_auth.HashTreeExtn) {},
+			Keys:         staticKeys{otherPub, pub},
+			ErrAssertion: assert.NoError,
+		},
+		"unknown key": {
+			Modify:       func(*scmp_auth.HashTreeExtn) {},
+			Keys:         staticKeys{otherPub},
+			ErrAssertion: invalidSignature,
+		},
+		"no key": {
+			Modify:       func(*scmp_auth.HashTreeExtn) {},
+			ErrAssertion: assert.Error,
+		},
+		"modified order": {
+			Modify:       func(extn *scmp_auth.HashTreeExtn) { extn.Order[1] ^= 0x01 },
+			Keys:         staticKeys{pub},
+			ErrAssertion: invalidSignature,
+		},
+		"modified hash": {
+			Modify:       func(extn *scmp_auth.HashTreeExtn) { extn.Hashes[0] ^= 0xff },
+			Keys:         staticKeys{pub},
+			ErrAssertion: invalidSignature,
+		},
+		"modified timestamp": {
+			Modify:       func(extn *scmp_auth.HashTreeExtn) { extn.Timestamp-- },
+			Keys:         staticKeys{pub},
+			ErrAssertion: invalidSignature,
+		},
+		"modified signature": {
+			Modify:       func(extn *scmp_auth.HashTreeExtn) { extn.Signature[0] ^= 0xff },
+			Keys:         staticKeys{pub},
+			ErrAssertion: invalidSignature,
+		},
+		"truncated hashes": {
+			Modify:       func(extn *scmp_auth.HashTreeExtn) { extn.Hashes = extn.Hashes[1:] },
+			Keys:         staticKeys{pub},
+			ErrAssertion: assert.Error,
+		},
+		"expired": {
+			Modify: func(extn *scmp_auth.HashTreeExtn) {
+				extn.Timestamp = util.TimeToSecs(time.Now().Add(-time.Hour))
+			},
+			Keys:         staticKeys{pub},
+			MaxAge:       time.Minute,
+			ErrAssertion: assert.Error,
+		},
+		"expired default max age": {
+			Modify: func(extn *scmp_auth.HashTreeExtn) {
+				extn.Timestamp = util.TimeToSecs(time.Now().Add(-time.Minute))
+			},
+			Keys:         staticKeys{pub},
+			ErrAssertion: assert.Error,
+		},
+		"future": {
+			Modify: func(extn *scmp_auth.HashTreeExtn) {
+				extn.Timestamp = util.TimeToSecs(time.Now().Add(time.Minute))
+			},
+			Keys:         staticKeys{pub},
+			MaxAge:       time.Hour,
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			extn := sign(t)
+			tc.Modify(extn)
+			verifier := &scmp_auth.HashTreeVerifier{Keys: tc.Keys, MaxAge: tc.MaxAge}
+			tc.ErrAssertion(t, verifier.Verify(context.Background(), ia, msgs[2], extn))
+		})
+	}
+}
+
+func TestHashTreeVerifierOption(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	msgs := scmpMsgs(3)
+	signer := &scmp_auth.HashTreeSigner{
+		Signer:       key,
+		SubjectKeyID: make([]byte, scmp_auth.SubjectKeyIDLength),
+	}
+	extns, err := signer.Sign(msgs)
+	require.NoError(t, err)
+
+	var e2e slayers.EndToEndExtn
+	require.NoError(t, scmp_auth.EncodeHashTreeExtn(&e2e, extns[1]))
+	decoded, err := scmp_auth.DecodeHashTreeExtn(roundTrip(t, &e2e))
+	require.NoError(t, err)
+	verifier := &scmp_auth.HashTreeVerifier{Keys: staticKeys{pub}}
+	assert.NoError(t, verifier.Verify(context.Background(), ia, msgs[1], decoded))
+}
+
+func invalidSignature(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+	return assert.True(t, errors.Is(err, scmp_auth.ErrInvalidSignature), err)
+}
@@ -33,16 +33,14 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 )
 
-// XXX(matzf): old common.Extension removed
-// var _ common.Extension = (*Extn)(nil)
-
 // BaseExtn is the base for Extn, scmp_auth.DRKeyExt and scmp_auth.HashTreeExt
 type BaseExtn struct {
 	// SecMode indicates the security mode of the extension.
 	SecMode SecMode
 }
 
-// Extn is the implementation of the SCIONPacketSecurity extension.
+// Extn is the implementation of the SCIONPacketSecurity extension. It is carried in the
+// end-to-end option of type slayers.OptTypeSPSE, see DecodeExtn and EncodeExtn.
 type Extn struct {
 	*BaseExtn
 	// Metadata contains the metadata required by the security mode.
@@ -110,20 +108,6 @@ func (s *BaseExtn) Reverse() (bool, error) {
 	return true, nil
 }
 
-/*
-// XXX(matzf): old common.L4ProtocolType removed
-func (s *BaseExtn) Class() common.L4ProtocolType {
-	return common.End2EndClass
-}
-*/
-
-/*
-// XXX(matzf): old common.ExtnType removed
-func (s *BaseExtn) Type() common.ExtnType {
-	return common.ExtnSCIONPacketSecurityType
-}
-*/
-
 func NewExtn(secMode SecMode) (*Extn, error) {
 	s := &Extn{BaseExtn: &BaseExtn{SecMode: secMode}}
 
@@ -135,7 +119,7 @@ func NewExtn(secMode SecMode) (*Extn, error) {
 		authLen = AesCMacAuthLength
 	case HmacSha256:
 		metaLen = HmacSha256MetaLength
-		authLen = HmacSha256MetaLength
+		authLen = HmacSha256AuthLength
 	case Ed25519:
 		metaLen = ED25519MetaLength
 		authLen = ED25519AuthLength
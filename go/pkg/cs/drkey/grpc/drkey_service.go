@@ -40,10 +40,13 @@ type DRKeyServer struct {
 	// AllowedDSs is a set of protocols per IP address (in 16 byte form). Represents the allowed
 	// protocols hosts can obtain delegation secrets for.
 	AllowedDSs map[[16]byte]map[string]struct{}
+	// Protocols are the DRKey protocols served. If nil, the built-in protocols are served.
+	Protocols *protocol.Registry
 }
 
 var _ cppb.DRKeyLvl1ServiceServer = &DRKeyServer{}
 var _ cppb.DRKeyLvl2ServiceServer = &DRKeyServer{}
+var _ cppb.DRKeyDelegationServiceServer = &DRKeyServer{}
 
 // DRKeyLvl1 handle a level 1 request and returns a level 1 response.
 func (d *DRKeyServer) DRKeyLvl1(ctx context.Context,
@@ -99,7 +102,12 @@ func (d *DRKeyServer) DRKeyLvl2(ctx context.Context,
 			"peer", peer, "err", err)
 		return nil, err
 	}
-	if err := d.validateLvl2Req(parsedReq, peer.Addr); err != nil {
+	proto, err := d.Protocols.Lookup(parsedReq.Protocol)
+	if err != nil {
+		logger.Error("[DRKey gRPC server] Rejecting Lvl2 request", "err", err)
+		return nil, err
+	}
+	if err := d.validateLvl2Req(parsedReq, proto, peer.Addr); err != nil {
 		log.Error("[DRKey gRPC server] Error validating Lvl2 request",
 			"err", err)
 		return nil, err
@@ -121,7 +129,7 @@ func (d *DRKeyServer) DRKeyLvl2(ctx context.Context,
 		return nil, err
 	}
 	lvl2Meta := drkey.Lvl2Meta{
-		Epoch:    lvl1Key.Epoch,
+		Epoch:    proto.LimitEpoch(lvl1Key.Epoch, parsedReq.ValTime),
 		SrcIA:    srcIA,
 		DstIA:    dstIA,
 		KeyType:  drkey.Lvl2KeyType(parsedReq.ReqType),
@@ -130,7 +138,7 @@ func (d *DRKeyServer) DRKeyLvl2(ctx context.Context,
 		DstHost:  parsedReq.DstHost.ToHostAddr(),
 	}
 
-	lvl2Key, err := deriveLvl2(lvl2Meta, lvl1Key)
+	lvl2Key, err := proto.DeriveLvl2(lvl2Meta, lvl1Key)
 	if err != nil {
 		logger.Error("[DRKey gRPC server] Error deriving level 2 key",
 			"err", err)
@@ -146,6 +154,61 @@ func (d *DRKeyServer) DRKeyLvl2(ctx context.Context,
 	return resp, nil
 }
 
+// DRKeyDS handles a delegation secret request and returns a delegation secret response.
+// Only the hosts allowed for the protocol obtain the delegation secret, which they use to
+// derive the AS2Host and Host2Host keys of the protocol themselves.
+func (d *DRKeyServer) DRKeyDS(ctx context.Context,
+	req *cppb.DRKeyDSRequest) (*cppb.DRKeyDSResponse, error) {
+	logger := log.FromCtx(ctx)
+	peer, ok := peer.FromContext(ctx)
+	if !ok {
+		logger.Error("[DRKey gRPC server] Cannot retrieve peer from ctx")
+		return nil, serrors.New("retrieving peer information from ctx")
+	}
+
+	parsedReq, err := ctrl.RequestToDSReq(req.BaseReq)
+	if err != nil {
+		logger.Error("[DRKey gRPC server] Invalid DRKey DS request",
+			"peer", peer, "err", err)
+		return nil, err
+	}
+	proto, err := d.validateDSReq(parsedReq, peer.Addr)
+	if err != nil {
+		logger.Error("[DRKey gRPC server] Error validating DS request",
+			"err", err)
+		return nil, err
+	}
+	logger.Debug("[DRKey gRPC server] Received DS request",
+		"protocol", parsedReq.Protocol, "SrcIA", parsedReq.SrcIA, "DstIA", parsedReq.DstIA)
+
+	lvl1Meta := drkey.Lvl1Meta{
+		SrcIA: parsedReq.SrcIA,
+		DstIA: parsedReq.DstIA,
+	}
+	lvl1Key, err := d.Store.GetLvl1Key(ctx, lvl1Meta, parsedReq.ValTime)
+	if err != nil {
+		logger.Error("[DRKey gRPC server] Error getting the level 1 key",
+			"err", err)
+		return nil, err
+	}
+	ds, err := deriveDS(proto, lvl1Key)
+	if err != nil {
+		logger.Error("[DRKey gRPC server] Error deriving delegation secret",
+			"err", err)
+		return nil, err
+	}
+	ds.Epoch = proto.LimitEpoch(ds.Epoch, parsedReq.ValTime)
+	baseRep, err := ctrl.DSToDSResp(ds)
+	if err != nil {
+		logger.Debug("[DRKey gRPC server] Error parsing DS to protobuf resp",
+			"err", err)
+		return nil, err
+	}
+	return &cppb.DRKeyDSResponse{
+		BaseRep: baseRep,
+	}, nil
+}
+
 func requestToLvl2Req(req *cppb.DRKeyLvl2Request) (ctrl.Lvl2Req, error) {
 	return ctrl.RequestToLvl2Req(req.BaseReq)
 }
@@ -160,22 +223,80 @@ func keyToLvl2Resp(drkey drkey.Lvl2Key) (*cppb.DRKeyLvl2Response, error) {
 	}, nil
 }
 
-// deriveLvl2 will derive the level 2 key specified by the meta data and the level 1 key.
-func deriveLvl2(meta drkey.Lvl2Meta, lvl1Key drkey.Lvl1Key) (
-	drkey.Lvl2Key, error) {
+// deriveDS derives the delegation secret of the protocol from the level 1 key. The delegation
+// secret is the AS2AS level 2 key of the protocol.
+func deriveDS(proto protocol.Protocol, lvl1Key drkey.Lvl1Key) (drkey.DelegationSecret, error) {
+	meta := drkey.Lvl2Meta{
+		Epoch:    lvl1Key.Epoch,
+		SrcIA:    lvl1Key.SrcIA,
+		DstIA:    lvl1Key.DstIA,
+		KeyType:  drkey.AS2AS,
+		Protocol: proto.Name(),
+		SrcHost:  addr.HostNone{},
+		DstHost:  addr.HostNone{},
+	}
+	key, err := proto.DeriveLvl2(meta, lvl1Key)
+	if err != nil {
+		return drkey.DelegationSecret{}, err
+	}
+	return drkey.DelegationSecret{
+		Protocol: proto.Name(),
+		Epoch:    key.Epoch,
+		SrcIA:    key.SrcIA,
+		DstIA:    key.DstIA,
+		Key:      key.Key,
+	}, nil
+}
+
+// validateDSReq checks that the local AS is part of the requested delegation secret, that the
+// protocol is declared with a delegated derivation and delegation allowed, and that the
+// requester is allowed to get delegation secrets for the protocol. It returns the protocol.
+func (d *DRKeyServer) validateDSReq(req ctrl.DSReq, peerAddr net.Addr) (protocol.Protocol,
+	error) {
 
-	der, found := protocol.KnownDerivations[meta.Protocol]
-	if !found {
-		return drkey.Lvl2Key{}, serrors.New("no derivation found for protocol",
-			"protocol", meta.Protocol)
+	tcpAddr, ok := peerAddr.(*net.TCPAddr)
+	if !ok {
+		return protocol.Protocol{}, serrors.New(
+			"invalid peer address type, expected *net.TCPAddr",
+			"peer", peerAddr, "type", common.TypeOf(peerAddr))
+	}
+	if req.SrcIA != d.LocalIA && req.DstIA != d.LocalIA {
+		return protocol.Protocol{}, serrors.New("invalid request, localIA not found in request",
+			"localIA", d.LocalIA, "srcIA", req.SrcIA, "dstIA", req.DstIA)
+	}
+	proto, err := d.Protocols.Lookup(req.Protocol)
+	if err != nil {
+		return protocol.Protocol{}, err
 	}
-	return der.DeriveLvl2(meta, lvl1Key)
+	if _, ok := proto.DelegatedDerivation(); !ok || !proto.Delegation {
+		return protocol.Protocol{}, serrors.New("protocol does not support delegation",
+			"protocol", req.Protocol)
+	}
+	if !d.isAllowedDS(tcpAddr.IP, req.Protocol) {
+		return protocol.Protocol{}, serrors.New("endhost not allowed for DRKey DS request",
+			"endhost address", tcpAddr.IP, "protocol", req.Protocol)
+	}
+	return proto, nil
+}
+
+// isAllowedDS returns whether the host is allowed to obtain delegation secrets for the protocol.
+func (d *DRKeyServer) isAllowedDS(ip net.IP, proto string) bool {
+	var rawIP [16]byte
+	copy(rawIP[:], ip.To16())
+	protocolSet, foundSet := d.AllowedDSs[rawIP]
+	if !foundSet {
+		return false
+	}
+	_, found := protocolSet[proto]
+	return found
 }
 
 // validateLvl2Req checks that the requester is in the destination of the key
 // if AS2Host or host2host, and checks that the requester is authorized as to
-// get a DS if AS2AS (AS2AS == DS).
-func (d *DRKeyServer) validateLvl2Req(req ctrl.Lvl2Req, peerAddr net.Addr) error {
+// get a DS if AS2AS (AS2AS == DS), which requires delegation for the protocol.
+func (d *DRKeyServer) validateLvl2Req(req ctrl.Lvl2Req, proto protocol.Protocol,
+	peerAddr net.Addr) error {
+
 	tcpAddr, ok := peerAddr.(*net.TCPAddr)
 	if !ok {
 		return serrors.New("invalid peer address type, expected *net.TCPAddr",
@@ -205,19 +326,14 @@ func (d *DRKeyServer) validateLvl2Req(req ctrl.Lvl2Req, peerAddr net.Addr) error
 		fallthrough
 	case drkey.AS2AS:
 		// check in the allowed endhosts list
-		var rawIP [16]byte
-		copy(rawIP[:], localAddr.IP().To16())
-		protocolSet, foundSet := d.AllowedDSs[rawIP]
-		if foundSet {
-			if _, found := protocolSet[req.Protocol]; found {
-				log.Debug("Authorized delegated secret",
-					"reqType", req.ReqType,
-					"requester address", localAddr,
-					"srcHost", req.SrcHost.ToHostAddr().String(),
-					"dstHost", req.DstHost.ToHostAddr().String(),
-				)
-				return nil
-			}
+		if proto.Delegation && d.isAllowedDS(localAddr.IP(), req.Protocol) {
+			log.Debug("Authorized delegated secret",
+				"reqType", req.ReqType,
+				"requester address", localAddr,
+				"srcHost", req.SrcHost.ToHostAddr().String(),
+				"dstHost", req.DstHost.ToHostAddr().String(),
+			)
+			return nil
 		}
 		return serrors.New("endhost not allowed for DRKey request",
 			"reqType", req.ReqType,
@@ -15,10 +15,16 @@
 package grpc_test
 
 import (
+	"errors"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	ctrl "github.com/scionproto/scion/go/lib/ctrl/drkey"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
 	"github.com/scionproto/scion/go/lib/xtest"
 	dk_grpc "github.com/scionproto/scion/go/pkg/cs/drkey/grpc"
 	"github.com/scionproto/scion/go/pkg/cs/drkey/test"
@@ -30,7 +36,118 @@ func TestDeriveLvl2Key(t *testing.T) {
 
 	meta, lvl1Key := test.GetInputToDeriveLvl2Key(t)
 
-	lvl2Key, err := dk_grpc.DeriveLvl2(meta, lvl1Key)
+	proto, err := protocol.DefaultRegistry().Lookup(meta.Protocol)
+	require.NoError(t, err)
+	lvl2Key, err := proto.DeriveLvl2(meta, lvl1Key)
 	require.NoError(t, err)
 	require.EqualValues(t, expectedKey, lvl2Key.Key)
 }
+
+func TestDeriveDS(t *testing.T) {
+	meta, lvl1Key := test.GetInputToDeriveLvl2Key(t)
+	proto, err := protocol.DefaultRegistry().Lookup("piskes")
+	require.NoError(t, err)
+	ds, err := dk_grpc.DeriveDS(proto, lvl1Key)
+	require.NoError(t, err)
+
+	// the keys derived from the DS are the same the CS derives from the level 1 key.
+	meta.Protocol = "piskes"
+	meta.KeyType = drkey.Host2Host
+	meta.SrcHost = addr.HostFromIP(net.IPv4(127, 0, 0, 1))
+	meta.DstHost = addr.HostFromIP(net.IPv4(127, 0, 0, 2))
+	expected, err := proto.DeriveLvl2(meta, lvl1Key)
+	require.NoError(t, err)
+	der, ok := proto.DelegatedDerivation()
+	require.True(t, ok)
+	key, err := der.DeriveLvl2FromDS(meta, ds)
+	require.NoError(t, err)
+	require.Equal(t, expected.Key, key.Key)
+}
+
+func TestValidateDSReq(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:1")
+	remoteIA := xtest.MustParseIA("1-ff00:0:2")
+	var allowed [16]byte
+	copy(allowed[:], net.IPv4(127, 0, 0, 1).To16())
+	server := &dk_grpc.DRKeyServer{
+		LocalIA: localIA,
+		AllowedDSs: map[[16]byte]map[string]struct{}{
+			allowed: {"piskes": {}, "scmp": {}},
+		},
+	}
+	allowedPeer := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+	testCases := map[string]struct {
+		req       ctrl.DSReq
+		peer      net.Addr
+		assertErr require.ErrorAssertionFunc
+	}{
+		"allowed as source": {
+			req:       ctrl.DSReq{Protocol: "piskes", SrcIA: localIA, DstIA: remoteIA},
+			peer:      allowedPeer,
+			assertErr: require.NoError,
+		},
+		"allowed as destination": {
+			req:       ctrl.DSReq{Protocol: "piskes", SrcIA: remoteIA, DstIA: localIA},
+			peer:      allowedPeer,
+			assertErr: require.NoError,
+		},
+		"not local": {
+			req:       ctrl.DSReq{Protocol: "piskes", SrcIA: remoteIA, DstIA: remoteIA},
+			peer:      allowedPeer,
+			assertErr: require.Error,
+		},
+		"host not allowed": {
+			req:       ctrl.DSReq{Protocol: "piskes", SrcIA: localIA, DstIA: remoteIA},
+			peer:      &net.TCPAddr{IP: net.IPv4(127, 0, 0, 2)},
+			assertErr: require.Error,
+		},
+		"protocol without delegation": {
+			req:       ctrl.DSReq{Protocol: "scmp", SrcIA: localIA, DstIA: remoteIA},
+			peer:      allowedPeer,
+			assertErr: require.Error,
+		},
+		"unknown protocol": {
+			req:       ctrl.DSReq{Protocol: "unknown", SrcIA: localIA, DstIA: remoteIA},
+			peer:      allowedPeer,
+			assertErr: require.Error,
+		},
+		"not a TCP peer": {
+			req:       ctrl.DSReq{Protocol: "piskes", SrcIA: localIA, DstIA: remoteIA},
+			peer:      &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)},
+			assertErr: require.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			tc.assertErr(t, server.ValidateDSReq(tc.req, tc.peer))
+		})
+	}
+}
+
+func TestValidateDSReqConfiguredProtocols(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:1")
+	remoteIA := xtest.MustParseIA("1-ff00:0:2")
+	var allowed [16]byte
+	copy(allowed[:], net.IPv4(127, 0, 0, 1).To16())
+	server := &dk_grpc.DRKeyServer{
+		LocalIA: localIA,
+		AllowedDSs: map[[16]byte]map[string]struct{}{
+			allowed: {"piskes": {}, "foo": {}, "bar": {}},
+		},
+		Protocols: protocol.ProtocolsConfig{
+			"foo": {Derivation: protocol.DelegatedType, Delegation: true},
+			"bar": {Derivation: protocol.DelegatedType},
+		}.Registry(),
+	}
+	peer := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+	req := ctrl.DSReq{Protocol: "foo", SrcIA: localIA, DstIA: remoteIA}
+	require.NoError(t, server.ValidateDSReq(req, peer))
+
+	req.Protocol = "bar"
+	require.Error(t, server.ValidateDSReq(req, peer))
+
+	req.Protocol = "piskes"
+	err := server.ValidateDSReq(req, peer)
+	require.True(t, errors.Is(err, protocol.ErrUndeclaredProtocol), err)
+}
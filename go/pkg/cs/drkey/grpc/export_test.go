@@ -14,6 +14,17 @@
 
 package grpc
 
+import (
+	"net"
+
+	ctrl "github.com/scionproto/scion/go/lib/ctrl/drkey"
+)
+
 var (
-	DeriveLvl2 = deriveLvl2
+	DeriveDS = deriveDS
 )
+
+func (d *DRKeyServer) ValidateDSReq(req ctrl.DSReq, peerAddr net.Addr) error {
+	_, err := d.validateDSReq(req, peerAddr)
+	return err
+}
@@ -92,6 +92,29 @@ func (s *ServiceStore) DeriveLvl1(dstIA addr.IA, valTime time.Time) (drkey.Lvl1K
 	if err != nil {
 		return drkey.Lvl1Key{}, serrors.WrapStr("getting secret value", err)
 	}
+	return s.deriveLvl1(dstIA, sv)
+}
+
+// DeriveLvl1Keys returns the Lvl1 DRKeys of all the epochs valid at valTime, the newest first.
+func (s *ServiceStore) DeriveLvl1Keys(dstIA addr.IA, valTime time.Time) ([]drkey.Lvl1Key,
+	error) {
+
+	svs, err := s.SecretValues.GetSecretValues(valTime)
+	if err != nil {
+		return nil, serrors.WrapStr("getting secret values", err)
+	}
+	keys := make([]drkey.Lvl1Key, 0, len(svs))
+	for _, sv := range svs {
+		key, err := s.deriveLvl1(dstIA, sv)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *ServiceStore) deriveLvl1(dstIA addr.IA, sv drkey.SV) (drkey.Lvl1Key, error) {
 	meta := drkey.Lvl1Meta{
 		Epoch: sv.Epoch,
 		SrcIA: s.LocalIA,
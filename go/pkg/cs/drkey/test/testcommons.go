@@ -25,7 +25,6 @@ import (
 	"github.com/scionproto/scion/go/lib/drkeystorage"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
-	csdrkey "github.com/scionproto/scion/go/pkg/cs/drkey"
 )
 
 func getTestMasterSecret() []byte {
@@ -35,7 +34,7 @@ func getTestMasterSecret() []byte {
 // SecretValueTestFactory works as a SecretValueFactory but uses a user-controlled-variable instead
 // of time.Now when calling GetSecretValue.
 type SecretValueTestFactory struct {
-	csdrkey.SecretValueFactory
+	drkey.SecretValueFactory
 	Now time.Time
 }
 
@@ -43,10 +42,15 @@ func (f *SecretValueTestFactory) GetSecretValue(_ time.Time) (drkey.SV, error) {
 	return f.SecretValueFactory.GetSecretValue(f.Now)
 }
 
+func (f *SecretValueTestFactory) GetSecretValues(_ time.Time) ([]drkey.SV, error) {
+	return f.SecretValueFactory.GetSecretValues(f.Now)
+}
+
 func GetSecretValueTestFactory() drkeystorage.SecretValueFactory {
 	return &SecretValueTestFactory{
-		SecretValueFactory: *csdrkey.NewSecretValueFactory(getTestMasterSecret(), 10*time.Second),
-		Now:                util.SecsToTime(0),
+		SecretValueFactory: *drkey.NewSecretValueFactory(getTestMasterSecret(),
+			10*time.Second, 0),
+		Now: util.SecsToTime(0),
 	}
 }
 
@@ -59,6 +59,13 @@ type Metrics struct {
 	BeaconingReceivedTotal                 *prometheus.CounterVec
 	BeaconingRegisteredTotal               *prometheus.CounterVec
 	BeaconingRegistrarInternalErrorsTotal  *prometheus.CounterVec
+	ColibriActiveReservations              *prometheus.GaugeVec
+	ColibriCapacityReloadsTotal            *prometheus.CounterVec
+	ColibriExpiredIndicesTotal             *prometheus.CounterVec
+	ColibriGrantedBandwidthKbps            *prometheus.HistogramVec
+	ColibriIndexConfirmationSeconds        *prometheus.HistogramVec
+	ColibriOverCapacityReservations        *prometheus.GaugeVec
+	ColibriRequestsTotal                   *prometheus.CounterVec
 	DiscoveryRequestsTotal                 *prometheus.CounterVec
 	SegmentLookupRequestsTotal             *prometheus.CounterVec
 	SegmentLookupSegmentsSentTotal         *prometheus.CounterVec
@@ -109,6 +116,60 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"seg_type"},
 		),
+		ColibriActiveReservations: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "control_colibri_active_reservations",
+				Help: "Number of COLIBRI reservations with an active index.",
+			},
+			[]string{"type"},
+		),
+		ColibriCapacityReloadsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_colibri_capacity_reloads_total",
+				Help: "Total number of reloads of the COLIBRI interface capacities.",
+			},
+			[]string{prom.LabelResult},
+		),
+		ColibriExpiredIndicesTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_colibri_expired_indices_total",
+				Help: "Total number of expired COLIBRI reservation indices removed.",
+			},
+			[]string{},
+		),
+		ColibriGrantedBandwidthKbps: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name: "control_colibri_granted_bandwidth_kbps",
+				Help: "Bandwidth granted to COLIBRI segment reservations, in kbps.",
+				// 64 kbps, 256 kbps, ... 4 Tbps.
+				Buckets: prometheus.ExponentialBuckets(64, 4, 14),
+			},
+			[]string{"ingress", "egress"},
+		),
+		ColibriIndexConfirmationSeconds: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name: "control_colibri_index_confirmation_seconds",
+				Help: "Time between the admission of a COLIBRI segment index and its " +
+					"confirmation.",
+				Buckets: prom.DefaultLatencyBuckets,
+			},
+			[]string{},
+		),
+		ColibriOverCapacityReservations: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "control_colibri_over_capacity_reservations",
+				Help: "Number of COLIBRI segment reservations exceeding the interface " +
+					"capacities.",
+			},
+			[]string{},
+		),
+		ColibriRequestsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_colibri_requests_total",
+				Help: "Total number of COLIBRI requests processed, by type and result.",
+			},
+			[]string{"type", prom.LabelResult},
+		),
 		DiscoveryRequestsTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "discovery_requests_total",
@@ -142,9 +203,9 @@ func NewMetrics() *Metrics {
 }
 
 // StartHTTPEndpoints starts the HTTP endpoints that expose the metrics and
-// additional information.
+// additional information. The extra pages are served together with the default ones.
 func StartHTTPEndpoints(elemId string, cfg interface{}, signer cstrust.RenewingSigner,
-	ca cstrust.ChainBuilder, metrics env.Metrics) error {
+	ca cstrust.ChainBuilder, metrics env.Metrics, extra service.StatusPages) error {
 	statusPages := service.StatusPages{
 		"info":      service.NewInfoHandler(),
 		"config":    service.NewConfigHandler(cfg),
@@ -152,6 +213,9 @@ func StartHTTPEndpoints(elemId string, cfg interface{}, signer cstrust.RenewingS
 		"signer":    signerHandler(signer),
 		"log/level": log.ConsoleLevel.ServeHTTP,
 	}
+	for name, page := range extra {
+		statusPages[name] = page
+	}
 	if ca != (cstrust.ChainBuilder{}) {
 		statusPages["ca"] = caHandler(ca)
 	}
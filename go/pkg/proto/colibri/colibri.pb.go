@@ -0,0 +1,2150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.22.0
+// 	protoc        v3.11.4
+// source: proto/colibri/v1/colibri.proto
+
+package colibri
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type IndexState int32
+
+const (
+	IndexState_INDEX_STATE_UNSPECIFIED IndexState = 0
+	IndexState_INDEX_STATE_TEMPORARY   IndexState = 1
+	IndexState_INDEX_STATE_PENDING     IndexState = 2
+	IndexState_INDEX_STATE_ACTIVE      IndexState = 3
+)
+
+// Enum value maps for IndexState.
+var (
+	IndexState_name = map[int32]string{
+		0: "INDEX_STATE_UNSPECIFIED",
+		1: "INDEX_STATE_TEMPORARY",
+		2: "INDEX_STATE_PENDING",
+		3: "INDEX_STATE_ACTIVE",
+	}
+	IndexState_value = map[string]int32{
+		"INDEX_STATE_UNSPECIFIED": 0,
+		"INDEX_STATE_TEMPORARY":   1,
+		"INDEX_STATE_PENDING":     2,
+		"INDEX_STATE_ACTIVE":      3,
+	}
+)
+
+func (x IndexState) Enum() *IndexState {
+	p := new(IndexState)
+	*p = x
+	return p
+}
+
+func (x IndexState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IndexState) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_colibri_v1_colibri_proto_enumTypes[0].Descriptor()
+}
+
+func (IndexState) Type() protoreflect.EnumType {
+	return &file_proto_colibri_v1_colibri_proto_enumTypes[0]
+}
+
+func (x IndexState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IndexState.Descriptor instead.
+func (IndexState) EnumDescriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{0}
+}
+
+type ProcessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Raw            []byte           `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	Path           *ReservationPath `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Authenticators [][]byte         `protobuf:"bytes,3,rep,name=authenticators,proto3" json:"authenticators,omitempty"`
+}
+
+func (x *ProcessRequest) Reset() {
+	*x = ProcessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProcessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessRequest) ProtoMessage() {}
+
+func (x *ProcessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessRequest.ProtoReflect.Descriptor instead.
+func (*ProcessRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProcessRequest) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *ProcessRequest) GetPath() *ReservationPath {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *ProcessRequest) GetAuthenticators() [][]byte {
+	if x != nil {
+		return x.Authenticators
+	}
+	return nil
+}
+
+type ProcessResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Raw     []byte           `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	Path    *ReservationPath `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Failure *Failure         `protobuf:"bytes,3,opt,name=failure,proto3" json:"failure,omitempty"`
+}
+
+func (x *ProcessResponse) Reset() {
+	*x = ProcessResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProcessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessResponse) ProtoMessage() {}
+
+func (x *ProcessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessResponse.ProtoReflect.Descriptor instead.
+func (*ProcessResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProcessResponse) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *ProcessResponse) GetPath() *ReservationPath {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *ProcessResponse) GetFailure() *Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type Failure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorCode   uint32 `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	FailedHop   uint32 `protobuf:"varint,2,opt,name=failed_hop,json=failedHop,proto3" json:"failed_hop,omitempty"`
+	FailedIsdAs uint64 `protobuf:"varint,3,opt,name=failed_isd_as,json=failedIsdAs,proto3" json:"failed_isd_as,omitempty"`
+	MaxBw       uint32 `protobuf:"varint,4,opt,name=max_bw,json=maxBw,proto3" json:"max_bw,omitempty"`
+}
+
+func (x *Failure) Reset() {
+	*x = Failure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Failure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Failure) ProtoMessage() {}
+
+func (x *Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Failure.ProtoReflect.Descriptor instead.
+func (*Failure) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Failure) GetErrorCode() uint32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *Failure) GetFailedHop() uint32 {
+	if x != nil {
+		return x.FailedHop
+	}
+	return 0
+}
+
+func (x *Failure) GetFailedIsdAs() uint64 {
+	if x != nil {
+		return x.FailedIsdAs
+	}
+	return 0
+}
+
+func (x *Failure) GetMaxBw() uint32 {
+	if x != nil {
+		return x.MaxBw
+	}
+	return 0
+}
+
+type ReservationPath struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Steps       []*PathStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	CurrentStep uint32      `protobuf:"varint,2,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+}
+
+func (x *ReservationPath) Reset() {
+	*x = ReservationPath{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReservationPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationPath) ProtoMessage() {}
+
+func (x *ReservationPath) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationPath.ProtoReflect.Descriptor instead.
+func (*ReservationPath) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReservationPath) GetSteps() []*PathStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *ReservationPath) GetCurrentStep() uint32 {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return 0
+}
+
+type PathStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsdAs   uint64 `protobuf:"varint,1,opt,name=isd_as,json=isdAs,proto3" json:"isd_as,omitempty"`
+	Ingress uint32 `protobuf:"varint,2,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	Egress  uint32 `protobuf:"varint,3,opt,name=egress,proto3" json:"egress,omitempty"`
+}
+
+func (x *PathStep) Reset() {
+	*x = PathStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathStep) ProtoMessage() {}
+
+func (x *PathStep) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathStep.ProtoReflect.Descriptor instead.
+func (*PathStep) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PathStep) GetIsdAs() uint64 {
+	if x != nil {
+		return x.IsdAs
+	}
+	return 0
+}
+
+func (x *PathStep) GetIngress() uint32 {
+	if x != nil {
+		return x.Ingress
+	}
+	return 0
+}
+
+func (x *PathStep) GetEgress() uint32 {
+	if x != nil {
+		return x.Egress
+	}
+	return 0
+}
+
+type ListStitchablesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DstIsdAs uint64 `protobuf:"varint,1,opt,name=dst_isd_as,json=dstIsdAs,proto3" json:"dst_isd_as,omitempty"`
+}
+
+func (x *ListStitchablesRequest) Reset() {
+	*x = ListStitchablesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStitchablesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStitchablesRequest) ProtoMessage() {}
+
+func (x *ListStitchablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStitchablesRequest.ProtoReflect.Descriptor instead.
+func (*ListStitchablesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListStitchablesRequest) GetDstIsdAs() uint64 {
+	if x != nil {
+		return x.DstIsdAs
+	}
+	return 0
+}
+
+type ListStitchablesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reservations []*StitchableSegment `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+}
+
+func (x *ListStitchablesResponse) Reset() {
+	*x = ListStitchablesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStitchablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStitchablesResponse) ProtoMessage() {}
+
+func (x *ListStitchablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStitchablesResponse.ProtoReflect.Descriptor instead.
+func (*ListStitchablesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListStitchablesResponse) GetReservations() []*StitchableSegment {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+type StitchableSegment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       []byte           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Path     *ReservationPath `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	PathType uint32           `protobuf:"varint,3,opt,name=path_type,json=pathType,proto3" json:"path_type,omitempty"`
+	AllocBw  uint32           `protobuf:"varint,4,opt,name=alloc_bw,json=allocBw,proto3" json:"alloc_bw,omitempty"`
+}
+
+func (x *StitchableSegment) Reset() {
+	*x = StitchableSegment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StitchableSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StitchableSegment) ProtoMessage() {}
+
+func (x *StitchableSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StitchableSegment.ProtoReflect.Descriptor instead.
+func (*StitchableSegment) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StitchableSegment) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *StitchableSegment) GetPath() *ReservationPath {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *StitchableSegment) GetPathType() uint32 {
+	if x != nil {
+		return x.PathType
+	}
+	return 0
+}
+
+func (x *StitchableSegment) GetAllocBw() uint32 {
+	if x != nil {
+		return x.AllocBw
+	}
+	return 0
+}
+
+type E2ESetupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DstIsdAs    uint64 `protobuf:"varint,1,opt,name=dst_isd_as,json=dstIsdAs,proto3" json:"dst_isd_as,omitempty"`
+	RequestedBw uint32 `protobuf:"varint,2,opt,name=requested_bw,json=requestedBw,proto3" json:"requested_bw,omitempty"`
+}
+
+func (x *E2ESetupRequest) Reset() {
+	*x = E2ESetupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2ESetupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2ESetupRequest) ProtoMessage() {}
+
+func (x *E2ESetupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2ESetupRequest.ProtoReflect.Descriptor instead.
+func (*E2ESetupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *E2ESetupRequest) GetDstIsdAs() uint64 {
+	if x != nil {
+		return x.DstIsdAs
+	}
+	return 0
+}
+
+func (x *E2ESetupRequest) GetRequestedBw() uint32 {
+	if x != nil {
+		return x.RequestedBw
+	}
+	return 0
+}
+
+type E2ESetupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reservation *E2EReservation `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	Failure     *Failure        `protobuf:"bytes,2,opt,name=failure,proto3" json:"failure,omitempty"`
+}
+
+func (x *E2ESetupResponse) Reset() {
+	*x = E2ESetupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2ESetupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2ESetupResponse) ProtoMessage() {}
+
+func (x *E2ESetupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2ESetupResponse.ProtoReflect.Descriptor instead.
+func (*E2ESetupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *E2ESetupResponse) GetReservation() *E2EReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *E2ESetupResponse) GetFailure() *Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type E2ERenewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestedBw uint32 `protobuf:"varint,2,opt,name=requested_bw,json=requestedBw,proto3" json:"requested_bw,omitempty"`
+}
+
+func (x *E2ERenewRequest) Reset() {
+	*x = E2ERenewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2ERenewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2ERenewRequest) ProtoMessage() {}
+
+func (x *E2ERenewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2ERenewRequest.ProtoReflect.Descriptor instead.
+func (*E2ERenewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *E2ERenewRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *E2ERenewRequest) GetRequestedBw() uint32 {
+	if x != nil {
+		return x.RequestedBw
+	}
+	return 0
+}
+
+type E2ERenewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reservation *E2EReservation `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	Failure     *Failure        `protobuf:"bytes,2,opt,name=failure,proto3" json:"failure,omitempty"`
+}
+
+func (x *E2ERenewResponse) Reset() {
+	*x = E2ERenewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2ERenewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2ERenewResponse) ProtoMessage() {}
+
+func (x *E2ERenewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2ERenewResponse.ProtoReflect.Descriptor instead.
+func (*E2ERenewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *E2ERenewResponse) GetReservation() *E2EReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *E2ERenewResponse) GetFailure() *Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type E2ECleanupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *E2ECleanupRequest) Reset() {
+	*x = E2ECleanupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2ECleanupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2ECleanupRequest) ProtoMessage() {}
+
+func (x *E2ECleanupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2ECleanupRequest.ProtoReflect.Descriptor instead.
+func (*E2ECleanupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *E2ECleanupRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *E2ECleanupRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type E2ECleanupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *E2ECleanupResponse) Reset() {
+	*x = E2ECleanupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2ECleanupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2ECleanupResponse) ProtoMessage() {}
+
+func (x *E2ECleanupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2ECleanupResponse.ProtoReflect.Descriptor instead.
+func (*E2ECleanupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{13}
+}
+
+type E2EReservation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    []byte           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Index uint32           `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Token []byte           `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Path  *ReservationPath `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *E2EReservation) Reset() {
+	*x = E2EReservation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2EReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2EReservation) ProtoMessage() {}
+
+func (x *E2EReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2EReservation.ProtoReflect.Descriptor instead.
+func (*E2EReservation) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *E2EReservation) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *E2EReservation) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *E2EReservation) GetToken() []byte {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *E2EReservation) GetPath() *ReservationPath {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+type ListReservationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListReservationsRequest) Reset() {
+	*x = ListReservationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReservationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReservationsRequest) ProtoMessage() {}
+
+func (x *ListReservationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReservationsRequest.ProtoReflect.Descriptor instead.
+func (*ListReservationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{15}
+}
+
+type ListReservationsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Segments []*SegmentReservationInfo `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty"`
+	E2E      []*E2EReservationInfo     `protobuf:"bytes,2,rep,name=e2e,proto3" json:"e2e,omitempty"`
+}
+
+func (x *ListReservationsResponse) Reset() {
+	*x = ListReservationsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReservationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReservationsResponse) ProtoMessage() {}
+
+func (x *ListReservationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReservationsResponse.ProtoReflect.Descriptor instead.
+func (*ListReservationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListReservationsResponse) GetSegments() []*SegmentReservationInfo {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *ListReservationsResponse) GetE2E() []*E2EReservationInfo {
+	if x != nil {
+		return x.E2E
+	}
+	return nil
+}
+
+type SegmentReservationInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       []byte              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PathType uint32              `protobuf:"varint,2,opt,name=path_type,json=pathType,proto3" json:"path_type,omitempty"`
+	Ingress  uint32              `protobuf:"varint,3,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	Egress   uint32              `protobuf:"varint,4,opt,name=egress,proto3" json:"egress,omitempty"`
+	Path     *ReservationPath    `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	Indices  []*ReservationIndex `protobuf:"bytes,6,rep,name=indices,proto3" json:"indices,omitempty"`
+	BaseId   []byte              `protobuf:"bytes,7,opt,name=base_id,json=baseId,proto3" json:"base_id,omitempty"`
+}
+
+func (x *SegmentReservationInfo) Reset() {
+	*x = SegmentReservationInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SegmentReservationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentReservationInfo) ProtoMessage() {}
+
+func (x *SegmentReservationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentReservationInfo.ProtoReflect.Descriptor instead.
+func (*SegmentReservationInfo) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SegmentReservationInfo) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SegmentReservationInfo) GetPathType() uint32 {
+	if x != nil {
+		return x.PathType
+	}
+	return 0
+}
+
+func (x *SegmentReservationInfo) GetIngress() uint32 {
+	if x != nil {
+		return x.Ingress
+	}
+	return 0
+}
+
+func (x *SegmentReservationInfo) GetEgress() uint32 {
+	if x != nil {
+		return x.Egress
+	}
+	return 0
+}
+
+func (x *SegmentReservationInfo) GetPath() *ReservationPath {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *SegmentReservationInfo) GetIndices() []*ReservationIndex {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+func (x *SegmentReservationInfo) GetBaseId() []byte {
+	if x != nil {
+		return x.BaseId
+	}
+	return nil
+}
+
+type E2EReservationInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         []byte              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SegmentIds [][]byte            `protobuf:"bytes,2,rep,name=segment_ids,json=segmentIds,proto3" json:"segment_ids,omitempty"`
+	Indices    []*ReservationIndex `protobuf:"bytes,3,rep,name=indices,proto3" json:"indices,omitempty"`
+}
+
+func (x *E2EReservationInfo) Reset() {
+	*x = E2EReservationInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2EReservationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2EReservationInfo) ProtoMessage() {}
+
+func (x *E2EReservationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2EReservationInfo.ProtoReflect.Descriptor instead.
+func (*E2EReservationInfo) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *E2EReservationInfo) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *E2EReservationInfo) GetSegmentIds() [][]byte {
+	if x != nil {
+		return x.SegmentIds
+	}
+	return nil
+}
+
+func (x *E2EReservationInfo) GetIndices() []*ReservationIndex {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+type ReservationIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index      uint32               `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Expiration *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	AllocBw    uint32               `protobuf:"varint,3,opt,name=alloc_bw,json=allocBw,proto3" json:"alloc_bw,omitempty"`
+	State      IndexState           `protobuf:"varint,4,opt,name=state,proto3,enum=proto.colibri.v1.IndexState" json:"state,omitempty"`
+}
+
+func (x *ReservationIndex) Reset() {
+	*x = ReservationIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReservationIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationIndex) ProtoMessage() {}
+
+func (x *ReservationIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationIndex.ProtoReflect.Descriptor instead.
+func (*ReservationIndex) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReservationIndex) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ReservationIndex) GetExpiration() *timestamp.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *ReservationIndex) GetAllocBw() uint32 {
+	if x != nil {
+		return x.AllocBw
+	}
+	return 0
+}
+
+func (x *ReservationIndex) GetState() IndexState {
+	if x != nil {
+		return x.State
+	}
+	return IndexState_INDEX_STATE_UNSPECIFIED
+}
+
+var File_proto_colibri_v1_colibri_proto protoreflect.FileDescriptor
+
+var file_proto_colibri_v1_colibri_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
+	0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x81, 0x01, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x35, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x26, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72,
+	0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x35, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x33, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0x82, 0x01, 0x0a, 0x07, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x68,
+	0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x48, 0x6f, 0x70, 0x12, 0x22, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x69, 0x73,
+	0x64, 0x5f, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x5f, 0x62,
+	0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x61, 0x78, 0x42, 0x77, 0x22, 0x66,
+	0x0a, 0x0f, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74,
+	0x65, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73,
+	0x74, 0x65, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x53, 0x74, 0x65, 0x70, 0x22, 0x53, 0x0a, 0x08, 0x50, 0x61, 0x74, 0x68, 0x53, 0x74,
+	0x65, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x69, 0x73, 0x64, 0x41, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x36, 0x0a, 0x16, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x74, 0x69, 0x74, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x73, 0x64,
+	0x5f, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x73, 0x74, 0x49, 0x73,
+	0x64, 0x41, 0x73, 0x22, 0x62, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x69, 0x74, 0x63,
+	0x68, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47,
+	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x69, 0x74, 0x63, 0x68, 0x61, 0x62,
+	0x6c, 0x65, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x69, 0x74,
+	0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x35, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x74, 0x68, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x5f, 0x62, 0x77, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x42, 0x77, 0x22, 0x52, 0x0a, 0x0f,
+	0x45, 0x32, 0x45, 0x53, 0x65, 0x74, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x73, 0x74, 0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x77, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x77,
+	0x22, 0x8b, 0x01, 0x0a, 0x10, 0x45, 0x32, 0x45, 0x53, 0x65, 0x74, 0x75, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x32,
+	0x45, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x07, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0x44,
+	0x0a, 0x0f, 0x45, 0x32, 0x45, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62,
+	0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x42, 0x77, 0x22, 0x8b, 0x01, 0x0a, 0x10, 0x45, 0x32, 0x45, 0x52, 0x65, 0x6e, 0x65,
+	0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x72, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x32, 0x45, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a,
+	0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x22, 0x39, 0x0a, 0x11, 0x45, 0x32, 0x45, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x14, 0x0a,
+	0x12, 0x45, 0x32, 0x45, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x0e, 0x45, 0x32, 0x45, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x35, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x61, 0x74, 0x68, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x19, 0x0a, 0x17, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x98, 0x01, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x44, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x73,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x03, 0x65, 0x32, 0x65, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x32, 0x45, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x65, 0x32, 0x65, 0x22,
+	0x85, 0x02, 0x0a, 0x16, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x74, 0x68, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70,
+	0x61, 0x74, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x35, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x3c, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x17,
+	0x0a, 0x07, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x45, 0x32, 0x45, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x73, 0x12,
+	0x3c, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22, 0xb3, 0x01,
+	0x0a, 0x10, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x5f, 0x62, 0x77,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x42, 0x77, 0x12,
+	0x32, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x2a, 0x75, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x1b, 0x0a, 0x17, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19,
+	0x0a, 0x15, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x45,
+	0x4d, 0x50, 0x4f, 0x52, 0x41, 0x52, 0x59, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x4e, 0x44,
+	0x45, 0x58, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x49, 0x4e, 0x44, 0x45, 0x58, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x03, 0x32, 0xcc, 0x01, 0x0a, 0x0e, 0x43,
+	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a,
+	0x07, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x68, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x69, 0x74, 0x63, 0x68, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x12, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x69, 0x74, 0x63, 0x68,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x69, 0x74, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x93, 0x02, 0x0a, 0x15, 0x45, 0x32,
+	0x45, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x05, 0x53, 0x65, 0x74, 0x75, 0x70, 0x12, 0x21, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x32, 0x45, 0x53, 0x65, 0x74, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x32, 0x45, 0x53, 0x65, 0x74, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x05, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x12, 0x21,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x32, 0x45, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x32, 0x45, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x07, 0x43, 0x6c, 0x65, 0x61, 0x6e,
+	0x75, 0x70, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x32, 0x45, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x32, 0x45, 0x43, 0x6c,
+	0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32,
+	0x79, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x04, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_colibri_v1_colibri_proto_rawDescOnce sync.Once
+	file_proto_colibri_v1_colibri_proto_rawDescData = file_proto_colibri_v1_colibri_proto_rawDesc
+)
+
+func file_proto_colibri_v1_colibri_proto_rawDescGZIP() []byte {
+	file_proto_colibri_v1_colibri_proto_rawDescOnce.Do(func() {
+		file_proto_colibri_v1_colibri_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_colibri_v1_colibri_proto_rawDescData)
+	})
+	return file_proto_colibri_v1_colibri_proto_rawDescData
+}
+
+var file_proto_colibri_v1_colibri_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_colibri_v1_colibri_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_proto_colibri_v1_colibri_proto_goTypes = []interface{}{
+	(IndexState)(0),                  // 0: proto.colibri.v1.IndexState
+	(*ProcessRequest)(nil),           // 1: proto.colibri.v1.ProcessRequest
+	(*ProcessResponse)(nil),          // 2: proto.colibri.v1.ProcessResponse
+	(*Failure)(nil),                  // 3: proto.colibri.v1.Failure
+	(*ReservationPath)(nil),          // 4: proto.colibri.v1.ReservationPath
+	(*PathStep)(nil),                 // 5: proto.colibri.v1.PathStep
+	(*ListStitchablesRequest)(nil),   // 6: proto.colibri.v1.ListStitchablesRequest
+	(*ListStitchablesResponse)(nil),  // 7: proto.colibri.v1.ListStitchablesResponse
+	(*StitchableSegment)(nil),        // 8: proto.colibri.v1.StitchableSegment
+	(*E2ESetupRequest)(nil),          // 9: proto.colibri.v1.E2ESetupRequest
+	(*E2ESetupResponse)(nil),         // 10: proto.colibri.v1.E2ESetupResponse
+	(*E2ERenewRequest)(nil),          // 11: proto.colibri.v1.E2ERenewRequest
+	(*E2ERenewResponse)(nil),         // 12: proto.colibri.v1.E2ERenewResponse
+	(*E2ECleanupRequest)(nil),        // 13: proto.colibri.v1.E2ECleanupRequest
+	(*E2ECleanupResponse)(nil),       // 14: proto.colibri.v1.E2ECleanupResponse
+	(*E2EReservation)(nil),           // 15: proto.colibri.v1.E2EReservation
+	(*ListReservationsRequest)(nil),  // 16: proto.colibri.v1.ListReservationsRequest
+	(*ListReservationsResponse)(nil), // 17: proto.colibri.v1.ListReservationsResponse
+	(*SegmentReservationInfo)(nil),   // 18: proto.colibri.v1.SegmentReservationInfo
+	(*E2EReservationInfo)(nil),       // 19: proto.colibri.v1.E2EReservationInfo
+	(*ReservationIndex)(nil),         // 20: proto.colibri.v1.ReservationIndex
+	(*timestamp.Timestamp)(nil),      // 21: google.protobuf.Timestamp
+}
+var file_proto_colibri_v1_colibri_proto_depIdxs = []int32{
+	4,  // 0: proto.colibri.v1.ProcessRequest.path:type_name -> proto.colibri.v1.ReservationPath
+	4,  // 1: proto.colibri.v1.ProcessResponse.path:type_name -> proto.colibri.v1.ReservationPath
+	3,  // 2: proto.colibri.v1.ProcessResponse.failure:type_name -> proto.colibri.v1.Failure
+	5,  // 3: proto.colibri.v1.ReservationPath.steps:type_name -> proto.colibri.v1.PathStep
+	8,  // 4: proto.colibri.v1.ListStitchablesResponse.reservations:type_name -> proto.colibri.v1.StitchableSegment
+	4,  // 5: proto.colibri.v1.StitchableSegment.path:type_name -> proto.colibri.v1.ReservationPath
+	15, // 6: proto.colibri.v1.E2ESetupResponse.reservation:type_name -> proto.colibri.v1.E2EReservation
+	3,  // 7: proto.colibri.v1.E2ESetupResponse.failure:type_name -> proto.colibri.v1.Failure
+	15, // 8: proto.colibri.v1.E2ERenewResponse.reservation:type_name -> proto.colibri.v1.E2EReservation
+	3,  // 9: proto.colibri.v1.E2ERenewResponse.failure:type_name -> proto.colibri.v1.Failure
+	4,  // 10: proto.colibri.v1.E2EReservation.path:type_name -> proto.colibri.v1.ReservationPath
+	18, // 11: proto.colibri.v1.ListReservationsResponse.segments:type_name -> proto.colibri.v1.SegmentReservationInfo
+	19, // 12: proto.colibri.v1.ListReservationsResponse.e2e:type_name -> proto.colibri.v1.E2EReservationInfo
+	4,  // 13: proto.colibri.v1.SegmentReservationInfo.path:type_name -> proto.colibri.v1.ReservationPath
+	20, // 14: proto.colibri.v1.SegmentReservationInfo.indices:type_name -> proto.colibri.v1.ReservationIndex
+	20, // 15: proto.colibri.v1.E2EReservationInfo.indices:type_name -> proto.colibri.v1.ReservationIndex
+	21, // 16: proto.colibri.v1.ReservationIndex.expiration:type_name -> google.protobuf.Timestamp
+	0,  // 17: proto.colibri.v1.ReservationIndex.state:type_name -> proto.colibri.v1.IndexState
+	1,  // 18: proto.colibri.v1.ColibriService.Process:input_type -> proto.colibri.v1.ProcessRequest
+	6,  // 19: proto.colibri.v1.ColibriService.ListStitchables:input_type -> proto.colibri.v1.ListStitchablesRequest
+	9,  // 20: proto.colibri.v1.E2EReservationService.Setup:input_type -> proto.colibri.v1.E2ESetupRequest
+	11, // 21: proto.colibri.v1.E2EReservationService.Renew:input_type -> proto.colibri.v1.E2ERenewRequest
+	13, // 22: proto.colibri.v1.E2EReservationService.Cleanup:input_type -> proto.colibri.v1.E2ECleanupRequest
+	16, // 23: proto.colibri.v1.ReservationListService.List:input_type -> proto.colibri.v1.ListReservationsRequest
+	2,  // 24: proto.colibri.v1.ColibriService.Process:output_type -> proto.colibri.v1.ProcessResponse
+	7,  // 25: proto.colibri.v1.ColibriService.ListStitchables:output_type -> proto.colibri.v1.ListStitchablesResponse
+	10, // 26: proto.colibri.v1.E2EReservationService.Setup:output_type -> proto.colibri.v1.E2ESetupResponse
+	12, // 27: proto.colibri.v1.E2EReservationService.Renew:output_type -> proto.colibri.v1.E2ERenewResponse
+	14, // 28: proto.colibri.v1.E2EReservationService.Cleanup:output_type -> proto.colibri.v1.E2ECleanupResponse
+	17, // 29: proto.colibri.v1.ReservationListService.List:output_type -> proto.colibri.v1.ListReservationsResponse
+	24, // [24:30] is the sub-list for method output_type
+	18, // [18:24] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_proto_colibri_v1_colibri_proto_init() }
+func file_proto_colibri_v1_colibri_proto_init() {
+	if File_proto_colibri_v1_colibri_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_colibri_v1_colibri_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Failure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReservationPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStitchablesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStitchablesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StitchableSegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2ESetupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2ESetupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2ERenewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2ERenewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2ECleanupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2ECleanupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2EReservation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListReservationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListReservationsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SegmentReservationInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2EReservationInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReservationIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_colibri_v1_colibri_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_proto_colibri_v1_colibri_proto_goTypes,
+		DependencyIndexes: file_proto_colibri_v1_colibri_proto_depIdxs,
+		EnumInfos:         file_proto_colibri_v1_colibri_proto_enumTypes,
+		MessageInfos:      file_proto_colibri_v1_colibri_proto_msgTypes,
+	}.Build()
+	File_proto_colibri_v1_colibri_proto = out.File
+	file_proto_colibri_v1_colibri_proto_rawDesc = nil
+	file_proto_colibri_v1_colibri_proto_goTypes = nil
+	file_proto_colibri_v1_colibri_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ColibriServiceClient is the client API for ColibriService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ColibriServiceClient interface {
+	Process(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*ProcessResponse, error)
+	ListStitchables(ctx context.Context, in *ListStitchablesRequest, opts ...grpc.CallOption) (*ListStitchablesResponse, error)
+}
+
+type colibriServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewColibriServiceClient(cc grpc.ClientConnInterface) ColibriServiceClient {
+	return &colibriServiceClient{cc}
+}
+
+func (c *colibriServiceClient) Process(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*ProcessResponse, error) {
+	out := new(ProcessResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriService/Process", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriServiceClient) ListStitchables(ctx context.Context, in *ListStitchablesRequest, opts ...grpc.CallOption) (*ListStitchablesResponse, error) {
+	out := new(ListStitchablesResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriService/ListStitchables", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ColibriServiceServer is the server API for ColibriService service.
+type ColibriServiceServer interface {
+	Process(context.Context, *ProcessRequest) (*ProcessResponse, error)
+	ListStitchables(context.Context, *ListStitchablesRequest) (*ListStitchablesResponse, error)
+}
+
+// UnimplementedColibriServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedColibriServiceServer struct {
+}
+
+func (*UnimplementedColibriServiceServer) Process(context.Context, *ProcessRequest) (*ProcessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Process not implemented")
+}
+func (*UnimplementedColibriServiceServer) ListStitchables(context.Context, *ListStitchablesRequest) (*ListStitchablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStitchables not implemented")
+}
+
+func RegisterColibriServiceServer(s *grpc.Server, srv ColibriServiceServer) {
+	s.RegisterService(&_ColibriService_serviceDesc, srv)
+}
+
+func _ColibriService_Process_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriServiceServer).Process(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriService/Process",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriServiceServer).Process(ctx, req.(*ProcessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriService_ListStitchables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStitchablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriServiceServer).ListStitchables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriService/ListStitchables",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriServiceServer).ListStitchables(ctx, req.(*ListStitchablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ColibriService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.colibri.v1.ColibriService",
+	HandlerType: (*ColibriServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Process",
+			Handler:    _ColibriService_Process_Handler,
+		},
+		{
+			MethodName: "ListStitchables",
+			Handler:    _ColibriService_ListStitchables_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/colibri/v1/colibri.proto",
+}
+
+// E2EReservationServiceClient is the client API for E2EReservationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type E2EReservationServiceClient interface {
+	Setup(ctx context.Context, in *E2ESetupRequest, opts ...grpc.CallOption) (*E2ESetupResponse, error)
+	Renew(ctx context.Context, in *E2ERenewRequest, opts ...grpc.CallOption) (*E2ERenewResponse, error)
+	Cleanup(ctx context.Context, in *E2ECleanupRequest, opts ...grpc.CallOption) (*E2ECleanupResponse, error)
+}
+
+type e2EReservationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewE2EReservationServiceClient(cc grpc.ClientConnInterface) E2EReservationServiceClient {
+	return &e2EReservationServiceClient{cc}
+}
+
+func (c *e2EReservationServiceClient) Setup(ctx context.Context, in *E2ESetupRequest, opts ...grpc.CallOption) (*E2ESetupResponse, error) {
+	out := new(E2ESetupResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.E2EReservationService/Setup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *e2EReservationServiceClient) Renew(ctx context.Context, in *E2ERenewRequest, opts ...grpc.CallOption) (*E2ERenewResponse, error) {
+	out := new(E2ERenewResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.E2EReservationService/Renew", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *e2EReservationServiceClient) Cleanup(ctx context.Context, in *E2ECleanupRequest, opts ...grpc.CallOption) (*E2ECleanupResponse, error) {
+	out := new(E2ECleanupResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.E2EReservationService/Cleanup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// E2EReservationServiceServer is the server API for E2EReservationService service.
+type E2EReservationServiceServer interface {
+	Setup(context.Context, *E2ESetupRequest) (*E2ESetupResponse, error)
+	Renew(context.Context, *E2ERenewRequest) (*E2ERenewResponse, error)
+	Cleanup(context.Context, *E2ECleanupRequest) (*E2ECleanupResponse, error)
+}
+
+// UnimplementedE2EReservationServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedE2EReservationServiceServer struct {
+}
+
+func (*UnimplementedE2EReservationServiceServer) Setup(context.Context, *E2ESetupRequest) (*E2ESetupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Setup not implemented")
+}
+func (*UnimplementedE2EReservationServiceServer) Renew(context.Context, *E2ERenewRequest) (*E2ERenewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Renew not implemented")
+}
+func (*UnimplementedE2EReservationServiceServer) Cleanup(context.Context, *E2ECleanupRequest) (*E2ECleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cleanup not implemented")
+}
+
+func RegisterE2EReservationServiceServer(s *grpc.Server, srv E2EReservationServiceServer) {
+	s.RegisterService(&_E2EReservationService_serviceDesc, srv)
+}
+
+func _E2EReservationService_Setup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(E2ESetupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(E2EReservationServiceServer).Setup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.E2EReservationService/Setup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(E2EReservationServiceServer).Setup(ctx, req.(*E2ESetupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _E2EReservationService_Renew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(E2ERenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(E2EReservationServiceServer).Renew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.E2EReservationService/Renew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(E2EReservationServiceServer).Renew(ctx, req.(*E2ERenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _E2EReservationService_Cleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(E2ECleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(E2EReservationServiceServer).Cleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.E2EReservationService/Cleanup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(E2EReservationServiceServer).Cleanup(ctx, req.(*E2ECleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _E2EReservationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.colibri.v1.E2EReservationService",
+	HandlerType: (*E2EReservationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Setup",
+			Handler:    _E2EReservationService_Setup_Handler,
+		},
+		{
+			MethodName: "Renew",
+			Handler:    _E2EReservationService_Renew_Handler,
+		},
+		{
+			MethodName: "Cleanup",
+			Handler:    _E2EReservationService_Cleanup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/colibri/v1/colibri.proto",
+}
+
+// ReservationListServiceClient is the client API for ReservationListService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReservationListServiceClient interface {
+	List(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error)
+}
+
+type reservationListServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReservationListServiceClient(cc grpc.ClientConnInterface) ReservationListServiceClient {
+	return &reservationListServiceClient{cc}
+}
+
+func (c *reservationListServiceClient) List(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error) {
+	out := new(ListReservationsResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ReservationListService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReservationListServiceServer is the server API for ReservationListService service.
+type ReservationListServiceServer interface {
+	List(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+}
+
+// UnimplementedReservationListServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedReservationListServiceServer struct {
+}
+
+func (*UnimplementedReservationListServiceServer) List(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+
+func RegisterReservationListServiceServer(s *grpc.Server, srv ReservationListServiceServer) {
+	s.RegisterService(&_ReservationListService_serviceDesc, srv)
+}
+
+func _ReservationListService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationListServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ReservationListService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationListServiceServer).List(ctx, req.(*ListReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ReservationListService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.colibri.v1.ReservationListService",
+	HandlerType: (*ReservationListServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _ReservationListService_List_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/colibri/v1/colibri.proto",
+}
@@ -124,6 +124,100 @@ func (x *DRKeyLvl2Response) GetBaseRep() *drkey.DRKeyLvl2Response {
 	return nil
 }
 
+type DRKeyDSRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BaseReq *drkey.DRKeyDSRequest `protobuf:"bytes,1,opt,name=base_req,json=baseReq,proto3" json:"base_req,omitempty"`
+}
+
+func (x *DRKeyDSRequest) Reset() {
+	*x = DRKeyDSRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyDSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyDSRequest) ProtoMessage() {}
+
+func (x *DRKeyDSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyDSRequest.ProtoReflect.Descriptor instead.
+func (*DRKeyDSRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_drkey_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DRKeyDSRequest) GetBaseReq() *drkey.DRKeyDSRequest {
+	if x != nil {
+		return x.BaseReq
+	}
+	return nil
+}
+
+type DRKeyDSResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BaseRep *drkey.DRKeyDSResponse `protobuf:"bytes,1,opt,name=base_rep,json=baseRep,proto3" json:"base_rep,omitempty"`
+}
+
+func (x *DRKeyDSResponse) Reset() {
+	*x = DRKeyDSResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyDSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyDSResponse) ProtoMessage() {}
+
+func (x *DRKeyDSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyDSResponse.ProtoReflect.Descriptor instead.
+func (*DRKeyDSResponse) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_drkey_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DRKeyDSResponse) GetBaseRep() *drkey.DRKeyDSResponse {
+	if x != nil {
+		return x.BaseRep
+	}
+	return nil
+}
+
 var File_proto_control_plane_v1_drkey_proto protoreflect.FileDescriptor
 
 var file_proto_control_plane_v1_drkey_proto_rawDesc = []byte{
@@ -143,26 +237,44 @@ var file_proto_control_plane_v1_drkey_proto_rawDesc = []byte{
 	0x72, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x76, 0x31, 0x2e,
 	0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x32, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x70, 0x32, 0x70, 0x0a, 0x10, 0x44, 0x52,
-	0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5c,
-	0x0a, 0x09, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x12, 0x25, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x76,
-	0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79,
-	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76,
-	0x6c, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x76, 0x0a, 0x10,
-	0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x32, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x62, 0x0a, 0x09, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x32, 0x12, 0x28, 0x2e,
+	0x65, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x70, 0x22, 0x50, 0x0a, 0x0e, 0x44, 0x52,
+	0x4b, 0x65, 0x79, 0x44, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x08,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x6d, 0x67, 0x6d,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x44, 0x53, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x22, 0x52, 0x0a, 0x0f,
+	0x44, 0x52, 0x4b, 0x65, 0x79, 0x44, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3f, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x44, 0x53, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x70,
+	0x32, 0x70, 0x0a, 0x10, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x5c, 0x0a, 0x09, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c,
+	0x31, 0x12, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c,
+	0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x32, 0x76, 0x0a, 0x10, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x32, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x62, 0x0a, 0x09, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c,
+	0x76, 0x6c, 0x32, 0x12, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b,
+	0x65, 0x79, 0x4c, 0x76, 0x6c, 0x32, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c,
 	0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x32,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x76, 0x0a, 0x16, 0x44, 0x52,
+	0x4b, 0x65, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x5c, 0x0a, 0x07, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x44, 0x53, 0x12,
+	0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x44, 0x53,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
 	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x32, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63,
-	0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x44, 0x53, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f,
+	0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -177,27 +289,35 @@ func file_proto_control_plane_v1_drkey_proto_rawDescGZIP() []byte {
 	return file_proto_control_plane_v1_drkey_proto_rawDescData
 }
 
-var file_proto_control_plane_v1_drkey_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_control_plane_v1_drkey_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_proto_control_plane_v1_drkey_proto_goTypes = []interface{}{
 	(*DRKeyLvl2Request)(nil),        // 0: proto.control_plane.v1.DRKeyLvl2Request
 	(*DRKeyLvl2Response)(nil),       // 1: proto.control_plane.v1.DRKeyLvl2Response
-	(*drkey.DRKeyLvl2Request)(nil),  // 2: proto.drkey.mgmt.v1.DRKeyLvl2Request
-	(*drkey.DRKeyLvl2Response)(nil), // 3: proto.drkey.mgmt.v1.DRKeyLvl2Response
-	(*drkey.DRKeyLvl1Request)(nil),  // 4: proto.drkey.mgmt.v1.DRKeyLvl1Request
-	(*drkey.DRKeyLvl1Response)(nil), // 5: proto.drkey.mgmt.v1.DRKeyLvl1Response
+	(*DRKeyDSRequest)(nil),          // 2: proto.control_plane.v1.DRKeyDSRequest
+	(*DRKeyDSResponse)(nil),         // 3: proto.control_plane.v1.DRKeyDSResponse
+	(*drkey.DRKeyLvl2Request)(nil),  // 4: proto.drkey.mgmt.v1.DRKeyLvl2Request
+	(*drkey.DRKeyLvl2Response)(nil), // 5: proto.drkey.mgmt.v1.DRKeyLvl2Response
+	(*drkey.DRKeyDSRequest)(nil),    // 6: proto.drkey.mgmt.v1.DRKeyDSRequest
+	(*drkey.DRKeyDSResponse)(nil),   // 7: proto.drkey.mgmt.v1.DRKeyDSResponse
+	(*drkey.DRKeyLvl1Request)(nil),  // 8: proto.drkey.mgmt.v1.DRKeyLvl1Request
+	(*drkey.DRKeyLvl1Response)(nil), // 9: proto.drkey.mgmt.v1.DRKeyLvl1Response
 }
 var file_proto_control_plane_v1_drkey_proto_depIdxs = []int32{
-	2, // 0: proto.control_plane.v1.DRKeyLvl2Request.base_req:type_name -> proto.drkey.mgmt.v1.DRKeyLvl2Request
-	3, // 1: proto.control_plane.v1.DRKeyLvl2Response.base_rep:type_name -> proto.drkey.mgmt.v1.DRKeyLvl2Response
-	4, // 2: proto.control_plane.v1.DRKeyLvl1Service.DRKeyLvl1:input_type -> proto.drkey.mgmt.v1.DRKeyLvl1Request
-	0, // 3: proto.control_plane.v1.DRKeyLvl2Service.DRKeyLvl2:input_type -> proto.control_plane.v1.DRKeyLvl2Request
-	5, // 4: proto.control_plane.v1.DRKeyLvl1Service.DRKeyLvl1:output_type -> proto.drkey.mgmt.v1.DRKeyLvl1Response
-	1, // 5: proto.control_plane.v1.DRKeyLvl2Service.DRKeyLvl2:output_type -> proto.control_plane.v1.DRKeyLvl2Response
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 0: proto.control_plane.v1.DRKeyLvl2Request.base_req:type_name -> proto.drkey.mgmt.v1.DRKeyLvl2Request
+	5, // 1: proto.control_plane.v1.DRKeyLvl2Response.base_rep:type_name -> proto.drkey.mgmt.v1.DRKeyLvl2Response
+	6, // 2: proto.control_plane.v1.DRKeyDSRequest.base_req:type_name -> proto.drkey.mgmt.v1.DRKeyDSRequest
+	7, // 3: proto.control_plane.v1.DRKeyDSResponse.base_rep:type_name -> proto.drkey.mgmt.v1.DRKeyDSResponse
+	8, // 4: proto.control_plane.v1.DRKeyLvl1Service.DRKeyLvl1:input_type -> proto.drkey.mgmt.v1.DRKeyLvl1Request
+	0, // 5: proto.control_plane.v1.DRKeyLvl2Service.DRKeyLvl2:input_type -> proto.control_plane.v1.DRKeyLvl2Request
+	2, // 6: proto.control_plane.v1.DRKeyDelegationService.DRKeyDS:input_type -> proto.control_plane.v1.DRKeyDSRequest
+	9, // 7: proto.control_plane.v1.DRKeyLvl1Service.DRKeyLvl1:output_type -> proto.drkey.mgmt.v1.DRKeyLvl1Response
+	1, // 8: proto.control_plane.v1.DRKeyLvl2Service.DRKeyLvl2:output_type -> proto.control_plane.v1.DRKeyLvl2Response
+	3, // 9: proto.control_plane.v1.DRKeyDelegationService.DRKeyDS:output_type -> proto.control_plane.v1.DRKeyDSResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_control_plane_v1_drkey_proto_init() }
@@ -230,6 +350,30 @@ func file_proto_control_plane_v1_drkey_proto_init() {
 				return nil
 			}
 		}
+		file_proto_control_plane_v1_drkey_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyDSRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_drkey_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyDSResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -237,9 +381,9 @@ func file_proto_control_plane_v1_drkey_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_control_plane_v1_drkey_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_proto_control_plane_v1_drkey_proto_goTypes,
 		DependencyIndexes: file_proto_control_plane_v1_drkey_proto_depIdxs,
@@ -402,3 +546,75 @@ var _DRKeyLvl2Service_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/control_plane/v1/drkey.proto",
 }
+
+// DRKeyDelegationServiceClient is the client API for DRKeyDelegationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DRKeyDelegationServiceClient interface {
+	DRKeyDS(ctx context.Context, in *DRKeyDSRequest, opts ...grpc.CallOption) (*DRKeyDSResponse, error)
+}
+
+type dRKeyDelegationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDRKeyDelegationServiceClient(cc grpc.ClientConnInterface) DRKeyDelegationServiceClient {
+	return &dRKeyDelegationServiceClient{cc}
+}
+
+func (c *dRKeyDelegationServiceClient) DRKeyDS(ctx context.Context, in *DRKeyDSRequest, opts ...grpc.CallOption) (*DRKeyDSResponse, error) {
+	out := new(DRKeyDSResponse)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.DRKeyDelegationService/DRKeyDS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DRKeyDelegationServiceServer is the server API for DRKeyDelegationService service.
+type DRKeyDelegationServiceServer interface {
+	DRKeyDS(context.Context, *DRKeyDSRequest) (*DRKeyDSResponse, error)
+}
+
+// UnimplementedDRKeyDelegationServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDRKeyDelegationServiceServer struct {
+}
+
+func (*UnimplementedDRKeyDelegationServiceServer) DRKeyDS(context.Context, *DRKeyDSRequest) (*DRKeyDSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DRKeyDS not implemented")
+}
+
+func RegisterDRKeyDelegationServiceServer(s *grpc.Server, srv DRKeyDelegationServiceServer) {
+	s.RegisterService(&_DRKeyDelegationService_serviceDesc, srv)
+}
+
+func _DRKeyDelegationService_DRKeyDS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DRKeyDSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DRKeyDelegationServiceServer).DRKeyDS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.DRKeyDelegationService/DRKeyDS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DRKeyDelegationServiceServer).DRKeyDS(ctx, req.(*DRKeyDSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DRKeyDelegationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.control_plane.v1.DRKeyDelegationService",
+	HandlerType: (*DRKeyDelegationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DRKeyDS",
+			Handler:    _DRKeyDelegationService_DRKeyDS_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/control_plane/v1/drkey.proto",
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/pkg/proto/control_plane (interfaces: ChainRenewalServiceServer,InterfaceStateConsumerServiceServer,InterfaceStateServiceServer,TrustMaterialServiceServer,DRKeyLvl1ServiceServer,DRKeyLvl2ServiceServer)
+// Source: github.com/scionproto/scion/go/pkg/proto/control_plane (interfaces: ChainRenewalServiceServer,InterfaceStateConsumerServiceServer,InterfaceStateServiceServer,TrustMaterialServiceServer,DRKeyLvl1ServiceServer,DRKeyLvl2ServiceServer,DRKeyDelegationServiceServer)
 
 // Package mock_control_plane is a generated GoMock package.
 package mock_control_plane
@@ -269,3 +269,41 @@ func (mr *MockDRKeyLvl2ServiceServerMockRecorder) DRKeyLvl2(arg0, arg1 interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DRKeyLvl2", reflect.TypeOf((*MockDRKeyLvl2ServiceServer)(nil).DRKeyLvl2), arg0, arg1)
 }
+
+// MockDRKeyDelegationServiceServer is a mock of DRKeyDelegationServiceServer interface
+type MockDRKeyDelegationServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDRKeyDelegationServiceServerMockRecorder
+}
+
+// MockDRKeyDelegationServiceServerMockRecorder is the mock recorder for MockDRKeyDelegationServiceServer
+type MockDRKeyDelegationServiceServerMockRecorder struct {
+	mock *MockDRKeyDelegationServiceServer
+}
+
+// NewMockDRKeyDelegationServiceServer creates a new mock instance
+func NewMockDRKeyDelegationServiceServer(ctrl *gomock.Controller) *MockDRKeyDelegationServiceServer {
+	mock := &MockDRKeyDelegationServiceServer{ctrl: ctrl}
+	mock.recorder = &MockDRKeyDelegationServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDRKeyDelegationServiceServer) EXPECT() *MockDRKeyDelegationServiceServerMockRecorder {
+	return m.recorder
+}
+
+// DRKeyDS mocks base method
+func (m *MockDRKeyDelegationServiceServer) DRKeyDS(arg0 context.Context, arg1 *control_plane.DRKeyDSRequest) (*control_plane.DRKeyDSResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DRKeyDS", arg0, arg1)
+	ret0, _ := ret[0].(*control_plane.DRKeyDSResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DRKeyDS indicates an expected call of DRKeyDS
+func (mr *MockDRKeyDelegationServiceServerMockRecorder) DRKeyDS(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DRKeyDS", reflect.TypeOf((*MockDRKeyDelegationServiceServer)(nil).DRKeyDS), arg0, arg1)
+}
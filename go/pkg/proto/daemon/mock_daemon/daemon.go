@@ -49,6 +49,66 @@ func (mr *MockDaemonServiceServerMockRecorder) AS(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AS", reflect.TypeOf((*MockDaemonServiceServer)(nil).AS), arg0, arg1)
 }
 
+// ColibriCleanup mocks base method
+func (m *MockDaemonServiceServer) ColibriCleanup(arg0 context.Context, arg1 *daemon.ColibriCleanupRequest) (*daemon.ColibriCleanupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriCleanup", arg0, arg1)
+	ret0, _ := ret[0].(*daemon.ColibriCleanupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ColibriCleanup indicates an expected call of ColibriCleanup
+func (mr *MockDaemonServiceServerMockRecorder) ColibriCleanup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriCleanup", reflect.TypeOf((*MockDaemonServiceServer)(nil).ColibriCleanup), arg0, arg1)
+}
+
+// ColibriList mocks base method
+func (m *MockDaemonServiceServer) ColibriList(arg0 context.Context, arg1 *daemon.ColibriListRequest) (*daemon.ColibriListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriList", arg0, arg1)
+	ret0, _ := ret[0].(*daemon.ColibriListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ColibriList indicates an expected call of ColibriList
+func (mr *MockDaemonServiceServerMockRecorder) ColibriList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriList", reflect.TypeOf((*MockDaemonServiceServer)(nil).ColibriList), arg0, arg1)
+}
+
+// ColibriRenew mocks base method
+func (m *MockDaemonServiceServer) ColibriRenew(arg0 context.Context, arg1 *daemon.ColibriRenewRequest) (*daemon.ColibriRenewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriRenew", arg0, arg1)
+	ret0, _ := ret[0].(*daemon.ColibriRenewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ColibriRenew indicates an expected call of ColibriRenew
+func (mr *MockDaemonServiceServerMockRecorder) ColibriRenew(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriRenew", reflect.TypeOf((*MockDaemonServiceServer)(nil).ColibriRenew), arg0, arg1)
+}
+
+// ColibriSetup mocks base method
+func (m *MockDaemonServiceServer) ColibriSetup(arg0 context.Context, arg1 *daemon.ColibriSetupRequest) (*daemon.ColibriSetupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColibriSetup", arg0, arg1)
+	ret0, _ := ret[0].(*daemon.ColibriSetupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ColibriSetup indicates an expected call of ColibriSetup
+func (mr *MockDaemonServiceServerMockRecorder) ColibriSetup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColibriSetup", reflect.TypeOf((*MockDaemonServiceServer)(nil).ColibriSetup), arg0, arg1)
+}
+
 // DRKeyLvl2 mocks base method
 func (m *MockDaemonServiceServer) DRKeyLvl2(arg0 context.Context, arg1 *daemon.DRKeyLvl2Request) (*daemon.DRKeyLvl2Response, error) {
 	m.ctrl.T.Helper()
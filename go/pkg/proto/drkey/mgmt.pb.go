@@ -358,6 +358,148 @@ func (x *DRKeyLvl2Response) GetMisc() []byte {
 	return nil
 }
 
+type DRKeyDSRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Protocol string               `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	ValTime  *timestamp.Timestamp `protobuf:"bytes,2,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
+	SrcIa    uint64               `protobuf:"varint,3,opt,name=src_ia,json=srcIa,proto3" json:"src_ia,omitempty"`
+	DstIa    uint64               `protobuf:"varint,4,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
+}
+
+func (x *DRKeyDSRequest) Reset() {
+	*x = DRKeyDSRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyDSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyDSRequest) ProtoMessage() {}
+
+func (x *DRKeyDSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyDSRequest.ProtoReflect.Descriptor instead.
+func (*DRKeyDSRequest) Descriptor() ([]byte, []int) {
+	return file_proto_drkey_mgmt_v1_mgmt_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DRKeyDSRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *DRKeyDSRequest) GetValTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.ValTime
+	}
+	return nil
+}
+
+func (x *DRKeyDSRequest) GetSrcIa() uint64 {
+	if x != nil {
+		return x.SrcIa
+	}
+	return 0
+}
+
+func (x *DRKeyDSRequest) GetDstIa() uint64 {
+	if x != nil {
+		return x.DstIa
+	}
+	return 0
+}
+
+type DRKeyDSResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp  *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Ds         []byte               `protobuf:"bytes,2,opt,name=ds,proto3" json:"ds,omitempty"`
+	EpochBegin *timestamp.Timestamp `protobuf:"bytes,3,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
+	EpochEnd   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
+}
+
+func (x *DRKeyDSResponse) Reset() {
+	*x = DRKeyDSResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyDSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyDSResponse) ProtoMessage() {}
+
+func (x *DRKeyDSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyDSResponse.ProtoReflect.Descriptor instead.
+func (*DRKeyDSResponse) Descriptor() ([]byte, []int) {
+	return file_proto_drkey_mgmt_v1_mgmt_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DRKeyDSResponse) GetTimestamp() *timestamp.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *DRKeyDSResponse) GetDs() []byte {
+	if x != nil {
+		return x.Ds
+	}
+	return nil
+}
+
+func (x *DRKeyDSResponse) GetEpochBegin() *timestamp.Timestamp {
+	if x != nil {
+		return x.EpochBegin
+	}
+	return nil
+}
+
+func (x *DRKeyDSResponse) GetEpochEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.EpochEnd
+	}
+	return nil
+}
+
 type DRKeyLvl2Request_DRKeyHost struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -370,7 +512,7 @@ type DRKeyLvl2Request_DRKeyHost struct {
 func (x *DRKeyLvl2Request_DRKeyHost) Reset() {
 	*x = DRKeyLvl2Request_DRKeyHost{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[4]
+		mi := &file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -383,7 +525,7 @@ func (x *DRKeyLvl2Request_DRKeyHost) String() string {
 func (*DRKeyLvl2Request_DRKeyHost) ProtoMessage() {}
 
 func (x *DRKeyLvl2Request_DRKeyHost) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[4]
+	mi := &file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,11 +629,34 @@ var file_proto_drkey_mgmt_v1_mgmt_proto_rawDesc = []byte{
 	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
 	0x70, 0x52, 0x08, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x45, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6d,
-	0x69, 0x73, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6d, 0x69, 0x73, 0x63, 0x42,
-	0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63,
-	0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x67,
-	0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x72, 0x6b, 0x65,
-	0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x73, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6d, 0x69, 0x73, 0x63, 0x22,
+	0x91, 0x01, 0x0a, 0x0e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x44, 0x53, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x35,
+	0x0a, 0x08, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x76, 0x61,
+	0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x72, 0x63, 0x49, 0x61, 0x12, 0x15, 0x0a, 0x06,
+	0x64, 0x73, 0x74, 0x5f, 0x69, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73,
+	0x74, 0x49, 0x61, 0x22, 0xd1, 0x01, 0x0a, 0x0f, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x44, 0x53, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x64,
+	0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x37,
+	0x0a, 0x09, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x45, 0x6e, 0x64, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -506,32 +671,38 @@ func file_proto_drkey_mgmt_v1_mgmt_proto_rawDescGZIP() []byte {
 	return file_proto_drkey_mgmt_v1_mgmt_proto_rawDescData
 }
 
-var file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proto_drkey_mgmt_v1_mgmt_proto_goTypes = []interface{}{
 	(*DRKeyLvl1Request)(nil),           // 0: proto.drkey.mgmt.v1.DRKeyLvl1Request
 	(*DRKeyLvl1Response)(nil),          // 1: proto.drkey.mgmt.v1.DRKeyLvl1Response
 	(*DRKeyLvl2Request)(nil),           // 2: proto.drkey.mgmt.v1.DRKeyLvl2Request
 	(*DRKeyLvl2Response)(nil),          // 3: proto.drkey.mgmt.v1.DRKeyLvl2Response
-	(*DRKeyLvl2Request_DRKeyHost)(nil), // 4: proto.drkey.mgmt.v1.DRKeyLvl2Request.DRKeyHost
-	(*timestamp.Timestamp)(nil),        // 5: google.protobuf.Timestamp
+	(*DRKeyDSRequest)(nil),             // 4: proto.drkey.mgmt.v1.DRKeyDSRequest
+	(*DRKeyDSResponse)(nil),            // 5: proto.drkey.mgmt.v1.DRKeyDSResponse
+	(*DRKeyLvl2Request_DRKeyHost)(nil), // 6: proto.drkey.mgmt.v1.DRKeyLvl2Request.DRKeyHost
+	(*timestamp.Timestamp)(nil),        // 7: google.protobuf.Timestamp
 }
 var file_proto_drkey_mgmt_v1_mgmt_proto_depIdxs = []int32{
-	5,  // 0: proto.drkey.mgmt.v1.DRKeyLvl1Request.val_time:type_name -> google.protobuf.Timestamp
-	5,  // 1: proto.drkey.mgmt.v1.DRKeyLvl1Request.timestamp:type_name -> google.protobuf.Timestamp
-	5,  // 2: proto.drkey.mgmt.v1.DRKeyLvl1Response.epoch_begin:type_name -> google.protobuf.Timestamp
-	5,  // 3: proto.drkey.mgmt.v1.DRKeyLvl1Response.epoch_end:type_name -> google.protobuf.Timestamp
-	5,  // 4: proto.drkey.mgmt.v1.DRKeyLvl1Response.timestamp:type_name -> google.protobuf.Timestamp
-	5,  // 5: proto.drkey.mgmt.v1.DRKeyLvl2Request.val_time:type_name -> google.protobuf.Timestamp
-	4,  // 6: proto.drkey.mgmt.v1.DRKeyLvl2Request.src_host:type_name -> proto.drkey.mgmt.v1.DRKeyLvl2Request.DRKeyHost
-	4,  // 7: proto.drkey.mgmt.v1.DRKeyLvl2Request.dst_host:type_name -> proto.drkey.mgmt.v1.DRKeyLvl2Request.DRKeyHost
-	5,  // 8: proto.drkey.mgmt.v1.DRKeyLvl2Response.timestamp:type_name -> google.protobuf.Timestamp
-	5,  // 9: proto.drkey.mgmt.v1.DRKeyLvl2Response.epoch_begin:type_name -> google.protobuf.Timestamp
-	5,  // 10: proto.drkey.mgmt.v1.DRKeyLvl2Response.epoch_end:type_name -> google.protobuf.Timestamp
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	7,  // 0: proto.drkey.mgmt.v1.DRKeyLvl1Request.val_time:type_name -> google.protobuf.Timestamp
+	7,  // 1: proto.drkey.mgmt.v1.DRKeyLvl1Request.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 2: proto.drkey.mgmt.v1.DRKeyLvl1Response.epoch_begin:type_name -> google.protobuf.Timestamp
+	7,  // 3: proto.drkey.mgmt.v1.DRKeyLvl1Response.epoch_end:type_name -> google.protobuf.Timestamp
+	7,  // 4: proto.drkey.mgmt.v1.DRKeyLvl1Response.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 5: proto.drkey.mgmt.v1.DRKeyLvl2Request.val_time:type_name -> google.protobuf.Timestamp
+	6,  // 6: proto.drkey.mgmt.v1.DRKeyLvl2Request.src_host:type_name -> proto.drkey.mgmt.v1.DRKeyLvl2Request.DRKeyHost
+	6,  // 7: proto.drkey.mgmt.v1.DRKeyLvl2Request.dst_host:type_name -> proto.drkey.mgmt.v1.DRKeyLvl2Request.DRKeyHost
+	7,  // 8: proto.drkey.mgmt.v1.DRKeyLvl2Response.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 9: proto.drkey.mgmt.v1.DRKeyLvl2Response.epoch_begin:type_name -> google.protobuf.Timestamp
+	7,  // 10: proto.drkey.mgmt.v1.DRKeyLvl2Response.epoch_end:type_name -> google.protobuf.Timestamp
+	7,  // 11: proto.drkey.mgmt.v1.DRKeyDSRequest.val_time:type_name -> google.protobuf.Timestamp
+	7,  // 12: proto.drkey.mgmt.v1.DRKeyDSResponse.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 13: proto.drkey.mgmt.v1.DRKeyDSResponse.epoch_begin:type_name -> google.protobuf.Timestamp
+	7,  // 14: proto.drkey.mgmt.v1.DRKeyDSResponse.epoch_end:type_name -> google.protobuf.Timestamp
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_proto_drkey_mgmt_v1_mgmt_proto_init() }
@@ -589,6 +760,30 @@ func file_proto_drkey_mgmt_v1_mgmt_proto_init() {
 			}
 		}
 		file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyDSRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyDSResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_drkey_mgmt_v1_mgmt_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DRKeyLvl2Request_DRKeyHost); i {
 			case 0:
 				return &v.state
@@ -607,7 +802,7 @@ func file_proto_drkey_mgmt_v1_mgmt_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_drkey_mgmt_v1_mgmt_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,398 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"time"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+func (d *DataPlane) processColibri(ingressID uint16, rawPkt []byte, s slayers.SCION,
+	origPacket []byte, buffer gopacket.SerializeBuffer) (processResult, error) {
+
+	p := colibriPacketProcessor{
+		d:          d,
+		ingressID:  ingressID,
+		rawPkt:     rawPkt,
+		scionLayer: s,
+		origPacket: origPacket,
+		buffer:     buffer,
+	}
+	return p.process()
+}
+
+// colibriPacketProcessor processes packets with a COLIBRI path. The hop fields of a COLIBRI
+// path are always kept in reservation direction; packets travelling against it have the R
+// flag set.
+type colibriPacketProcessor struct {
+	// d is a reference to the dataplane instance that initiated this processor.
+	d *DataPlane
+	// ingressID is the interface ID this packet came in, determined from the
+	// socket.
+	ingressID uint16
+	// rawPkt is the raw packet, it is updated during processing to contain the
+	// message to send out.
+	rawPkt []byte
+	// scionLayer is the SCION gopacket layer.
+	scionLayer slayers.SCION
+	// origPacket is the raw original packet, must not be modified.
+	origPacket []byte
+	// buffer is the buffer that can be used to serialize gopacket layers.
+	buffer gopacket.SerializeBuffer
+
+	// path is the decoded COLIBRI path. Will be set during processing.
+	path *colibri.Path
+	// hopField is the current hop field. Will be set during processing.
+	hopField *colibri.HopField
+}
+
+func (p *colibriPacketProcessor) process() (processResult, error) {
+	if r, err := p.parsePath(); err != nil {
+		return r, err
+	}
+	if r, err := p.validatePktLen(); err != nil {
+		return r, err
+	}
+	if r, err := p.validateIngressID(); err != nil {
+		return r, err
+	}
+	if r, err := p.validateExpiry(); err != nil {
+		return r, err
+	}
+	if r, err := p.verifyCurrentMAC(); err != nil {
+		return r, err
+	}
+	if r, err := p.policeReservation(); err != nil {
+		return r, err
+	}
+
+	// Inbound: pkts destined to the local IA.
+	if p.scionLayer.DstIA.Equal(p.d.localIA) && p.path.IsLastHop() {
+		a, err := p.d.resolveLocalDst(p.scionLayer)
+		if err != nil {
+			return p.packSCMP(
+				&slayers.SCMP{
+					TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeDestinationUnreachable,
+						slayers.SCMPCodeNoRoute),
+				},
+				&slayers.SCMPDestinationUnreachable{}, err)
+		}
+		return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
+	}
+	if p.path.IsLastHop() {
+		return p.packSCMP(
+			&slayers.SCMP{
+				TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+					slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+			serrors.WithCtx(cannotRoute, "reason", "COLIBRI path ends before destination",
+				"dst_ia", p.scionLayer.DstIA),
+		)
+	}
+
+	if r, err := p.validateEgressID(); err != nil {
+		return r, err
+	}
+	if r, err := p.validateSegmentTransition(); err != nil {
+		return r, err
+	}
+	if r, err := p.validateEgressUp(); err != nil {
+		return r, err
+	}
+
+	egressID := p.egressInterface()
+	if c, ok := p.d.external[egressID]; ok {
+		if err := p.path.IncPath(); err != nil {
+			return processResult{}, serrors.WrapStr("incrementing path", err)
+		}
+		if err := updateSCIONLayer(p.rawPkt, p.scionLayer, p.buffer); err != nil {
+			return processResult{}, err
+		}
+		return processResult{EgressID: egressID, OutConn: c, OutPkt: p.rawPkt}, nil
+	}
+	// ASTransit: pkts leaving from another AS BR.
+	a := p.d.internalNextHops[egressID]
+	return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
+}
+
+func (p *colibriPacketProcessor) parsePath() (processResult, error) {
+	var ok bool
+	p.path, ok = p.scionLayer.Path.(*colibri.Path)
+	if !ok {
+		return processResult{}, malformedPath
+	}
+	var err error
+	if p.hopField, err = p.path.CurrentHopField(); err != nil {
+		return processResult{}, serrors.Wrap(malformedPath, err)
+	}
+	return processResult{}, nil
+}
+
+// policeReservation checks that the reservation of the packet does not exceed its
+// bandwidth. Overusing packets are either dropped or demoted to best effort by clearing
+// their traffic class, see OveruseDemote.
+func (p *colibriPacketProcessor) policeReservation() (processResult, error) {
+	m := p.d.ColibriMonitor
+	if m == nil {
+		return processResult{}, nil
+	}
+	if m.Conforms(&p.path.InfoField, p.hopField, len(p.rawPkt), time.Now()) {
+		return processResult{}, nil
+	}
+	if p.d.Metrics != nil {
+		labels := interfaceToMetricLabels(p.ingressID, p.d.localIA, p.d.neighborIAs)
+		labels["action"] = m.Action.String()
+		p.d.Metrics.ColibriOverusePacketsTotal.With(labels).Inc()
+		p.d.Metrics.ColibriOveruseBytesTotal.With(labels).Add(float64(len(p.rawPkt)))
+	}
+	if m.Action == OveruseDrop {
+		return processResult{}, serrors.WithCtx(reservationOveruse,
+			"src_ia", p.scionLayer.SrcIA, "bw_cls", p.path.InfoField.BwCls)
+	}
+	// The traffic class spans the lower nibble of the first and the upper nibble of
+	// the second byte of the common header. Rewriting it in place keeps the raw packet
+	// consistent for the paths that forward it without serializing it again.
+	p.scionLayer.TrafficClass = 0
+	p.rawPkt[0] &= 0xf0
+	p.rawPkt[1] &= 0x0f
+	return processResult{}, nil
+}
+
+func (p *colibriPacketProcessor) validatePktLen() (processResult, error) {
+	if int(p.scionLayer.PayloadLen) == len(p.scionLayer.Payload) {
+		return processResult{}, nil
+	}
+	return p.packSCMP(
+		&slayers.SCMP{
+			TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPacketSize),
+		},
+		&slayers.SCMPParameterProblem{Pointer: 0},
+		serrors.New("bad packet size",
+			"header", p.scionLayer.PayloadLen, "actual", len(p.scionLayer.Payload)),
+	)
+}
+
+// ingressInterface returns the ingress interface of the current hop in travel direction.
+func (p *colibriPacketProcessor) ingressInterface() uint16 {
+	if p.path.InfoField.R {
+		return p.hopField.EgressID
+	}
+	return p.hopField.IngressID
+}
+
+// egressInterface returns the egress interface of the current hop in travel direction.
+func (p *colibriPacketProcessor) egressInterface() uint16 {
+	if p.path.InfoField.R {
+		return p.hopField.IngressID
+	}
+	return p.hopField.EgressID
+}
+
+func (p *colibriPacketProcessor) validateIngressID() (processResult, error) {
+	pktIngressID := p.ingressInterface()
+	if p.ingressID != 0 && p.ingressID != pktIngressID {
+		errCode := slayers.SCMPCodeUnknownHopFieldIngress
+		if p.path.InfoField.R {
+			errCode = slayers.SCMPCodeUnknownHopFieldEgress
+		}
+		return p.packSCMP(
+			&slayers.SCMP{
+				TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem, errCode),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+			serrors.New("ingress interface invalid",
+				"pkt_ingress", pktIngressID, "router_ingress", p.ingressID),
+		)
+	}
+	return processResult{}, nil
+}
+
+func (p *colibriPacketProcessor) validateExpiry() (processResult, error) {
+	expiration := p.path.InfoField.Expiration()
+	if !expiration.Before(time.Now()) {
+		return processResult{}, nil
+	}
+	return p.packSCMP(
+		&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+			slayers.SCMPCodePathExpired),
+		},
+		&slayers.SCMPParameterProblem{Pointer: p.currentInfoPointer()},
+		serrors.New("expired reservation", "expiration", expiration, "if_id", p.ingressID,
+			"curr_hf", p.path.InfoField.CurrHF),
+	)
+}
+
+func (p *colibriPacketProcessor) verifyCurrentMAC() (processResult, error) {
+	if err := colibri.VerifyMAC(p.d.macFactory(), &p.path.InfoField, p.hopField); err != nil {
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidHopFieldMAC),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+			serrors.WithCtx(err, "reverse", p.path.InfoField.R, "if_id", p.ingressID,
+				"curr_hf", p.path.InfoField.CurrHF),
+		)
+	}
+	return processResult{}, nil
+}
+
+func (p *colibriPacketProcessor) validateEgressID() (processResult, error) {
+	pktEgressID := p.egressInterface()
+	_, ih := p.d.internalNextHops[pktEgressID]
+	_, eh := p.d.external[pktEgressID]
+	if ih || eh {
+		return processResult{}, nil
+	}
+	errCode := slayers.SCMPCodeUnknownHopFieldEgress
+	if p.path.InfoField.R {
+		errCode = slayers.SCMPCodeUnknownHopFieldIngress
+	}
+	return p.packSCMP(
+		&slayers.SCMP{
+			TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem, errCode),
+		},
+		&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+		cannotRoute,
+	)
+}
+
+// validateSegmentTransition checks that the pair of interfaces of the current hop is valid.
+// A COLIBRI path carries a single hop field per AS, thus the hop field of an AS where an E2E
+// reservation transitions from one segment reservation to the next one joins two segments.
+// Such transitions are only allowed for E2E reservations. The check is done by the ingress
+// router; packets originating in this AS are not checked.
+func (p *colibriPacketProcessor) validateSegmentTransition() (processResult, error) {
+	if p.ingressID == 0 {
+		return processResult{}, nil
+	}
+	pktEgressID := p.egressInterface()
+	ingress, egress := p.d.linkTypes[p.ingressID], p.d.linkTypes[pktEgressID]
+	switch {
+	// hops within a single segment.
+	case ingress == topology.Child && egress == topology.Parent:
+		return processResult{}, nil
+	case ingress == topology.Parent && egress == topology.Child:
+		return processResult{}, nil
+	case ingress == topology.Core && egress == topology.Core:
+		return processResult{}, nil
+	}
+	if !p.path.InfoField.S {
+		switch {
+		// hops joining two segments of an E2E reservation.
+		case ingress == topology.Core && egress == topology.Child:
+			return processResult{}, nil
+		case ingress == topology.Child && egress == topology.Core:
+			return processResult{}, nil
+		case ingress == topology.Child && egress == topology.Child:
+			return processResult{}, nil
+		case ingress == topology.Peer && egress == topology.Child:
+			return processResult{}, nil
+		case ingress == topology.Child && egress == topology.Peer:
+			return processResult{}, nil
+		}
+	}
+	return p.packSCMP(
+		&slayers.SCMP{
+			TypeCode: slayers.CreateSCMPTypeCode(
+				slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidSegmentChange,
+			),
+		},
+		&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+		serrors.WithCtx(cannotRoute, "ingress_id", p.ingressID, "ingress_type", ingress,
+			"egress_id", pktEgressID, "egress_type", egress,
+			"segment_reservation", p.path.InfoField.S))
+}
+
+func (p *colibriPacketProcessor) validateEgressUp() (processResult, error) {
+	egressID := p.egressInterface()
+	v, ok := p.d.bfdSessions[egressID]
+	if !ok || v.IsUp() {
+		return processResult{}, nil
+	}
+	scmpH := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
+	}
+	var scmpP gopacket.SerializableLayer = &slayers.SCMPExternalInterfaceDown{
+		IA:   p.d.localIA,
+		IfID: uint64(egressID),
+	}
+	if _, external := p.d.external[egressID]; !external {
+		scmpH.TypeCode =
+			slayers.CreateSCMPTypeCode(slayers.SCMPTypeInternalConnectivityDown, 0)
+		scmpP = &slayers.SCMPInternalConnectivityDown{
+			IA:      p.d.localIA,
+			Ingress: uint64(p.ingressID),
+			Egress:  uint64(egressID),
+		}
+	}
+	return p.packSCMP(scmpH, scmpP, serrors.New("bfd session down"))
+}
+
+func (p *colibriPacketProcessor) currentInfoPointer() uint16 {
+	return uint16(slayers.CmnHdrLen + p.scionLayer.AddrHdrLen() + colibri.TimestampLen)
+}
+
+func (p *colibriPacketProcessor) currentHopPointer() uint16 {
+	return uint16(slayers.CmnHdrLen + p.scionLayer.AddrHdrLen() + colibri.MinLen +
+		colibri.HopLen*int(p.path.InfoField.CurrHF))
+}
+
+func (p *colibriPacketProcessor) packSCMP(scmpH *slayers.SCMP,
+	scmpP gopacket.SerializableLayer, cause error) (processResult, error) {
+
+	// in reply to an SCMP error do nothing:
+	scmpErr, err := isSCMPError(p.origPacket)
+	if err != nil {
+		return processResult{}, err
+	}
+	if scmpErr {
+		return processResult{}, serrors.WrapStr("SCMP error for SCMP error pkt -> DROP", cause)
+	}
+	// The COLIBRI path is not modified before the packet is forwarded, the original packet
+	// can be quoted directly.
+	quoteLen := len(p.origPacket)
+	if quoteLen > slayers.MaxSCMPPacketLen {
+		quoteLen = slayers.MaxSCMPPacketLen
+	}
+	quote := make([]byte, quoteLen)
+	copy(quote, p.origPacket[:quoteLen])
+
+	_, external := p.d.external[p.ingressID]
+	rawSCMP, err := scmpPacker{
+		internalIP: p.d.internalIP,
+		localIA:    p.d.localIA,
+		origPacket: p.origPacket,
+		ingressID:  p.ingressID,
+		scionL:     &p.scionLayer,
+		buffer:     p.buffer,
+		quote:      quote,
+		auth:       p.d.SCMPAuth,
+	}.prepareSCMP(
+		scmpH,
+		scmpP,
+		external,
+		cause,
+	)
+	return processResult{OutPkt: rawSCMP}, err
+}
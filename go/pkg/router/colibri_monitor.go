@@ -0,0 +1,226 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+// OveruseAction is the action taken for a COLIBRI packet that exceeds the bandwidth of
+// its reservation.
+type OveruseAction int
+
+const (
+	// OveruseDemote forwards the packet with its traffic class cleared. The router has no
+	// QoS queues, so demoted packets are forwarded as any other packet: clearing the traffic
+	// class only marks them as best effort for the networks that schedule on it.
+	OveruseDemote OveruseAction = iota
+	// OveruseDrop drops the packet.
+	OveruseDrop
+)
+
+func (a OveruseAction) String() string {
+	switch a {
+	case OveruseDemote:
+		return "demote"
+	case OveruseDrop:
+		return "drop"
+	default:
+		return "unknown"
+	}
+}
+
+// maxBuckets is the maximum number of reservations policed at the same time. When it is
+// reached, the bucket of the reservation expiring first is evicted.
+const maxBuckets = 1 << 16
+
+// ReservationMonitor polices the bandwidth used by COLIBRI reservations. It keeps one
+// token bucket per reservation, refilled at the rate of the reservation's bandwidth class.
+// The reservations are only identified by the fields authenticated by the hop field MAC, so
+// that the packets cannot choose their bucket. At most maxBuckets buckets are kept, ordered
+// by expiration so that expiring and evicting them does not depend on how many there are.
+// ReservationMonitor is safe for concurrent use.
+type ReservationMonitor struct {
+	// Action is the action taken for packets exceeding their reservation.
+	Action OveruseAction
+	// Burst is the time during which a reservation can send at its reserved rate
+	// with a full bucket. It determines the bucket size.
+	Burst time.Duration
+
+	mtx     sync.Mutex
+	buckets map[reservationKey]*tokenBucket
+	expiry  bucketHeap
+}
+
+// NewReservationMonitor creates a new monitor.
+func NewReservationMonitor(action OveruseAction, burst time.Duration) *ReservationMonitor {
+	return &ReservationMonitor{
+		Action:  action,
+		Burst:   burst,
+		buckets: make(map[reservationKey]*tokenBucket),
+	}
+}
+
+// reservationKey identifies a reservation with the fields covered by the hop field MAC. The
+// suffix of segment reservations contains their whole ID, including the AS that allocated it,
+// and E2E suffixes are chosen at random. The source address is not authenticated and must not
+// be used. Segment and E2E reservations have independent ID spaces.
+type reservationKey struct {
+	suffix          [colibri.ResIDSuffixLen]byte
+	segment         bool
+	ingress, egress uint16
+}
+
+// Conforms accounts a packet of size bytes to the reservation described by the info field
+// and the current hop field, whose MAC must have been verified. It returns false if the
+// reservation has exceeded its bandwidth, in which case the packet consumes no tokens.
+func (m *ReservationMonitor) Conforms(info *colibri.InfoField, hf *colibri.HopField, size int,
+	now time.Time) bool {
+
+	key := reservationKey{
+		suffix:  info.ResIDSuffix,
+		segment: info.S,
+		ingress: hf.IngressID,
+		egress:  hf.EgressID,
+	}
+	rate := bucketRate(reservation.BWCls(info.BwCls))
+	expiration := info.Expiration()
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.buckets == nil {
+		m.buckets = make(map[reservationKey]*tokenBucket)
+	}
+	m.sweep(now)
+	b, ok := m.buckets[key]
+	if !ok {
+		if len(m.buckets) >= maxBuckets {
+			// evict the bucket of the reservation expiring first.
+			delete(m.buckets, heap.Pop(&m.expiry).(*tokenBucket).key)
+		}
+		b = &tokenBucket{key: key, last: now, expiration: expiration}
+		m.buckets[key] = b
+		heap.Push(&m.expiry, b)
+	}
+	// The bandwidth class changes when a new index of the reservation is activated.
+	b.setRate(rate, m.bucketSize(rate))
+	if expiration.After(b.expiration) {
+		b.expiration = expiration
+		heap.Fix(&m.expiry, b.index)
+	}
+	return b.take(float64(size), now)
+}
+
+// bucketSize returns the size of the bucket in bytes. A bucket can always hold at least
+// one packet of maximum size.
+func (m *ReservationMonitor) bucketSize(rate float64) float64 {
+	size := rate * m.Burst.Seconds()
+	if size < bufSize {
+		return bufSize
+	}
+	return size
+}
+
+// sweep removes the buckets of expired reservations. It must be called with the lock held.
+func (m *ReservationMonitor) sweep(now time.Time) {
+	for len(m.expiry) > 0 && m.expiry[0].expiration.Before(now) {
+		delete(m.buckets, heap.Pop(&m.expiry).(*tokenBucket).key)
+	}
+}
+
+// bucketRate returns the refill rate in bytes per second for the given bandwidth class.
+func bucketRate(bwCls reservation.BWCls) float64 {
+	return float64(bwCls.ToKbps()) * 1000 / 8
+}
+
+// tokenBucket is a token bucket counting bytes.
+type tokenBucket struct {
+	// key is the reservation of the bucket.
+	key reservationKey
+	// index is the position of the bucket in the expiry heap.
+	index int
+	// rate is the refill rate in bytes per second.
+	rate float64
+	// size is the maximum amount of tokens in the bucket.
+	size float64
+	// tokens is the amount of tokens at the time last.
+	tokens float64
+	// last is the time of the last refill.
+	last time.Time
+	// expiration is the latest expiration time seen for the reservation.
+	expiration time.Time
+}
+
+func (b *tokenBucket) setRate(rate, size float64) {
+	if b.rate == rate && b.size == size {
+		return
+	}
+	if b.rate == 0 {
+		// New buckets start full.
+		b.tokens = size
+	}
+	b.rate = rate
+	b.size = size
+	if b.tokens > size {
+		b.tokens = size
+	}
+}
+
+func (b *tokenBucket) take(n float64, now time.Time) bool {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.size {
+			b.tokens = b.size
+		}
+		b.last = now
+	}
+	if b.tokens < n {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
+
+// bucketHeap orders the buckets by the expiration of their reservations.
+type bucketHeap []*tokenBucket
+
+func (h bucketHeap) Len() int           { return len(h) }
+func (h bucketHeap) Less(i, j int) bool { return h[i].expiration.Before(h[j].expiration) }
+
+func (h bucketHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *bucketHeap) Push(x interface{}) {
+	b := x.(*tokenBucket)
+	b.index = len(*h)
+	*h = append(*h, b)
+}
+
+func (h *bucketHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	b := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return b
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+)
+
+func TestReservationMonitor(t *testing.T) {
+	now := time.Now()
+	hf := &colibri.HopField{IngressID: 1, EgressID: 2}
+	// BwCls 9 is 256 kbps, i.e. 32000 bytes per second.
+	info := &colibri.InfoField{
+		ResIDSuffix: [colibri.ResIDSuffixLen]byte{1},
+		BwCls:       9,
+	}
+	info.ExpTick = uint32(reservation.TickFromTime(now.Add(time.Minute)))
+	other := *info
+	other.ResIDSuffix = [colibri.ResIDSuffixLen]byte{2}
+
+	m := router.NewReservationMonitor(router.OveruseDrop, time.Second)
+	// The bucket starts full.
+	assert.True(t, m.Conforms(info, hf, 32000, now))
+	assert.False(t, m.Conforms(info, hf, 1, now))
+	// Other reservations are accounted independently.
+	assert.True(t, m.Conforms(&other, hf, 1000, now))
+	// Segment reservations are distinct from E2E reservations with the same ID.
+	segment := *info
+	segment.S = true
+	assert.True(t, m.Conforms(&segment, hf, 1000, now))
+	// Segment reservations of other ASes with the same suffix are accounted independently.
+	otherAS := segment
+	as1, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("00000001"))
+	require.NoError(t, err)
+	as2, err := reservation.NewSegmentID(xtest.MustParseAS("ff00:0:2"),
+		xtest.MustParseHexString("00000001"))
+	require.NoError(t, err)
+	copy(segment.ResIDSuffix[:], as1.ToRaw())
+	copy(otherAS.ResIDSuffix[:], as2.ToRaw())
+	assert.True(t, m.Conforms(&segment, hf, 32000, now))
+	assert.False(t, m.Conforms(&segment, hf, 1, now))
+	assert.True(t, m.Conforms(&otherAS, hf, 32000, now))
+	// Hops over other interfaces are accounted independently.
+	assert.True(t, m.Conforms(info, &colibri.HopField{IngressID: 1, EgressID: 3}, 1000, now))
+	// Refill at the reserved rate.
+	assert.True(t, m.Conforms(info, hf, 16000, now.Add(500*time.Millisecond)))
+	assert.False(t, m.Conforms(info, hf, 1000, now.Add(500*time.Millisecond)))
+	// The bucket never holds more than its size.
+	assert.True(t, m.Conforms(info, hf, 32000, now.Add(30*time.Second)))
+	assert.False(t, m.Conforms(info, hf, 1000, now.Add(30*time.Second)))
+}
+
+func TestReservationMonitorMaxBuckets(t *testing.T) {
+	now := time.Now()
+	hf := &colibri.HopField{IngressID: 1, EgressID: 2}
+	newInfo := func(i int, exp time.Duration) *colibri.InfoField {
+		info := &colibri.InfoField{
+			BwCls:   9,
+			ExpTick: uint32(reservation.TickFromTime(now.Add(exp))),
+		}
+		binary.BigEndian.PutUint32(info.ResIDSuffix[:], uint32(i))
+		return info
+	}
+	m := router.NewReservationMonitor(router.OveruseDrop, time.Second)
+	first := newInfo(0, time.Minute)
+	assert.True(t, m.Conforms(first, hf, 32000, now))
+	for i := 1; i < router.MaxBuckets; i++ {
+		m.Conforms(newInfo(i, time.Hour), hf, 1, now)
+	}
+	// The bucket of the reservation expiring first is evicted to police a new one.
+	assert.True(t, m.Conforms(newInfo(router.MaxBuckets, time.Hour), hf, 32000, now))
+	assert.True(t, m.Conforms(first, hf, 32000, now))
+}
+
+func TestReservationMonitorMinBucketSize(t *testing.T) {
+	now := time.Now()
+	info := &colibri.InfoField{
+		BwCls:   1,
+		ExpTick: uint32(reservation.TickFromTime(now.Add(time.Minute))),
+	}
+	m := router.NewReservationMonitor(router.OveruseDemote, time.Millisecond)
+	// Even with a tiny burst, a reservation can send a packet of maximum size.
+	assert.True(t, m.Conforms(info, &colibri.HopField{}, 9000, now))
+}
+
+// BenchmarkReservationMonitorFull measures the packets of new reservations while the monitor
+// is full, i.e. when each of them evicts a bucket.
+func BenchmarkReservationMonitorFull(b *testing.B) {
+	now := time.Now()
+	hf := &colibri.HopField{IngressID: 1, EgressID: 2}
+	infos := make([]*colibri.InfoField, router.MaxBuckets+b.N)
+	for i := range infos {
+		infos[i] = &colibri.InfoField{
+			BwCls:   9,
+			ExpTick: uint32(reservation.TickFromTime(now.Add(time.Hour))),
+		}
+		binary.BigEndian.PutUint32(infos[i].ResIDSuffix[:], uint32(i))
+	}
+	m := router.NewReservationMonitor(router.OveruseDrop, time.Second)
+	for _, info := range infos[:router.MaxBuckets] {
+		m.Conforms(info, hf, 1, now)
+	}
+	b.ResetTimer()
+	for _, info := range infos[router.MaxBuckets:] {
+		m.Conforms(info, hf, 1, now)
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/ipv4"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/mock_router"
+)
+
+func TestProcessColibriPkt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+
+	testCases := map[string]struct {
+		mockMsg      func(bool) *ipv4.Message
+		prepareDP    func(*gomock.Controller) *router.DataPlane
+		srcInterface uint16
+		assertFunc   assert.ErrorAssertionFunc
+	}{
+		"inbound": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 2, false,
+					[2]uint16{0, 40}, [2]uint16{31, 30}, [2]uint16{1, 0})
+				spkt.DstIA = localIA
+				dst := &net.IPAddr{IP: net.ParseIP("10.0.100.100").To4()}
+				_ = spkt.SetDstAddr(dst)
+				ret := toMsg(t, spkt, cpath)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: dst.IP, Port: topology.EndhostPort}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"outbound": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(1): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						1: topology.Parent,
+					},
+					nil, nil, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 0, true,
+					[2]uint16{0, 1}, [2]uint16{31, 30}, [2]uint16{41, 0})
+				spkt.SrcIA = localIA
+				if !afterProcessing {
+					return toMsg(t, spkt, cpath)
+				}
+				require.NoError(t, cpath.IncPath())
+				ret := toMsg(t, spkt, cpath)
+				ret.Addr = nil
+				ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				return ret
+			},
+			srcInterface: 0,
+			assertFunc:   assert.NoError,
+		},
+		"brtransit": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(2): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						2: topology.Parent,
+					},
+					nil, nil, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 2}, [2]uint16{41, 0})
+				if !afterProcessing {
+					return toMsg(t, spkt, cpath)
+				}
+				require.NoError(t, cpath.IncPath())
+				ret := toMsg(t, spkt, cpath)
+				ret.Addr = nil
+				ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"brtransit reverse": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(1): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						2: topology.Parent,
+					},
+					nil, nil, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 2}, [2]uint16{41, 0})
+				_, err := cpath.Reverse()
+				require.NoError(t, err)
+				if !afterProcessing {
+					return toMsg(t, spkt, cpath)
+				}
+				require.NoError(t, cpath.IncPath())
+				ret := toMsg(t, spkt, cpath)
+				ret.Addr = nil
+				ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				return ret
+			},
+			srcInterface: 2,
+			assertFunc:   assert.NoError,
+		},
+		"astransit": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(nil,
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						3: topology.Parent,
+					},
+					mock_router.NewMockBatchConn(ctrl),
+					map[uint16]net.Addr{
+						uint16(3): &net.UDPAddr{IP: net.ParseIP("10.0.200.200").To4()},
+					}, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 3}, [2]uint16{41, 0})
+				ret := toMsg(t, spkt, cpath)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: net.ParseIP("10.0.200.200").To4()}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"overuse demoted": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(nil,
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						3: topology.Parent,
+					},
+					mock_router.NewMockBatchConn(ctrl),
+					map[uint16]net.Addr{
+						uint16(3): &net.UDPAddr{IP: net.ParseIP("10.0.200.200").To4()},
+					}, nil, localIA, key)
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 3}, [2]uint16{41, 0})
+				dp.ColibriMonitor = exhaustedMonitor(router.OveruseDemote, spkt, cpath)
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 3}, [2]uint16{41, 0})
+				if afterProcessing {
+					spkt.TrafficClass = 0
+				}
+				ret := toMsg(t, spkt, cpath)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: net.ParseIP("10.0.200.200").To4()}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"overuse dropped": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(nil,
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						3: topology.Parent,
+					},
+					mock_router.NewMockBatchConn(ctrl),
+					map[uint16]net.Addr{
+						uint16(3): &net.UDPAddr{IP: net.ParseIP("10.0.200.200").To4()},
+					}, nil, localIA, key)
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 3}, [2]uint16{41, 0})
+				dp.ColibriMonitor = exhaustedMonitor(router.OveruseDrop, spkt, cpath)
+				return dp
+			},
+			mockMsg: func(bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 3}, [2]uint16{41, 0})
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 1,
+			assertFunc:   assert.Error,
+		},
+		"e2e segment transition": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(2): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						2: topology.Core,
+					},
+					nil, nil, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, false,
+					[2]uint16{0, 30}, [2]uint16{1, 2}, [2]uint16{41, 0})
+				if !afterProcessing {
+					return toMsg(t, spkt, cpath)
+				}
+				require.NoError(t, cpath.IncPath())
+				ret := toMsg(t, spkt, cpath)
+				ret.Addr = nil
+				ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"segment reservation transition": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(1): mock_router.NewMockBatchConn(ctrl),
+						uint16(2): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						2: topology.Core,
+					},
+					nil, nil, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 2}, [2]uint16{41, 0})
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 1,
+			assertFunc:   assert.Error,
+		},
+		"invalid mac": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(1): mock_router.NewMockBatchConn(ctrl),
+						uint16(2): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						2: topology.Parent,
+					},
+					nil, nil, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 2}, [2]uint16{41, 0})
+				cpath.HopFields[1].Mac[0]++
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 1,
+			assertFunc:   assert.Error,
+		},
+		"invalid ingress": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(2): mock_router.NewMockBatchConn(ctrl),
+						uint16(5): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						2: topology.Parent,
+						5: topology.Child,
+					},
+					nil, nil, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 2}, [2]uint16{41, 0})
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 5,
+			assertFunc:   assert.Error,
+		},
+		"expired": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(1): mock_router.NewMockBatchConn(ctrl),
+						uint16(2): mock_router.NewMockBatchConn(ctrl),
+					},
+					map[uint16]topology.LinkType{
+						1: topology.Child,
+						2: topology.Parent,
+					},
+					nil, nil, nil, localIA, key)
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, key, 1, true,
+					[2]uint16{0, 30}, [2]uint16{1, 2}, [2]uint16{41, 0})
+				cpath.InfoField.ExpTick = uint32(reservation.TickFromTime(
+					time.Now().Add(-time.Minute)))
+				for _, hf := range cpath.HopFields {
+					hf.Mac = computeColibriMAC(t, key, &cpath.InfoField, hf)
+				}
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 1,
+			assertFunc:   assert.Error,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dp := tc.prepareDP(ctrl)
+			input, want := tc.mockMsg(false), tc.mockMsg(true)
+			buffer := gopacket.NewSerializeBuffer()
+			origMsg := make([]byte, len(input.Buffers[0]))
+			copy(origMsg, input.Buffers[0])
+			result, err := dp.ProcessPkt(tc.srcInterface, input, slayers.SCION{}, origMsg,
+				buffer)
+			tc.assertFunc(t, err)
+			if err != nil {
+				return
+			}
+			assert.NotNil(t, result.OutConn)
+			outPkt := &ipv4.Message{
+				Buffers: [][]byte{result.OutPkt},
+				Addr:    result.OutAddr,
+			}
+			assert.Equal(t, want, outPkt)
+		})
+	}
+}
+
+// prepColibriMsg returns a SCION packet with a COLIBRI path containing one hop field per pair
+// of interfaces, all with valid MACs.
+func prepColibriMsg(t *testing.T, key []byte, currHF uint8, segment bool,
+	ifPairs ...[2]uint16) (*slayers.SCION, *colibri.Path) {
+
+	spkt := &slayers.SCION{
+		Version:      0,
+		TrafficClass: 0xb8,
+		FlowID:       0xdead,
+		NextHdr:      common.L4UDP,
+		PathType:     colibri.PathType,
+		DstIA:        xtest.MustParseIA("4-ff00:0:411"),
+		SrcIA:        xtest.MustParseIA("2-ff00:0:222"),
+	}
+	require.NoError(t, spkt.SetSrcAddr(&net.IPAddr{IP: net.ParseIP("10.0.0.1").To4()}))
+	require.NoError(t, spkt.SetDstAddr(&net.IPAddr{IP: net.ParseIP("10.0.0.2").To4()}))
+	cpath := &colibri.Path{
+		InfoField: colibri.InfoField{
+			S:           segment,
+			CurrHF:      currHF,
+			HFCount:     uint8(len(ifPairs)),
+			ResIDSuffix: [12]byte{0xbe, 0xef},
+			ExpTick:     uint32(reservation.TickFromTime(time.Now().Add(time.Minute))),
+			BwCls:       5,
+			RLC:         2,
+		},
+	}
+	for _, ifs := range ifPairs {
+		hf := &colibri.HopField{IngressID: ifs[0], EgressID: ifs[1]}
+		hf.Mac = computeColibriMAC(t, key, &cpath.InfoField, hf)
+		cpath.HopFields = append(cpath.HopFields, hf)
+	}
+	return spkt, cpath
+}
+
+// exhaustedMonitor returns a monitor in which the reservation of the packet has no
+// tokens left. The bucket is drained in the future, so that it is not refilled before
+// the packet is processed.
+func exhaustedMonitor(action router.OveruseAction, spkt *slayers.SCION,
+	cpath *colibri.Path) *router.ReservationMonitor {
+
+	m := router.NewReservationMonitor(action, time.Millisecond)
+	future := time.Now().Add(time.Second)
+	for m.Conforms(&cpath.InfoField, cpath.HopFields[cpath.InfoField.CurrHF], 1, future) {
+	}
+	return m
+}
+
+func computeColibriMAC(t *testing.T, key []byte, info *colibri.InfoField,
+	hf *colibri.HopField) [colibri.MacLen]byte {
+
+	mac, err := scrypto.InitMac(key)
+	require.NoError(t, err)
+	return colibri.MAC(mac, info, hf)
+}
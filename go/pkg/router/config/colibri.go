@@ -0,0 +1,93 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	// OveruseDemote forwards packets exceeding their reservation with their traffic class
+	// cleared. The router has no QoS queues, so it forwards them as any other packet.
+	OveruseDemote = "demote"
+	// OveruseDrop drops packets exceeding their reservation.
+	OveruseDrop = "drop"
+
+	// DefaultColibriBurst is the default burst duration of a reservation's token bucket.
+	DefaultColibriBurst = 100 * time.Millisecond
+)
+
+var _ config.Config = (*Colibri)(nil)
+
+// Colibri contains the configuration of the COLIBRI reservation policing.
+type Colibri struct {
+	// OveruseAction is the action taken for packets that exceed the bandwidth
+	// of their reservation. Either "demote" or "drop". (default demote)
+	OveruseAction string `toml:"overuse_action,omitempty"`
+	// Burst is the amount of traffic, expressed as a duration at the reserved
+	// rate, that a reservation may send in a single burst. (default 100ms)
+	Burst util.DurWrap `toml:"burst,omitempty"`
+}
+
+// InitDefaults initializes values of unset keys.
+func (cfg *Colibri) InitDefaults() {
+	if cfg.OveruseAction == "" {
+		cfg.OveruseAction = OveruseDemote
+	}
+	if cfg.Burst.Duration == 0 {
+		cfg.Burst.Duration = DefaultColibriBurst
+	}
+}
+
+// Validate validates that all values are parsable.
+func (cfg *Colibri) Validate() error {
+	switch cfg.OveruseAction {
+	case OveruseDemote, OveruseDrop:
+	default:
+		return serrors.New("invalid overuse_action", "value", cfg.OveruseAction)
+	}
+	if cfg.Burst.Duration <= 0 {
+		return serrors.New("burst must be positive", "burst", cfg.Burst)
+	}
+	return nil
+}
+
+// Sample writes a config sample to the writer.
+func (cfg *Colibri) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, colibriSample)
+}
+
+// ConfigName is the key in the toml file.
+func (cfg *Colibri) ConfigName() string {
+	return "colibri"
+}
+
+const colibriSample = `
+# The action taken for packets that exceed the bandwidth of their COLIBRI
+# reservation. Either "demote" (forward with the traffic class cleared, the
+# router has no QoS queues and forwards them as any other packet) or "drop".
+# (default demote)
+overuse_action = "demote"
+
+# The burst duration of the per-reservation token bucket. The bucket holds
+# the amount of traffic the reservation can send at its reserved rate during
+# this time. (default 100ms)
+burst = "100ms"
+`
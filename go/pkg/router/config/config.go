@@ -31,6 +31,8 @@ type Config struct {
 	Features env.Features `toml:"features,omitempty"`
 	Logging  log.Config   `toml:"log,omitempty"`
 	Metrics  env.Metrics  `toml:"metrics,omitempty"`
+	Colibri  Colibri      `toml:"colibri,omitempty"`
+	SCMPAuth SCMPAuth     `toml:"scmp_auth,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -39,6 +41,8 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
+		&cfg.Colibri,
+		&cfg.SCMPAuth,
 	)
 }
 
@@ -48,6 +52,8 @@ func (cfg *Config) Validate() error {
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
+		&cfg.Colibri,
+		&cfg.SCMPAuth,
 	)
 }
 
@@ -57,5 +63,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
+		&cfg.Colibri,
+		&cfg.SCMPAuth,
 	)
 }
@@ -17,10 +17,13 @@ package config_test
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/log/logtest"
 	"github.com/scionproto/scion/go/pkg/router/config"
@@ -45,4 +48,89 @@ func InitTestConfig(cfg *config.Config) {
 func CheckTestConfig(t *testing.T, cfg *config.Config, id string) {
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, nil, nil, id)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
+	assert.Equal(t, config.OveruseDemote, cfg.Colibri.OveruseAction)
+	assert.Equal(t, config.DefaultColibriBurst, cfg.Colibri.Burst.Duration)
+	assert.False(t, cfg.SCMPAuth.Enabled)
+	assert.Equal(t, drkey.DefaultEpochDuration, cfg.SCMPAuth.EpochDuration.Duration)
+	assert.Equal(t, drkey.DefaultGracePeriod, cfg.SCMPAuth.GracePeriod.Duration)
+}
+
+func TestColibriValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Modify    func(cfg *config.Colibri)
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"defaults": {
+			Modify:    func(*config.Colibri) {},
+			Assertion: assert.NoError,
+		},
+		"drop": {
+			Modify:    func(cfg *config.Colibri) { cfg.OveruseAction = config.OveruseDrop },
+			Assertion: assert.NoError,
+		},
+		"invalid action": {
+			Modify:    func(cfg *config.Colibri) { cfg.OveruseAction = "shape" },
+			Assertion: assert.Error,
+		},
+		"negative burst": {
+			Modify:    func(cfg *config.Colibri) { cfg.Burst.Duration = -time.Second },
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cfg config.Colibri
+			cfg.InitDefaults()
+			tc.Modify(&cfg)
+			tc.Assertion(t, cfg.Validate())
+		})
+	}
+}
+
+func TestSCMPAuthGracePeriodDisabled(t *testing.T) {
+	var cfg config.Config
+	err := toml.NewDecoder(bytes.NewReader([]byte("[scmp_auth]\ngrace_period = \"0s\"\n"))).
+		Strict(true).Decode(&cfg)
+	require.NoError(t, err)
+	cfg.InitDefaults()
+	assert.NoError(t, cfg.SCMPAuth.Validate())
+	assert.Zero(t, cfg.SCMPAuth.Grace())
+}
+
+func TestSCMPAuthValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Modify    func(cfg *config.SCMPAuth)
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"defaults": {
+			Modify:    func(*config.SCMPAuth) {},
+			Assertion: assert.NoError,
+		},
+		"enabled": {
+			Modify:    func(cfg *config.SCMPAuth) { cfg.Enabled = true },
+			Assertion: assert.NoError,
+		},
+		"epoch shorter than a second": {
+			Modify:    func(cfg *config.SCMPAuth) { cfg.EpochDuration.Duration = time.Millisecond },
+			Assertion: assert.Error,
+		},
+		"grace period as long as epoch": {
+			Modify: func(cfg *config.SCMPAuth) {
+				cfg.GracePeriod.Duration = cfg.EpochDuration.Duration
+			},
+			Assertion: assert.Error,
+		},
+		"grace period disabled": {
+			Modify:    func(cfg *config.SCMPAuth) { cfg.GracePeriod.Duration = 0 },
+			Assertion: assert.NoError,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cfg config.SCMPAuth
+			cfg.InitDefaults()
+			tc.Modify(&cfg)
+			tc.Assertion(t, cfg.Validate())
+		})
+	}
 }
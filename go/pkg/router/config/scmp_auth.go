@@ -0,0 +1,68 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io"
+
+	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/drkey"
+)
+
+var _ config.Config = (*SCMPAuth)(nil)
+
+// SCMPAuth contains the configuration of the authentication of the SCMP error messages sent by
+// the router. The messages are authenticated with the DRKey derived from the AS master key,
+// so the epochs must be configured as in the drkey section of the control service.
+type SCMPAuth struct {
+	// Enabled enables the authentication of the SCMP error messages. (default false)
+	Enabled bool `toml:"enabled,omitempty"`
+	// EpochConfig configures the DRKey epochs, as in the control service.
+	drkey.EpochConfig
+}
+
+// InitDefaults initializes values of unset keys.
+func (cfg *SCMPAuth) InitDefaults() {
+	cfg.EpochConfig.InitDefaults()
+}
+
+// Validate validates that all values are parsable.
+func (cfg *SCMPAuth) Validate() error {
+	return cfg.EpochConfig.Validate()
+}
+
+// Sample writes a config sample to the writer.
+func (cfg *SCMPAuth) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, scmpAuthSample)
+}
+
+// ConfigName is the key in the toml file.
+func (cfg *SCMPAuth) ConfigName() string {
+	return "scmp_auth"
+}
+
+const scmpAuthSample = `
+# Authenticate the SCMP error messages sent by the router with the scmp DRKey
+# protocol. (default false)
+enabled = false
+
+# The duration of the DRKey epochs. It must be the same as the epoch_duration
+# of the drkey section of the control service. (default 24h)
+epoch_duration = "24h"
+
+# The overlap of consecutive DRKey epochs, 0 disables it. It must be the same as
+# the grace_period of the drkey section of the control service. (default 5m)
+grace_period = "5m"
+`
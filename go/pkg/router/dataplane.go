@@ -39,9 +39,11 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/empty"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/underlay/conn"
 	underlayconn "github.com/scionproto/scion/go/lib/underlay/conn"
@@ -99,6 +101,12 @@ type DataPlane struct {
 	mtx              sync.Mutex
 	running          bool
 	Metrics          *Metrics
+	// ColibriMonitor polices the bandwidth of COLIBRI reservations. If it is
+	// nil, COLIBRI traffic is not policed.
+	ColibriMonitor *ReservationMonitor
+	// SCMPAuth authenticates the SCMP error messages sent by the router. If it is nil, the
+	// messages are not authenticated.
+	SCMPAuth *SCMPAuthenticator
 }
 
 var (
@@ -113,6 +121,7 @@ var (
 	noBFDSessionFound             = serrors.New("no BFD sessions was found")
 	noBFDSessionConfigured        = serrors.New("no BFD sessions have been configured")
 	errBFDDisabled                = serrors.New("BFD is disabled")
+	reservationOveruse            = serrors.New("reservation bandwidth exceeded")
 )
 
 type scmpError struct {
@@ -591,6 +600,8 @@ func (d *DataPlane) processPkt(ingressID uint16, rawPkt []byte, srcAddr net.Addr
 		return d.processOHP(ingressID, rawPkt, s, buffer)
 	case scion.PathType:
 		return d.processSCION(ingressID, rawPkt, s, origPacket, buffer)
+	case colibri.PathType:
+		return d.processColibri(ingressID, rawPkt, s, origPacket, buffer)
 	default:
 		return processResult{}, serrors.WithCtx(unsupportedPathType, "type", s.PathType)
 	}
@@ -693,25 +704,12 @@ type scionPacketProcessor struct {
 func (p *scionPacketProcessor) packSCMP(scmpH *slayers.SCMP, scmpP gopacket.SerializableLayer,
 	cause error) (processResult, error) {
 
-	// parse everything to see if the original packet was an SCMP error.
-	var (
-		scionLayer slayers.SCION
-		udpLayer   slayers.UDP
-		hbhExtn    slayers.HopByHopExtn
-		e2eExtn    slayers.EndToEndExtn
-		scmpLayer  slayers.SCMP
-	)
-	parser := gopacket.NewDecodingLayerParser(
-		slayers.LayerTypeSCION, &scionLayer, &udpLayer, &hbhExtn, &e2eExtn, &scmpLayer,
-	)
-	decoded := make([]gopacket.LayerType, 5)
-	if err := parser.DecodeLayers(p.origPacket, &decoded); err != nil {
-		if _, ok := err.(gopacket.UnsupportedLayerType); !ok {
-			return processResult{}, serrors.WrapStr("decoding packet", err)
-		}
-	}
 	// in reply to an SCMP error do nothing:
-	if decoded[len(decoded)-1] == slayers.LayerTypeSCMP && !scmpLayer.TypeCode.InfoMsg() {
+	scmpErr, err := isSCMPError(p.origPacket)
+	if err != nil {
+		return processResult{}, err
+	}
+	if scmpErr {
 		return processResult{}, serrors.WrapStr("SCMP error for SCMP error pkt -> DROP", cause)
 	}
 
@@ -748,6 +746,7 @@ func (p *scionPacketProcessor) packSCMP(scmpH *slayers.SCMP, scmpP gopacket.Seri
 		scionL:     &p.scionLayer,
 		buffer:     p.buffer,
 		quote:      quote,
+		auth:       p.d.SCMPAuth,
 	}.prepareSCMP(
 		scmpH,
 		scmpP,
@@ -757,6 +756,27 @@ func (p *scionPacketProcessor) packSCMP(scmpH *slayers.SCMP, scmpP gopacket.Seri
 	return processResult{OutPkt: rawSCMP}, err
 }
 
+// isSCMPError parses the packet to see if it is an SCMP error.
+func isSCMPError(pkt []byte) (bool, error) {
+	var (
+		scionLayer slayers.SCION
+		udpLayer   slayers.UDP
+		hbhExtn    slayers.HopByHopExtn
+		e2eExtn    slayers.EndToEndExtn
+		scmpLayer  slayers.SCMP
+	)
+	parser := gopacket.NewDecodingLayerParser(
+		slayers.LayerTypeSCION, &scionLayer, &udpLayer, &hbhExtn, &e2eExtn, &scmpLayer,
+	)
+	decoded := make([]gopacket.LayerType, 5)
+	if err := parser.DecodeLayers(pkt, &decoded); err != nil {
+		if _, ok := err.(gopacket.UnsupportedLayerType); !ok {
+			return false, serrors.WrapStr("decoding packet", err)
+		}
+	}
+	return decoded[len(decoded)-1] == slayers.LayerTypeSCMP && !scmpLayer.TypeCode.InfoMsg(), nil
+}
+
 func (p *scionPacketProcessor) parsePath() (processResult, error) {
 	var ok bool
 	p.path, ok = p.scionLayer.Path.(*scion.Raw)
@@ -1326,6 +1346,8 @@ type scmpPacker struct {
 	scionL *slayers.SCION
 	buffer gopacket.SerializeBuffer
 	quote  []byte
+	// auth, if set, authenticates the SCMP error messages.
+	auth *SCMPAuthenticator
 }
 
 func (s scmpPacker) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.SerializableLayer,
@@ -1333,32 +1355,31 @@ func (s scmpPacker) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.Serializable
 
 	// We use the original packet but put the already updated path, because usually a router will
 	// not keep a copy of the original/unmodified packet around.
-	pathRaw := s.scionL.Path.(*scion.Raw).Raw
+	currPath := s.scionL.Path
+	var pathRaw []byte
+	if raw, ok := currPath.(*scion.Raw); ok {
+		pathRaw = raw.Raw
+	}
 
 	if err := s.scionL.DecodeFromBytes(s.origPacket, gopacket.NilDecodeFeedback); err != nil {
 		panic(err)
 	}
-	path := s.scionL.Path.(*scion.Raw)
-
-	path.Raw = pathRaw
-	decPath, err := path.ToDecoded()
-	if err != nil {
-		return nil, serrors.Wrap(cannotRoute, err, "details", "decoding raw path")
-	}
-	s.scionL.Path, err = decPath.Reverse()
-	if err != nil {
-		return nil, serrors.Wrap(cannotRoute, err, "details", "reversing path for SCMP")
-	}
-	revPath := s.scionL.Path.(*scion.Decoded)
-	if incPath || revPath.IsXover() {
-		infoField := revPath.InfoFields[revPath.PathMeta.CurrINF]
-		if infoField.ConsDir {
-			hopField := revPath.HopFields[revPath.PathMeta.CurrHF]
-			infoField.UpdateSegID(hopField.Mac)
+	var err error
+	switch currPath.(type) {
+	case *scion.Raw:
+		path := s.scionL.Path.(*scion.Raw)
+		path.Raw = pathRaw
+		if s.scionL.Path, err = reverseSCIONForSCMP(path, incPath); err != nil {
+			return nil, err
 		}
-		if err := revPath.IncPath(); err != nil {
-			return nil, serrors.Wrap(cannotRoute, err, "details", "incrementing path for SCMP")
+	case *colibri.Path:
+		if s.scionL.Path, err = reverseColibriForSCMP(currPath.(*colibri.Path),
+			incPath); err != nil {
+
+			return nil, err
 		}
+	default:
+		return nil, serrors.WithCtx(unsupportedPathType, "type", s.scionL.PathType)
 	}
 
 	s.scionL.DstIA = s.scionL.SrcIA
@@ -1385,10 +1406,14 @@ func (s scmpPacker) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.Serializable
 		ComputeChecksums: true,
 		FixLengths:       true,
 	}
-	scmpLayers := []gopacket.SerializableLayer{s.scionL, scmpH, scmpP}
+	scmpLayers := []gopacket.SerializableLayer{scmpH, scmpP}
+	authenticate := cause != nil && s.auth != nil
 	if cause != nil {
 		// add quote for errors.
 		hdrLen := slayers.CmnHdrLen + s.scionL.AddrHdrLen() + s.scionL.Path.Len()
+		if authenticate {
+			hdrLen += scmpAuthExtnLen
+		}
 		switch scmpH.TypeCode.Type() {
 		case slayers.SCMPTypeExternalInterfaceDown:
 			hdrLen += 20
@@ -1403,13 +1428,84 @@ func (s scmpPacker) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.Serializable
 		}
 		scmpLayers = append(scmpLayers, gopacket.Payload(s.quote))
 	}
-	err = gopacket.SerializeLayers(s.buffer, sopts, scmpLayers...)
+	if authenticate {
+		err = s.serializeAuthenticated(sopts, scmpLayers)
+	} else {
+		scmpLayers = append([]gopacket.SerializableLayer{s.scionL}, scmpLayers...)
+		err = gopacket.SerializeLayers(s.buffer, sopts, scmpLayers...)
+	}
 	if err != nil {
 		return nil, serrors.Wrap(cannotRoute, err, "details", "serializing SCMP message")
 	}
 	return s.buffer.Bytes(), scmpError{TypeCode: scmpH.TypeCode, Cause: cause}
 }
 
+// serializeAuthenticated serializes the SCMP layers, and then the SCION header with an
+// end-to-end extension authenticating the serialized SCMP message.
+func (s scmpPacker) serializeAuthenticated(sopts gopacket.SerializeOptions,
+	scmpLayers []gopacket.SerializableLayer) error {
+
+	// The layers are prepended to the buffer, as in gopacket.SerializeLayers.
+	for i := len(scmpLayers) - 1; i >= 0; i-- {
+		if err := scmpLayers[i].SerializeTo(s.buffer, sopts); err != nil {
+			return err
+		}
+	}
+	dst, err := s.scionL.DstAddr()
+	if err != nil {
+		return err
+	}
+	extn, err := s.auth.authenticate(s.localIA, s.scionL.DstIA, dst, s.buffer.Bytes())
+	if err != nil {
+		return serrors.WrapStr("authenticating SCMP message", err)
+	}
+	e2e := &slayers.EndToEndExtn{}
+	if err := scmp_auth.EncodeDRKeyExtn(e2e, extn); err != nil {
+		return err
+	}
+	e2e.NextHdr = common.L4SCMP
+	s.scionL.NextHdr = common.End2EndClass
+	if err := e2e.SerializeTo(s.buffer, sopts); err != nil {
+		return err
+	}
+	return s.scionL.SerializeTo(s.buffer, sopts)
+}
+
+func reverseSCIONForSCMP(path *scion.Raw, incPath bool) (*scion.Decoded, error) {
+	decPath, err := path.ToDecoded()
+	if err != nil {
+		return nil, serrors.Wrap(cannotRoute, err, "details", "decoding raw path")
+	}
+	if _, err := decPath.Reverse(); err != nil {
+		return nil, serrors.Wrap(cannotRoute, err, "details", "reversing path for SCMP")
+	}
+	if incPath || decPath.IsXover() {
+		infoField := decPath.InfoFields[decPath.PathMeta.CurrINF]
+		if infoField.ConsDir {
+			hopField := decPath.HopFields[decPath.PathMeta.CurrHF]
+			infoField.UpdateSegID(hopField.Mac)
+		}
+		if err := decPath.IncPath(); err != nil {
+			return nil, serrors.Wrap(cannotRoute, err, "details", "incrementing path for SCMP")
+		}
+	}
+	return decPath, nil
+}
+
+// reverseColibriForSCMP reverses the COLIBRI path, such that the SCMP message travels back
+// to the source against the reservation direction.
+func reverseColibriForSCMP(path *colibri.Path, incPath bool) (*colibri.Path, error) {
+	if _, err := path.Reverse(); err != nil {
+		return nil, serrors.Wrap(cannotRoute, err, "details", "reversing path for SCMP")
+	}
+	if incPath {
+		if err := path.IncPath(); err != nil {
+			return nil, serrors.Wrap(cannotRoute, err, "details", "incrementing path for SCMP")
+		}
+	}
+	return path, nil
+}
+
 type segIDUpdater struct{}
 
 func (segIDUpdater) update(p *scion.Raw) error {
@@ -15,6 +15,8 @@ import (
 
 var NewServices = newServices
 
+const MaxBuckets = maxBuckets
+
 type ProcessResult struct {
 	processResult
 }
@@ -21,21 +21,23 @@ import (
 
 // Metrics defines the data-plane metrics for the BR.
 type Metrics struct {
-	InputBytesTotal           *prometheus.CounterVec
-	OutputBytesTotal          *prometheus.CounterVec
-	InputPacketsTotal         *prometheus.CounterVec
-	OutputPacketsTotal        *prometheus.CounterVec
-	DroppedPacketsTotal       *prometheus.CounterVec
-	InterfaceUp               *prometheus.GaugeVec
-	BFDInterfaceStateChanges  *prometheus.CounterVec
-	BFDPacketsSent            *prometheus.CounterVec
-	BFDPacketsReceived        *prometheus.CounterVec
-	ServiceInstanceCount      *prometheus.GaugeVec
-	ServiceInstanceChanges    *prometheus.CounterVec
-	SiblingReachable          *prometheus.GaugeVec
-	SiblingBFDPacketsSent     *prometheus.CounterVec
-	SiblingBFDPacketsReceived *prometheus.CounterVec
-	SiblingBFDStateChanges    *prometheus.CounterVec
+	InputBytesTotal            *prometheus.CounterVec
+	OutputBytesTotal           *prometheus.CounterVec
+	InputPacketsTotal          *prometheus.CounterVec
+	OutputPacketsTotal         *prometheus.CounterVec
+	DroppedPacketsTotal        *prometheus.CounterVec
+	InterfaceUp                *prometheus.GaugeVec
+	BFDInterfaceStateChanges   *prometheus.CounterVec
+	BFDPacketsSent             *prometheus.CounterVec
+	BFDPacketsReceived         *prometheus.CounterVec
+	ServiceInstanceCount       *prometheus.GaugeVec
+	ServiceInstanceChanges     *prometheus.CounterVec
+	SiblingReachable           *prometheus.GaugeVec
+	SiblingBFDPacketsSent      *prometheus.CounterVec
+	SiblingBFDPacketsReceived  *prometheus.CounterVec
+	SiblingBFDStateChanges     *prometheus.CounterVec
+	ColibriOverusePacketsTotal *prometheus.CounterVec
+	ColibriOveruseBytesTotal   *prometheus.CounterVec
 }
 
 // NewMetrics initializes the metrics for the Border Router, and registers them
@@ -150,5 +152,21 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"sibling", "isd_as"},
 		),
+		ColibriOverusePacketsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_colibri_overuse_pkts_total",
+				Help: "Total number of COLIBRI packets that exceeded the bandwidth of their " +
+					"reservation, by the action taken.",
+			},
+			[]string{"interface", "isd_as", "neighbor_isd_as", "action"},
+		),
+		ColibriOveruseBytesTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_colibri_overuse_bytes_total",
+				Help: "Total number of bytes of COLIBRI packets that exceeded the bandwidth " +
+					"of their reservation, by the action taken.",
+			},
+			[]string{"interface", "isd_as", "neighbor_isd_as", "action"},
+		),
 	}
 }
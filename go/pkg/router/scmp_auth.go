@@ -0,0 +1,116 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+)
+
+// SCMPAuthProtocol is the DRKey protocol of the keys authenticating the SCMP messages.
+const SCMPAuthProtocol = scmp_auth.DRKeyProtocol
+
+// scmpAuthExtnLen is the length of the end-to-end extension carrying the SCMPAuthDRKey
+// option: the extension header, the option header and the option data, padded to a multiple
+// of 4 bytes.
+const scmpAuthExtnLen = (2 + 2 + scmp_auth.DRKeyTotalLength + 3) / 4 * 4
+
+// SecretValues provides the DRKey secret values of the local AS.
+type SecretValues interface {
+	// GetSecretValue returns the secret value of the newest epoch containing the time.
+	GetSecretValue(t time.Time) (drkey.SV, error)
+}
+
+// SCMPAuthenticator authenticates the SCMP error messages sent by the router with an
+// SCMPAuthDRKey extension. The MAC is computed with the AS-to-host key of the scmp protocol,
+// from the local AS to the destination host of the message. The key is derived from the
+// secret value the router shares with the control service, so that the destination host can
+// fetch it from its own control service. The SCMPAuthHashTree extension is not used, as it
+// requires the AS key, which the router does not have.
+type SCMPAuthenticator struct {
+	// SecretValues provides the secret values, it must derive them as the control service.
+	SecretValues SecretValues
+}
+
+// NewSCMPAuthenticator returns an authenticator that derives the secret values from the
+// master key, with the epoch configuration of the control service.
+func NewSCMPAuthenticator(masterKey []byte,
+	epochDuration, gracePeriod time.Duration) *SCMPAuthenticator {
+
+	return &SCMPAuthenticator{
+		SecretValues: drkey.NewSecretValueFactory(masterKey, epochDuration, gracePeriod),
+	}
+}
+
+// authenticate returns the extension authenticating the SCMP message, i.e. the serialized
+// SCMP header and payload, sent from the local AS to the destination.
+func (a *SCMPAuthenticator) authenticate(localIA, dstIA addr.IA, dst net.Addr,
+	scmp []byte) (*scmp_auth.DRKeyExtn, error) {
+
+	key, err := a.key(localIA, dstIA, dst, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	extn := scmp_auth.NewDRKeyExtn()
+	if err := extn.SetDirection(scmp_auth.AsToHost); err != nil {
+		return nil, err
+	}
+	if err := extn.Authenticate(key.Key, scmp); err != nil {
+		return nil, err
+	}
+	return extn, nil
+}
+
+// key derives the AS-to-host key of the scmp protocol valid at the time.
+func (a *SCMPAuthenticator) key(localIA, dstIA addr.IA, dst net.Addr,
+	t time.Time) (drkey.Lvl2Key, error) {
+
+	var dstHost addr.HostAddr
+	switch v := dst.(type) {
+	case *net.IPAddr:
+		dstHost = addr.HostFromIP(v.IP)
+	case addr.HostSVC:
+		dstHost = v
+	default:
+		return drkey.Lvl2Key{}, serrors.New("unsupported destination address", "addr", dst)
+	}
+	sv, err := a.SecretValues.GetSecretValue(t)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	lvl1, err := protocol.DeriveLvl1(drkey.Lvl1Meta{
+		Epoch: sv.Epoch,
+		SrcIA: localIA,
+		DstIA: dstIA,
+	}, sv)
+	if err != nil {
+		return drkey.Lvl2Key{}, serrors.WrapStr("deriving level 1 key", err)
+	}
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: SCMPAuthProtocol,
+		Epoch:    sv.Epoch,
+		SrcIA:    localIA,
+		DstIA:    dstIA,
+		DstHost:  dstHost,
+	}
+	return protocol.KnownDerivations[SCMPAuthProtocol].DeriveLvl2(meta, lvl1)
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/mock_router"
+)
+
+func TestSCMPAuthentication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	srcHost := net.ParseIP("10.0.1.1").To4()
+	svs := drkey.NewSecretValueFactory([]byte("master key"), 24*time.Hour, 5*time.Minute)
+
+	// scmpError processes a packet arriving on the wrong interface, which the router answers
+	// with an SCMP parameter problem, and decodes the SCMP message.
+	scmpError := func(t *testing.T, auth *router.SCMPAuthenticator) (*slayers.SCION,
+		*slayers.EndToEndExtn, *slayers.SCMP, []gopacket.LayerType) {
+
+		dp := router.NewDP(nil, nil, nil, nil, map[addr.HostSVC][]*net.UDPAddr{}, localIA, key)
+		require.NoError(t, dp.AddInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			net.ParseIP("10.0.0.1").To4()))
+		dp.SCMPAuth = auth
+		spkt, dpath := prepBaseMsg()
+		require.NoError(t, spkt.SetSrcAddr(&net.IPAddr{IP: srcHost}))
+		require.NoError(t, spkt.SetDstAddr(&net.IPAddr{IP: net.ParseIP("10.0.2.2").To4()}))
+		dpath.HopFields = []*path.HopField{
+			{ConsIngress: 41, ConsEgress: 40},
+			{ConsIngress: 31, ConsEgress: 404},
+			{ConsIngress: 1, ConsEgress: 0},
+		}
+		dpath.HopFields[1].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[1])
+		input := toMsg(t, spkt, dpath)
+		origMsg := append([]byte{}, input.Buffers[0]...)
+		result, err := dp.ProcessPkt(1, input, slayers.SCION{}, origMsg,
+			gopacket.NewSerializeBuffer())
+		require.Error(t, err)
+		require.NotNil(t, result.OutPkt)
+
+		var (
+			scionL slayers.SCION
+			e2e    slayers.EndToEndExtn
+			scmp   slayers.SCMP
+		)
+		parser := gopacket.NewDecodingLayerParser(slayers.LayerTypeSCION, &scionL, &e2e, &scmp)
+		parser.IgnoreUnsupported = true
+		decoded := make([]gopacket.LayerType, 0, 3)
+		require.NoError(t, parser.DecodeLayers(result.OutPkt, &decoded))
+		return &scionL, &e2e, &scmp, decoded
+	}
+
+	t.Run("authenticated", func(t *testing.T) {
+		scionL, e2e, scmp, decoded := scmpError(t,
+			&router.SCMPAuthenticator{SecretValues: svs})
+		require.Equal(t, []gopacket.LayerType{slayers.LayerTypeSCION,
+			slayers.LayerTypeEndToEndExtn, slayers.LayerTypeSCMP}, decoded)
+		assert.Equal(t, common.End2EndClass, scionL.NextHdr)
+		assert.Equal(t, common.L4SCMP, e2e.NextHdr)
+		extn, err := scmp_auth.DecodeDRKeyExtn(e2e)
+		require.NoError(t, err)
+		assert.Equal(t, scmp_auth.AsToHost, extn.Direction)
+
+		// the destination host derives the same key from the level 1 key of the router AS.
+		sv, err := svs.GetSecretValue(time.Now())
+		require.NoError(t, err)
+		lvl1, err := protocol.DeriveLvl1(drkey.Lvl1Meta{
+			Epoch: sv.Epoch,
+			SrcIA: localIA,
+			DstIA: scionL.DstIA,
+		}, sv)
+		require.NoError(t, err)
+		lvl2, err := protocol.KnownDerivations["scmp"].DeriveLvl2(drkey.Lvl2Meta{
+			KeyType:  drkey.AS2Host,
+			Protocol: "scmp",
+			SrcIA:    localIA,
+			DstIA:    scionL.DstIA,
+			DstHost:  addr.HostFromIP(srcHost),
+		}, lvl1)
+		require.NoError(t, err)
+		msg := append(append([]byte{}, scmp.Contents...), scmp.Payload...)
+		assert.NoError(t, extn.Verify(lvl2.Key, msg))
+
+		msg[len(msg)-1] ^= 0xff
+		assert.Error(t, extn.Verify(lvl2.Key, msg))
+	})
+	t.Run("not authenticated", func(t *testing.T) {
+		scionL, _, _, decoded := scmpError(t, nil)
+		assert.Equal(t, []gopacket.LayerType{slayers.LayerTypeSCION, slayers.LayerTypeSCMP},
+			decoded)
+		assert.Equal(t, common.L4SCMP, scionL.NextHdr)
+	})
+}
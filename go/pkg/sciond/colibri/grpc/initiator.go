@@ -0,0 +1,118 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	sc_grpc "github.com/scionproto/scion/go/pkg/grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+// E2EInitiator requests COLIBRI e2e reservations to the local CS.
+type E2EInitiator struct {
+	Dialer sc_grpc.Dialer
+}
+
+var _ colgrpc.E2EInitiator = (*E2EInitiator)(nil)
+var _ colgrpc.ReservationLister = (*E2EInitiator)(nil)
+
+// SetupE2E asks the local CS to set up a new e2e reservation to dst. If an AS in the path
+// rejects it, the returned error is a *reservation.Failure.
+func (i *E2EInitiator) SetupE2E(ctx context.Context, dst addr.IA, bw reservation.BWCls) (
+	*colgrpc.E2EReservation, error) {
+
+	conn, err := i.Dialer.Dial(ctx, addr.SvcCS)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := colpb.NewE2EReservationServiceClient(conn)
+	rep, err := client.Setup(ctx, &colpb.E2ESetupRequest{
+		DstIsdAs:    uint64(dst.IAInt()),
+		RequestedBw: uint32(bw),
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("requesting e2e reservation", err)
+	}
+	if rep.Failure != nil {
+		return nil, colgrpc.FailureFromPB(rep.Failure)
+	}
+	return colgrpc.E2EReservationFromPB(rep.Reservation)
+}
+
+// RenewE2E asks the local CS to set up a new index for the e2e reservation.
+func (i *E2EInitiator) RenewE2E(ctx context.Context, id *reservation.E2EID,
+	bw reservation.BWCls) (*colgrpc.E2EReservation, error) {
+
+	conn, err := i.Dialer.Dial(ctx, addr.SvcCS)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := colpb.NewE2EReservationServiceClient(conn)
+	rep, err := client.Renew(ctx, &colpb.E2ERenewRequest{
+		Id:          id.ToRaw(),
+		RequestedBw: uint32(bw),
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("renewing e2e reservation", err)
+	}
+	if rep.Failure != nil {
+		return nil, colgrpc.FailureFromPB(rep.Failure)
+	}
+	return colgrpc.E2EReservationFromPB(rep.Reservation)
+}
+
+// CleanupE2E asks the local CS to remove the index of the e2e reservation.
+func (i *E2EInitiator) CleanupE2E(ctx context.Context, id *reservation.E2EID,
+	idx reservation.IndexNumber) error {
+
+	conn, err := i.Dialer.Dial(ctx, addr.SvcCS)
+	if err != nil {
+		return serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := colpb.NewE2EReservationServiceClient(conn)
+	_, err = client.Cleanup(ctx, &colpb.E2ECleanupRequest{
+		Id:    id.ToRaw(),
+		Index: uint32(idx),
+	})
+	if err != nil {
+		return serrors.WrapStr("cleaning up e2e reservation", err)
+	}
+	return nil
+}
+
+// ListReservations asks the local CS for the reservations stored in the AS.
+func (i *E2EInitiator) ListReservations(ctx context.Context) (
+	*colpb.ListReservationsResponse, error) {
+
+	conn, err := i.Dialer.Dial(ctx, addr.SvcCS)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := colpb.NewReservationListServiceClient(conn)
+	rep, err := client.List(ctx, &colpb.ListReservationsRequest{})
+	if err != nil {
+		return nil, serrors.WrapStr("listing reservations", err)
+	}
+	return rep, nil
+}
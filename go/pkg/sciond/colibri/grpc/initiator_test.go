@@ -0,0 +1,104 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/go/cs/reservation/e2e"
+	"github.com/scionproto/scion/go/cs/reservation/segmenttest"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/grpc/mock_grpc"
+	"github.com/scionproto/scion/go/cs/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/xtest"
+	sc_mock_grpc "github.com/scionproto/scion/go/pkg/grpc/mock_grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+	sd_grpc "github.com/scionproto/scion/go/pkg/sciond/colibri/grpc"
+)
+
+func TestE2EInitiator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	csInitiator := mock_grpc.NewMockHostE2EInitiator(ctrl)
+	// the CS identifies the host by the address of the connection
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host := net.ParseIP("127.0.0.1").To4()
+	server := grpc.NewServer()
+	colpb.RegisterE2EReservationServiceServer(server,
+		&colgrpc.E2EServer{Initiator: csInitiator})
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	colpb.RegisterReservationListServiceServer(server, &colgrpc.ListServer{Store: store})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	dialer := sc_mock_grpc.NewMockDialer(ctrl)
+	dialer.EXPECT().Dial(gomock.Any(), addr.SvcCS).DoAndReturn(
+		func(ctx context.Context, _ net.Addr) (*grpc.ClientConn, error) {
+			return grpc.DialContext(ctx, listener.Addr().String(), grpc.WithInsecure())
+		}).Times(4)
+
+	dst := xtest.MustParseIA("1-ff00:0:112")
+	rsv := &colgrpc.E2EReservation{
+		ID:    reservation.E2EID{ASID: xtest.MustParseAS("ff00:0:111")},
+		Index: 1,
+		Token: reservation.Token{
+			InfoField: reservation.InfoField{
+				ExpirationTick: 42,
+				BWCls:          5,
+				Idx:            1,
+				PathType:       reservation.E2EPath,
+			},
+			HopFields: []reservation.HopField{{Egress: 1}, {Ingress: 2}},
+		},
+		Path: segmenttest.NewPathFromComponents(0, "1-ff00:0:111", 1, 2, "1-ff00:0:112", 0),
+	}
+	initiator := sd_grpc.E2EInitiator{Dialer: dialer}
+
+	csInitiator.EXPECT().SetupE2E(gomock.Any(), host, dst, reservation.BWCls(5)).Return(rsv,
+		nil)
+	got, err := initiator.SetupE2E(context.Background(), dst, 5)
+	require.NoError(t, err)
+	require.Equal(t, rsv, got)
+
+	csInitiator.EXPECT().RenewE2E(gomock.Any(), host, &rsv.ID, reservation.BWCls(5)).Return(rsv,
+		nil)
+	got, err = initiator.RenewE2E(context.Background(), &rsv.ID, 5)
+	require.NoError(t, err)
+	require.Equal(t, rsv, got)
+
+	csInitiator.EXPECT().CleanupE2E(gomock.Any(), host, &rsv.ID, reservation.IndexNumber(1))
+	err = initiator.CleanupE2E(context.Background(), &rsv.ID, 1)
+	require.NoError(t, err)
+
+	store.EXPECT().ListSegmentReservations(gomock.Any()).Return(nil, nil)
+	store.EXPECT().ListE2EReservations(gomock.Any()).Return([]*e2e.Reservation{{
+		ID: rsv.ID,
+	}}, nil)
+	list, err := initiator.ListReservations(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, list.Segments)
+	require.Len(t, list.E2E, 1)
+	require.Equal(t, rsv.ID.ToRaw(), list.E2E[0].Id)
+}
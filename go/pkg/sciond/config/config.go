@@ -21,10 +21,12 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/storage"
 	trustengine "github.com/scionproto/scion/go/pkg/trust/config"
@@ -47,6 +49,8 @@ type Config struct {
 	DRKeyDB     storage.DBConfig   `toml:"drkey_db,omitempty"`
 	SD          SDConfig           `toml:"sd,omitempty"`
 	TrustEngine trustengine.Config `toml:"trustengine,omitempty"`
+	// DRKeyProtocols declares the DRKey protocols served to the applications.
+	DRKeyProtocols protocol.ProtocolsConfig `toml:"drkey_protocols,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -60,6 +64,7 @@ func (cfg *Config) InitDefaults() {
 		cfg.PathDB.WithDefault(fmt.Sprintf(storage.DefaultPathDBPath, "sd")),
 		&cfg.SD,
 		&cfg.TrustEngine,
+		&cfg.DRKeyProtocols,
 	)
 }
 
@@ -74,6 +79,7 @@ func (cfg *Config) Validate() error {
 		&cfg.DRKeyDB,
 		&cfg.SD,
 		&cfg.TrustEngine,
+		&cfg.DRKeyProtocols,
 	)
 }
 
@@ -107,6 +113,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		),
 		&cfg.SD,
 		&cfg.TrustEngine,
+		config.OverrideName(&cfg.DRKeyProtocols, "drkey_protocols"),
 	)
 }
 
@@ -126,6 +133,11 @@ type SDConfig struct {
 	// If HiddenPathGroups begins with http:// or https://, it will be fetched
 	// over the network from the specified URL instead.
 	HiddenPathGroups string `toml:"hidden_path_groups,omitempty"`
+	// SCMPAuthPolicy defines which interface down notifications are accepted from the
+	// applications: "ignore" accepts all of them, "prefer" rejects the ones carrying an SCMP
+	// message with an invalid authentication, and "require" only accepts the ones carrying an
+	// authenticated SCMP message.
+	SCMPAuthPolicy string `toml:"scmp_auth_policy,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -135,12 +147,18 @@ func (cfg *SDConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	if cfg.SCMPAuthPolicy == "" {
+		cfg.SCMPAuthPolicy = snet.SCMPAuthIgnore.String()
+	}
 }
 
 func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
+	if _, err := snet.ParseSCMPAuthPolicy(cfg.SCMPAuthPolicy); err != nil {
+		return err
+	}
 	return nil
 }
 
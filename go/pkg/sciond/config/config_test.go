@@ -47,6 +47,7 @@ func InitTestConfig(cfg *Config) {
 func InitTestSDConfig(cfg *SDConfig) {
 	cfg.Address = "garbage"
 	cfg.DisableSegVerification = true
+	cfg.SCMPAuthPolicy = "garbage"
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
@@ -61,4 +62,18 @@ func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, sciond.DefaultAPIAddress, cfg.Address)
 	assert.False(t, cfg.DisableSegVerification)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Equal(t, "ignore", cfg.SCMPAuthPolicy)
+}
+
+func TestSDConfigValidate(t *testing.T) {
+	for _, policy := range []string{"ignore", "prefer", "require"} {
+		var cfg SDConfig
+		cfg.SCMPAuthPolicy = policy
+		cfg.InitDefaults()
+		assert.NoError(t, cfg.Validate(), policy)
+	}
+	var cfg SDConfig
+	cfg.SCMPAuthPolicy = "always"
+	cfg.InitDefaults()
+	assert.Error(t, cfg.Validate())
 }
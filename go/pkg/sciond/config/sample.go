@@ -29,4 +29,10 @@ query_interval = "5m"
 
 # The configuration containing hidden path groups. (default "")
 hidden_path_groups =  ""
+
+# The interface down notifications accepted from the applications. "ignore"
+# accepts all of them, "prefer" rejects the ones carrying an SCMP message with an
+# invalid authentication, and "require" only accepts the ones carrying an
+# authenticated SCMP message. (default "ignore")
+scmp_auth_policy = "ignore"
 `
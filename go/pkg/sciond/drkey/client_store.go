@@ -21,42 +21,61 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
 	"github.com/scionproto/scion/go/lib/drkeystorage"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/util"
 )
 
-// Fetcher obtains a Lvl2 DRKey from the local CS.
+// Fetcher obtains a Lvl2 DRKey or a delegation secret from the local CS.
 type Fetcher interface {
 	GetDRKeyLvl2(ctx context.Context, meta drkey.Lvl2Meta, a addr.IA,
 		valTime time.Time) (drkey.Lvl2Key, error)
+	// GetDelegationSecret obtains the delegation secret for the protocol, source and
+	// destination IAs of the meta. The CS only hands it out to the hosts allowed for the
+	// protocol.
+	GetDelegationSecret(ctx context.Context, meta drkey.Lvl2Meta,
+		valTime time.Time) (drkey.DelegationSecret, error)
 }
 
 // ClientStore is the DRKey store used in the client side, i.e. sciond.
 // It implements drkeystorage.ClientStore.
 type ClientStore struct {
-	ia      addr.IA
-	db      drkey.Lvl2DB
-	fetcher Fetcher
+	ia        addr.IA
+	db        drkey.Lvl2DB
+	fetcher   Fetcher
+	protocols *protocol.Registry
 }
 
 var _ drkeystorage.ClientStore = &ClientStore{}
 
-// NewClientStore constructs a new client store without assigned messenger.
-func NewClientStore(local addr.IA, db drkey.Lvl2DB, fetcher Fetcher) *ClientStore {
+// NewClientStore constructs a new client store without assigned messenger. Only the keys of
+// the protocols declared in the registry are served; a nil registry declares the built-in
+// protocols.
+func NewClientStore(local addr.IA, db drkey.Lvl2DB, fetcher Fetcher,
+	protocols *protocol.Registry) *ClientStore {
+
 	return &ClientStore{
-		ia:      local,
-		db:      db,
-		fetcher: fetcher,
+		ia:        local,
+		db:        db,
+		fetcher:   fetcher,
+		protocols: protocols,
 	}
 }
 
 // GetLvl2Key returns the level 2 drkey from the local DB or if not found, by asking our local CS.
+// The AS2Host and Host2Host keys of protocols supporting delegation are derived locally from
+// the delegation secret, if the CS hands it out to this host. Keys of undeclared protocols are
+// rejected with an error wrapping protocol.ErrUndeclaredProtocol.
 func (s *ClientStore) GetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
 	valTime time.Time) (drkey.Lvl2Key, error) {
 
 	logger := log.FromCtx(ctx)
+	proto, err := s.protocols.Lookup(meta.Protocol)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
 	// is it in storage?
 	k, err := s.db.GetLvl2Key(ctx, meta, util.TimeToSecs(valTime))
 	if err == nil {
@@ -65,6 +84,14 @@ func (s *ClientStore) GetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
 	if err != sql.ErrNoRows {
 		return drkey.Lvl2Key{}, serrors.WrapStr("looking up level 2 key in DB", err)
 	}
+	if der, ok := delegatedDerivation(proto, meta); ok {
+		k, err = s.deriveFromDS(ctx, der, proto, meta, valTime)
+		if err == nil {
+			return k, s.insertLvl2Key(ctx, k)
+		}
+		logger.Debug("[DRKey ClientStore] Cannot derive level 2 key from delegation secret",
+			"err", err)
+	}
 	logger.Debug("[DRKey ClientStore] Level 2 key not stored. Requesting it to CS")
 	// if not, ask our CS for it
 
@@ -72,11 +99,68 @@ func (s *ClientStore) GetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
 	if err != nil {
 		return drkey.Lvl2Key{}, serrors.WrapStr("fetching lvl2 key from local CS", err)
 	}
-	if err = s.db.InsertLvl2Key(ctx, k); err != nil {
-		logger.Error("[DRKey ClientStore] Could not insert level 2 in DB", "error", err)
-		return k, serrors.WrapStr("inserting level 2 key in DB", err)
+	k.Epoch = proto.LimitEpoch(k.Epoch, valTime)
+	return k, s.insertLvl2Key(ctx, k)
+}
+
+// deriveFromDS derives the level 2 key from the delegation secret, which is looked up in the DB
+// as the AS2AS key of the protocol, or fetched from the local CS.
+func (s *ClientStore) deriveFromDS(ctx context.Context, der protocol.DelegatedDerivation,
+	proto protocol.Protocol, meta drkey.Lvl2Meta, valTime time.Time) (drkey.Lvl2Key, error) {
+
+	dsMeta := meta
+	dsMeta.KeyType = drkey.AS2AS
+	dsMeta.SrcHost = addr.HostNone{}
+	dsMeta.DstHost = addr.HostNone{}
+	var ds drkey.DelegationSecret
+	k, err := s.db.GetLvl2Key(ctx, dsMeta, util.TimeToSecs(valTime))
+	switch {
+	case err == nil:
+		ds = drkey.DelegationSecret{
+			Protocol: k.Protocol,
+			Epoch:    k.Epoch,
+			SrcIA:    k.SrcIA,
+			DstIA:    k.DstIA,
+			Key:      k.Key,
+		}
+	case err == sql.ErrNoRows:
+		if ds, err = s.fetcher.GetDelegationSecret(ctx, dsMeta, valTime); err != nil {
+			return drkey.Lvl2Key{}, serrors.WrapStr("fetching delegation secret from local CS",
+				err)
+		}
+		ds.Epoch = proto.LimitEpoch(ds.Epoch, valTime)
+		dsMeta.Epoch = ds.Epoch
+		if err := s.insertLvl2Key(ctx, drkey.Lvl2Key{Lvl2Meta: dsMeta, Key: ds.Key}); err != nil {
+			return drkey.Lvl2Key{}, err
+		}
+	default:
+		return drkey.Lvl2Key{}, serrors.WrapStr("looking up delegation secret in DB", err)
+	}
+	meta.Epoch = ds.Epoch
+	return der.DeriveLvl2FromDS(meta, ds)
+}
+
+func (s *ClientStore) insertLvl2Key(ctx context.Context, k drkey.Lvl2Key) error {
+	if err := s.db.InsertLvl2Key(ctx, k); err != nil {
+		log.FromCtx(ctx).Error("[DRKey ClientStore] Could not insert level 2 in DB",
+			"error", err)
+		return serrors.WrapStr("inserting level 2 key in DB", err)
+	}
+	return nil
+}
+
+// delegatedDerivation returns the derivation of the protocol if the key can be derived from a
+// delegation secret.
+func delegatedDerivation(proto protocol.Protocol, meta drkey.Lvl2Meta) (
+	protocol.DelegatedDerivation, bool) {
+
+	if meta.KeyType != drkey.AS2Host && meta.KeyType != drkey.Host2Host {
+		return nil, false
+	}
+	if !proto.Delegation {
+		return nil, false
 	}
-	return k, nil
+	return proto.DelegatedDerivation()
 }
 
 // DeleteExpiredKeys will remove any expired keys.
@@ -0,0 +1,194 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/drkeydbsqlite"
+	"github.com/scionproto/scion/go/lib/drkey/protocol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	sd_drkey "github.com/scionproto/scion/go/pkg/sciond/drkey"
+)
+
+var (
+	srcIA   = xtest.MustParseIA("1-ff00:0:1")
+	dstIA   = xtest.MustParseIA("1-ff00:0:2")
+	lvl1Key = drkey.Lvl1Key{
+		Lvl1Meta: drkey.Lvl1Meta{
+			Epoch: drkey.NewEpoch(0, 100),
+			SrcIA: srcIA,
+			DstIA: dstIA,
+		},
+		Key: xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+	}
+)
+
+// fetcher derives the keys from lvl1Key, as the CS does.
+type fetcher struct {
+	lvl2Calls int
+	dsCalls   int
+	dsAllowed bool
+}
+
+func (f *fetcher) GetDRKeyLvl2(_ context.Context, meta drkey.Lvl2Meta, _ addr.IA,
+	_ time.Time) (drkey.Lvl2Key, error) {
+
+	f.lvl2Calls++
+	meta.Epoch = lvl1Key.Epoch
+	return protocol.KnownDerivations[meta.Protocol].DeriveLvl2(meta, lvl1Key)
+}
+
+func (f *fetcher) GetDelegationSecret(_ context.Context, meta drkey.Lvl2Meta,
+	_ time.Time) (drkey.DelegationSecret, error) {
+
+	f.dsCalls++
+	if !f.dsAllowed {
+		return drkey.DelegationSecret{}, serrors.New("endhost not allowed")
+	}
+	meta.Epoch = lvl1Key.Epoch
+	k, err := protocol.KnownDerivations[meta.Protocol].DeriveLvl2(meta, lvl1Key)
+	if err != nil {
+		return drkey.DelegationSecret{}, err
+	}
+	return drkey.DelegationSecret{
+		Protocol: meta.Protocol,
+		Epoch:    k.Epoch,
+		SrcIA:    k.SrcIA,
+		DstIA:    k.DstIA,
+		Key:      k.Key,
+	}, nil
+}
+
+func TestGetLvl2KeyFromDS(t *testing.T) {
+	testCases := map[string]struct {
+		protocol  string
+		dsAllowed bool
+		dsCalls   int
+		lvl2Calls int
+	}{
+		"derived from DS": {
+			protocol:  "piskes",
+			dsAllowed: true,
+			dsCalls:   1,
+		},
+		"DS not allowed": {
+			protocol:  "piskes",
+			dsCalls:   2,
+			lvl2Calls: 2,
+		},
+		"protocol without delegation": {
+			protocol:  "scmp",
+			dsAllowed: true,
+			lvl2Calls: 2,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			db, cleanF := newLvl2DB(t)
+			defer cleanF()
+			f := &fetcher{dsAllowed: tc.dsAllowed}
+			store := sd_drkey.NewClientStore(dstIA, db, f, nil)
+			valTime := util.SecsToTime(10)
+			for i, dstHost := range []net.IP{net.IPv4(127, 0, 0, 1), net.IPv4(127, 0, 0, 2)} {
+				meta := drkey.Lvl2Meta{
+					KeyType:  drkey.AS2Host,
+					Protocol: tc.protocol,
+					SrcIA:    srcIA,
+					DstIA:    dstIA,
+					SrcHost:  addr.HostNone{},
+					DstHost:  addr.HostFromIP(dstHost),
+				}
+				k, err := store.GetLvl2Key(context.Background(), meta, valTime)
+				require.NoError(t, err, "key %d", i)
+				meta.Epoch = lvl1Key.Epoch
+				expected, err := protocol.KnownDerivations[tc.protocol].DeriveLvl2(meta, lvl1Key)
+				require.NoError(t, err)
+				require.Equal(t, expected.Key, k.Key, "key %d", i)
+				// the second lookup is served from the DB
+				_, err = store.GetLvl2Key(context.Background(), meta, valTime)
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.dsCalls, f.dsCalls)
+			require.Equal(t, tc.lvl2Calls, f.lvl2Calls)
+		})
+	}
+}
+
+func TestGetLvl2KeyConfiguredProtocols(t *testing.T) {
+	db, cleanF := newLvl2DB(t)
+	defer cleanF()
+	f := &fetcher{dsAllowed: true}
+	protocols := protocol.ProtocolsConfig{
+		"scmp": {
+			Derivation:  protocol.StandardType,
+			KeyLifetime: util.DurWrap{Duration: 10 * time.Second},
+		},
+	}.Registry()
+	store := sd_drkey.NewClientStore(dstIA, db, f, protocols)
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.AS2Host,
+		Protocol: "piskes",
+		SrcIA:    srcIA,
+		DstIA:    dstIA,
+		SrcHost:  addr.HostNone{},
+		DstHost:  addr.HostFromIP(net.IPv4(127, 0, 0, 1)),
+	}
+	_, err := store.GetLvl2Key(context.Background(), meta, util.SecsToTime(10))
+	require.True(t, errors.Is(err, protocol.ErrUndeclaredProtocol), err)
+	require.Zero(t, f.dsCalls+f.lvl2Calls)
+
+	meta.Protocol = "scmp"
+	k, err := store.GetLvl2Key(context.Background(), meta, util.SecsToTime(10))
+	require.NoError(t, err)
+	require.Equal(t, util.SecsToTime(20).UTC(), k.Epoch.NotAfter)
+	_, err = store.GetLvl2Key(context.Background(), meta, util.SecsToTime(15))
+	require.NoError(t, err)
+	require.Equal(t, 1, f.lvl2Calls)
+	// the key expired with its lifetime, and is fetched again.
+	_, err = store.GetLvl2Key(context.Background(), meta, util.SecsToTime(25))
+	require.NoError(t, err)
+	require.Equal(t, 2, f.lvl2Calls)
+	// the key fetched again replaces the expired one, with the same epoch begin.
+	k, err = store.GetLvl2Key(context.Background(), meta, util.SecsToTime(30))
+	require.NoError(t, err)
+	require.Equal(t, util.SecsToTime(35).UTC(), k.Epoch.NotAfter)
+	require.Equal(t, 2, f.lvl2Calls)
+}
+
+func newLvl2DB(t *testing.T) (drkey.Lvl2DB, func()) {
+	dir, err := ioutil.TempDir("", "client-store-test-")
+	require.NoError(t, err)
+	db, err := drkeydbsqlite.NewLvl2Backend(filepath.Join(dir, "drkey.db"))
+	require.NoError(t, err)
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
@@ -65,6 +65,40 @@ func (f DRKeyFetcher) GetDRKeyLvl2(ctx context.Context, lvl2meta drkey.Lvl2Meta,
 	return lvl2Key, nil
 }
 
+// GetDelegationSecret fetches the delegation secret for the protocol, source and destination
+// IAs of the metadata by requesting the CS.
+func (f DRKeyFetcher) GetDelegationSecret(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.DelegationSecret, error) {
+
+	conn, err := f.Dialer.Dial(ctx, addr.SvcCS)
+	if err != nil {
+		return drkey.DelegationSecret{}, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := cppb.NewDRKeyDelegationServiceClient(conn)
+	dsReq := ctrl.DSReq{
+		Protocol: meta.Protocol,
+		ValTime:  valTime,
+		SrcIA:    meta.SrcIA,
+		DstIA:    meta.DstIA,
+	}
+	baseReq, err := ctrl.DSReqToProtoRequest(dsReq)
+	if err != nil {
+		return drkey.DelegationSecret{},
+			serrors.WrapStr("parsing DS request to protobuf", err)
+	}
+	rep, err := client.DRKeyDS(ctx, &cppb.DRKeyDSRequest{BaseReq: baseReq})
+	if err != nil {
+		return drkey.DelegationSecret{}, serrors.WrapStr("requesting delegation secret", err)
+	}
+	ds, err := ctrl.GetDSFromReply(rep.BaseRep, dsReq)
+	if err != nil {
+		return drkey.DelegationSecret{},
+			serrors.WrapStr("obtaining delegation secret from reply", err)
+	}
+	return ds, nil
+}
+
 func lvl2reqToProtoRequest(req ctrl.Lvl2Req) (*cppb.DRKeyLvl2Request, error) {
 	baseReq, err := ctrl.Lvl2reqToProtoRequest(req)
 	if err != nil {
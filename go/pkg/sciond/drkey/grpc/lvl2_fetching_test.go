@@ -29,6 +29,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/pkg/grpc/mock_grpc"
 	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
@@ -38,13 +39,20 @@ import (
 )
 
 func dialer(drkeyServer cppb.DRKeyLvl2ServiceServer) func(context.Context,
+	string) (net.Conn, error) {
+	return serverDialer(func(server *grpc.Server) {
+		cppb.RegisterDRKeyLvl2ServiceServer(server, drkeyServer)
+	})
+}
+
+func serverDialer(register func(*grpc.Server)) func(context.Context,
 	string) (net.Conn, error) {
 	bufsize := 1024 * 1024
 	listener := bufconn.Listen(bufsize)
 
 	server := grpc.NewServer()
 
-	cppb.RegisterDRKeyLvl2ServiceServer(server, drkeyServer)
+	register(server)
 
 	go func() {
 		if err := server.Serve(listener); err != nil {
@@ -107,3 +115,64 @@ func TestLvl2KeyFetching(t *testing.T) {
 	_, err = fetcher.GetDRKeyLvl2(context.Background(), meta, dstIA, now)
 	require.NoError(t, err)
 }
+
+func TestDSFetching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().UTC()
+	epochBegin, err := ptypes.TimestampProto(util.SecsToTime(0))
+	require.NoError(t, err)
+	epochEnd, err := ptypes.TimestampProto(util.SecsToTime(10))
+	require.NoError(t, err)
+	timestamp, err := ptypes.TimestampProto(now)
+	require.NoError(t, err)
+
+	srcIA := xtest.MustParseIA("1-ff00:0:1")
+	dstIA := xtest.MustParseIA("1-ff00:0:2")
+	resp := &cppb.DRKeyDSResponse{
+		BaseRep: &drkey_pb.DRKeyDSResponse{
+			Timestamp:  timestamp,
+			Ds:         xtest.MustParseHexString("c584cad32613547c64823c756651b6f5"),
+			EpochBegin: epochBegin,
+			EpochEnd:   epochEnd,
+		},
+	}
+	csSrv := mock_cppb.NewMockDRKeyDelegationServiceServer(ctrl)
+	csSrv.EXPECT().DRKeyDS(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *cppb.DRKeyDSRequest) (*cppb.DRKeyDSResponse, error) {
+			require.Equal(t, "piskes", req.BaseReq.Protocol)
+			require.Equal(t, uint64(srcIA.IAInt()), req.BaseReq.SrcIa)
+			require.Equal(t, uint64(dstIA.IAInt()), req.BaseReq.DstIa)
+			return resp, nil
+		},
+	)
+
+	conn, err := grpc.DialContext(context.Background(),
+		"",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(serverDialer(func(server *grpc.Server) {
+			cppb.RegisterDRKeyDelegationServiceServer(server, csSrv)
+		})),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	dialer := mock_grpc.NewMockDialer(ctrl)
+	dialer.EXPECT().Dial(gomock.Any(), gomock.Any()).Return(conn, nil)
+
+	fetcher := sd_grpc.DRKeyFetcher{
+		Dialer: dialer,
+	}
+	meta := drkey.Lvl2Meta{
+		Protocol: "piskes",
+		SrcIA:    srcIA,
+		DstIA:    dstIA,
+	}
+	ds, err := fetcher.GetDelegationSecret(context.Background(), meta, now)
+	require.NoError(t, err)
+	require.Equal(t, drkey.DRKey(resp.BaseRep.Ds), ds.Key)
+	require.Equal(t, drkey.NewEpoch(0, 10), ds.Epoch)
+	require.Equal(t, srcIA, ds.SrcIA)
+	require.Equal(t, dstIA, ds.DstIA)
+}
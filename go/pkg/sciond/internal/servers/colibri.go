@@ -0,0 +1,262 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	timestamppb "github.com/golang/protobuf/ptypes/timestamp"
+
+	base "github.com/scionproto/scion/go/cs/reservation"
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
+)
+
+// ColibriSetup sets up a new COLIBRI e2e reservation from this AS to the destination.
+// A rejection by an AS in the path is returned as the failure in the response. The
+// reservation is owned by the host of the requesting application: the control service only
+// sees this daemon, so the daemon records the owner and checks it on renewals and cleanups.
+// All the applications on the same host share the ownership, as do the ones behind the same
+// NAT.
+func (s *DaemonServer) ColibriSetup(ctx context.Context,
+	req *sdpb.ColibriSetupRequest) (*sdpb.ColibriSetupResponse, error) {
+
+	if s.Colibri == nil {
+		return nil, serrors.New("COLIBRI not supported")
+	}
+	bw := reservation.BWCls(req.RequestedBw)
+	if err := bw.Validate(); err != nil {
+		return nil, err
+	}
+	host, err := colgrpc.RequestingHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dst := addr.IAInt(req.DstIsdAs).IA()
+	rsv, err := s.Colibri.SetupE2E(ctx, dst, bw)
+	if err != nil {
+		log.FromCtx(ctx).Debug("Cannot set up COLIBRI reservation", "dst", dst, "err", err)
+		var failure *reservation.Failure
+		if errors.As(err, &failure) {
+			return &sdpb.ColibriSetupResponse{Failure: colgrpc.FailureToPB(failure)}, nil
+		}
+		return nil, serrors.WrapStr("setting up COLIBRI reservation", err)
+	}
+	pb, err := s.colibriReservationToPB(rsv)
+	if err != nil {
+		return nil, err
+	}
+	s.colibriOwners.add(&rsv.ID, host, rsv.Token.ExpirationTick.ToTime())
+	return &sdpb.ColibriSetupResponse{Reservation: pb}, nil
+}
+
+// ColibriRenew requests a new index for an existing COLIBRI e2e reservation. Only the host
+// that set up the reservation through this daemon can renew it.
+func (s *DaemonServer) ColibriRenew(ctx context.Context,
+	req *sdpb.ColibriRenewRequest) (*sdpb.ColibriRenewResponse, error) {
+
+	if s.Colibri == nil {
+		return nil, serrors.New("COLIBRI not supported")
+	}
+	id, err := reservation.E2EIDFromRaw(req.Id)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing reservation ID", err)
+	}
+	bw := reservation.BWCls(req.RequestedBw)
+	if err := bw.Validate(); err != nil {
+		return nil, err
+	}
+	host, err := colgrpc.RequestingHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.colibriOwners.check(id, host, time.Now()); err != nil {
+		return nil, err
+	}
+	rsv, err := s.Colibri.RenewE2E(ctx, id, bw)
+	if err != nil {
+		log.FromCtx(ctx).Debug("Cannot renew COLIBRI reservation", "id", id, "err", err)
+		var failure *reservation.Failure
+		if errors.As(err, &failure) {
+			return &sdpb.ColibriRenewResponse{Failure: colgrpc.FailureToPB(failure)}, nil
+		}
+		return nil, serrors.WrapStr("renewing COLIBRI reservation", err)
+	}
+	pb, err := s.colibriReservationToPB(rsv)
+	if err != nil {
+		return nil, err
+	}
+	s.colibriOwners.add(&rsv.ID, host, rsv.Token.ExpirationTick.ToTime())
+	return &sdpb.ColibriRenewResponse{Reservation: pb}, nil
+}
+
+// ColibriCleanup removes an index, and all the previous ones, of a COLIBRI e2e reservation.
+// Only the host that set up the reservation through this daemon can clean it up.
+func (s *DaemonServer) ColibriCleanup(ctx context.Context,
+	req *sdpb.ColibriCleanupRequest) (*sdpb.ColibriCleanupResponse, error) {
+
+	if s.Colibri == nil {
+		return nil, serrors.New("COLIBRI not supported")
+	}
+	id, err := reservation.E2EIDFromRaw(req.Id)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing reservation ID", err)
+	}
+	idx := reservation.IndexNumber(req.Index)
+	if err := idx.Validate(); err != nil {
+		return nil, err
+	}
+	host, err := colgrpc.RequestingHost(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.colibriOwners.check(id, host, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.Colibri.CleanupE2E(ctx, id, idx); err != nil {
+		return nil, serrors.WrapStr("cleaning up COLIBRI reservation", err)
+	}
+	return &sdpb.ColibriCleanupResponse{}, nil
+}
+
+// ColibriList lists the COLIBRI reservations stored in the local AS.
+func (s *DaemonServer) ColibriList(ctx context.Context,
+	_ *sdpb.ColibriListRequest) (*sdpb.ColibriListResponse, error) {
+
+	if s.ColibriLister == nil {
+		return nil, serrors.New("COLIBRI not supported")
+	}
+	rsvs, err := s.ColibriLister.ListReservations(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("listing COLIBRI reservations", err)
+	}
+	return &sdpb.ColibriListResponse{Reservations: rsvs}, nil
+}
+
+// colibriReservationToPB converts the reservation into its protobuf representation. The path
+// contains the COLIBRI data plane path and can be used as is to send packets.
+func (s *DaemonServer) colibriReservationToPB(
+	rsv *colgrpc.E2EReservation) (*sdpb.ColibriReservation, error) {
+
+	if len(rsv.Path) < 2 || len(rsv.Token.HopFields) != len(rsv.Path) {
+		return nil, serrors.New("inconsistent reservation path and token",
+			"path_len", len(rsv.Path), "hop_fields", len(rsv.Token.HopFields))
+	}
+	dp := base.DataPlanePath(rsv.ID.Suffix[:], false, &rsv.Token)
+	raw := make([]byte, dp.Len())
+	if err := dp.SerializeTo(raw); err != nil {
+		return nil, serrors.WrapStr("serializing COLIBRI path", err)
+	}
+	topo := s.TopoProvider.Get()
+	egress := common.IFIDType(rsv.Path[0].Egress)
+	nextHop, ok := topo.UnderlayNextHop(egress)
+	if !ok {
+		return nil, serrors.New("unknown first interface", "ifid", egress)
+	}
+	var interfaces []*sdpb.PathInterface
+	for _, step := range rsv.Path {
+		if step.Ingress != 0 {
+			interfaces = append(interfaces, &sdpb.PathInterface{
+				Id:    uint64(step.Ingress),
+				IsdAs: uint64(step.IA.IAInt()),
+			})
+		}
+		if step.Egress != 0 {
+			interfaces = append(interfaces, &sdpb.PathInterface{
+				Id:    uint64(step.Egress),
+				IsdAs: uint64(step.IA.IAInt()),
+			})
+		}
+	}
+	return &sdpb.ColibriReservation{
+		Id:    rsv.ID.ToRaw(),
+		Index: uint32(rsv.Index),
+		Token: rsv.Token.ToRaw(),
+		Path: &sdpb.Path{
+			Raw: raw,
+			Interface: &sdpb.Interface{
+				Address: &sdpb.Underlay{Address: nextHop.String()},
+			},
+			Interfaces: interfaces,
+			Mtu:        uint32(topo.MTU()),
+			Expiration: &timestamppb.Timestamp{
+				Seconds: rsv.Token.ExpirationTick.ToTime().Unix(),
+			},
+		},
+	}, nil
+}
+
+// colibriOwnersGCInterval is the minimum time between two removals of the expired owners.
+const colibriOwnersGCInterval = time.Minute
+
+// colibriOwners records the hosts that set up the COLIBRI e2e reservations through the daemon.
+// The zero value is ready to use.
+type colibriOwners struct {
+	mtx    sync.Mutex
+	owners map[reservation.E2EID]colibriOwner
+	nextGC time.Time
+}
+
+type colibriOwner struct {
+	host       net.IP
+	expiration time.Time
+}
+
+// add records the host as the owner of the reservation until the expiration time. The
+// owners of the expired reservations are forgotten at most once per colibriOwnersGCInterval.
+func (o *colibriOwners) add(id *reservation.E2EID, host net.IP, expiration time.Time) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if o.owners == nil {
+		o.owners = make(map[reservation.E2EID]colibriOwner)
+	}
+	if now := time.Now(); now.After(o.nextGC) {
+		for k, owner := range o.owners {
+			if owner.expiration.Before(now) {
+				delete(o.owners, k)
+			}
+		}
+		o.nextGC = now.Add(colibriOwnersGCInterval)
+	}
+	if owner, ok := o.owners[*id]; ok && owner.expiration.After(expiration) {
+		expiration = owner.expiration
+	}
+	o.owners[*id] = colibriOwner{host: host, expiration: expiration}
+}
+
+// check returns an error if the host did not set up the reservation through the daemon,
+// or if the reservation has expired.
+func (o *colibriOwners) check(id *reservation.E2EID, host net.IP, now time.Time) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	owner, ok := o.owners[*id]
+	if !ok || owner.expiration.Before(now) {
+		return serrors.New("unknown COLIBRI reservation", "id", id)
+	}
+	if !owner.host.Equal(host) {
+		return serrors.New("COLIBRI reservation not owned by the requesting host", "id", id,
+			"host", host)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestColibriOwners(t *testing.T) {
+	id, err := reservation.NewE2EID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("beefcafe000000000000"))
+	require.NoError(t, err)
+	other, err := reservation.NewE2EID(xtest.MustParseAS("ff00:0:1"),
+		xtest.MustParseHexString("beefcafe000000000001"))
+	require.NoError(t, err)
+	owner := net.ParseIP("10.0.0.1")
+	now := time.Now()
+
+	var owners colibriOwners
+	owners.add(id, owner, now.Add(time.Minute))
+	require.NoError(t, owners.check(id, net.ParseIP("10.0.0.1"), now))
+	// applications on other hosts cannot renew or clean up the reservation.
+	require.Error(t, owners.check(id, net.ParseIP("10.0.0.2"), now))
+	// nor can they use reservations not set up through the daemon.
+	require.Error(t, owners.check(other, owner, now))
+	// once expired, the reservation cannot be renewed anymore.
+	require.Error(t, owners.check(id, owner, now.Add(2*time.Minute)))
+}
@@ -22,9 +22,11 @@ import (
 
 	durationpb "github.com/golang/protobuf/ptypes/duration"
 	timestamppb "github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/gopacket"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/sync/singleflight"
 
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	ctrl_drkey "github.com/scionproto/scion/go/lib/ctrl/drkey"
@@ -35,6 +37,7 @@ import (
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/util"
@@ -46,16 +49,25 @@ import (
 
 // DaemonServer handles gRPC requests to the SCION daemon.
 type DaemonServer struct {
-	Fetcher      fetcher.Fetcher
-	TopoProvider topology.Provider
-	RevCache     revcache.RevCache
-	ASInspector  trust.Inspector
-	DRKeyStore   drkeystorage.ClientStore
+	Fetcher       fetcher.Fetcher
+	TopoProvider  topology.Provider
+	RevCache      revcache.RevCache
+	ASInspector   trust.Inspector
+	DRKeyStore    drkeystorage.ClientStore
+	Colibri       colgrpc.E2EInitiator
+	ColibriLister colgrpc.ReservationLister
+	// SCMPAuthPolicy defines which interface down notifications are accepted. With
+	// snet.SCMPAuthRequire, only the notifications carrying the authenticated SCMP message
+	// that reported the interface down are accepted.
+	SCMPAuthPolicy snet.SCMPAuthPolicy
+	// SCMPVerifier verifies the SCMP messages carried by the notifications.
+	SCMPVerifier *snet.SCMPVerifier
 
 	Metrics Metrics
 
 	foregroundPathDedupe singleflight.Group
 	backgroundPathDedupe singleflight.Group
+	colibriOwners        colibriOwners
 }
 
 // Paths serves the paths request.
@@ -318,6 +330,14 @@ func (s *DaemonServer) NotifyInterfaceDown(ctx context.Context,
 func (s *DaemonServer) notifyInterfaceDown(ctx context.Context,
 	req *sdpb.NotifyInterfaceDownRequest) (*sdpb.NotifyInterfaceDownResponse, error) {
 
+	if err := s.verifyInterfaceDown(ctx, req); err != nil {
+		log.FromCtx(ctx).Info("Rejecting interface down notification", "err", err,
+			"isd_as", addr.IAInt(req.IsdAs).IA(), "id", req.Id)
+		return nil, metricsError{
+			err:    serrors.WrapStr("verifying notification", err),
+			result: prom.ErrVerify,
+		}
+	}
 	revInfo := &path_mgmt.RevInfo{
 		RawIsdas:     addr.IAInt(req.IsdAs),
 		IfID:         common.IFIDType(req.Id),
@@ -344,6 +364,68 @@ func (s *DaemonServer) notifyInterfaceDown(ctx context.Context,
 	return &sdpb.NotifyInterfaceDownResponse{}, nil
 }
 
+// verifyInterfaceDown checks the SCMP message carried by the notification, as defined by the
+// SCMP authentication policy. The message must report the interface of the notification, and
+// be authenticated by the AS of the interface.
+func (s *DaemonServer) verifyInterfaceDown(ctx context.Context,
+	req *sdpb.NotifyInterfaceDownRequest) error {
+
+	if s.SCMPAuthPolicy == snet.SCMPAuthIgnore {
+		return nil
+	}
+	if req.ScmpAuthentication == nil {
+		if s.SCMPAuthPolicy == snet.SCMPAuthRequire {
+			return snet.ErrNoSCMPAuthentication
+		}
+		return nil
+	}
+	auth := &snet.SCMPAuthentication{
+		SrcIA:   addr.IAInt(req.ScmpAuthentication.SourceIsdAs).IA(),
+		DstIA:   s.TopoProvider.Get().IA(),
+		DstHost: addr.HostFromIP(req.ScmpAuthentication.DestinationHost),
+		Message: req.ScmpAuthentication.Message,
+		OptType: slayers.OptionType(req.ScmpAuthentication.OptionType),
+		OptData: req.ScmpAuthentication.OptionData,
+	}
+	ia, ifID, err := interfaceDownFromSCMP(auth.Message)
+	if err != nil {
+		return serrors.WrapStr("parsing SCMP message", err)
+	}
+	if !ia.Equal(addr.IAInt(req.IsdAs).IA()) || ifID != req.Id || !ia.Equal(auth.SrcIA) {
+		return serrors.New("SCMP message does not match notification",
+			"scmp_src", auth.SrcIA, "scmp_isd_as", ia, "scmp_id", ifID)
+	}
+	if s.SCMPVerifier == nil {
+		return serrors.New("no SCMP verifier")
+	}
+	return s.SCMPVerifier.Verify(ctx, auth)
+}
+
+// interfaceDownFromSCMP returns the AS and the interface reported down by the raw SCMP message.
+func interfaceDownFromSCMP(raw []byte) (addr.IA, uint64, error) {
+	var scmp slayers.SCMP
+	if err := scmp.DecodeFromBytes(raw, gopacket.NilDecodeFeedback); err != nil {
+		return addr.IA{}, 0, err
+	}
+	switch scmp.TypeCode.Type() {
+	case slayers.SCMPTypeExternalInterfaceDown:
+		var msg slayers.SCMPExternalInterfaceDown
+		if err := msg.DecodeFromBytes(scmp.Payload, gopacket.NilDecodeFeedback); err != nil {
+			return addr.IA{}, 0, err
+		}
+		return msg.IA, msg.IfID, nil
+	case slayers.SCMPTypeInternalConnectivityDown:
+		var msg slayers.SCMPInternalConnectivityDown
+		if err := msg.DecodeFromBytes(scmp.Payload, gopacket.NilDecodeFeedback); err != nil {
+			return addr.IA{}, 0, err
+		}
+		return msg.IA, msg.Egress, nil
+	default:
+		return addr.IA{}, 0, serrors.New("not an interface down message",
+			"type", scmp.TypeCode)
+	}
+}
+
 // DRKeyLvl2 serves a Lvl2Key request
 func (s *DaemonServer) DRKeyLvl2(ctx context.Context,
 	req *sdpb.DRKeyLvl2Request) (*sdpb.DRKeyLvl2Response, error) {
@@ -21,10 +21,13 @@ import (
 	"net"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 
+	colgrpc "github.com/scionproto/scion/go/cs/reservationstorage/grpc"
+	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/drkeystorage"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
@@ -35,6 +38,8 @@ import (
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
 	"github.com/scionproto/scion/go/lib/topology"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	"github.com/scionproto/scion/go/pkg/sciond/fetcher"
@@ -104,22 +109,30 @@ func TrustEngine(cfgDir string, db trust.DB, dialer libgrpc.Dialer) (trust.Engin
 
 // ServerConfig is the configuration for the daemon API server.
 type ServerConfig struct {
-	Fetcher      fetcher.Fetcher
-	PathDB       pathdb.PathDB
-	RevCache     revcache.RevCache
-	Engine       trust.Engine
-	TopoProvider topology.Provider
-	DRKeyStore   drkeystorage.ClientStore
+	Fetcher       fetcher.Fetcher
+	PathDB        pathdb.PathDB
+	RevCache      revcache.RevCache
+	Engine        trust.Engine
+	TopoProvider  topology.Provider
+	DRKeyStore    drkeystorage.ClientStore
+	Colibri       colgrpc.E2EInitiator
+	ColibriLister colgrpc.ReservationLister
+	// SCMPAuthPolicy defines which interface down notifications are accepted.
+	SCMPAuthPolicy snet.SCMPAuthPolicy
 }
 
 // NewServer constructs a daemon API server.
 func NewServer(cfg ServerConfig) *servers.DaemonServer {
 	return &servers.DaemonServer{
-		Fetcher:      cfg.Fetcher,
-		ASInspector:  cfg.Engine.Inspector,
-		RevCache:     cfg.RevCache,
-		TopoProvider: cfg.TopoProvider,
-		DRKeyStore:   cfg.DRKeyStore,
+		Fetcher:        cfg.Fetcher,
+		ASInspector:    cfg.Engine.Inspector,
+		RevCache:       cfg.RevCache,
+		TopoProvider:   cfg.TopoProvider,
+		DRKeyStore:     cfg.DRKeyStore,
+		Colibri:        cfg.Colibri,
+		ColibriLister:  cfg.ColibriLister,
+		SCMPAuthPolicy: cfg.SCMPAuthPolicy,
+		SCMPVerifier:   newSCMPVerifier(cfg),
 		Metrics: servers.Metrics{
 			PathsRequests: servers.RequestMetrics{
 				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
@@ -216,3 +229,29 @@ func APIAddress(listen string) string {
 		return listen
 	}
 }
+
+// newSCMPVerifier returns the verifier of the SCMP messages carried by the interface down
+// notifications. The DRKey extensions can only be verified if the DRKey store is set.
+func newSCMPVerifier(cfg ServerConfig) *snet.SCMPVerifier {
+	v := &snet.SCMPVerifier{
+		HashTree: &scmp_auth.HashTreeVerifier{
+			Keys:   trust.Verifier{Engine: cfg.Engine},
+			MaxAge: scmp_auth.DefaultHashTreeMaxAge,
+		},
+	}
+	if cfg.DRKeyStore != nil {
+		v.Keys = lvl2KeyFetcher{store: cfg.DRKeyStore}
+	}
+	return v
+}
+
+// lvl2KeyFetcher fetches the level 2 DRKeys from the DRKey store of the daemon.
+type lvl2KeyFetcher struct {
+	store drkeystorage.ClientStore
+}
+
+func (f lvl2KeyFetcher) DRKeyGetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	return f.store.GetLvl2Key(ctx, meta, valTime)
+}
@@ -15,6 +15,10 @@
 package storage
 
 const sample = `
+# The database backend, either "sqlite" or "memory". Only the DRKey databases
+# support the memory backend, which ignores the connection. (default "sqlite")
+backend = "sqlite"
+
 # Connection for the database.
 connection = "%s"
 
@@ -25,4 +29,9 @@ max_open_conns = 0
 # The maximum number of idle connections to the database. In case of 0,
 # the limit is not set and uses the go default. (default 0)
 max_idle_conns = 0
+
+# The maximum number of entries of a memory backend. When it is reached, the
+# entries expiring first are evicted. In case of 0, the backend default is used.
+# (default 0)
+max_entries = 0
 `
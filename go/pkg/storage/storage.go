@@ -21,9 +21,12 @@ import (
 
 	"github.com/scionproto/scion/go/cs/beacon"
 	sqlitebeacondb "github.com/scionproto/scion/go/cs/beacon/beacondbsqlite"
+	sqlitecolibridb "github.com/scionproto/scion/go/cs/reservation/sqlite"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/drkey/drkeydbmem"
 	"github.com/scionproto/scion/go/lib/drkey/drkeydbsqlite"
 	"github.com/scionproto/scion/go/lib/infra/modules/db"
 	"github.com/scionproto/scion/go/lib/log"
@@ -31,6 +34,7 @@ import (
 	sqlitepathdb "github.com/scionproto/scion/go/lib/pathdb/sqlite"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/revcache/memrevcache"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/trust"
 	"github.com/scionproto/scion/go/pkg/trust/renewal"
 	sqliterenewaldb "github.com/scionproto/scion/go/pkg/trust/renewal/sqlite"
@@ -43,30 +47,42 @@ type Backend string
 const (
 	// BackendSqlite indicates an sqlite backend.
 	BackendSqlite Backend = "sqlite"
+	// BackendMemory indicates an in-memory backend. Only the DRKey databases support it.
+	BackendMemory Backend = "memory"
 	// DefaultPath indicates the default connection string for a generic database.
-	DefaultPath        = "/share/scion.db"
-	DefaultTrustDBPath = "/share/data/%s.trust.db"
-	DefaultPathDBPath  = "/share/cache/%s.path.db"
-	DefaultDRKeyDBPath = "/share/cache/%s.drkey.db"
+	DefaultPath          = "/share/scion.db"
+	DefaultTrustDBPath   = "/share/data/%s.trust.db"
+	DefaultPathDBPath    = "/share/cache/%s.path.db"
+	DefaultDRKeyDBPath   = "/share/cache/%s.drkey.db"
+	DefaultColibriDBPath = "/share/cache/%s.colibri.db"
 )
 
 // Default samples for various databases.
 var (
 	SampleBeaconDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: "/share/cache/%s.beacon.db",
 	}
 	SamplePathDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: DefaultPathDBPath,
 	}
 	SampleRenewalDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: "/share/data/trustdb/%s.renewal.db",
 	}
 	SampleTrustDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: DefaultTrustDBPath,
 	}
 	SampleDRKeyDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: DefaultDRKeyDBPath,
 	}
+	SampleColibriDB = DBConfig{
+		Backend:    BackendSqlite,
+		Connection: DefaultColibriDBPath,
+	}
 )
 
 // SetID returns a clone of the configuration that has the ID set on the connection string.
@@ -79,9 +95,35 @@ var _ (config.Config) = (*DBConfig)(nil)
 
 // DBConfig is the configuration for the connection to a database.
 type DBConfig struct {
-	Connection   string `toml:"connection,omitempty"`
-	MaxOpenConns int    `toml:"max_open_conns,omitempty"`
-	MaxIdleConns int    `toml:"max_idle_conns,omitempty"`
+	// Backend is the database backend. The empty value means sqlite.
+	Backend      Backend `toml:"backend,omitempty"`
+	Connection   string  `toml:"connection,omitempty"`
+	MaxOpenConns int     `toml:"max_open_conns,omitempty"`
+	MaxIdleConns int     `toml:"max_idle_conns,omitempty"`
+	// MaxEntries is the maximum number of entries of a memory backend. Limits of 0 mean the
+	// backend default will be used.
+	MaxEntries int `toml:"max_entries,omitempty"`
+}
+
+// Configured returns true if a database is configured, i.e. either the backend is in memory or
+// there is a connection string.
+func (cfg *DBConfig) Configured() bool {
+	return cfg.Backend == BackendMemory || cfg.Connection != ""
+}
+
+func (cfg *DBConfig) backend() Backend {
+	if cfg.Backend == "" {
+		return BackendSqlite
+	}
+	return cfg.Backend
+}
+
+// requireSqlite returns an error if the configured backend is not sqlite.
+func (cfg *DBConfig) requireSqlite(name string) error {
+	if b := cfg.backend(); b != BackendSqlite {
+		return serrors.New("unsupported backend", "db", name, "backend", b)
+	}
+	return nil
 }
 
 type writeDefault struct {
@@ -117,6 +159,14 @@ func (cfg *DBConfig) InitDefaults() {
 }
 
 func (cfg *DBConfig) Validate() error {
+	switch cfg.backend() {
+	case BackendSqlite, BackendMemory:
+	default:
+		return serrors.New("unknown backend", "backend", cfg.Backend)
+	}
+	if cfg.MaxEntries < 0 {
+		return serrors.New("max_entries must not be negative", "max_entries", cfg.MaxEntries)
+	}
 	return nil
 }
 
@@ -131,6 +181,9 @@ func (cfg *DBConfig) ConfigName() string {
 }
 
 func NewBeaconStorage(c DBConfig, ia addr.IA) (beacon.DB, error) {
+	if err := c.requireSqlite("BeaconDB"); err != nil {
+		return nil, err
+	}
 	log.Info("Connecting BeaconDB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := sqlitebeacondb.New(c.Connection, ia)
 	if err != nil {
@@ -141,6 +194,9 @@ func NewBeaconStorage(c DBConfig, ia addr.IA) (beacon.DB, error) {
 }
 
 func NewPathStorage(c DBConfig) (pathdb.PathDB, error) {
+	if err := c.requireSqlite("PathDB"); err != nil {
+		return nil, err
+	}
 	log.Info("Connecting PathDB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := sqlitepathdb.New(c.Connection)
 	if err != nil {
@@ -155,6 +211,9 @@ func NewRevocationStorage() revcache.RevCache {
 }
 
 func NewTrustStorage(c DBConfig) (trust.DB, error) {
+	if err := c.requireSqlite("TrustDB"); err != nil {
+		return nil, err
+	}
 	log.Info("Connecting TrustDB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := sqlitetrustdb.New(c.Connection)
 	if err != nil {
@@ -165,6 +224,9 @@ func NewTrustStorage(c DBConfig) (trust.DB, error) {
 }
 
 func NewRenewalStorage(c DBConfig) (renewal.DB, error) {
+	if err := c.requireSqlite("RenewalDB"); err != nil {
+		return nil, err
+	}
 	log.Info("Connecting RenewalDB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := sqliterenewaldb.New(c.Connection)
 	if err != nil {
@@ -175,7 +237,11 @@ func NewRenewalStorage(c DBConfig) (renewal.DB, error) {
 }
 
 func NewDRKeyLvl1Storage(c DBConfig) (drkey.Lvl1DB, error) {
-	log.Info("Connecting DRKeyDB", "	", BackendSqlite, "connection", c.Connection)
+	if c.backend() == BackendMemory {
+		log.Info("Creating DRKeyDB", "backend", BackendMemory, "max_entries", c.MaxEntries)
+		return drkeydbmem.NewLvl1Backend(c.MaxEntries), nil
+	}
+	log.Info("Connecting DRKeyDB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := drkeydbsqlite.NewLvl1Backend(c.Connection)
 	if err != nil {
 		return nil, err
@@ -185,7 +251,11 @@ func NewDRKeyLvl1Storage(c DBConfig) (drkey.Lvl1DB, error) {
 }
 
 func NewDRKeyLvl2Storage(c DBConfig) (drkey.Lvl2DB, error) {
-	log.Info("Connecting DRKeyDB", "	", BackendSqlite, "connection", c.Connection)
+	if c.backend() == BackendMemory {
+		log.Info("Creating DRKeyDB", "backend", BackendMemory, "max_entries", c.MaxEntries)
+		return drkeydbmem.NewLvl2Backend(c.MaxEntries), nil
+	}
+	log.Info("Connecting DRKeyDB", "backend", BackendSqlite, "connection", c.Connection)
 	db, err := drkeydbsqlite.NewLvl2Backend(c.Connection)
 	if err != nil {
 		return nil, err
@@ -193,3 +263,16 @@ func NewDRKeyLvl2Storage(c DBConfig) (drkey.Lvl2DB, error) {
 	SetConnLimits(db, c)
 	return db, nil
 }
+
+func NewColibriStorage(c DBConfig) (backend.DB, error) {
+	if err := c.requireSqlite("ColibriDB"); err != nil {
+		return nil, err
+	}
+	log.Info("Connecting ColibriDB", "backend", BackendSqlite, "connection", c.Connection)
+	db, err := sqlitecolibridb.New(c.Connection)
+	if err != nil {
+		return nil, err
+	}
+	SetConnLimits(db, c)
+	return db, nil
+}
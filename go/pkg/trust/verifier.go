@@ -16,6 +16,7 @@ package trust
 
 import (
 	"context"
+	"crypto"
 	"crypto/x509"
 	"fmt"
 	"math/rand"
@@ -113,6 +114,35 @@ func (v Verifier) Verify(ctx context.Context, signedMsg *cryptopb.SignedMessage,
 	return nil, serrors.New("no chain in database can verify signature")
 }
 
+// ASKeys returns the public keys of the verified AS certificates of the AS with the given
+// subject key ID that are valid at the given date. It is used to verify signatures that do
+// not carry a signed message header, e.g., the root signatures of SCMP hash tree extensions.
+func (v Verifier) ASKeys(ctx context.Context, ia addr.IA, subjectKeyID []byte,
+	date time.Time) ([]crypto.PublicKey, error) {
+
+	if !v.BoundIA.IsZero() && !v.BoundIA.Equal(ia) {
+		return nil, serrors.New("does not match bound ISD-AS", "expected", v.BoundIA, "actual", ia)
+	}
+	if v.Engine == nil {
+		return nil, serrors.New("nil engine that provides cert chains")
+	}
+	chains, err := v.getChains(ctx,
+		ChainQuery{
+			IA:           ia,
+			SubjectKeyID: subjectKeyID,
+			Date:         date,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]crypto.PublicKey, 0, len(chains))
+	for _, c := range chains {
+		keys = append(keys, c[0].PublicKey)
+	}
+	return keys, nil
+}
+
 func (v *Verifier) notifyTRC(ctx context.Context, id cppki.TRCID) error {
 	key := fmt.Sprintf("notify-%s", id)
 	_, ok := v.cacheGet(key, "notify_trc")
@@ -32,6 +32,7 @@ import (
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/scrypto/signed"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/spse/scmp_auth"
 	"github.com/scionproto/scion/go/lib/xtest"
 	cryptopb "github.com/scionproto/scion/go/pkg/proto/crypto"
 	"github.com/scionproto/scion/go/pkg/trust"
@@ -224,3 +225,86 @@ func validSignS(t *testing.T, msg []byte, rawIA string,
 	require.NoError(t, err)
 	return meta
 }
+
+func TestVerifierASKeys(t *testing.T) {
+	if *update {
+		t.Skip("test crypto is being updated")
+	}
+
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	chains := [][]*x509.Certificate{xtest.LoadChain(t,
+		filepath.Join(goldenDir, "ISD1/ASff00_0_110/crypto/as/ISD1-ASff00_0_110.pem"))}
+	key := loadKey(t, filepath.Join(goldenDir,
+		"ISD1/ASff00_0_110/crypto/as/cp-as.key"))
+	skid, err := cppki.SubjectKeyID(key.Public())
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		provider   func(mctrl *gomock.Controller) trust.Provider
+		boundIA    addr.IA
+		assertFunc assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			provider: func(mctrl *gomock.Controller) trust.Provider {
+				p := mock_trust.NewMockProvider(mctrl)
+				// The root is verified once for the whole batch.
+				p.EXPECT().GetChains(ctxMatcher{},
+					chainQueryMatcher{ia: ia, skid: skid},
+					trust.OptionsMatcher{},
+				).Return(chains, nil).Times(1)
+				return p
+			},
+			assertFunc: assert.NoError,
+		},
+		"invalid boundIA missmatch": {
+			provider:   func(mctrl *gomock.Controller) trust.Provider { return nil },
+			boundIA:    xtest.MustParseIA("1-ff00:0:210"),
+			assertFunc: assert.Error,
+		},
+		"invalid provider nil": {
+			provider:   func(mctrl *gomock.Controller) trust.Provider { return nil },
+			assertFunc: assert.Error,
+		},
+		"invalid provider errors": {
+			provider: func(mctrl *gomock.Controller) trust.Provider {
+				p := mock_trust.NewMockProvider(mctrl)
+				p.EXPECT().GetChains(ctxMatcher{}, gomock.Any(), trust.OptionsMatcher{}).
+					AnyTimes().Return(nil, serrors.New("internal"))
+				return p
+			},
+			assertFunc: assert.Error,
+		},
+		"invalid engine, gives zero chains": {
+			provider: func(mctrl *gomock.Controller) trust.Provider {
+				p := mock_trust.NewMockProvider(mctrl)
+				p.EXPECT().GetChains(ctxMatcher{}, gomock.Any(), trust.OptionsMatcher{}).
+					AnyTimes().Return(nil, nil)
+				return p
+			},
+			assertFunc: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			signer := &scmp_auth.HashTreeSigner{Signer: key, SubjectKeyID: skid}
+			msgs := [][]byte{[]byte("scmp 1"), []byte("scmp 2"), []byte("scmp 3")}
+			extns, err := signer.Sign(msgs)
+			require.NoError(t, err)
+
+			v := &scmp_auth.HashTreeVerifier{
+				Keys: trust.Verifier{
+					BoundIA: tc.boundIA,
+					Engine:  tc.provider(mctrl),
+				},
+			}
+			for i, extn := range extns {
+				tc.assertFunc(t, v.Verify(context.Background(), ia, msgs[i], extn))
+			}
+		})
+	}
+}
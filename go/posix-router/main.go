@@ -86,7 +86,9 @@ func run(file string, metrics *router.Metrics) error {
 	wg := new(sync.WaitGroup)
 	dp := &router.Connector{
 		DataPlane: router.DataPlane{
-			Metrics: metrics,
+			Metrics:        metrics,
+			ColibriMonitor: newColibriMonitor(fileConfig.Colibri),
+			SCMPAuth:       newSCMPAuthenticator(fileConfig.SCMPAuth, controlConfig),
 		},
 	}
 	iaCtx := &control.IACtx{
@@ -149,6 +151,24 @@ func loadControlConfig(cfg config.Config) (*control.Config, error) {
 	return newConf, nil
 }
 
+func newColibriMonitor(cfg config.Colibri) *router.ReservationMonitor {
+	action := router.OveruseDemote
+	if cfg.OveruseAction == config.OveruseDrop {
+		action = router.OveruseDrop
+	}
+	return router.NewReservationMonitor(action, cfg.Burst.Duration)
+}
+
+func newSCMPAuthenticator(cfg config.SCMPAuth,
+	controlConfig *control.Config) *router.SCMPAuthenticator {
+
+	if !cfg.Enabled {
+		return nil
+	}
+	return router.NewSCMPAuthenticator(controlConfig.MasterKeys.Key0,
+		cfg.EpochDuration.Duration, cfg.Grace())
+}
+
 func setupHTTPHandlers(cfg config.Config) error {
 	statusPages := service.StatusPages{
 		"info":      service.NewInfoHandler(),
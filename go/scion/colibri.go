@@ -0,0 +1,465 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/app"
+)
+
+func newColibri(pather CommandPather) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "colibri",
+		Short: "Inspect the COLIBRI reservations of the local AS and manage E2E ones",
+		Long: `'colibri' inspects the segment and E2E reservations stored in the local AS,
+and sets up, renews and tears down E2E reservations through the local SCION
+Daemon.
+
+Segment reservations can only be inspected: the control service of the AS
+that initiates them sets them up, renews and tears them down according to its
+configuration.`,
+		Args: cobra.NoArgs,
+	}
+	cmd.AddCommand(
+		newColibriList(pather),
+		newColibriShow(pather),
+		newColibriRequest(pather),
+		newColibriTeardown(pather),
+	)
+	return cmd
+}
+
+// colibriFlags are the flags common to all colibri subcommands.
+type colibriFlags struct {
+	sciond  string
+	timeout time.Duration
+	json    bool
+}
+
+func (f *colibriFlags) register(cmd *cobra.Command, withJSON bool) {
+	cmd.Flags().StringVar(&f.sciond, "sciond", sciond.DefaultAPIAddress, "SCION Deamon address")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 5*time.Second, "Timeout")
+	if withJSON {
+		cmd.Flags().BoolVarP(&f.json, "json", "j", false,
+			"Write the output as machine readable json")
+	}
+}
+
+func (f *colibriFlags) connect(ctx context.Context) (sciond.Connector, error) {
+	conn, err := sciond.NewService(f.sciond).Connect(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("connecting to SCIOND", err)
+	}
+	return conn, nil
+}
+
+func newColibriList(pather CommandPather) *cobra.Command {
+	var flags colibriFlags
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the segment and E2E reservations stored in the local AS",
+		Example: fmt.Sprintf(`  %[1]s colibri list
+  %[1]s colibri list --json`, pather.CommandPath()),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			ctx, cancel := context.WithTimeout(context.Background(), flags.timeout)
+			defer cancel()
+			rsvs, err := listColibriRsvs(ctx, &flags)
+			if err != nil {
+				return err
+			}
+			if flags.json {
+				return writeJSON(os.Stdout, rsvs)
+			}
+			rsvs.Human(os.Stdout, false)
+			return nil
+		},
+	}
+	flags.register(cmd, true)
+	return cmd
+}
+
+func newColibriShow(pather CommandPather) *cobra.Command {
+	var flags colibriFlags
+	var cmd = &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a segment or E2E reservation stored in the local AS",
+		Example: fmt.Sprintf(`  %[1]s colibri show ff00:0:110-00000001
+  %[1]s colibri show ff00:0:110-0123456789abcdef0123 --json`, pather.CommandPath()),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			segID, e2eID, err := parseColibriID(args[0])
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+			ctx, cancel := context.WithTimeout(context.Background(), flags.timeout)
+			defer cancel()
+			rsvs, err := listColibriRsvs(ctx, &flags)
+			if err != nil {
+				return err
+			}
+			rsvs = rsvs.filter(segID, e2eID)
+			if len(rsvs.Segments) == 0 && len(rsvs.E2E) == 0 {
+				return app.WithExitCode(serrors.New("reservation not found", "id", args[0]), 1)
+			}
+			if flags.json {
+				return writeJSON(os.Stdout, rsvs)
+			}
+			rsvs.Human(os.Stdout, true)
+			return nil
+		},
+	}
+	flags.register(cmd, true)
+	return cmd
+}
+
+func newColibriRequest(pather CommandPather) *cobra.Command {
+	var flags colibriFlags
+	var bw uint8
+	var renew string
+	var cmd = &cobra.Command{
+		Use:   "request <dst>",
+		Short: "Request a new E2E reservation, or a new index of an existing one",
+		Example: fmt.Sprintf(`  %[1]s colibri request 1-ff00:0:112 --bw 5
+  %[1]s colibri request 1-ff00:0:112 --bw 7 --renew ff00:0:110-0123456789abcdef0123`,
+			pather.CommandPath()),
+		Long: `'request' asks the local SCION Daemon to set up an E2E reservation to the
+destination AS, stitching the segment reservations available in the local AS.
+With --renew, it requests a new index of an E2E reservation set up from this
+host through the same SCION Daemon instead.
+
+If an AS in the path rejects the request, the failing AS and the maximum
+bandwidth class it could have granted are displayed, and the command exits
+with code 1.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dst, err := addr.IAFromString(args[0])
+			if err != nil {
+				return serrors.WrapStr("invalid destination ISD-AS", err)
+			}
+			bwCls := reservation.BWCls(bw)
+			if err := bwCls.Validate(); err != nil {
+				return err
+			}
+			var id *reservation.E2EID
+			if renew != "" {
+				if id, err = reservation.E2EIDFromString(renew); err != nil {
+					return serrors.WrapStr("invalid E2E reservation ID", err)
+				}
+			}
+			cmd.SilenceUsage = true
+			ctx, cancel := context.WithTimeout(context.Background(), flags.timeout)
+			defer cancel()
+			conn, err := flags.connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer conn.Close(ctx)
+			var rsv *sciond.ColibriReservation
+			if id == nil {
+				rsv, err = conn.ColibriSetupRsv(ctx, dst, bwCls)
+			} else {
+				rsv, err = conn.ColibriRenewRsv(ctx, id, bwCls)
+			}
+			var failure *reservation.Failure
+			if errors.As(err, &failure) {
+				return app.WithExitCode(failure, 1)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Reservation %s index %d, bw class %d (%d kbps), "+
+				"expires %s\n", &rsv.ID, rsv.Index, rsv.Token.BWCls,
+				rsv.Token.BWCls.ToKbps(), rsv.Token.ExpirationTick.ToTime())
+			fmt.Fprintf(os.Stdout, "Path: %s\n",
+				app.ColorPath(rsv.Path, app.WithDisableColor(true)))
+			return nil
+		},
+	}
+	flags.register(cmd, false)
+	cmd.Flags().Uint8Var(&bw, "bw", 1, "Requested bandwidth class")
+	cmd.Flags().StringVar(&renew, "renew", "",
+		"ID of the E2E reservation to renew with a new index")
+	return cmd
+}
+
+func newColibriTeardown(pather CommandPather) *cobra.Command {
+	var flags colibriFlags
+	var cmd = &cobra.Command{
+		Use:   "teardown <id> [index]",
+		Short: "Remove an index of an E2E reservation, and all the indices before it",
+		Example: fmt.Sprintf(`  %[1]s colibri teardown ff00:0:110-0123456789abcdef0123
+  %[1]s colibri teardown ff00:0:110-0123456789abcdef0123 2`, pather.CommandPath()),
+		Long: `'teardown' removes an index of an E2E reservation, and all the indices before
+it, in all the ASes of the reservation path. If no index is specified, the
+newest one is removed, tearing down the reservation.
+
+Only the E2E reservations set up from this host through the same SCION Daemon
+can be torn down. Segment reservations cannot be torn down with this command.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := reservation.E2EIDFromString(args[0])
+			if err != nil {
+				return serrors.WrapStr("invalid E2E reservation ID", err)
+			}
+			var idx *reservation.IndexNumber
+			if len(args) == 2 {
+				n, err := strconv.ParseUint(args[1], 10, 8)
+				if err != nil {
+					return serrors.WrapStr("invalid index", err)
+				}
+				i := reservation.IndexNumber(n)
+				if err := i.Validate(); err != nil {
+					return err
+				}
+				idx = &i
+			}
+			cmd.SilenceUsage = true
+			ctx, cancel := context.WithTimeout(context.Background(), flags.timeout)
+			defer cancel()
+			conn, err := flags.connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer conn.Close(ctx)
+			if idx == nil {
+				if idx, err = lastE2EIndex(ctx, conn, id); err != nil {
+					return err
+				}
+			}
+			if err := conn.ColibriCleanupRsv(ctx, id, *idx); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Removed index %d of reservation %s\n", *idx, id)
+			return nil
+		},
+	}
+	flags.register(cmd, false)
+	return cmd
+}
+
+func listColibriRsvs(ctx context.Context, flags *colibriFlags) (*colibriRsvs, error) {
+	conn, err := flags.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(ctx)
+	rsvs, err := conn.ColibriListRsvs(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("listing COLIBRI reservations", err)
+	}
+	return newColibriRsvs(rsvs), nil
+}
+
+// lastE2EIndex returns the newest index of the e2e reservation.
+func lastE2EIndex(ctx context.Context, conn sciond.Connector, id *reservation.E2EID) (
+	*reservation.IndexNumber, error) {
+
+	rsvs, err := conn.ColibriListRsvs(ctx)
+	if err != nil {
+		return nil, serrors.WrapStr("listing COLIBRI reservations", err)
+	}
+	for _, rsv := range rsvs.E2E {
+		if rsv.ID != *id {
+			continue
+		}
+		if len(rsv.Indices) == 0 {
+			break
+		}
+		return &rsv.Indices[len(rsv.Indices)-1].Idx, nil
+	}
+	return nil, serrors.New("reservation without indices", "id", id)
+}
+
+// parseColibriID parses the ID of either a segment or an e2e reservation.
+func parseColibriID(s string) (*reservation.SegmentID, *reservation.E2EID, error) {
+	if id, err := reservation.SegmentIDFromString(s); err == nil {
+		return id, nil, nil
+	}
+	id, err := reservation.E2EIDFromString(s)
+	if err != nil {
+		return nil, nil, serrors.New("invalid reservation ID", "id", s)
+	}
+	return nil, id, nil
+}
+
+// colibriRsvs are the reservations of the local AS, as displayed by the colibri commands.
+type colibriRsvs struct {
+	Segments []colibriSegmentRsv `json:"segments"`
+	E2E      []colibriE2ERsv     `json:"e2e"`
+}
+
+type colibriSegmentRsv struct {
+	ID       string         `json:"id"`
+	BaseID   string         `json:"base_id,omitempty"`
+	PathType string         `json:"path_type"`
+	Ingress  uint16         `json:"ingress"`
+	Egress   uint16         `json:"egress"`
+	Path     string         `json:"path,omitempty"`
+	Indices  []colibriIndex `json:"indices"`
+}
+
+type colibriE2ERsv struct {
+	ID       string         `json:"id"`
+	Segments []string       `json:"segments"`
+	Indices  []colibriIndex `json:"indices"`
+}
+
+type colibriIndex struct {
+	Index      uint8     `json:"index"`
+	State      string    `json:"state,omitempty"`
+	BWCls      uint8     `json:"bw_cls"`
+	BWKbps     uint64    `json:"bw_kbps"`
+	Expiration time.Time `json:"expiration"`
+}
+
+func newColibriRsvs(rsvs *sciond.ColibriReservations) *colibriRsvs {
+	res := &colibriRsvs{
+		Segments: make([]colibriSegmentRsv, 0, len(rsvs.Segments)),
+		E2E:      make([]colibriE2ERsv, 0, len(rsvs.E2E)),
+	}
+	for _, rsv := range rsvs.Segments {
+		seg := colibriSegmentRsv{
+			ID:       rsv.ID.String(),
+			PathType: rsv.PathType.String(),
+			Ingress:  rsv.Ingress,
+			Egress:   rsv.Egress,
+			Path:     colibriPathString(rsv.Path),
+			Indices:  newColibriIndices(rsv.Indices),
+		}
+		if rsv.BaseID != nil {
+			seg.BaseID = rsv.BaseID.String()
+		}
+		res.Segments = append(res.Segments, seg)
+	}
+	for _, rsv := range rsvs.E2E {
+		e2e := colibriE2ERsv{
+			ID:       rsv.ID.String(),
+			Segments: make([]string, len(rsv.Segments)),
+			Indices:  newColibriIndices(rsv.Indices),
+		}
+		for i := range rsv.Segments {
+			e2e.Segments[i] = rsv.Segments[i].String()
+		}
+		res.E2E = append(res.E2E, e2e)
+	}
+	return res
+}
+
+func newColibriIndices(indices []sciond.ColibriIndex) []colibriIndex {
+	res := make([]colibriIndex, len(indices))
+	for i, idx := range indices {
+		res[i] = colibriIndex{
+			Index:      uint8(idx.Idx),
+			State:      idx.State,
+			BWCls:      uint8(idx.AllocBW),
+			BWKbps:     idx.AllocBW.ToKbps(),
+			Expiration: idx.Expiration,
+		}
+	}
+	return res
+}
+
+// colibriPathString formats the reservation path like the SCION paths, e.g.
+// 1-ff00:0:110 1>2 1-ff00:0:111.
+func colibriPathString(steps []sciond.ColibriPathStep) string {
+	if len(steps) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(steps[0].IA.String())
+	for i := 1; i < len(steps); i++ {
+		fmt.Fprintf(&b, " %d>%d %s", steps[i-1].Egress, steps[i].Ingress, steps[i].IA)
+	}
+	return b.String()
+}
+
+// filter returns only the reservation with the given ID.
+func (r *colibriRsvs) filter(segID *reservation.SegmentID,
+	e2eID *reservation.E2EID) *colibriRsvs {
+
+	res := &colibriRsvs{}
+	for _, rsv := range r.Segments {
+		if segID != nil && rsv.ID == segID.String() {
+			res.Segments = append(res.Segments, rsv)
+		}
+	}
+	for _, rsv := range r.E2E {
+		if e2eID != nil && rsv.ID == e2eID.String() {
+			res.E2E = append(res.E2E, rsv)
+		}
+	}
+	return res
+}
+
+// Human writes the reservations in human readable form. Unless all indices are requested,
+// only the newest index of each reservation is written.
+func (r *colibriRsvs) Human(w io.Writer, allIndices bool) {
+	writeIndices := func(indices []colibriIndex) {
+		if !allIndices && len(indices) > 0 {
+			indices = indices[len(indices)-1:]
+		}
+		for _, idx := range indices {
+			state := ""
+			if idx.State != "" {
+				state = idx.State + ", "
+			}
+			fmt.Fprintf(w, "    index %2d: %sbw class %d (%d kbps), expires %s\n",
+				idx.Index, state, idx.BWCls, idx.BWKbps, idx.Expiration)
+		}
+	}
+	fmt.Fprintf(w, "Segment reservations: %d\n", len(r.Segments))
+	for _, rsv := range r.Segments {
+		fmt.Fprintf(w, "  %s %s, interfaces %d>%d, %d indices\n", rsv.ID, rsv.PathType,
+			rsv.Ingress, rsv.Egress, len(rsv.Indices))
+		if rsv.BaseID != "" {
+			fmt.Fprintf(w, "    base: %s\n", rsv.BaseID)
+		}
+		if rsv.Path != "" {
+			fmt.Fprintf(w, "    path: %s\n", rsv.Path)
+		}
+		writeIndices(rsv.Indices)
+	}
+	fmt.Fprintf(w, "E2E reservations: %d\n", len(r.E2E))
+	for _, rsv := range r.E2E {
+		fmt.Fprintf(w, "  %s, %d indices\n", rsv.ID, len(rsv.Indices))
+		fmt.Fprintf(w, "    segments: %s\n", strings.Join(rsv.Segments, ", "))
+		writeIndices(rsv.Indices)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
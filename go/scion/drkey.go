@@ -0,0 +1,274 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/app"
+)
+
+func newDRKey(pather CommandPather) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "drkey",
+		Short: "Fetch and inspect DRKeys through the SCION Daemon",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(
+		newDRKeyLvl2(pather),
+	)
+	return cmd
+}
+
+func newDRKeyLvl2(pather CommandPather) *cobra.Command {
+	var flags struct {
+		sciond      string
+		timeout     time.Duration
+		json        bool
+		keyType     string
+		srcHost     string
+		dstHost     string
+		verifyWith  string
+		expectedMAC string
+	}
+	var cmd = &cobra.Command{
+		Use:   "lvl2 <protocol> <src ISD-AS> <dst ISD-AS>",
+		Short: "Fetch a level 2 DRKey from the SCION Daemon and display its metadata",
+		Example: fmt.Sprintf(`  %[1]s drkey lvl2 scmp 1-ff00:0:110 1-ff00:0:111
+  %[1]s drkey lvl2 piskes 1-ff00:0:110 1-ff00:0:111 --type as2host --dst-host 127.0.0.1
+  %[1]s drkey lvl2 scmp 1-ff00:0:110 1-ff00:0:111 --verify-with test --json`,
+			pather.CommandPath()),
+		Long: `'lvl2' fetches a level 2 DRKey from the local SCION Daemon and displays its
+metadata: protocol, key type, epoch and a SHA-256 hash of the key. The key
+itself is never displayed.
+
+The key types are as2as (default), as2host, which requires --dst-host, and
+host2host, which requires --src-host and --dst-host.
+
+With --verify-with, the AES-CMAC of the given test input is computed with the
+key. Running the command with the same arguments in the source and destination
+AS must result in the same key hash and MAC. With --expected-mac, the command
+exits with code 1 if the computed MAC differs from the expected one.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			meta, err := parseDRKeyLvl2Meta(args, flags.keyType, flags.srcHost,
+				flags.dstHost)
+			if err != nil {
+				return err
+			}
+			var expected []byte
+			if flags.expectedMAC != "" {
+				if flags.verifyWith == "" {
+					return serrors.New("--expected-mac requires --verify-with")
+				}
+				if expected, err = hex.DecodeString(flags.expectedMAC); err != nil {
+					return serrors.WrapStr("invalid expected MAC", err)
+				}
+			}
+			cmd.SilenceUsage = true
+			ctx, cancel := context.WithTimeout(context.Background(), flags.timeout)
+			defer cancel()
+			conn, err := sciond.NewService(flags.sciond).Connect(ctx)
+			if err != nil {
+				return serrors.WrapStr("connecting to SCIOND", err)
+			}
+			defer conn.Close(ctx)
+			key, err := conn.DRKeyGetLvl2Key(ctx, meta, time.Now())
+			if err != nil {
+				return serrors.WrapStr("fetching level 2 key", err)
+			}
+			res, err := newDRKeyLvl2Result(key, flags.verifyWith, expected)
+			if err != nil {
+				return err
+			}
+			if flags.json {
+				err = writeJSON(os.Stdout, res)
+			} else {
+				res.Human(os.Stdout)
+			}
+			if err != nil {
+				return err
+			}
+			if v := res.Verification; v != nil && v.Match != nil && !*v.Match {
+				return app.WithExitCode(serrors.New("MAC mismatch",
+					"expected", v.Expected, "actual", v.MAC), 1)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&flags.sciond, "sciond", sciond.DefaultAPIAddress,
+		"SCION Deamon address")
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", 5*time.Second, "Timeout")
+	cmd.Flags().BoolVarP(&flags.json, "json", "j", false,
+		"Write the output as machine readable json")
+	cmd.Flags().StringVarP(&flags.keyType, "type", "t", "as2as",
+		"Key type (as2as|as2host|host2host)")
+	cmd.Flags().StringVar(&flags.srcHost, "src-host", "", "Source host address")
+	cmd.Flags().StringVar(&flags.dstHost, "dst-host", "", "Destination host address")
+	cmd.Flags().StringVar(&flags.verifyWith, "verify-with", "",
+		"Test input to compute a MAC over with the key")
+	cmd.Flags().StringVar(&flags.expectedMAC, "expected-mac", "",
+		"Expected MAC of the test input, in hex")
+	return cmd
+}
+
+// parseDRKeyLvl2Meta parses the metadata of the requested key from the command arguments.
+func parseDRKeyLvl2Meta(args []string, keyType, srcHost, dstHost string) (drkey.Lvl2Meta,
+	error) {
+
+	meta := drkey.Lvl2Meta{
+		Protocol: args[0],
+		SrcHost:  addr.HostNone{},
+		DstHost:  addr.HostNone{},
+	}
+	var err error
+	if meta.SrcIA, err = addr.IAFromString(args[1]); err != nil {
+		return drkey.Lvl2Meta{}, serrors.WrapStr("invalid source ISD-AS", err)
+	}
+	if meta.DstIA, err = addr.IAFromString(args[2]); err != nil {
+		return drkey.Lvl2Meta{}, serrors.WrapStr("invalid destination ISD-AS", err)
+	}
+	var needSrc, needDst bool
+	switch strings.ToLower(keyType) {
+	case "as2as":
+		meta.KeyType = drkey.AS2AS
+	case "as2host":
+		meta.KeyType = drkey.AS2Host
+		needDst = true
+	case "host2host":
+		meta.KeyType = drkey.Host2Host
+		needSrc, needDst = true, true
+	default:
+		return drkey.Lvl2Meta{}, serrors.New("invalid key type", "type", keyType)
+	}
+	if needSrc != (srcHost != "") || needDst != (dstHost != "") {
+		return drkey.Lvl2Meta{}, serrors.New("hosts do not match the key type",
+			"type", meta.KeyType, "src_host", srcHost, "dst_host", dstHost)
+	}
+	if needSrc {
+		if meta.SrcHost = addr.HostFromIPStr(srcHost); meta.SrcHost == nil {
+			return drkey.Lvl2Meta{}, serrors.New("invalid source host", "host", srcHost)
+		}
+	}
+	if needDst {
+		if meta.DstHost = addr.HostFromIPStr(dstHost); meta.DstHost == nil {
+			return drkey.Lvl2Meta{}, serrors.New("invalid destination host", "host", dstHost)
+		}
+	}
+	return meta, nil
+}
+
+type drkeyLvl2Result struct {
+	Protocol     string             `json:"protocol"`
+	KeyType      string             `json:"key_type"`
+	SrcIA        addr.IA            `json:"src_isd_as"`
+	DstIA        addr.IA            `json:"dst_isd_as"`
+	SrcHost      string             `json:"src_host,omitempty"`
+	DstHost      string             `json:"dst_host,omitempty"`
+	NotBefore    time.Time          `json:"not_before"`
+	NotAfter     time.Time          `json:"not_after"`
+	KeyHash      string             `json:"key_hash"`
+	Verification *drkeyVerification `json:"verification,omitempty"`
+}
+
+type drkeyVerification struct {
+	Input    string `json:"input"`
+	MAC      string `json:"mac"`
+	Expected string `json:"expected,omitempty"`
+	Match    *bool  `json:"match,omitempty"`
+}
+
+// newDRKeyLvl2Result describes the key without disclosing it. If input is not empty, the MAC
+// of the input is computed and, if expected is set, compared with it.
+func newDRKeyLvl2Result(key drkey.Lvl2Key, input string, expected []byte) (
+	*drkeyLvl2Result, error) {
+
+	hash := sha256.Sum256(key.Key)
+	res := &drkeyLvl2Result{
+		Protocol:  key.Protocol,
+		KeyType:   key.KeyType.String(),
+		SrcIA:     key.SrcIA,
+		DstIA:     key.DstIA,
+		NotBefore: key.Epoch.NotBefore,
+		NotAfter:  key.Epoch.NotAfter,
+		KeyHash:   hex.EncodeToString(hash[:]),
+	}
+	if key.SrcHost != nil && key.SrcHost.Type() != addr.HostTypeNone {
+		res.SrcHost = key.SrcHost.String()
+	}
+	if key.DstHost != nil && key.DstHost.Type() != addr.HostTypeNone {
+		res.DstHost = key.DstHost.String()
+	}
+	if input == "" {
+		return res, nil
+	}
+	mac, err := scrypto.InitMac(key.Key)
+	if err != nil {
+		return nil, serrors.WrapStr("initializing MAC", err)
+	}
+	mac.Write([]byte(input))
+	sum := mac.Sum(nil)
+	res.Verification = &drkeyVerification{
+		Input: input,
+		MAC:   hex.EncodeToString(sum),
+	}
+	if expected != nil {
+		match := hex.EncodeToString(expected) == res.Verification.MAC
+		res.Verification.Expected = hex.EncodeToString(expected)
+		res.Verification.Match = &match
+	}
+	return res, nil
+}
+
+func (r *drkeyLvl2Result) Human(w io.Writer) {
+	src, dst := r.SrcIA.String(), r.DstIA.String()
+	if r.SrcHost != "" {
+		src += "," + r.SrcHost
+	}
+	if r.DstHost != "" {
+		dst += "," + r.DstHost
+	}
+	fmt.Fprintf(w, "Protocol:    %s\n", r.Protocol)
+	fmt.Fprintf(w, "Key type:    %s\n", r.KeyType)
+	fmt.Fprintf(w, "Source:      %s\n", src)
+	fmt.Fprintf(w, "Destination: %s\n", dst)
+	fmt.Fprintf(w, "Epoch:       %s - %s\n", r.NotBefore.Format(time.RFC3339),
+		r.NotAfter.Format(time.RFC3339))
+	fmt.Fprintf(w, "Key hash:    sha256:%s\n", r.KeyHash)
+	if v := r.Verification; v != nil {
+		fmt.Fprintf(w, "MAC:         %s (input %q)\n", v.MAC, v.Input)
+		if v.Match != nil {
+			result := "match"
+			if !*v.Match {
+				result = "MISMATCH, expected " + v.Expected
+			}
+			fmt.Fprintf(w, "Verified:    %s\n", result)
+		}
+	}
+}
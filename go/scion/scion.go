@@ -88,6 +88,8 @@ func main() {
 	cmd.AddCommand(
 		command.NewCompletion(cmd),
 		command.NewVersion(cmd),
+		newColibri(cmd),
+		newDRKey(cmd),
 		newPing(cmd),
 		newShowpaths(cmd),
 		newTraceroute(cmd),
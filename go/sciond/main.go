@@ -47,6 +47,7 @@ import (
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/scrypto/signed"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/pkg/command"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
@@ -55,6 +56,7 @@ import (
 	cryptopb "github.com/scionproto/scion/go/pkg/proto/crypto"
 	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
 	"github.com/scionproto/scion/go/pkg/sciond"
+	colgrpc "github.com/scionproto/scion/go/pkg/sciond/colibri/grpc"
 	"github.com/scionproto/scion/go/pkg/sciond/config"
 	"github.com/scionproto/scion/go/pkg/sciond/drkey"
 	dk_grpc "github.com/scionproto/scion/go/pkg/sciond/drkey/grpc"
@@ -165,7 +167,7 @@ func run(file string) error {
 	}
 
 	var drkeyStore drkeystorage.ClientStore
-	if cfg.DRKeyDB.Connection != "" {
+	if cfg.DRKeyDB.Configured() {
 		ia := itopo.Get().IA()
 		drkeyDB, err := storage.NewDRKeyLvl2Storage(cfg.DRKeyDB)
 		if err != nil {
@@ -176,7 +178,8 @@ func run(file string) error {
 		drkeyFetcher := dk_grpc.DRKeyFetcher{
 			Dialer: dialer,
 		}
-		drkeyStore = drkey.NewClientStore(ia, drkeyDB, drkeyFetcher)
+		drkeyStore = drkey.NewClientStore(ia, drkeyDB, drkeyFetcher,
+			cfg.DRKeyProtocols.Registry())
 
 		drkeyCleaner := periodic.Start(drkeystorage.NewStoreCleaner(drkeyStore),
 			time.Hour, 10*time.Minute)
@@ -217,6 +220,12 @@ func run(file string) error {
 		}}
 	}
 
+	scmpAuthPolicy, err := snet.ParseSCMPAuthPolicy(cfg.SD.SCMPAuthPolicy)
+	if err != nil {
+		return err
+	}
+
+	colibri := &colgrpc.E2EInitiator{Dialer: dialer}
 	server := grpc.NewServer(libgrpc.UnaryServerInterceptor())
 	sdpb.RegisterDaemonServiceServer(server, sciond.NewServer(sciond.ServerConfig{
 		Fetcher: fetcher.NewFetcher(
@@ -230,11 +239,14 @@ func run(file string) error {
 				TopoProvider: itopo.Provider(),
 			},
 		),
-		Engine:       engine,
-		PathDB:       pathDB,
-		RevCache:     revCache,
-		TopoProvider: itopo.Provider(),
-		DRKeyStore:   drkeyStore,
+		Engine:         engine,
+		PathDB:         pathDB,
+		RevCache:       revCache,
+		TopoProvider:   itopo.Provider(),
+		DRKeyStore:     drkeyStore,
+		Colibri:        colibri,
+		ColibriLister:  colibri,
+		SCMPAuthPolicy: scmpAuthPolicy,
 	}))
 
 	promgrpc.Register(server)